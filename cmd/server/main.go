@@ -1,33 +1,302 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/activities"
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/campaigns"
+	"github.com/claudineijrdev/sib-crm-backend/internal/cannedresponses"
+	"github.com/claudineijrdev/sib-crm-backend/internal/chatbot"
 	"github.com/claudineijrdev/sib-crm-backend/internal/container"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/database"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/logging"
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/middleware"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/sla"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tasks"
+	"github.com/claudineijrdev/sib-crm-backend/internal/teams"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
 func main() {
 	godotenv.Load()
+	logger := logging.Setup(logging.LoadConfig())
+
+	// Antes do container: os serviços pegam os providers globais do OpenTelemetry
+	shutdownTelemetry, err := telemetry.Setup(context.Background(), telemetry.LoadConfig())
+	if err != nil {
+		logging.Fatal("failed to set up telemetry", err)
+	}
+	defer shutdownTelemetry(context.Background())
+
 	database.Connect()
+	database.Migrate(
+		&tenants.Tenant{},
+		&auth.User{},
+		&activities.Activity{},
+		&tasks.Task{},
+		&whatsapp.Message{},
+		&customers.Customer{},
+		&whatsapp.Conversation{},
+		&whatsapp.Template{},
+		&whatsapp.Media{},
+		&whatsapp.PendingEvent{},
+		&customers.Segment{},
+		&campaigns.Campaign{},
+		&campaigns.CampaignRecipient{},
+		&chatbot.Flow{},
+		&chatbot.Session{},
+		&cannedresponses.CannedResponse{},
+		&businesshours.Calendar{},
+		&sla.Policy{},
+		&sla.Timer{},
+		&whatsapp.Channel{},
+		&teams.Team{},
+		&teams.TeamMember{},
+		&teams.Presence{},
+		&teams.Transfer{},
+	)
 
 	// Criar container de dependências
 	container := container.NewContainer(database.DB)
 
-	r := gin.Default()
+	// Iniciar workers em background
+	if err := container.AdminServer.Start(context.Background()); err != nil {
+		logging.Fatal("failed to start admin server", err)
+	}
+	defer container.AdminServer.Stop()
+	container.Cache.Start(context.Background())
+	defer container.Cache.Stop()
+	container.RealtimeBroker.Start(context.Background())
+	defer container.RealtimeBroker.Stop()
+	container.ReminderScheduler.Start(context.Background())
+	defer container.ReminderScheduler.Stop()
+	container.CampaignDispatcher.Start(context.Background())
+	defer container.CampaignDispatcher.Stop()
+	container.ChatbotTimeouts.Start(context.Background())
+	defer container.ChatbotTimeouts.Stop()
+	container.SLAMonitor.Start(context.Background())
+	defer container.SLAMonitor.Stop()
+	container.TeamDistributor.Start(context.Background())
+	defer container.TeamDistributor.Stop()
+	// Por último: no encerramento é a primeira a parar, entregando o que ficou na fila
+	container.WebhookEvents.Start(context.Background())
+	defer container.WebhookEvents.Stop()
+
+	r := gin.New()
+	r.Use(gin.Recovery())
 
 	// Adicionar middleware de telemetria global
 	r.Use(middleware.TelemetryMiddleware(container.Telemetry))
+	r.Use(middleware.LoggingMiddleware(logger))
+
+	// Webhooks de provedores externos (autenticados por assinatura)
+	webhooks := r.Group("/webhooks")
+	{
+		webhooks.GET("/whatsapp", container.WebhookHandler.Verify)
+		webhooks.POST("/whatsapp", container.WebhookHandler.Receive)
+	}
+
+	// Download de mídia autorizado pela URL assinada
+	r.GET("/media/:tenant_id/:id", container.MediaHandler.Download)
 
 	api := r.Group("/api")
 	{
+		// Conexões em tempo real: o navegador autentica com o ticket na query string
+		realtimeRoutes := api.Group("/realtime")
+		{
+			realtimeRoutes.POST("/tickets", container.Identity, container.RealtimeHandler.IssueTicket)
+			realtimeRoutes.GET("/ws", container.RealtimeHandler.Authenticate(), container.RealtimeHandler.WebSocket)
+			realtimeRoutes.GET("/events", container.RealtimeHandler.Authenticate(), container.RealtimeHandler.Events)
+		}
+
 		authRoutes := api.Group("/auth")
 		{
 			authRoutes.POST("/register", container.AuthHandler.Register)
 			authRoutes.POST("/login", container.AuthHandler.Login)
 		}
+
+		// Rotas autenticadas (escopo do tenant)
+		protected := api.Group("")
+		protected.Use(container.Identity)
+		{
+			activityRoutes := protected.Group("/activities")
+			{
+				activityRoutes.POST("", container.ActivityHandler.Create)
+				activityRoutes.GET("/:id", container.ActivityHandler.Get)
+			}
+
+			timelineRoutes := protected.Group("/timeline")
+			{
+				timelineRoutes.GET("/records/:subject_type/:subject_id", container.ActivityHandler.RecordTimeline)
+				timelineRoutes.GET("/users/:user_id", container.ActivityHandler.UserTimeline)
+			}
+
+			taskRoutes := protected.Group("/tasks")
+			{
+				taskRoutes.POST("", container.TaskHandler.Create)
+				taskRoutes.GET("/mine", container.TaskHandler.Mine)
+				taskRoutes.GET("/overdue", container.TaskHandler.Overdue)
+				taskRoutes.GET("/reminders", container.TaskHandler.Reminders)
+				taskRoutes.GET("/:id", container.TaskHandler.Get)
+				taskRoutes.POST("/:id/complete", container.TaskHandler.Complete)
+				taskRoutes.POST("/:id/reminder/ack", container.TaskHandler.AcknowledgeReminder)
+			}
+
+			whatsappRoutes := protected.Group("/whatsapp")
+			{
+				whatsappRoutes.GET("/channels", container.ChannelHandler.List)
+				whatsappRoutes.POST("/channels", container.ChannelHandler.Create)
+				whatsappRoutes.GET("/channels/:id", container.ChannelHandler.Get)
+				whatsappRoutes.PUT("/channels/:id", container.ChannelHandler.Update)
+				whatsappRoutes.DELETE("/channels/:id", container.ChannelHandler.Delete)
+				whatsappRoutes.POST("/channels/:id/default", container.ChannelHandler.SetDefault)
+
+				whatsappRoutes.POST("/messages", container.MessageHandler.Send)
+				whatsappRoutes.GET("/messages/:id", container.MessageHandler.Get)
+				whatsappRoutes.GET("/messages/:id/media", container.MediaHandler.MessageMedia)
+
+				whatsappRoutes.POST("/media", container.MediaHandler.Upload)
+				whatsappRoutes.GET("/media/:id", container.MediaHandler.Get)
+
+				whatsappRoutes.GET("/conversations", container.ConversationHandler.List)
+				whatsappRoutes.GET("/conversations/:id", container.ConversationHandler.Get)
+				whatsappRoutes.GET("/conversations/:id/messages", container.ConversationHandler.Messages)
+				whatsappRoutes.POST("/conversations/:id/messages", container.ConversationHandler.Reply)
+				whatsappRoutes.POST("/conversations/:id/read", container.ConversationHandler.MarkRead)
+				whatsappRoutes.PATCH("/conversations/:id/status", container.ConversationHandler.UpdateStatus)
+				whatsappRoutes.PUT("/conversations/:id/assignee", container.ConversationHandler.Assign)
+
+				whatsappRoutes.GET("/templates", container.TemplateHandler.List)
+				whatsappRoutes.POST("/templates", container.TemplateHandler.Create)
+				whatsappRoutes.POST("/templates/sync", container.TemplateHandler.Sync)
+				whatsappRoutes.GET("/templates/:id", container.TemplateHandler.Get)
+				whatsappRoutes.DELETE("/templates/:id", container.TemplateHandler.Delete)
+				whatsappRoutes.POST("/templates/:id/send", container.TemplateHandler.Send)
+			}
+
+			customerRoutes := protected.Group("/customers")
+			{
+				customerRoutes.POST("/segments", container.CustomerHandler.CreateSegment)
+				customerRoutes.GET("/segments", container.CustomerHandler.ListSegments)
+				customerRoutes.GET("/segments/:id/customers", container.CustomerHandler.SegmentCustomers)
+				customerRoutes.GET("/:id", container.CustomerHandler.Get)
+				customerRoutes.POST("/:id/opt-out", container.CustomerHandler.OptOut)
+				customerRoutes.DELETE("/:id/opt-out", container.CustomerHandler.OptIn)
+			}
+
+			campaignRoutes := protected.Group("/campaigns")
+			{
+				campaignRoutes.POST("", container.CampaignHandler.Create)
+				campaignRoutes.GET("", container.CampaignHandler.List)
+				campaignRoutes.GET("/:id", container.CampaignHandler.Get)
+				campaignRoutes.POST("/:id/start", container.CampaignHandler.Start)
+				campaignRoutes.POST("/:id/cancel", container.CampaignHandler.Cancel)
+				campaignRoutes.GET("/:id/recipients", container.CampaignHandler.Recipients)
+			}
+
+			chatbotRoutes := protected.Group("/chatbot")
+			{
+				chatbotRoutes.POST("/flows/validate", container.FlowHandler.Validate)
+				chatbotRoutes.GET("/flows", container.FlowHandler.List)
+				chatbotRoutes.POST("/flows", container.FlowHandler.Create)
+				chatbotRoutes.GET("/flows/:id", container.FlowHandler.Get)
+				chatbotRoutes.PUT("/flows/:id", container.FlowHandler.Update)
+				chatbotRoutes.DELETE("/flows/:id", container.FlowHandler.Delete)
+				chatbotRoutes.POST("/flows/:id/activate", container.FlowHandler.Activate)
+				chatbotRoutes.POST("/flows/:id/deactivate", container.FlowHandler.Deactivate)
+			}
+
+			cannedRoutes := protected.Group("/canned-responses")
+			{
+				cannedRoutes.GET("", container.CannedHandler.List)
+				cannedRoutes.POST("", container.CannedHandler.Create)
+				cannedRoutes.GET("/search", container.CannedHandler.Search)
+				cannedRoutes.GET("/categories", container.CannedHandler.Categories)
+				cannedRoutes.GET("/:id", container.CannedHandler.Get)
+				cannedRoutes.PUT("/:id", container.CannedHandler.Update)
+				cannedRoutes.DELETE("/:id", container.CannedHandler.Delete)
+				cannedRoutes.GET("/:id/render", container.CannedHandler.Render)
+			}
+
+			hoursRoutes := protected.Group("/business-hours")
+			{
+				hoursRoutes.GET("", container.HoursHandler.Get)
+				hoursRoutes.PUT("", container.HoursHandler.Save)
+				hoursRoutes.DELETE("", container.HoursHandler.Delete)
+				hoursRoutes.GET("/status", container.HoursHandler.Status)
+				hoursRoutes.GET("/holidays", container.HoursHandler.Holidays)
+			}
+
+			slaRoutes := protected.Group("/sla")
+			{
+				slaRoutes.GET("/policy", container.SLAHandler.GetPolicy)
+				slaRoutes.PUT("/policy", container.SLAHandler.SavePolicy)
+				slaRoutes.DELETE("/policy", container.SLAHandler.DeletePolicy)
+				slaRoutes.GET("/breaches", container.SLAHandler.Breaches)
+				slaRoutes.GET("/conversations/:id/timers", container.SLAHandler.ConversationTimers)
+			}
+
+			teamRoutes := protected.Group("/teams")
+			{
+				teamRoutes.GET("/presence", container.TeamHandler.ListPresence)
+				teamRoutes.PUT("/presence", container.TeamHandler.SetPresence)
+				teamRoutes.POST("/conversations/:id/transfer", container.TeamHandler.Transfer)
+				teamRoutes.GET("/conversations/:id/transfers", container.TeamHandler.ListTransfers)
+
+				teamRoutes.GET("", container.TeamHandler.List)
+				teamRoutes.POST("", container.TeamHandler.Create)
+				teamRoutes.GET("/:id", container.TeamHandler.Get)
+				teamRoutes.PUT("/:id", container.TeamHandler.Update)
+				teamRoutes.DELETE("/:id", container.TeamHandler.Delete)
+				teamRoutes.POST("/:id/members", container.TeamHandler.AddMember)
+				teamRoutes.DELETE("/:id/members/:user_id", container.TeamHandler.RemoveMember)
+				teamRoutes.GET("/:id/queue", container.TeamHandler.Queue)
+			}
+		}
 	}
 
-	r.Run()
-}
\ No newline at end of file
+	// SIGINT/SIGTERM param de aceitar conexões e esperam as requisições em
+	// andamento; só depois os defers param os workers e a telemetria
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{Addr: serverAddr(), Handler: r, ReadHeaderTimeout: 10 * time.Second}
+	server.RegisterOnShutdown(container.RealtimeHub.Close)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Fatal("failed to start server", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	slog.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("server shutdown", "error", err)
+	}
+}
+
+// serverAddr segue o r.Run() do gin: PORT ou :8080
+func serverAddr() string {
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+	return ":8080"
+}
@@ -0,0 +1,23 @@
+package activities
+
+import "time"
+
+type CreateActivityRequest struct {
+	Type            ActivityType `json:"type" binding:"required"`
+	SubjectType     SubjectType  `json:"subject_type" binding:"required"`
+	SubjectID       string       `json:"subject_id" binding:"required,uuid"`
+	Title           string       `json:"title"`
+	Body            string       `json:"body"`
+	Direction       string       `json:"direction"`
+	DurationSeconds int          `json:"duration_seconds"`
+	Location        string       `json:"location"`
+	ReferenceID     string       `json:"reference_id"`
+	OccurredAt      *time.Time   `json:"occurred_at"`
+}
+
+type TimelineResponse struct {
+	Items    []Activity `json:"items"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"page_size"`
+	Total    int64      `json:"total"`
+}
@@ -0,0 +1,117 @@
+package activities
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ActivityHandler struct {
+	activityService ActivityService
+}
+
+func NewActivityHandler(activityService ActivityService) *ActivityHandler {
+	return &ActivityHandler{
+		activityService: activityService,
+	}
+}
+
+func (h *ActivityHandler) Create(c *gin.Context) {
+	var req CreateActivityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	activity, err := h.activityService.CreateActivity(c.Request.Context(), web.TenantID(c), web.UserID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, activity)
+}
+
+func (h *ActivityHandler) Get(c *gin.Context) {
+	activity, err := h.activityService.GetActivity(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, activity)
+}
+
+// RecordTimeline lista as atividades de um cliente, empresa, lead ou negócio
+func (h *ActivityHandler) RecordTimeline(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("subject_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, "invalid subject_id"))
+		return
+	}
+
+	h.timeline(c, TimelineFilter{
+		SubjectType: SubjectType(c.Param("subject_type")),
+		SubjectID:   subjectID,
+	})
+}
+
+// UserTimeline lista as atividades registradas por um usuário
+func (h *ActivityHandler) UserTimeline(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, "invalid user_id"))
+		return
+	}
+
+	h.timeline(c, TimelineFilter{UserID: userID})
+}
+
+func (h *ActivityHandler) timeline(c *gin.Context, filter TimelineFilter) {
+	pagination := web.ParsePagination(c)
+
+	filter.TenantID = web.TenantID(c)
+	filter.Types = parseTypes(c)
+	filter.Offset = pagination.Offset()
+	filter.Limit = pagination.PageSize
+
+	items, total, err := h.activityService.Timeline(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, TimelineResponse{
+		Items:    items,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+		Total:    total,
+	})
+}
+
+// parseTypes aceita ?type=note,call e ?type=note&type=call
+func parseTypes(c *gin.Context) []ActivityType {
+	var types []ActivityType
+	for _, value := range c.QueryArray("type") {
+		for _, t := range strings.Split(value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types = append(types, ActivityType(t))
+			}
+		}
+	}
+	return types
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrActivityNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidType), errors.Is(err, ErrInvalidSubjectType):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
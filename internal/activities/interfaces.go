@@ -0,0 +1,18 @@
+package activities
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type ActivityRepository interface {
+	Create(ctx context.Context, activity *Activity) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error)
+	List(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error)
+}
+
+type ActivityService interface {
+	CreateActivity(ctx context.Context, tenantID, userID uuid.UUID, req CreateActivityRequest) (*Activity, error)
+	GetActivity(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error)
+	Timeline(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error)
+}
@@ -0,0 +1,62 @@
+package activities
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// MockActivityRepository para testes
+type MockActivityRepository struct {
+	CreateFunc   func(ctx context.Context, activity *Activity) error
+	FindByIDFunc func(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error)
+	ListFunc     func(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error)
+}
+
+func (m *MockActivityRepository) Create(ctx context.Context, activity *Activity) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, activity)
+	}
+	return nil
+}
+
+func (m *MockActivityRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockActivityRepository) List(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+// MockActivityService para testes
+type MockActivityService struct {
+	CreateActivityFunc func(ctx context.Context, tenantID, userID uuid.UUID, req CreateActivityRequest) (*Activity, error)
+	GetActivityFunc    func(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error)
+	TimelineFunc       func(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error)
+}
+
+func (m *MockActivityService) CreateActivity(ctx context.Context, tenantID, userID uuid.UUID, req CreateActivityRequest) (*Activity, error) {
+	if m.CreateActivityFunc != nil {
+		return m.CreateActivityFunc(ctx, tenantID, userID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockActivityService) GetActivity(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error) {
+	if m.GetActivityFunc != nil {
+		return m.GetActivityFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockActivityService) Timeline(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error) {
+	if m.TimelineFunc != nil {
+		return m.TimelineFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
@@ -0,0 +1,83 @@
+package activities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ActivityType string
+
+const (
+	TypeNote            ActivityType = "note"
+	TypeCall            ActivityType = "call"
+	TypeMeeting         ActivityType = "meeting"
+	TypeTask            ActivityType = "task"
+	TypeWhatsAppMessage ActivityType = "whatsapp_message"
+)
+
+func (t ActivityType) Valid() bool {
+	switch t {
+	case TypeNote, TypeCall, TypeMeeting, TypeTask, TypeWhatsAppMessage:
+		return true
+	}
+	return false
+}
+
+// SubjectType identifica o registro ao qual a atividade está anexada
+type SubjectType string
+
+const (
+	SubjectCustomer SubjectType = "customer"
+	SubjectCompany  SubjectType = "company"
+	SubjectLead     SubjectType = "lead"
+	SubjectDeal     SubjectType = "deal"
+)
+
+func (s SubjectType) Valid() bool {
+	switch s {
+	case SubjectCustomer, SubjectCompany, SubjectLead, SubjectDeal:
+		return true
+	}
+	return false
+}
+
+type Activity struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID    uuid.UUID    `gorm:"type:uuid;not null;index:idx_activities_subject,priority:1;index:idx_activities_user,priority:1" json:"tenant_id"`
+	Type        ActivityType `gorm:"type:varchar(32);not null" json:"type"`
+	SubjectType SubjectType  `gorm:"type:varchar(32);not null;index:idx_activities_subject,priority:2" json:"subject_type"`
+	SubjectID   uuid.UUID    `gorm:"type:uuid;not null;index:idx_activities_subject,priority:3" json:"subject_id"`
+	UserID      uuid.UUID    `gorm:"type:uuid;not null;index:idx_activities_user,priority:2" json:"user_id"`
+	Title       string       `gorm:"type:varchar(255)" json:"title"`
+	Body        string       `gorm:"type:text" json:"body"`
+
+	// Campos específicos de cada tipo
+	Direction       string `gorm:"type:varchar(16)" json:"direction,omitempty"`     // call: inbound/outbound
+	DurationSeconds int    `json:"duration_seconds,omitempty"`                      // call, meeting
+	Location        string `gorm:"type:varchar(255)" json:"location,omitempty"`     // meeting
+	ReferenceID     string `gorm:"type:varchar(255)" json:"reference_id,omitempty"` // whatsapp: ID da mensagem
+
+	OccurredAt time.Time `gorm:"not null;index" json:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (a *Activity) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TimelineFilter define os critérios de listagem da timeline
+type TimelineFilter struct {
+	TenantID    uuid.UUID
+	SubjectType SubjectType
+	SubjectID   uuid.UUID
+	UserID      uuid.UUID
+	Types       []ActivityType
+	Offset      int
+	Limit       int
+}
@@ -0,0 +1,130 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type activityRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newActivityRepositoryBase(db *gorm.DB) *activityRepositoryBase {
+	return &activityRepositoryBase{db: db}
+}
+
+func (r *activityRepositoryBase) create(ctx context.Context, activity *Activity) error {
+	return r.db.WithContext(ctx).Create(activity).Error
+}
+
+func (r *activityRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error) {
+	var activity Activity
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&activity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &activity, nil
+}
+
+func (r *activityRepositoryBase) list(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Activity{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.SubjectType != "" {
+		query = query.Where("subject_type = ? AND subject_id = ?", filter.SubjectType, filter.SubjectID)
+	}
+	if filter.UserID != uuid.Nil {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if len(filter.Types) > 0 {
+		query = query.Where("type IN ?", filter.Types)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []Activity
+	err := query.Order("occurred_at DESC, id DESC").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// Repository com telemetria (decorator)
+type activityRepository struct {
+	base      *activityRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewActivityRepository(db *gorm.DB, telemetry telemetry.TelemetryService) ActivityRepository {
+	return &activityRepository{
+		base:      newActivityRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *activityRepository) Create(ctx context.Context, activity *Activity) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.activity.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", activity.TenantID.String())
+	span.SetTag("activity_type", string(activity.Type))
+
+	err := r.base.create(ctx, activity)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+
+	r.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "repository.activity.create.success",
+		Value: 1,
+		Tags:  map[string]string{"type": string(activity.Type)},
+	})
+
+	return nil
+}
+
+func (r *activityRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.activity.find_by_id")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+	span.SetTag("activity_id", id)
+
+	activity, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return activity, nil
+}
+
+func (r *activityRepository) List(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.activity.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", filter.TenantID.String())
+	span.SetTag("subject_type", string(filter.SubjectType))
+	span.SetTag("limit", strconv.Itoa(filter.Limit))
+
+	items, total, err := r.base.list(ctx, filter)
+	if err != nil {
+		span.SetError(err)
+		return nil, 0, err
+	}
+	return items, total, nil
+}
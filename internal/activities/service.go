@@ -0,0 +1,108 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidType        = errors.New("invalid activity type")
+	ErrInvalidSubjectType = errors.New("invalid subject type")
+	ErrActivityNotFound   = errors.New("activity not found")
+)
+
+type activityService struct {
+	activityRepo ActivityRepository
+	telemetry    telemetry.TelemetryService
+}
+
+func NewActivityService(activityRepo ActivityRepository, telemetry telemetry.TelemetryService) ActivityService {
+	return &activityService{
+		activityRepo: activityRepo,
+		telemetry:    telemetry,
+	}
+}
+
+func (s *activityService) CreateActivity(ctx context.Context, tenantID, userID uuid.UUID, req CreateActivityRequest) (*Activity, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "activities.create_activity")
+	defer span.End()
+
+	if !req.Type.Valid() {
+		return nil, ErrInvalidType
+	}
+	if !req.SubjectType.Valid() {
+		return nil, ErrInvalidSubjectType
+	}
+	subjectID, err := uuid.Parse(req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurredAt := time.Now()
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+	}
+
+	activity := &Activity{
+		TenantID:        tenantID,
+		Type:            req.Type,
+		SubjectType:     req.SubjectType,
+		SubjectID:       subjectID,
+		UserID:          userID,
+		Title:           req.Title,
+		Body:            req.Body,
+		Direction:       req.Direction,
+		DurationSeconds: req.DurationSeconds,
+		Location:        req.Location,
+		ReferenceID:     req.ReferenceID,
+		OccurredAt:      occurredAt,
+	}
+	if err := s.activityRepo.Create(ctx, activity); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "activities.created",
+		Properties: map[string]interface{}{
+			"activity_id":  activity.ID.String(),
+			"tenant_id":    tenantID.String(),
+			"type":         string(activity.Type),
+			"subject_type": string(activity.SubjectType),
+		},
+		Timestamp: time.Now(),
+	})
+
+	return activity, nil
+}
+
+func (s *activityService) GetActivity(ctx context.Context, tenantID uuid.UUID, id string) (*Activity, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrActivityNotFound
+	}
+
+	activity, err := s.activityRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if activity == nil {
+		return nil, ErrActivityNotFound
+	}
+	return activity, nil
+}
+
+func (s *activityService) Timeline(ctx context.Context, filter TimelineFilter) ([]Activity, int64, error) {
+	for _, t := range filter.Types {
+		if !t.Valid() {
+			return nil, 0, ErrInvalidType
+		}
+	}
+	if filter.SubjectType != "" && !filter.SubjectType.Valid() {
+		return nil, 0, ErrInvalidSubjectType
+	}
+	return s.activityRepo.List(ctx, filter)
+}
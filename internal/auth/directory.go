@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/google/uuid"
+)
+
+// userDirectory valida as sessões contra o repositório de usuários (com cache)
+type userDirectory struct {
+	userRepo UserRepository
+}
+
+func NewUserDirectory(userRepo UserRepository) web.UserDirectory {
+	return &userDirectory{userRepo: userRepo}
+}
+
+func (d *userDirectory) UserInTenant(ctx context.Context, tenantID, userID uuid.UUID) (bool, error) {
+	user, err := d.userRepo.FindByID(ctx, userID.String())
+	if err != nil {
+		return false, err
+	}
+	return user != nil && user.TenantID == tenantID, nil
+}
@@ -1,5 +1,7 @@
 package auth
 
+import "time"
+
 type RegisterRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
@@ -16,7 +18,10 @@ type RegisterResponse struct {
 	UserID   string `json:"user_id"`
 }
 
+// LoginResponse.Token vai no header Authorization: Bearer das rotas autenticadas
 type LoginResponse struct {
-	UserID string `json:"user_id"`
-	Token  string `json:"token"`
+	TenantID  string    `json:"tenant_id"`
+	UserID    string    `json:"user_id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
 } 
\ No newline at end of file
@@ -2,30 +2,34 @@ package auth
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
 	"github.com/gin-gonic/gin"
 )
 
 type AuthHandler struct {
 	authService AuthService
+	sessions    *web.Sessions
 }
 
-func NewAuthHandler(authService AuthService) *AuthHandler {
+func NewAuthHandler(authService AuthService, sessions *web.Sessions) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
+		sessions:    sessions,
 	}
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
 		return
 	}
 
-	user, tenant, err := h.authService.RegisterUser(req)
+	user, tenant, err := h.authService.RegisterUser(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, web.ErrorBody(c, err.Error()))
 		return
 	}
 
@@ -40,20 +44,22 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
 		return
 	}
 
-	user, err := h.authService.LoginUser(req)
+	user, err := h.authService.LoginUser(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.JSON(http.StatusUnauthorized, web.ErrorBody(c, err.Error()))
 		return
 	}
 
-	// TODO: Implementar geração de JWT token
+	token, expiresAt := h.sessions.Issue(user.TenantID, user.ID, time.Now())
 	response := LoginResponse{
-		UserID: user.ID.String(),
-		Token:  "jwt-token-here", // Placeholder
+		TenantID:  user.TenantID.String(),
+		UserID:    user.ID.String(),
+		Token:     token,
+		ExpiresAt: expiresAt,
 	}
 
 	c.JSON(http.StatusOK, response)
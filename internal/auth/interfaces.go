@@ -1,16 +1,19 @@
 package auth
 
 import (
+	"context"
 	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
 )
 
 type UserRepository interface {
-	Create(user *User) error
-	FindByEmail(email string) (*User, error)
-	FindByID(id string) (*User, error)
+	Create(ctx context.Context, user *User) error
+	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id string) error
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	FindByID(ctx context.Context, id string) (*User, error)
 }
 
 type AuthService interface {
-	RegisterUser(req RegisterRequest) (*User, *tenants.Tenant, error)
-	LoginUser(req LoginRequest) (*User, error)
+	RegisterUser(ctx context.Context, req RegisterRequest) (*User, *tenants.Tenant, error)
+	LoginUser(ctx context.Context, req LoginRequest) (*User, error)
 } 
\ No newline at end of file
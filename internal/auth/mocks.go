@@ -1,73 +1,114 @@
 package auth
 
 import (
+	"context"
 	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
 )
 
 // MockUserRepository para testes
 type MockUserRepository struct {
-	CreateFunc      func(user *User) error
-	FindByEmailFunc func(email string) (*User, error)
-	FindByIDFunc    func(id string) (*User, error)
+	CreateFunc      func(ctx context.Context, user *User) error
+	UpdateFunc      func(ctx context.Context, user *User) error
+	DeleteFunc      func(ctx context.Context, id string) error
+	FindByEmailFunc func(ctx context.Context, email string) (*User, error)
+	FindByIDFunc    func(ctx context.Context, id string) (*User, error)
 }
 
-func (m *MockUserRepository) Create(user *User) error {
+func (m *MockUserRepository) Create(ctx context.Context, user *User) error {
 	if m.CreateFunc != nil {
-		return m.CreateFunc(user)
+		return m.CreateFunc(ctx, user)
 	}
 	return nil
 }
 
-func (m *MockUserRepository) FindByEmail(email string) (*User, error) {
+func (m *MockUserRepository) Update(ctx context.Context, user *User) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, user)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*User, error) {
 	if m.FindByEmailFunc != nil {
-		return m.FindByEmailFunc(email)
+		return m.FindByEmailFunc(ctx, email)
 	}
 	return nil, nil
 }
 
-func (m *MockUserRepository) FindByID(id string) (*User, error) {
+func (m *MockUserRepository) FindByID(ctx context.Context, id string) (*User, error) {
 	if m.FindByIDFunc != nil {
-		return m.FindByIDFunc(id)
+		return m.FindByIDFunc(ctx, id)
 	}
 	return nil, nil
 }
 
 // MockTenantRepository para testes
 type MockTenantRepository struct {
-	CreateFunc func(tenant *tenants.Tenant) error
-	FindByIDFunc func(id string) (*tenants.Tenant, error)
+	CreateFunc func(ctx context.Context, tenant *tenants.Tenant) error
+	UpdateFunc func(ctx context.Context, tenant *tenants.Tenant) error
+	DeleteFunc func(ctx context.Context, id string) error
+	FindByIDFunc func(ctx context.Context, id string) (*tenants.Tenant, error)
+	FindByWhatsAppPhoneNumberIDFunc func(ctx context.Context, phoneNumberID string) (*tenants.Tenant, error)
 }
 
-func (m *MockTenantRepository) Create(tenant *tenants.Tenant) error {
+func (m *MockTenantRepository) Create(ctx context.Context, tenant *tenants.Tenant) error {
 	if m.CreateFunc != nil {
-		return m.CreateFunc(tenant)
+		return m.CreateFunc(ctx, tenant)
+	}
+	return nil
+}
+
+func (m *MockTenantRepository) Update(ctx context.Context, tenant *tenants.Tenant) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, tenant)
+	}
+	return nil
+}
+
+func (m *MockTenantRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
 	}
 	return nil
 }
 
-func (m *MockTenantRepository) FindByID(id string) (*tenants.Tenant, error) {
+func (m *MockTenantRepository) FindByID(ctx context.Context, id string) (*tenants.Tenant, error) {
 	if m.FindByIDFunc != nil {
-		return m.FindByIDFunc(id)
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockTenantRepository) FindByWhatsAppPhoneNumberID(ctx context.Context, phoneNumberID string) (*tenants.Tenant, error) {
+	if m.FindByWhatsAppPhoneNumberIDFunc != nil {
+		return m.FindByWhatsAppPhoneNumberIDFunc(ctx, phoneNumberID)
 	}
 	return nil, nil
 }
 
 // MockAuthService para testes
 type MockAuthService struct {
-	RegisterUserFunc func(req RegisterRequest) (*User, *tenants.Tenant, error)
-	LoginUserFunc    func(req LoginRequest) (*User, error)
+	RegisterUserFunc func(ctx context.Context, req RegisterRequest) (*User, *tenants.Tenant, error)
+	LoginUserFunc    func(ctx context.Context, req LoginRequest) (*User, error)
 }
 
-func (m *MockAuthService) RegisterUser(req RegisterRequest) (*User, *tenants.Tenant, error) {
+func (m *MockAuthService) RegisterUser(ctx context.Context, req RegisterRequest) (*User, *tenants.Tenant, error) {
 	if m.RegisterUserFunc != nil {
-		return m.RegisterUserFunc(req)
+		return m.RegisterUserFunc(ctx, req)
 	}
 	return nil, nil, nil
 }
 
-func (m *MockAuthService) LoginUser(req LoginRequest) (*User, error) {
+func (m *MockAuthService) LoginUser(ctx context.Context, req LoginRequest) (*User, error) {
 	if m.LoginUserFunc != nil {
-		return m.LoginUserFunc(req)
+		return m.LoginUserFunc(ctx, req)
 	}
 	return nil, nil
 } 
\ No newline at end of file
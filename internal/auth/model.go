@@ -11,8 +11,9 @@ type User struct {
 	ID            uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	TenantID      uuid.UUID      `gorm:"type:uuid;not null" json:"tenant_id"`
 	Tenant        tenants.Tenant `gorm:"foreignKey:TenantID"`
+	Name          string         `gorm:"type:varchar(255)" json:"name,omitempty"`
 	Email         string         `gorm:"type:varchar(255);not null;unique" json:"email"`
-	PasswordHash  string         `gorm:"type:varchar(255);not null" json:"-"`
+	PasswordHash  string         `gorm:"type:varchar(255);not null" json:"-" cache:"password_hash"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 }
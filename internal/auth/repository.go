@@ -3,14 +3,26 @@ package auth
 import (
 	"context"
 	"errors"
-	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var userKeys = cache.NewKeyBuilder("user")
+
+// userTags liga cada entrada ao registro e ao tenant, para Update/Delete e a
+// invalidação do tenant apagarem todas as buscas que devolveram o usuário
+func userTags(user *User) []string {
+	if user == nil {
+		return nil
+	}
+	return []string{userKeys.RecordTag(user.ID.String()), cache.TenantTag(user.TenantID.String())}
+}
+
 // Repository base (sem cache/telemetria)
 type userRepositoryBase struct {
 	db *gorm.DB
@@ -20,13 +32,22 @@ func newUserRepositoryBase(db *gorm.DB) *userRepositoryBase {
 	return &userRepositoryBase{db: db}
 }
 
-func (r *userRepositoryBase) create(user *User) error {
-	return r.db.Create(user).Error
+func (r *userRepositoryBase) create(ctx context.Context, user *User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+// update não regrava o tenant associado
+func (r *userRepositoryBase) update(ctx context.Context, user *User) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(user).Error
 }
 
-func (r *userRepositoryBase) findByEmail(email string) (*User, error) {
+func (r *userRepositoryBase) delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&User{}).Error
+}
+
+func (r *userRepositoryBase) findByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -36,9 +57,9 @@ func (r *userRepositoryBase) findByEmail(email string) (*User, error) {
 	return &user, nil
 }
 
-func (r *userRepositoryBase) findByID(id string) (*User, error) {
+func (r *userRepositoryBase) findByID(ctx context.Context, id string) (*User, error) {
 	var user User
-	err := r.db.Where("id = ?", id).First(&user).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -51,130 +72,156 @@ func (r *userRepositoryBase) findByID(id string) (*User, error) {
 // Repository com cache e telemetria (decorator)
 type userRepository struct {
 	base      *userRepositoryBase
-	cache     cache.CacheService
 	telemetry telemetry.TelemetryService
+
+	// cache grava também a ausência do registro; lookup só grava o que existe
+	cache  *cache.Loader[*User]
+	lookup *cache.Loader[*User]
 }
 
-func NewUserRepository(db *gorm.DB, cache cache.CacheService, telemetry telemetry.TelemetryService) UserRepository {
+func NewUserRepository(db *gorm.DB, cacheService cache.CacheService, telemetry telemetry.TelemetryService) UserRepository {
 	return &userRepository{
 		base:      newUserRepositoryBase(db),
-		cache:     cache,
 		telemetry: telemetry,
+		cache: cache.NewLoader[*User](cacheService, cache.LoaderOptions{
+			TTL:         10 * time.Minute,
+			NegativeTTL: 5 * time.Minute,
+			Jitter:      0.1,
+		}, telemetry).WithTags(userTags),
+		lookup: cache.NewLoader[*User](cacheService, cache.LoaderOptions{
+			TTL:    10 * time.Minute,
+			Jitter: 0.1,
+		}, telemetry).WithTags(userTags),
 	}
 }
 
-func (r *userRepository) Create(user *User) error {
-	ctx := context.Background()
+func (r *userRepository) Create(ctx context.Context, user *User) error {
 	span, ctx := r.telemetry.StartSpan(ctx, "repository.user.create")
 	defer span.End()
 
 	span.SetTag("user_id", user.ID.String())
-	span.SetTag("email", user.Email)
 
-	err := r.base.create(user)
+	err := r.base.create(ctx, user)
 	if err != nil {
 		span.SetError(err)
 		return err
 	}
 
-	// Invalidate cache
-	cacheKey := fmt.Sprintf("user:email:%s", user.Email)
-	r.cache.Delete(ctx, cacheKey)
+	// Invalidate cache: as buscas podem ter gravado a ausência do usuário
+	r.cache.Delete(ctx, userKeys.Global("email", user.Email))
+	r.cache.Delete(ctx, userKeys.Global("id", user.ID.String()))
 
 	// Track metric
 	r.telemetry.TrackMetric(ctx, telemetry.Metric{
 		Name:  "repository.user.create.success",
 		Value: 1,
-		Tags:  map[string]string{"email": user.Email},
 	})
 
 	return nil
 }
 
-func (r *userRepository) FindByEmail(email string) (*User, error) {
-	ctx := context.Background()
-	span, ctx := r.telemetry.StartSpan(ctx, "repository.user.find_by_email")
+func (r *userRepository) Update(ctx context.Context, user *User) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.user.update")
 	defer span.End()
 
-	span.SetTag("email", email)
-
-	// Try cache first
-	cacheKey := fmt.Sprintf("user:email:%s", email)
-	if cached, err := r.cache.Get(ctx, cacheKey); err == nil && cached != nil {
-		span.SetTag("cache_hit", "true")
-		if user, ok := cached.(*User); ok {
-			r.telemetry.TrackMetric(ctx, telemetry.Metric{
-				Name:  "repository.user.find_by_email.cache_hit",
-				Value: 1,
-				Tags:  map[string]string{"email": email},
-			})
-			return user, nil
-		}
+	span.SetTag("user_id", user.ID.String())
+
+	if err := r.base.update(ctx, user); err != nil {
+		span.SetError(err)
+		return err
 	}
 
-	// Cache miss - query database
-	span.SetTag("cache_hit", "false")
-	user, err := r.base.findByEmail(email)
+	// Invalidate cache: o registro sai de todas as chaves, inclusive a do e-mail antigo
+	r.cache.InvalidateTags(ctx, userKeys.RecordTag(user.ID.String()))
+	r.cache.Delete(ctx, userKeys.Global("email", user.Email))
+
+	r.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "repository.user.update.success",
+		Value: 1,
+		Tags:  map[string]string{"user_id": user.ID.String()},
+	})
+
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.user.delete")
+	defer span.End()
+
+	span.SetTag("user_id", id)
+
+	if err := r.base.delete(ctx, id); err != nil {
+		span.SetError(err)
+		return err
+	}
+
+	// Invalidate cache
+	r.cache.InvalidateTags(ctx, userKeys.RecordTag(id))
+
+	r.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "repository.user.delete.success",
+		Value: 1,
+		Tags:  map[string]string{"user_id": id},
+	})
+
+	return nil
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*User, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.user.find_by_email")
+	defer span.End()
+
+	// Cache-aside com coalescência: misses concorrentes da mesma chave fazem uma consulta só
+	// (a consulta é compartilhada, então não herda o cancelamento de quem a iniciou)
+	cacheKey := userKeys.Global("email", email)
+	user, hit, err := r.cache.Load(ctx, cacheKey, func() (*User, error) {
+		return r.base.findByEmail(context.WithoutCancel(ctx), email)
+	})
 	if err != nil {
 		span.SetError(err)
 		return nil, err
 	}
 
-	// Cache the result (even if nil to avoid cache penetration)
-	ttl := 10 * time.Minute
-	if user == nil {
-		ttl = 5 * time.Minute // Shorter TTL for non-existent users
+	result := "cache_miss"
+	if hit {
+		result = "cache_hit"
 	}
-	r.cache.Set(ctx, cacheKey, user, ttl)
-
+	span.SetTag("cache_hit", strconv.FormatBool(hit))
 	r.telemetry.TrackMetric(ctx, telemetry.Metric{
-		Name:  "repository.user.find_by_email.cache_miss",
+		Name:  "repository.user.find_by_email." + result,
 		Value: 1,
-		Tags:  map[string]string{"email": email},
 	})
 
 	return user, nil
 }
 
-func (r *userRepository) FindByID(id string) (*User, error) {
-	ctx := context.Background()
+func (r *userRepository) FindByID(ctx context.Context, id string) (*User, error) {
 	span, ctx := r.telemetry.StartSpan(ctx, "repository.user.find_by_id")
 	defer span.End()
 
 	span.SetTag("user_id", id)
 
-	// Try cache first
-	cacheKey := fmt.Sprintf("user:id:%s", id)
-	if cached, err := r.cache.Get(ctx, cacheKey); err == nil && cached != nil {
-		span.SetTag("cache_hit", "true")
-		if user, ok := cached.(*User); ok {
-			r.telemetry.TrackMetric(ctx, telemetry.Metric{
-				Name:  "repository.user.find_by_id.cache_hit",
-				Value: 1,
-				Tags:  map[string]string{"user_id": id},
-			})
-			return user, nil
-		}
-	}
-
-	// Cache miss - query database
-	span.SetTag("cache_hit", "false")
-	user, err := r.base.findByID(id)
+	// Cache-aside com coalescência: misses concorrentes da mesma chave fazem uma consulta só
+	// (a consulta é compartilhada, então não herda o cancelamento de quem a iniciou)
+	cacheKey := userKeys.Global("id", id)
+	user, hit, err := r.lookup.Load(ctx, cacheKey, func() (*User, error) {
+		return r.base.findByID(context.WithoutCancel(ctx), id)
+	})
 	if err != nil {
 		span.SetError(err)
 		return nil, err
 	}
 
-	// Cache the result
-	if user != nil {
-		r.cache.Set(ctx, cacheKey, user, 10*time.Minute)
+	result := "cache_miss"
+	if hit {
+		result = "cache_hit"
 	}
-
+	span.SetTag("cache_hit", strconv.FormatBool(hit))
 	r.telemetry.TrackMetric(ctx, telemetry.Metric{
-		Name:  "repository.user.find_by_id.cache_miss",
+		Name:  "repository.user.find_by_id." + result,
 		Value: 1,
 		Tags:  map[string]string{"user_id": id},
 	})
 
 	return user, nil
-} 
\ No newline at end of file
+}
@@ -32,8 +32,7 @@ func NewAuthService(
 	}
 }
 
-func (s *authService) RegisterUser(req RegisterRequest) (*User, *tenants.Tenant, error) {
-	ctx := context.Background()
+func (s *authService) RegisterUser(ctx context.Context, req RegisterRequest) (*User, *tenants.Tenant, error) {
 	span, ctx := s.telemetry.StartSpan(ctx, "auth.register_user")
 	defer span.End()
 
@@ -41,11 +40,10 @@ func (s *authService) RegisterUser(req RegisterRequest) (*User, *tenants.Tenant,
 	s.telemetry.TrackMetric(ctx, telemetry.Metric{
 		Name:  "auth.register.attempt",
 		Value: 1,
-		Tags:  map[string]string{"email": req.Email},
 	})
 
 	// Verificar se email já existe (cache já está no repository)
-	existingUser, err := s.userRepo.FindByEmail(req.Email)
+	existingUser, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		span.SetError(err)
 		return nil, nil, err
@@ -64,7 +62,7 @@ func (s *authService) RegisterUser(req RegisterRequest) (*User, *tenants.Tenant,
 
 	// Criar tenant usando o repository
 	tenant := &tenants.Tenant{Name: req.Name}
-	err = s.tenantRepo.Create(tenant)
+	err = s.tenantRepo.Create(ctx, tenant)
 	if err != nil {
 		span.SetError(err)
 		return nil, nil, err
@@ -76,7 +74,7 @@ func (s *authService) RegisterUser(req RegisterRequest) (*User, *tenants.Tenant,
 		Email:        req.Email,
 		PasswordHash: string(hashedPassword),
 	}
-	err = s.userRepo.Create(user)
+	err = s.userRepo.Create(ctx, user)
 	if err != nil {
 		span.SetError(err)
 		return nil, nil, err
@@ -88,7 +86,6 @@ func (s *authService) RegisterUser(req RegisterRequest) (*User, *tenants.Tenant,
 		Properties: map[string]interface{}{
 			"user_id":   user.ID.String(),
 			"tenant_id": tenant.ID.String(),
-			"email":     req.Email,
 		},
 		Timestamp: time.Now(),
 	})
@@ -96,8 +93,7 @@ func (s *authService) RegisterUser(req RegisterRequest) (*User, *tenants.Tenant,
 	return user, tenant, nil
 }
 
-func (s *authService) LoginUser(req LoginRequest) (*User, error) {
-	ctx := context.Background()
+func (s *authService) LoginUser(ctx context.Context, req LoginRequest) (*User, error) {
 	span, ctx := s.telemetry.StartSpan(ctx, "auth.login_user")
 	defer span.End()
 
@@ -105,11 +101,10 @@ func (s *authService) LoginUser(req LoginRequest) (*User, error) {
 	s.telemetry.TrackMetric(ctx, telemetry.Metric{
 		Name:  "auth.login.attempt",
 		Value: 1,
-		Tags:  map[string]string{"email": req.Email},
 	})
 
 	// Buscar user por email (cache já está no repository)
-	user, err := s.userRepo.FindByEmail(req.Email)
+	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		span.SetError(err)
 		return nil, err
@@ -131,7 +126,6 @@ func (s *authService) LoginUser(req LoginRequest) (*User, error) {
 		Name: "auth.login.success",
 		Properties: map[string]interface{}{
 			"user_id": user.ID.String(),
-			"email":   req.Email,
 		},
 		Timestamp: time.Now(),
 	})
@@ -0,0 +1,252 @@
+package businesshours
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	// Containers mínimos não trazem o banco de fusos do sistema
+	_ "time/tzdata"
+)
+
+// maxLookaheadDays limita a busca pela próxima abertura (calendário sempre fechado)
+const maxLookaheadDays = 400
+
+var locations sync.Map
+
+func loadLocation(name string) (*time.Location, error) {
+	if cached, ok := locations.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, location)
+	return location, nil
+}
+
+// Location retorna o fuso do calendário; fuso inválido cai no padrão
+func (c *Calendar) Location() *time.Location {
+	if location, err := loadLocation(c.Timezone); err == nil && c.Timezone != "" {
+		return location
+	}
+	location, _ := loadLocation(DefaultTimezone)
+	return location
+}
+
+// Status é o resultado da avaliação do calendário em um instante
+type Status struct {
+	Open        bool       `json:"open"`
+	Reason      string     `json:"reason,omitempty"` // feriado ou data especial que define o dia
+	NextOpening *time.Time `json:"next_opening,omitempty"`
+	NextClosing *time.Time `json:"next_closing,omitempty"`
+}
+
+type window struct {
+	start, end time.Time
+}
+
+// IsOpen indica se o atendimento está aberto; calendário ausente é sempre aberto
+func (c *Calendar) IsOpen(at time.Time) bool {
+	if c == nil {
+		return true
+	}
+	for _, w := range c.windows(at) {
+		if !at.Before(w.start) && at.Before(w.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate calcula o status no instante e a próxima mudança (abertura ou fechamento)
+func (c *Calendar) Evaluate(at time.Time) Status {
+	if c == nil {
+		return Status{Open: true}
+	}
+
+	_, reason := c.dayIntervals(at.In(c.Location()))
+	status := Status{Reason: reason}
+	if c.IsOpen(at) {
+		status.Open = true
+		if closing, ok := c.NextClosing(at); ok {
+			status.NextClosing = &closing
+		}
+		return status
+	}
+	if opening, ok := c.NextOpening(at); ok {
+		status.NextOpening = &opening
+	}
+	return status
+}
+
+// NextOpening retorna o próximo início de expediente a partir de at (ou at, se aberto)
+func (c *Calendar) NextOpening(at time.Time) (time.Time, bool) {
+	if c == nil {
+		return at, true
+	}
+	if c.IsOpen(at) {
+		return at, true
+	}
+
+	local := at.In(c.Location())
+	for offset := 0; offset < maxLookaheadDays; offset++ {
+		for _, w := range c.windows(local.AddDate(0, 0, offset)) {
+			if w.start.After(at) {
+				return w.start, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// NextClosing retorna o fim do expediente em curso; faixas contíguas entre dias são unidas
+func (c *Calendar) NextClosing(at time.Time) (time.Time, bool) {
+	if c == nil || !c.IsOpen(at) {
+		return time.Time{}, false
+	}
+
+	local := at.In(c.Location())
+	closing := at
+	for offset := 0; offset < maxLookaheadDays; offset++ {
+		extended := false
+		for _, w := range c.windows(local.AddDate(0, 0, offset)) {
+			if !closing.Before(w.start) && closing.Before(w.end) {
+				closing = w.end
+				extended = true
+			}
+		}
+		if !extended && offset > 0 {
+			return closing, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// windows retorna as faixas de atendimento do dia local de at, em ordem
+func (c *Calendar) windows(at time.Time) []window {
+	location := c.Location()
+	local := at.In(location)
+	intervals, _ := c.dayIntervals(local)
+
+	year, month, day := local.Date()
+	result := make([]window, 0, len(intervals))
+	for _, interval := range intervals {
+		start, errStart := parseClock(interval.Start)
+		end, errEnd := parseClock(interval.End)
+		if errStart != nil || errEnd != nil || start >= end {
+			continue
+		}
+		result = append(result, window{
+			start: time.Date(year, month, day, start/60, start%60, 0, 0, location),
+			end:   time.Date(year, month, day, end/60, end%60, 0, 0, location),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].start.Before(result[j].start) })
+	return result
+}
+
+// dayIntervals aplica a precedência: data especial, feriado e grade semanal
+func (c *Calendar) dayIntervals(local time.Time) ([]Interval, string) {
+	date := local.Format(dateLayout)
+
+	for _, special := range c.SpecialDates {
+		if special.Date != date {
+			continue
+		}
+		if special.Closed {
+			return nil, special.Name
+		}
+		return special.Intervals, special.Name
+	}
+
+	if c.ObserveNationalHolidays {
+		for _, holiday := range NationalHolidays(local.Year()) {
+			if holiday.Date == date && (!holiday.Optional || c.ObserveOptionalHolidays) {
+				return nil, holiday.Name
+			}
+		}
+	}
+
+	for name, weekday := range weekdayNames {
+		if weekday == local.Weekday() {
+			return c.Weekly[name], ""
+		}
+	}
+	return nil, ""
+}
+
+var weekdaysPT = [...]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"}
+
+// FormatOpening descreve a próxima abertura para o cliente ("segunda-feira, 02/06 às 08:00")
+func (c *Calendar) FormatOpening(opening time.Time) string {
+	local := opening.In(c.Location())
+	return fmt.Sprintf("%s, %s às %s", weekdaysPT[local.Weekday()], local.Format("02/01"), local.Format("15:04"))
+}
+
+// AddBusinessTime soma d contando só o expediente; calendário ausente soma tempo corrido
+func (c *Calendar) AddBusinessTime(from time.Time, d time.Duration) time.Time {
+	if c == nil {
+		return from.Add(d)
+	}
+
+	remaining := d
+	local := from.In(c.Location())
+	for offset := 0; offset < maxLookaheadDays; offset++ {
+		for _, w := range c.windows(local.AddDate(0, 0, offset)) {
+			if !w.end.After(from) {
+				continue
+			}
+			start := w.start
+			if from.After(start) {
+				start = from
+			}
+			available := w.end.Sub(start)
+			if remaining <= available {
+				return start.Add(remaining)
+			}
+			remaining -= available
+		}
+	}
+	// Calendário sem expediente à vista: cai no tempo corrido
+	return from.Add(d)
+}
+
+// BusinessDuration mede quanto do intervalo [from, to) caiu dentro do expediente
+func (c *Calendar) BusinessDuration(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	if c == nil {
+		return to.Sub(from)
+	}
+
+	location := c.Location()
+	local := from.In(location)
+	days := int(civilDate(to.In(location)).Sub(civilDate(local)) / (24 * time.Hour))
+
+	var total time.Duration
+	for offset := 0; offset <= days; offset++ {
+		for _, w := range c.windows(local.AddDate(0, 0, offset)) {
+			start, end := w.start, w.end
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+	}
+	return total
+}
+
+// civilDate descarta hora e fuso para contar dias de calendário sem efeito de horário de verão
+func civilDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
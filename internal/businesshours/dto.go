@@ -0,0 +1,17 @@
+package businesshours
+
+type SaveCalendarRequest struct {
+	Timezone                string         `json:"timezone"`
+	Weekly                  WeeklySchedule `json:"weekly" binding:"required"`
+	SpecialDates            []SpecialDate  `json:"special_dates"`
+	ObserveNationalHolidays *bool          `json:"observe_national_holidays"`
+	ObserveOptionalHolidays bool           `json:"observe_optional_holidays"`
+	AwayMessageEnabled      bool           `json:"away_message_enabled"`
+	AwayMessage             string         `json:"away_message"`
+	AwayCooldownMinutes     *int           `json:"away_cooldown_minutes"`
+}
+
+type HolidayListResponse struct {
+	Year  int       `json:"year"`
+	Items []Holiday `json:"items"`
+}
@@ -0,0 +1,108 @@
+package businesshours
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+)
+
+type BusinessHoursHandler struct {
+	hoursService BusinessHoursService
+}
+
+func NewBusinessHoursHandler(hoursService BusinessHoursService) *BusinessHoursHandler {
+	return &BusinessHoursHandler{
+		hoursService: hoursService,
+	}
+}
+
+func (h *BusinessHoursHandler) Get(c *gin.Context) {
+	calendar, err := h.hoursService.GetCalendar(c.Request.Context(), web.TenantID(c))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+func (h *BusinessHoursHandler) Save(c *gin.Context) {
+	var req SaveCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	calendar, err := h.hoursService.SaveCalendar(c.Request.Context(), web.TenantID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+func (h *BusinessHoursHandler) Delete(c *gin.Context) {
+	if err := h.hoursService.DeleteCalendar(c.Request.Context(), web.TenantID(c)); err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Status avalia o calendário agora ou em ?at=2025-06-02T10:00:00-03:00
+func (h *BusinessHoursHandler) Status(c *gin.Context) {
+	at := time.Now()
+	if value := c.Query("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.ErrorBody(c, "at must be RFC3339"))
+			return
+		}
+		at = parsed
+	}
+
+	status, err := h.hoursService.Status(c.Request.Context(), web.TenantID(c), at)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *BusinessHoursHandler) Holidays(c *gin.Context) {
+	year := time.Now().Year()
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1900 || parsed > 2999 {
+			c.JSON(http.StatusBadRequest, web.ErrorBody(c, "invalid year"))
+			return
+		}
+		year = parsed
+	}
+
+	holidays, err := h.hoursService.Holidays(c.Request.Context(), web.TenantID(c), year)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, HolidayListResponse{Year: year, Items: holidays})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrCalendarNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidTimezone),
+		errors.Is(err, ErrInvalidSchedule):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
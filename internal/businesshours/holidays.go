@@ -0,0 +1,79 @@
+package businesshours
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday é um feriado nacional; Optional marca os pontos facultativos (carnaval e Corpus Christi)
+type Holiday struct {
+	Date     string `json:"date"` // YYYY-MM-DD
+	Name     string `json:"name"`
+	Optional bool   `json:"optional"`
+	Custom   bool   `json:"custom,omitempty"`
+}
+
+// NationalHolidays lista os feriados nacionais brasileiros do ano, em ordem
+func NationalHolidays(year int) []Holiday {
+	easter := easterSunday(year)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	holidays := []struct {
+		date     time.Time
+		name     string
+		optional bool
+	}{
+		{day(time.January, 1), "Confraternização Universal", false},
+		{easter.AddDate(0, 0, -48), "Carnaval", true},
+		{easter.AddDate(0, 0, -47), "Carnaval", true},
+		{easter.AddDate(0, 0, -2), "Sexta-feira Santa", false},
+		{day(time.April, 21), "Tiradentes", false},
+		{day(time.May, 1), "Dia do Trabalho", false},
+		{easter.AddDate(0, 0, 60), "Corpus Christi", true},
+		{day(time.September, 7), "Independência do Brasil", false},
+		{day(time.October, 12), "Nossa Senhora Aparecida", false},
+		{day(time.November, 2), "Finados", false},
+		{day(time.November, 15), "Proclamação da República", false},
+		{day(time.December, 25), "Natal", false},
+	}
+	// Lei 14.759/2023
+	if year >= 2024 {
+		holidays = append(holidays, struct {
+			date     time.Time
+			name     string
+			optional bool
+		}{day(time.November, 20), "Dia Nacional de Zumbi e da Consciência Negra", false})
+	}
+
+	result := make([]Holiday, 0, len(holidays))
+	for _, h := range holidays {
+		result = append(result, Holiday{Date: h.date.Format(dateLayout), Name: h.name, Optional: h.optional})
+	}
+	sortHolidays(result)
+	return result
+}
+
+func sortHolidays(holidays []Holiday) {
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date < holidays[j].Date })
+}
+
+// easterSunday usa o algoritmo de Meeus/Jones/Butcher para o calendário gregoriano
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
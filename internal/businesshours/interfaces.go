@@ -0,0 +1,25 @@
+package businesshours
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CalendarRepository interface {
+	FindByTenant(ctx context.Context, tenantID uuid.UUID) (*Calendar, error)
+	// Save cria ou atualiza o calendário do tenant
+	Save(ctx context.Context, calendar *Calendar) error
+	Delete(ctx context.Context, tenantID uuid.UUID) error
+}
+
+type BusinessHoursService interface {
+	// GetCalendar retorna ErrCalendarNotFound quando o tenant não configurou horário (sempre aberto)
+	GetCalendar(ctx context.Context, tenantID uuid.UUID) (*Calendar, error)
+	SaveCalendar(ctx context.Context, tenantID uuid.UUID, req SaveCalendarRequest) (*Calendar, error)
+	DeleteCalendar(ctx context.Context, tenantID uuid.UUID) error
+	Status(ctx context.Context, tenantID uuid.UUID, at time.Time) (*Status, error)
+	// Holidays lista os feriados nacionais observados e as datas fechadas do tenant no ano
+	Holidays(ctx context.Context, tenantID uuid.UUID, year int) ([]Holiday, error)
+}
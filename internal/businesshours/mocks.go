@@ -0,0 +1,80 @@
+package businesshours
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MockCalendarRepository para testes
+type MockCalendarRepository struct {
+	FindByTenantFunc func(ctx context.Context, tenantID uuid.UUID) (*Calendar, error)
+	SaveFunc         func(ctx context.Context, calendar *Calendar) error
+	DeleteFunc       func(ctx context.Context, tenantID uuid.UUID) error
+}
+
+func (m *MockCalendarRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) (*Calendar, error) {
+	if m.FindByTenantFunc != nil {
+		return m.FindByTenantFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarRepository) Save(ctx context.Context, calendar *Calendar) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, calendar)
+	}
+	return nil
+}
+
+func (m *MockCalendarRepository) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID)
+	}
+	return nil
+}
+
+// MockBusinessHoursService para testes
+type MockBusinessHoursService struct {
+	GetCalendarFunc    func(ctx context.Context, tenantID uuid.UUID) (*Calendar, error)
+	SaveCalendarFunc   func(ctx context.Context, tenantID uuid.UUID, req SaveCalendarRequest) (*Calendar, error)
+	DeleteCalendarFunc func(ctx context.Context, tenantID uuid.UUID) error
+	StatusFunc         func(ctx context.Context, tenantID uuid.UUID, at time.Time) (*Status, error)
+	HolidaysFunc       func(ctx context.Context, tenantID uuid.UUID, year int) ([]Holiday, error)
+}
+
+func (m *MockBusinessHoursService) GetCalendar(ctx context.Context, tenantID uuid.UUID) (*Calendar, error) {
+	if m.GetCalendarFunc != nil {
+		return m.GetCalendarFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockBusinessHoursService) SaveCalendar(ctx context.Context, tenantID uuid.UUID, req SaveCalendarRequest) (*Calendar, error) {
+	if m.SaveCalendarFunc != nil {
+		return m.SaveCalendarFunc(ctx, tenantID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockBusinessHoursService) DeleteCalendar(ctx context.Context, tenantID uuid.UUID) error {
+	if m.DeleteCalendarFunc != nil {
+		return m.DeleteCalendarFunc(ctx, tenantID)
+	}
+	return nil
+}
+
+func (m *MockBusinessHoursService) Status(ctx context.Context, tenantID uuid.UUID, at time.Time) (*Status, error) {
+	if m.StatusFunc != nil {
+		return m.StatusFunc(ctx, tenantID, at)
+	}
+	return nil, nil
+}
+
+func (m *MockBusinessHoursService) Holidays(ctx context.Context, tenantID uuid.UUID, year int) ([]Holiday, error) {
+	if m.HolidaysFunc != nil {
+		return m.HolidaysFunc(ctx, tenantID, year)
+	}
+	return nil, nil
+}
@@ -0,0 +1,163 @@
+package businesshours
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultTimezone            = "America/Sao_Paulo"
+	DefaultAwayCooldownMinutes = 720
+
+	dateLayout = "2006-01-02"
+)
+
+var (
+	ErrCalendarNotFound = errors.New("business hours calendar not found")
+	ErrInvalidTimezone  = errors.New("invalid timezone")
+	ErrInvalidSchedule  = errors.New("invalid business hours schedule")
+)
+
+// Interval é uma faixa de atendimento no horário local ("08:00" a "18:00"; "24:00" fecha o dia)
+type Interval struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// WeeklySchedule mapeia o dia da semana ("monday" ... "sunday") às faixas de atendimento;
+// dia ausente ou vazio é fechado
+type WeeklySchedule map[string][]Interval
+
+// SpecialDate sobrepõe um dia específico: fechado ou com horário próprio
+type SpecialDate struct {
+	Date      string     `json:"date"` // YYYY-MM-DD
+	Name      string     `json:"name,omitempty"`
+	Closed    bool       `json:"closed"`
+	Intervals []Interval `json:"intervals,omitempty"`
+}
+
+// Calendar define o horário de atendimento do tenant
+type Calendar struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"tenant_id"`
+	Timezone string    `gorm:"type:varchar(64);not null" json:"timezone"`
+
+	Weekly       WeeklySchedule `gorm:"type:text;serializer:json" json:"weekly"`
+	SpecialDates []SpecialDate  `gorm:"type:text;serializer:json" json:"special_dates"`
+
+	// Feriados nacionais fecham o atendimento; os facultativos só quando marcados
+	ObserveNationalHolidays bool `gorm:"not null" json:"observe_national_holidays"`
+	ObserveOptionalHolidays bool `gorm:"not null;default:false" json:"observe_optional_holidays"`
+
+	AwayMessageEnabled  bool   `gorm:"not null;default:false" json:"away_message_enabled"`
+	AwayMessage         string `gorm:"type:text" json:"away_message,omitempty"`
+	AwayCooldownMinutes int    `gorm:"not null;default:0" json:"away_cooldown_minutes"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	location *time.Location
+}
+
+func (c *Calendar) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Validate confere fuso, dias, faixas e datas especiais
+func (c *Calendar) Validate() error {
+	if _, err := time.LoadLocation(c.Timezone); err != nil || c.Timezone == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidTimezone, c.Timezone)
+	}
+
+	for day, intervals := range c.Weekly {
+		if _, ok := weekdayNames[day]; !ok {
+			return fmt.Errorf("%w: unknown weekday %q", ErrInvalidSchedule, day)
+		}
+		if err := validateIntervals(intervals); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidSchedule, day, err)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, special := range c.SpecialDates {
+		if _, err := time.Parse(dateLayout, special.Date); err != nil {
+			return fmt.Errorf("%w: invalid date %q", ErrInvalidSchedule, special.Date)
+		}
+		if seen[special.Date] {
+			return fmt.Errorf("%w: duplicated date %s", ErrInvalidSchedule, special.Date)
+		}
+		seen[special.Date] = true
+		if !special.Closed && len(special.Intervals) == 0 {
+			return fmt.Errorf("%w: %s must be closed or have intervals", ErrInvalidSchedule, special.Date)
+		}
+		if err := validateIntervals(special.Intervals); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidSchedule, special.Date, err)
+		}
+	}
+
+	if c.AwayCooldownMinutes < 0 {
+		return fmt.Errorf("%w: away_cooldown_minutes must not be negative", ErrInvalidSchedule)
+	}
+	if c.AwayMessageEnabled && strings.TrimSpace(c.AwayMessage) == "" {
+		return fmt.Errorf("%w: away_message is required when enabled", ErrInvalidSchedule)
+	}
+	return nil
+}
+
+func validateIntervals(intervals []Interval) error {
+	type span struct{ start, end int }
+	spans := make([]span, 0, len(intervals))
+	for _, interval := range intervals {
+		start, err := parseClock(interval.Start)
+		if err != nil {
+			return err
+		}
+		end, err := parseClock(interval.End)
+		if err != nil {
+			return err
+		}
+		if start >= end {
+			return fmt.Errorf("interval %s-%s ends before it starts", interval.Start, interval.End)
+		}
+		for _, other := range spans {
+			if start < other.end && other.start < end {
+				return fmt.Errorf("interval %s-%s overlaps another interval", interval.Start, interval.End)
+			}
+		}
+		spans = append(spans, span{start, end})
+	}
+	return nil
+}
+
+// parseClock converte "HH:MM" em minutos desde a meia-noite
+func parseClock(value string) (int, error) {
+	var hour, minute int
+	if len(value) != 5 || value[2] != ':' {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	if _, err := fmt.Sscanf(value, "%02d:%02d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	if hour > 24 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	return hour*60 + minute, nil
+}
@@ -0,0 +1,98 @@
+package businesshours
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type calendarRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newCalendarRepositoryBase(db *gorm.DB) *calendarRepositoryBase {
+	return &calendarRepositoryBase{db: db}
+}
+
+func (r *calendarRepositoryBase) findByTenant(ctx context.Context, tenantID uuid.UUID) (*Calendar, error) {
+	var calendar Calendar
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&calendar).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+func (r *calendarRepositoryBase) save(ctx context.Context, calendar *Calendar) error {
+	if calendar.ID == uuid.Nil {
+		return r.db.WithContext(ctx).Create(calendar).Error
+	}
+	// Select("*") grava também os booleanos falsos
+	return r.db.WithContext(ctx).Select("*").Save(calendar).Error
+}
+
+func (r *calendarRepositoryBase) delete(ctx context.Context, tenantID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Delete(&Calendar{}).Error
+}
+
+// Repository com telemetria (decorator)
+type calendarRepository struct {
+	base      *calendarRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewCalendarRepository(db *gorm.DB, telemetry telemetry.TelemetryService) CalendarRepository {
+	return &calendarRepository{
+		base:      newCalendarRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *calendarRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) (*Calendar, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.business_hours.find_by_tenant")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	calendar, err := r.base.findByTenant(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func (r *calendarRepository) Save(ctx context.Context, calendar *Calendar) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.business_hours.save")
+	defer span.End()
+
+	span.SetTag("tenant_id", calendar.TenantID.String())
+
+	err := r.base.save(ctx, calendar)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *calendarRepository) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.business_hours.delete")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	err := r.base.delete(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
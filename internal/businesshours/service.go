@@ -0,0 +1,136 @@
+package businesshours
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+type businessHoursService struct {
+	calendarRepo CalendarRepository
+	telemetry    telemetry.TelemetryService
+}
+
+func NewBusinessHoursService(calendarRepo CalendarRepository, telemetry telemetry.TelemetryService) BusinessHoursService {
+	return &businessHoursService{
+		calendarRepo: calendarRepo,
+		telemetry:    telemetry,
+	}
+}
+
+func (s *businessHoursService) GetCalendar(ctx context.Context, tenantID uuid.UUID) (*Calendar, error) {
+	calendar, err := s.calendarRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if calendar == nil {
+		return nil, ErrCalendarNotFound
+	}
+	return calendar, nil
+}
+
+func (s *businessHoursService) SaveCalendar(ctx context.Context, tenantID uuid.UUID, req SaveCalendarRequest) (*Calendar, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "business_hours.save")
+	defer span.End()
+
+	calendar, err := s.calendarRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if calendar == nil {
+		calendar = &Calendar{TenantID: tenantID}
+	}
+
+	calendar.Timezone = req.Timezone
+	if calendar.Timezone == "" {
+		calendar.Timezone = DefaultTimezone
+	}
+	calendar.Weekly = req.Weekly
+	calendar.SpecialDates = req.SpecialDates
+	if calendar.SpecialDates == nil {
+		calendar.SpecialDates = []SpecialDate{}
+	}
+	calendar.ObserveNationalHolidays = req.ObserveNationalHolidays == nil || *req.ObserveNationalHolidays
+	calendar.ObserveOptionalHolidays = req.ObserveOptionalHolidays
+	calendar.AwayMessageEnabled = req.AwayMessageEnabled
+	calendar.AwayMessage = req.AwayMessage
+	calendar.AwayCooldownMinutes = DefaultAwayCooldownMinutes
+	if req.AwayCooldownMinutes != nil {
+		calendar.AwayCooldownMinutes = *req.AwayCooldownMinutes
+	}
+
+	if err := calendar.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.calendarRepo.Save(ctx, calendar); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "business_hours.saved",
+		Properties: map[string]interface{}{
+			"tenant_id":            tenantID.String(),
+			"timezone":             calendar.Timezone,
+			"special_dates":        len(calendar.SpecialDates),
+			"away_message_enabled": calendar.AwayMessageEnabled,
+		},
+		Timestamp: time.Now(),
+	})
+
+	return calendar, nil
+}
+
+func (s *businessHoursService) DeleteCalendar(ctx context.Context, tenantID uuid.UUID) error {
+	if _, err := s.GetCalendar(ctx, tenantID); err != nil {
+		return err
+	}
+	return s.calendarRepo.Delete(ctx, tenantID)
+}
+
+func (s *businessHoursService) Status(ctx context.Context, tenantID uuid.UUID, at time.Time) (*Status, error) {
+	calendar, err := s.calendarRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	// Sem calendário o atendimento é considerado sempre aberto
+	status := calendar.Evaluate(at)
+	return &status, nil
+}
+
+func (s *businessHoursService) Holidays(ctx context.Context, tenantID uuid.UUID, year int) ([]Holiday, error) {
+	calendar, err := s.calendarRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Datas especiais sobrepõem o feriado nacional do mesmo dia
+	overridden := map[string]bool{}
+	holidays := []Holiday{}
+	if calendar != nil {
+		prefix := fmt.Sprintf("%04d-", year)
+		for _, special := range calendar.SpecialDates {
+			overridden[special.Date] = true
+			if special.Closed && strings.HasPrefix(special.Date, prefix) {
+				holidays = append(holidays, Holiday{Date: special.Date, Name: special.Name, Custom: true})
+			}
+		}
+	}
+	if calendar == nil || calendar.ObserveNationalHolidays {
+		for _, holiday := range NationalHolidays(year) {
+			if overridden[holiday.Date] {
+				continue
+			}
+			if !holiday.Optional || (calendar != nil && calendar.ObserveOptionalHolidays) {
+				holidays = append(holidays, holiday)
+			}
+		}
+	}
+	sortHolidays(holidays)
+	return holidays, nil
+}
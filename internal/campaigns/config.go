@@ -0,0 +1,58 @@
+package campaigns
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMessagesPerSecond = 20
+	// Tier inicial da Meta: 1.000 conversas iniciadas pela empresa a cada 24h
+	defaultDailyLimit = 1000
+	// Um envio leva segundos; "sending" por mais tempo que isso é de uma instância que caiu
+	defaultClaimTimeout = 5 * time.Minute
+	// Erros transitórios do provider (limite de vazão, 5xx, rede) tentam de novo com
+	// espera dobrada a cada falha
+	defaultMaxAttempts  = 5
+	defaultRetryBackoff = 30 * time.Second
+)
+
+type Config struct {
+	// Vazão máxima de envios por número
+	MessagesPerSecond float64
+	// Limite de destinatários únicos em 24h do tier do número
+	DailyLimit int
+	// ClaimTimeout é quanto um destinatário pode ficar em "sending" antes de ser dado como falho
+	ClaimTimeout time.Duration
+	// MaxAttempts é o total de tentativas de um destinatário antes de marcá-lo como falho
+	MaxAttempts int
+	// RetryBackoff é a espera após a primeira falha transitória
+	RetryBackoff time.Duration
+}
+
+func LoadConfig() Config {
+	config := Config{
+		MessagesPerSecond: defaultMessagesPerSecond,
+		DailyLimit:        defaultDailyLimit,
+		ClaimTimeout:      defaultClaimTimeout,
+		MaxAttempts:       defaultMaxAttempts,
+		RetryBackoff:      defaultRetryBackoff,
+	}
+	if value, err := strconv.ParseFloat(os.Getenv("CAMPAIGN_MESSAGES_PER_SECOND"), 64); err == nil && value > 0 {
+		config.MessagesPerSecond = value
+	}
+	if value, err := strconv.Atoi(os.Getenv("WHATSAPP_MESSAGING_LIMIT")); err == nil && value > 0 {
+		config.DailyLimit = value
+	}
+	if value, err := time.ParseDuration(os.Getenv("CAMPAIGN_CLAIM_TIMEOUT")); err == nil && value > 0 {
+		config.ClaimTimeout = value
+	}
+	if value, err := strconv.Atoi(os.Getenv("CAMPAIGN_MAX_ATTEMPTS")); err == nil && value > 0 {
+		config.MaxAttempts = value
+	}
+	if value, err := time.ParseDuration(os.Getenv("CAMPAIGN_RETRY_BACKOFF")); err == nil && value > 0 {
+		config.RetryBackoff = value
+	}
+	return config
+}
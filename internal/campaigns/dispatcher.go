@@ -0,0 +1,299 @@
+package campaigns
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+)
+
+const (
+	dispatchBatchSize = 50
+	tierWindow        = 24 * time.Hour
+	maxRetryDelay     = 30 * time.Minute
+)
+
+// Dispatcher envia periodicamente os destinatários pendentes das campanhas em execução,
+// respeitando a vazão e o limite diário do tier de cada número de envio.
+type Dispatcher struct {
+	campaignRepo    CampaignRepository
+	customerRepo    customers.CustomerRepository
+	templateService whatsapp.TemplateService
+	channelService  whatsapp.ChannelService
+	telemetry       telemetry.TelemetryService
+	config          Config
+	interval        time.Duration
+
+	mu sync.Mutex
+	// limiters é indexado pelo phone_number_id: a vazão da Meta é por número
+	limiters map[string]*RateLimiter
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(
+	campaignRepo CampaignRepository,
+	customerRepo customers.CustomerRepository,
+	templateService whatsapp.TemplateService,
+	channelService whatsapp.ChannelService,
+	telemetry telemetry.TelemetryService,
+	config Config,
+	interval time.Duration,
+) *Dispatcher {
+	if config.ClaimTimeout <= 0 {
+		config.ClaimTimeout = defaultClaimTimeout
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+	return &Dispatcher{
+		campaignRepo:    campaignRepo,
+		customerRepo:    customerRepo,
+		templateService: templateService,
+		channelService:  channelService,
+		telemetry:       telemetry,
+		config:          config,
+		interval:        interval,
+		limiters:        make(map[string]*RateLimiter),
+	}
+}
+
+func (d *Dispatcher) Start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				d.RunOnce(ctx, now)
+			}
+		}
+	}()
+}
+
+func (d *Dispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+// RunOnce envia o que a vazão permitir em now e retorna quantas mensagens saíram
+func (d *Dispatcher) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	span, ctx := d.telemetry.StartSpan(ctx, "campaigns.dispatcher.run")
+	defer span.End()
+
+	// Reservas de instâncias que caíram no meio do envio, senão a campanha nunca conclui
+	stale, err := d.campaignRepo.FailStaleRecipients(ctx, now.Add(-d.config.ClaimTimeout))
+	if err != nil {
+		span.SetError(err)
+	} else if stale > 0 {
+		d.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "campaigns.recipients.stale",
+			Value: float64(stale),
+		})
+	}
+
+	dispatchable, err := d.campaignRepo.FindDispatchable(ctx, now, dispatchBatchSize)
+	if err != nil {
+		span.SetError(err)
+		return 0, err
+	}
+
+	sent := 0
+	for i := range dispatchable {
+		count, err := d.dispatch(ctx, &dispatchable[i], now)
+		if err != nil {
+			span.SetError(err)
+		}
+		sent += count
+	}
+
+	d.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "campaigns.messages.sent",
+		Value: float64(sent),
+	})
+
+	return sent, nil
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, campaign *Campaign, now time.Time) (int, error) {
+	if campaign.Status == CampaignScheduled {
+		campaign.Status = CampaignRunning
+		campaign.StartedAt = &now
+		started, err := d.campaignRepo.UpdateStatus(ctx, campaign, CampaignScheduled)
+		if err != nil || !started {
+			// Cancelada ou iniciada por outra instância desde a busca
+			return 0, err
+		}
+	}
+
+	// Vazão e tier são do número de envio, que pode ser compartilhado por várias campanhas
+	channel, err := d.channelService.SelectOutbound(ctx, campaign.TenantID, campaign.channelID(), "")
+	if err != nil {
+		return 0, err
+	}
+
+	sentInWindow, err := d.campaignRepo.CountSentSince(ctx, channel.PhoneNumberID, now.Add(-tierWindow))
+	if err != nil {
+		return 0, err
+	}
+	remaining := int64(d.config.DailyLimit) - sentInWindow
+
+	limiter := d.limiter(channel.PhoneNumberID)
+	sent := 0
+	for {
+		if remaining <= 0 {
+			d.telemetry.TrackMetric(ctx, telemetry.Metric{
+				Name:  "campaigns.dispatcher.tier_limit_reached",
+				Value: 1,
+				Tags:  map[string]string{"phone_number_id": channel.PhoneNumberID},
+			})
+			return sent, nil
+		}
+		if !limiter.Allow(now) {
+			return sent, nil
+		}
+
+		recipient, err := d.campaignRepo.ClaimNextRecipient(ctx, campaign.ID, now)
+		if err != nil {
+			return sent, err
+		}
+		if recipient == nil {
+			return sent, d.completeIfDone(ctx, campaign, now)
+		}
+
+		result, err := d.send(ctx, campaign, recipient, now)
+		if err != nil {
+			return sent, err
+		}
+		switch result {
+		case sendOK:
+			sent++
+			remaining--
+		case sendDeferred:
+			// O número está limitado ou o provider instável: o resto espera o próximo ciclo
+			return sent, nil
+		}
+	}
+}
+
+type sendResult int
+
+const (
+	sendOK sendResult = iota
+	// sendDropped: destinatário pulado (opt-out) ou com falha definitiva
+	sendDropped
+	// sendDeferred: erro transitório, o destinatário volta para a fila
+	sendDeferred
+)
+
+func (d *Dispatcher) send(ctx context.Context, campaign *Campaign, recipient *CampaignRecipient, now time.Time) (sendResult, error) {
+	// Recarrega o cliente: o opt-out pode ter acontecido depois do início da campanha
+	customer, err := d.customerRepo.FindByID(ctx, campaign.TenantID, recipient.CustomerID.String())
+	if err != nil {
+		return sendDropped, err
+	}
+	if customer == nil || customer.OptedOut() {
+		recipient.Status = RecipientSkipped
+		recipient.Error = "customer opted out"
+		return sendDropped, d.campaignRepo.UpdateRecipient(ctx, recipient)
+	}
+
+	req := whatsapp.SendTemplateRequest{
+		To:        customer.Phone,
+		Variables: RenderVariables(campaign.Variables, customer),
+		ChannelID: campaign.channelID(),
+	}
+	message, err := d.templateService.SendTemplate(ctx, campaign.TenantID, campaign.CreatedByID, campaign.TemplateID.String(), req)
+	if err != nil {
+		return d.fail(ctx, recipient, err, now)
+	}
+
+	recipient.Status = RecipientSent
+	recipient.WAMessageID = message.WAMessageID
+	recipient.PhoneNumberID = message.PhoneNumberID
+	recipient.SentAt = &now
+	recipient.NextAttemptAt = nil
+	return sendOK, d.campaignRepo.UpdateRecipient(ctx, recipient)
+}
+
+// fail devolve o destinatário para a fila com espera exponencial quando o erro é
+// transitório; número inválido, template rejeitado e afins falham de vez
+func (d *Dispatcher) fail(ctx context.Context, recipient *CampaignRecipient, sendErr error, now time.Time) (sendResult, error) {
+	recipient.Attempts++
+	recipient.Error = sendErr.Error()
+
+	if !whatsapp.IsRetryable(sendErr) || recipient.Attempts >= d.config.MaxAttempts {
+		recipient.Status = RecipientFailed
+		recipient.NextAttemptAt = nil
+		return sendDropped, d.campaignRepo.UpdateRecipient(ctx, recipient)
+	}
+
+	next := now.Add(d.retryDelay(recipient.Attempts))
+	recipient.Status = RecipientPending
+	recipient.ClaimedAt = nil
+	recipient.NextAttemptAt = &next
+	d.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "campaigns.recipients.retried",
+		Value: 1,
+		Tags:  map[string]string{"campaign_id": recipient.CampaignID.String()},
+	})
+	return sendDeferred, d.campaignRepo.UpdateRecipient(ctx, recipient)
+}
+
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.config.RetryBackoff
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+func (d *Dispatcher) completeIfDone(ctx context.Context, campaign *Campaign, now time.Time) error {
+	counts, err := d.campaignRepo.CountRecipientsByStatus(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	// Outra instância ainda pode estar enviando
+	if counts[RecipientPending]+counts[RecipientSending] > 0 {
+		return nil
+	}
+
+	// Só conclui o que ainda está em execução: um cancelamento concorrente prevalece
+	campaign.Status = CampaignCompleted
+	campaign.CompletedAt = &now
+	_, err = d.campaignRepo.UpdateStatus(ctx, campaign, CampaignRunning)
+	return err
+}
+
+func (d *Dispatcher) limiter(phoneNumberID string) *RateLimiter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	limiter, ok := d.limiters[phoneNumberID]
+	if !ok {
+		limiter = NewRateLimiter(d.config.MessagesPerSecond, int(math.Max(1, d.config.MessagesPerSecond)))
+		d.limiters[phoneNumberID] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,30 @@
+package campaigns
+
+import (
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+)
+
+type CreateCampaignRequest struct {
+	Name        string                     `json:"name" binding:"required"`
+	TemplateID  string                     `json:"template_id" binding:"required,uuid"`
+	SegmentID   string                     `json:"segment_id" binding:"required,uuid"`
+	ChannelID   *string                    `json:"channel_id" binding:"omitempty,uuid"`
+	Variables   whatsapp.TemplateVariables `json:"variables"`
+	ScheduledAt *time.Time                 `json:"scheduled_at"`
+}
+
+type CampaignListResponse struct {
+	Items    []Campaign `json:"items"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"page_size"`
+	Total    int64      `json:"total"`
+}
+
+type RecipientListResponse struct {
+	Items    []CampaignRecipient `json:"items"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"page_size"`
+	Total    int64               `json:"total"`
+}
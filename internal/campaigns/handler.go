@@ -0,0 +1,119 @@
+package campaigns
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/gin-gonic/gin"
+)
+
+type CampaignHandler struct {
+	campaignService CampaignService
+}
+
+func NewCampaignHandler(campaignService CampaignService) *CampaignHandler {
+	return &CampaignHandler{
+		campaignService: campaignService,
+	}
+}
+
+func (h *CampaignHandler) Create(c *gin.Context) {
+	var req CreateCampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	campaign, err := h.campaignService.CreateCampaign(c.Request.Context(), web.TenantID(c), web.UserID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, campaign)
+}
+
+func (h *CampaignHandler) List(c *gin.Context) {
+	pagination := web.ParsePagination(c)
+
+	items, total, err := h.campaignService.ListCampaigns(c.Request.Context(), web.TenantID(c), pagination.Offset(), pagination.PageSize)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, CampaignListResponse{
+		Items:    items,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+		Total:    total,
+	})
+}
+
+// Get inclui as estatísticas de entrega, leitura e resposta
+func (h *CampaignHandler) Get(c *gin.Context) {
+	campaign, err := h.campaignService.GetCampaign(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
+
+func (h *CampaignHandler) Start(c *gin.Context) {
+	campaign, err := h.campaignService.StartCampaign(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
+
+func (h *CampaignHandler) Cancel(c *gin.Context) {
+	campaign, err := h.campaignService.CancelCampaign(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
+
+func (h *CampaignHandler) Recipients(c *gin.Context) {
+	pagination := web.ParsePagination(c)
+	status := RecipientStatus(c.Query("status"))
+
+	items, total, err := h.campaignService.ListRecipients(c.Request.Context(), web.TenantID(c), c.Param("id"), status, pagination.Offset(), pagination.PageSize)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, RecipientListResponse{
+		Items:    items,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+		Total:    total,
+	})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrCampaignNotFound),
+		errors.Is(err, whatsapp.ErrTemplateNotFound),
+		errors.Is(err, customers.ErrSegmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrCampaignNotDraft), errors.Is(err, ErrCampaignFinished):
+		return http.StatusConflict
+	case errors.Is(err, whatsapp.ErrTemplateNotApproved):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrScheduleInPast), errors.Is(err, ErrInvalidRecipientStatus):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,47 @@
+package campaigns
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+type CampaignRepository interface {
+	Create(ctx context.Context, campaign *Campaign) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error)
+	List(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]Campaign, int64, error)
+	// UpdateStatus grava status, started_at e completed_at só se o status atual ainda
+	// for um de from; false quando outra escrita (um cancelamento, por exemplo) chegou antes
+	UpdateStatus(ctx context.Context, campaign *Campaign, from ...CampaignStatus) (bool, error)
+	// FindDispatchable retorna as campanhas em execução ou agendadas para até now
+	FindDispatchable(ctx context.Context, now time.Time, limit int) ([]Campaign, error)
+
+	// CreateRecipients ignora clientes que já são destinatários da campanha
+	CreateRecipients(ctx context.Context, recipients []CampaignRecipient) error
+	// ClaimNextRecipient move o próximo pendente para "sending" enquanto a campanha estiver
+	// em execução, pulando os que aguardam nova tentativa; nil quando não há mais
+	ClaimNextRecipient(ctx context.Context, campaignID uuid.UUID, now time.Time) (*CampaignRecipient, error)
+	// FailStaleRecipients marca como falhos os "sending" reservados antes de before
+	FailStaleRecipients(ctx context.Context, before time.Time) (int64, error)
+	UpdateRecipient(ctx context.Context, recipient *CampaignRecipient) error
+	FindRecipientByWAMessageID(ctx context.Context, waMessageID string) (*CampaignRecipient, error)
+	// FindLatestRecipientByPhone busca o último envio ao telefone desde since, para atribuir respostas
+	FindLatestRecipientByPhone(ctx context.Context, tenantID uuid.UUID, phone string, since time.Time) (*CampaignRecipient, error)
+	ListRecipients(ctx context.Context, campaignID uuid.UUID, status RecipientStatus, offset, limit int) ([]CampaignRecipient, int64, error)
+	CountRecipientsByStatus(ctx context.Context, campaignID uuid.UUID) (map[RecipientStatus]int64, error)
+	// CountSentSince conta os envios do número na janela do limite diário da Meta
+	CountSentSince(ctx context.Context, phoneNumberID string, since time.Time) (int64, error)
+}
+
+type CampaignService interface {
+	// HandleEvent é registrado como listener do WebhookService
+	HandleEvent(ctx context.Context, event whatsapp.Event)
+	CreateCampaign(ctx context.Context, tenantID, userID uuid.UUID, req CreateCampaignRequest) (*Campaign, error)
+	GetCampaign(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error)
+	ListCampaigns(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]Campaign, int64, error)
+	StartCampaign(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error)
+	CancelCampaign(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error)
+	ListRecipients(ctx context.Context, tenantID uuid.UUID, id string, status RecipientStatus, offset, limit int) ([]CampaignRecipient, int64, error)
+}
@@ -0,0 +1,124 @@
+package campaigns
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MockCampaignRepository para testes
+type MockCampaignRepository struct {
+	CreateFunc                     func(ctx context.Context, campaign *Campaign) error
+	FindByIDFunc                   func(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error)
+	ListFunc                       func(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]Campaign, int64, error)
+	UpdateStatusFunc               func(ctx context.Context, campaign *Campaign, from ...CampaignStatus) (bool, error)
+	FindDispatchableFunc           func(ctx context.Context, now time.Time, limit int) ([]Campaign, error)
+	CreateRecipientsFunc           func(ctx context.Context, recipients []CampaignRecipient) error
+	ClaimNextRecipientFunc         func(ctx context.Context, campaignID uuid.UUID, now time.Time) (*CampaignRecipient, error)
+	FailStaleRecipientsFunc        func(ctx context.Context, before time.Time) (int64, error)
+	UpdateRecipientFunc            func(ctx context.Context, recipient *CampaignRecipient) error
+	FindRecipientByWAMessageIDFunc func(ctx context.Context, waMessageID string) (*CampaignRecipient, error)
+	FindLatestRecipientByPhoneFunc func(ctx context.Context, tenantID uuid.UUID, phone string, since time.Time) (*CampaignRecipient, error)
+	ListRecipientsFunc             func(ctx context.Context, campaignID uuid.UUID, status RecipientStatus, offset, limit int) ([]CampaignRecipient, int64, error)
+	CountRecipientsByStatusFunc    func(ctx context.Context, campaignID uuid.UUID) (map[RecipientStatus]int64, error)
+	CountSentSinceFunc             func(ctx context.Context, phoneNumberID string, since time.Time) (int64, error)
+}
+
+func (m *MockCampaignRepository) Create(ctx context.Context, campaign *Campaign) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, campaign)
+	}
+	return nil
+}
+
+func (m *MockCampaignRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockCampaignRepository) List(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]Campaign, int64, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, tenantID, offset, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockCampaignRepository) UpdateStatus(ctx context.Context, campaign *Campaign, from ...CampaignStatus) (bool, error) {
+	if m.UpdateStatusFunc != nil {
+		return m.UpdateStatusFunc(ctx, campaign, from...)
+	}
+	return true, nil
+}
+
+func (m *MockCampaignRepository) FindDispatchable(ctx context.Context, now time.Time, limit int) ([]Campaign, error) {
+	if m.FindDispatchableFunc != nil {
+		return m.FindDispatchableFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockCampaignRepository) CreateRecipients(ctx context.Context, recipients []CampaignRecipient) error {
+	if m.CreateRecipientsFunc != nil {
+		return m.CreateRecipientsFunc(ctx, recipients)
+	}
+	return nil
+}
+
+func (m *MockCampaignRepository) ClaimNextRecipient(ctx context.Context, campaignID uuid.UUID, now time.Time) (*CampaignRecipient, error) {
+	if m.ClaimNextRecipientFunc != nil {
+		return m.ClaimNextRecipientFunc(ctx, campaignID, now)
+	}
+	return nil, nil
+}
+
+func (m *MockCampaignRepository) FailStaleRecipients(ctx context.Context, before time.Time) (int64, error) {
+	if m.FailStaleRecipientsFunc != nil {
+		return m.FailStaleRecipientsFunc(ctx, before)
+	}
+	return 0, nil
+}
+
+func (m *MockCampaignRepository) UpdateRecipient(ctx context.Context, recipient *CampaignRecipient) error {
+	if m.UpdateRecipientFunc != nil {
+		return m.UpdateRecipientFunc(ctx, recipient)
+	}
+	return nil
+}
+
+func (m *MockCampaignRepository) FindRecipientByWAMessageID(ctx context.Context, waMessageID string) (*CampaignRecipient, error) {
+	if m.FindRecipientByWAMessageIDFunc != nil {
+		return m.FindRecipientByWAMessageIDFunc(ctx, waMessageID)
+	}
+	return nil, nil
+}
+
+func (m *MockCampaignRepository) FindLatestRecipientByPhone(ctx context.Context, tenantID uuid.UUID, phone string, since time.Time) (*CampaignRecipient, error) {
+	if m.FindLatestRecipientByPhoneFunc != nil {
+		return m.FindLatestRecipientByPhoneFunc(ctx, tenantID, phone, since)
+	}
+	return nil, nil
+}
+
+func (m *MockCampaignRepository) ListRecipients(ctx context.Context, campaignID uuid.UUID, status RecipientStatus, offset, limit int) ([]CampaignRecipient, int64, error) {
+	if m.ListRecipientsFunc != nil {
+		return m.ListRecipientsFunc(ctx, campaignID, status, offset, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockCampaignRepository) CountRecipientsByStatus(ctx context.Context, campaignID uuid.UUID) (map[RecipientStatus]int64, error) {
+	if m.CountRecipientsByStatusFunc != nil {
+		return m.CountRecipientsByStatusFunc(ctx, campaignID)
+	}
+	return nil, nil
+}
+
+func (m *MockCampaignRepository) CountSentSince(ctx context.Context, phoneNumberID string, since time.Time) (int64, error) {
+	if m.CountSentSinceFunc != nil {
+		return m.CountSentSinceFunc(ctx, phoneNumberID, since)
+	}
+	return 0, nil
+}
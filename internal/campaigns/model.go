@@ -0,0 +1,148 @@
+package campaigns
+
+import (
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CampaignStatus string
+
+const (
+	CampaignDraft     CampaignStatus = "draft"
+	CampaignScheduled CampaignStatus = "scheduled"
+	CampaignRunning   CampaignStatus = "running"
+	CampaignCompleted CampaignStatus = "completed"
+	CampaignCanceled  CampaignStatus = "canceled"
+)
+
+// Campaign envia um template aprovado para todos os clientes de um segmento
+type Campaign struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name       string         `gorm:"type:varchar(255);not null" json:"name"`
+	TemplateID uuid.UUID      `gorm:"type:uuid;not null" json:"template_id"`
+	SegmentID  uuid.UUID      `gorm:"type:uuid;not null" json:"segment_id"`
+	Status     CampaignStatus `gorm:"type:varchar(16);not null;index" json:"status"`
+
+	// ChannelID é o número de envio; nulo usa o canal padrão do tenant
+	ChannelID *uuid.UUID `gorm:"type:uuid" json:"channel_id,omitempty"`
+
+	// Variables aceita {{customer.name}}, {{customer.first_name}}, {{customer.phone}}
+	// e {{customer.email}}, com valor padrão opcional: {{customer.first_name|cliente}}
+	Variables whatsapp.TemplateVariables `gorm:"type:text;serializer:json" json:"variables"`
+
+	ScheduledAt *time.Time `gorm:"index" json:"scheduled_at,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedByID uuid.UUID  `gorm:"type:uuid;not null" json:"created_by_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Stats *CampaignStats `gorm:"-" json:"stats,omitempty"`
+}
+
+func (c *Campaign) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// channelID é o canal no formato aceito pelo whatsapp; vazio usa o padrão do tenant
+func (c *Campaign) channelID() string {
+	if c.ChannelID == nil {
+		return ""
+	}
+	return c.ChannelID.String()
+}
+
+type RecipientStatus string
+
+const (
+	RecipientPending   RecipientStatus = "pending"
+	RecipientSending   RecipientStatus = "sending"
+	RecipientSent      RecipientStatus = "sent"
+	RecipientDelivered RecipientStatus = "delivered"
+	RecipientRead      RecipientStatus = "read"
+	RecipientReplied   RecipientStatus = "replied"
+	RecipientFailed    RecipientStatus = "failed"
+	RecipientSkipped   RecipientStatus = "skipped"
+)
+
+var recipientStatusRank = map[RecipientStatus]int{
+	RecipientPending:   0,
+	RecipientSending:   1,
+	RecipientSent:      2,
+	RecipientDelivered: 3,
+	RecipientRead:      4,
+	RecipientReplied:   5,
+}
+
+// CanTransitionTo mantém o status monotônico mesmo com webhooks fora de ordem
+func (s RecipientStatus) CanTransitionTo(next RecipientStatus) bool {
+	if s == RecipientFailed || s == RecipientSkipped {
+		return false
+	}
+	if next == RecipientFailed || next == RecipientSkipped {
+		return s != RecipientReplied
+	}
+	return recipientStatusRank[next] > recipientStatusRank[s]
+}
+
+// CampaignRecipient é o envio da campanha para um cliente
+type CampaignRecipient struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID    uuid.UUID       `gorm:"type:uuid;not null;index:idx_campaign_recipients_phone,priority:1" json:"tenant_id"`
+	CampaignID  uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_campaign_recipients_customer,priority:1;index:idx_campaign_recipients_status,priority:1" json:"campaign_id"`
+	CustomerID  uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_campaign_recipients_customer,priority:2" json:"customer_id"`
+	Phone       string          `gorm:"type:varchar(32);not null;index:idx_campaign_recipients_phone,priority:2" json:"phone"`
+	Status      RecipientStatus `gorm:"type:varchar(16);not null;index:idx_campaign_recipients_status,priority:2" json:"status"`
+	WAMessageID string          `gorm:"column:wa_message_id;type:varchar(128);index" json:"wa_message_id,omitempty"`
+	Error       string          `gorm:"type:varchar(512)" json:"error,omitempty"`
+	ClaimedAt   *time.Time      `json:"-"` // reserva do dispatcher, para achar envios órfãos
+	// PhoneNumberID é o número que enviou: o limite diário da Meta é por número
+	PhoneNumberID string     `gorm:"type:varchar(64);index:idx_campaign_recipients_number_sent,priority:1" json:"phone_number_id,omitempty"`
+	SentAt        *time.Time `gorm:"index;index:idx_campaign_recipients_number_sent,priority:2" json:"sent_at,omitempty"`
+	// Attempts conta as falhas transitórias; NextAttemptAt adia a próxima reserva
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	RepliedAt     *time.Time `json:"replied_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (r *CampaignRecipient) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// CampaignStats é cumulativo: quem leu também conta como entregue e enviado
+type CampaignStats struct {
+	Total     int64 `json:"total"`
+	Pending   int64 `json:"pending"`
+	Sent      int64 `json:"sent"`
+	Delivered int64 `json:"delivered"`
+	Read      int64 `json:"read"`
+	Replied   int64 `json:"replied"`
+	Failed    int64 `json:"failed"`
+	Skipped   int64 `json:"skipped"`
+}
+
+func NewCampaignStats(counts map[RecipientStatus]int64) *CampaignStats {
+	stats := &CampaignStats{
+		Pending: counts[RecipientPending] + counts[RecipientSending],
+		Replied: counts[RecipientReplied],
+		Failed:  counts[RecipientFailed],
+		Skipped: counts[RecipientSkipped],
+	}
+	stats.Read = stats.Replied + counts[RecipientRead]
+	stats.Delivered = stats.Read + counts[RecipientDelivered]
+	stats.Sent = stats.Delivered + counts[RecipientSent]
+	stats.Total = stats.Sent + stats.Pending + stats.Failed + stats.Skipped
+	return stats
+}
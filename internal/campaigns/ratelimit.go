@@ -0,0 +1,42 @@
+package campaigns
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter é um token bucket: até burst envios imediatos, repostos a rate por segundo
+type RateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// Allow consome um token se houver disponível em now
+func (l *RateLimiter) Allow(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
@@ -0,0 +1,416 @@
+package campaigns
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository base (sem telemetria)
+type campaignRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newCampaignRepositoryBase(db *gorm.DB) *campaignRepositoryBase {
+	return &campaignRepositoryBase{db: db}
+}
+
+func (r *campaignRepositoryBase) create(ctx context.Context, campaign *Campaign) error {
+	return r.db.WithContext(ctx).Create(campaign).Error
+}
+
+func (r *campaignRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error) {
+	var campaign Campaign
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&campaign).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+func (r *campaignRepositoryBase) list(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]Campaign, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Campaign{}).Where("tenant_id = ?", tenantID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []Campaign
+	err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// updateStatus é condicional: um Save da linha lida antes desfaria um cancelamento concorrente
+func (r *campaignRepositoryBase) updateStatus(ctx context.Context, campaign *Campaign, from []CampaignStatus) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Campaign{}).
+		Where("id = ? AND status IN ?", campaign.ID, from).
+		Updates(map[string]interface{}{
+			"status":       campaign.Status,
+			"started_at":   campaign.StartedAt,
+			"completed_at": campaign.CompletedAt,
+			"updated_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *campaignRepositoryBase) findDispatchable(ctx context.Context, now time.Time, limit int) ([]Campaign, error) {
+	var items []Campaign
+	err := r.db.WithContext(ctx).
+		Where("status = ? OR (status = ? AND scheduled_at <= ?)", CampaignRunning, CampaignScheduled, now).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *campaignRepositoryBase) createRecipients(ctx context.Context, recipients []CampaignRecipient) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "campaign_id"}, {Name: "customer_id"}},
+		DoNothing: true,
+	}).Create(&recipients).Error
+}
+
+func (r *campaignRepositoryBase) findRecipient(ctx context.Context, query *gorm.DB) (*CampaignRecipient, error) {
+	var recipient CampaignRecipient
+	err := query.First(&recipient).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &recipient, nil
+}
+
+// claimNextRecipient usa update condicional para que duas instâncias não enviem ao mesmo
+// cliente, e para de reservar assim que a campanha é cancelada
+func (r *campaignRepositoryBase) claimNextRecipient(ctx context.Context, campaignID uuid.UUID, now time.Time) (*CampaignRecipient, error) {
+	for {
+		recipient, err := r.findRecipient(ctx, r.db.WithContext(ctx).
+			Where("campaign_id = ? AND status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", campaignID, RecipientPending, now).
+			Order("created_at ASC, id ASC"))
+		if err != nil || recipient == nil {
+			return nil, err
+		}
+
+		running := r.db.WithContext(ctx).Model(&Campaign{}).Select("1").Where("id = ? AND status = ?", campaignID, CampaignRunning)
+		result := r.db.WithContext(ctx).Model(&CampaignRecipient{}).
+			Where("id = ? AND status = ? AND EXISTS (?)", recipient.ID, RecipientPending, running).
+			Updates(map[string]interface{}{"status": RecipientSending, "claimed_at": now, "updated_at": time.Now()})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			recipient.Status = RecipientSending
+			recipient.ClaimedAt = &now
+			return recipient, nil
+		}
+
+		// Sem reserva: ou outra instância pegou este, ou a campanha saiu de execução
+		var stillRunning int64
+		err = r.db.WithContext(ctx).Model(&Campaign{}).Where("id = ? AND status = ?", campaignID, CampaignRunning).Count(&stillRunning).Error
+		if err != nil || stillRunning == 0 {
+			return nil, err
+		}
+	}
+}
+
+// failStaleRecipients não devolve a reserva para "pending": a instância pode ter caído
+// depois de enviar, e reenviar uma campanha é pior do que perder um destinatário
+func (r *campaignRepositoryBase) failStaleRecipients(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&CampaignRecipient{}).
+		Where("status = ? AND (claimed_at IS NULL OR claimed_at < ?)", RecipientSending, before).
+		Updates(map[string]interface{}{
+			"status":     RecipientFailed,
+			"error":      "dispatch interrupted",
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *campaignRepositoryBase) updateRecipient(ctx context.Context, recipient *CampaignRecipient) error {
+	return r.db.WithContext(ctx).Save(recipient).Error
+}
+
+func (r *campaignRepositoryBase) findRecipientByWAMessageID(ctx context.Context, waMessageID string) (*CampaignRecipient, error) {
+	return r.findRecipient(ctx, r.db.WithContext(ctx).Where("wa_message_id = ?", waMessageID))
+}
+
+func (r *campaignRepositoryBase) findLatestRecipientByPhone(ctx context.Context, tenantID uuid.UUID, phone string, since time.Time) (*CampaignRecipient, error) {
+	return r.findRecipient(ctx, r.db.WithContext(ctx).
+		Where("tenant_id = ? AND phone = ? AND sent_at >= ?", tenantID, phone, since).
+		Order("sent_at DESC"))
+}
+
+func (r *campaignRepositoryBase) listRecipients(ctx context.Context, campaignID uuid.UUID, status RecipientStatus, offset, limit int) ([]CampaignRecipient, int64, error) {
+	query := r.db.WithContext(ctx).Model(&CampaignRecipient{}).Where("campaign_id = ?", campaignID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []CampaignRecipient
+	err := query.Order("created_at ASC, id ASC").Offset(offset).Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *campaignRepositoryBase) countRecipientsByStatus(ctx context.Context, campaignID uuid.UUID) (map[RecipientStatus]int64, error) {
+	var rows []struct {
+		Status RecipientStatus
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).Model(&CampaignRecipient{}).
+		Select("status, COUNT(*) AS count").
+		Where("campaign_id = ?", campaignID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[RecipientStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *campaignRepositoryBase) countSentSince(ctx context.Context, phoneNumberID string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&CampaignRecipient{}).
+		Where("phone_number_id = ? AND sent_at >= ?", phoneNumberID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// Repository com telemetria (decorator)
+type campaignRepository struct {
+	base      *campaignRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewCampaignRepository(db *gorm.DB, telemetry telemetry.TelemetryService) CampaignRepository {
+	return &campaignRepository{
+		base:      newCampaignRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *campaignRepository) Create(ctx context.Context, campaign *Campaign) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", campaign.TenantID.String())
+
+	err := r.base.create(ctx, campaign)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *campaignRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.find_by_id")
+	defer span.End()
+
+	span.SetTag("campaign_id", id)
+
+	campaign, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return campaign, nil
+}
+
+func (r *campaignRepository) List(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]Campaign, int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	items, total, err := r.base.list(ctx, tenantID, offset, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *campaignRepository) UpdateStatus(ctx context.Context, campaign *Campaign, from ...CampaignStatus) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.update_status")
+	defer span.End()
+
+	span.SetTag("campaign_id", campaign.ID.String())
+	span.SetTag("status", string(campaign.Status))
+
+	updated, err := r.base.updateStatus(ctx, campaign, from)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return updated, nil
+}
+
+func (r *campaignRepository) FindDispatchable(ctx context.Context, now time.Time, limit int) ([]Campaign, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.find_dispatchable")
+	defer span.End()
+
+	items, err := r.base.findDispatchable(ctx, now, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *campaignRepository) CreateRecipients(ctx context.Context, recipients []CampaignRecipient) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.create_recipients")
+	defer span.End()
+
+	err := r.base.createRecipients(ctx, recipients)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *campaignRepository) ClaimNextRecipient(ctx context.Context, campaignID uuid.UUID, now time.Time) (*CampaignRecipient, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.claim_next_recipient")
+	defer span.End()
+
+	span.SetTag("campaign_id", campaignID.String())
+
+	recipient, err := r.base.claimNextRecipient(ctx, campaignID, now)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return recipient, nil
+}
+
+func (r *campaignRepository) FailStaleRecipients(ctx context.Context, before time.Time) (int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.fail_stale_recipients")
+	defer span.End()
+
+	count, err := r.base.failStaleRecipients(ctx, before)
+	if err != nil {
+		span.SetError(err)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *campaignRepository) UpdateRecipient(ctx context.Context, recipient *CampaignRecipient) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.update_recipient")
+	defer span.End()
+
+	span.SetTag("recipient_id", recipient.ID.String())
+
+	err := r.base.updateRecipient(ctx, recipient)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *campaignRepository) FindRecipientByWAMessageID(ctx context.Context, waMessageID string) (*CampaignRecipient, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.find_recipient_by_wa_message_id")
+	defer span.End()
+
+	span.SetTag("wa_message_id", waMessageID)
+
+	recipient, err := r.base.findRecipientByWAMessageID(ctx, waMessageID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return recipient, nil
+}
+
+func (r *campaignRepository) FindLatestRecipientByPhone(ctx context.Context, tenantID uuid.UUID, phone string, since time.Time) (*CampaignRecipient, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.find_latest_recipient_by_phone")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	recipient, err := r.base.findLatestRecipientByPhone(ctx, tenantID, phone, since)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return recipient, nil
+}
+
+func (r *campaignRepository) ListRecipients(ctx context.Context, campaignID uuid.UUID, status RecipientStatus, offset, limit int) ([]CampaignRecipient, int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.list_recipients")
+	defer span.End()
+
+	span.SetTag("campaign_id", campaignID.String())
+
+	items, total, err := r.base.listRecipients(ctx, campaignID, status, offset, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *campaignRepository) CountRecipientsByStatus(ctx context.Context, campaignID uuid.UUID) (map[RecipientStatus]int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.count_recipients_by_status")
+	defer span.End()
+
+	span.SetTag("campaign_id", campaignID.String())
+
+	counts, err := r.base.countRecipientsByStatus(ctx, campaignID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *campaignRepository) CountSentSince(ctx context.Context, phoneNumberID string, since time.Time) (int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.campaign.count_sent_since")
+	defer span.End()
+
+	span.SetTag("phone_number_id", phoneNumberID)
+
+	count, err := r.base.countSentSince(ctx, phoneNumberID, since)
+	if err != nil {
+		span.SetError(err)
+		return 0, err
+	}
+	return count, nil
+}
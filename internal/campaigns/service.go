@@ -0,0 +1,348 @@
+package campaigns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+const (
+	recipientBatchSize = 500
+	// Respostas recebidas até 72h após o envio são atribuídas à campanha
+	replyAttributionWindow = 72 * time.Hour
+)
+
+var (
+	ErrCampaignNotFound       = errors.New("campaign not found")
+	ErrCampaignNotDraft       = errors.New("campaign already started")
+	ErrCampaignFinished       = errors.New("campaign already finished")
+	ErrScheduleInPast         = errors.New("scheduled_at must be in the future")
+	ErrInvalidRecipientStatus = errors.New("invalid recipient status")
+)
+
+// optOutKeywords são as palavras que o cliente pode responder para sair das campanhas
+var optOutKeywords = map[string]bool{
+	"SAIR":         true,
+	"PARAR":        true,
+	"STOP":         true,
+	"CANCELAR":     true,
+	"DESCADASTRAR": true,
+}
+
+type campaignService struct {
+	campaignRepo    CampaignRepository
+	templateService whatsapp.TemplateService
+	segmentService  customers.SegmentService
+	customerService customers.CustomerService
+	telemetry       telemetry.TelemetryService
+}
+
+func NewCampaignService(
+	campaignRepo CampaignRepository,
+	templateService whatsapp.TemplateService,
+	segmentService customers.SegmentService,
+	customerService customers.CustomerService,
+	telemetry telemetry.TelemetryService,
+) CampaignService {
+	return &campaignService{
+		campaignRepo:    campaignRepo,
+		templateService: templateService,
+		segmentService:  segmentService,
+		customerService: customerService,
+		telemetry:       telemetry,
+	}
+}
+
+func (s *campaignService) CreateCampaign(ctx context.Context, tenantID, userID uuid.UUID, req CreateCampaignRequest) (*Campaign, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "campaigns.create_campaign")
+	defer span.End()
+
+	template, err := s.templateService.GetTemplate(ctx, tenantID, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.Status != whatsapp.TemplateApproved {
+		return nil, whatsapp.ErrTemplateNotApproved
+	}
+	segment, err := s.segmentService.GetSegment(ctx, tenantID, req.SegmentID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ScheduledAt != nil && !req.ScheduledAt.After(time.Now()) {
+		return nil, ErrScheduleInPast
+	}
+
+	campaign := &Campaign{
+		TenantID:    tenantID,
+		Name:        req.Name,
+		TemplateID:  template.ID,
+		SegmentID:   segment.ID,
+		Status:      CampaignDraft,
+		Variables:   req.Variables,
+		ScheduledAt: req.ScheduledAt,
+		CreatedByID: userID,
+	}
+	if req.ChannelID != nil {
+		channelID := uuid.MustParse(*req.ChannelID)
+		campaign.ChannelID = &channelID
+	}
+	if err := s.campaignRepo.Create(ctx, campaign); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "campaigns.created",
+		Properties: map[string]interface{}{
+			"campaign_id": campaign.ID.String(),
+			"tenant_id":   tenantID.String(),
+			"template_id": template.ID.String(),
+		},
+		Timestamp: time.Now(),
+	})
+
+	return campaign, nil
+}
+
+func (s *campaignService) GetCampaign(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error) {
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.campaignRepo.CountRecipientsByStatus(ctx, campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	campaign.Stats = NewCampaignStats(counts)
+
+	return campaign, nil
+}
+
+func (s *campaignService) ListCampaigns(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]Campaign, int64, error) {
+	return s.campaignRepo.List(ctx, tenantID, offset, limit)
+}
+
+// StartCampaign materializa os destinatários do segmento e libera a campanha para o dispatcher
+func (s *campaignService) StartCampaign(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "campaigns.start_campaign")
+	defer span.End()
+
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != CampaignDraft {
+		return nil, ErrCampaignNotDraft
+	}
+
+	total, skipped, err := s.createRecipients(ctx, campaign)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	if campaign.ScheduledAt != nil && campaign.ScheduledAt.After(now) {
+		campaign.Status = CampaignScheduled
+	} else {
+		campaign.Status = CampaignRunning
+		campaign.StartedAt = &now
+	}
+	updated, err := s.campaignRepo.UpdateStatus(ctx, campaign, CampaignDraft)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrCampaignNotDraft
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "campaigns.started",
+		Properties: map[string]interface{}{
+			"campaign_id": campaign.ID.String(),
+			"tenant_id":   tenantID.String(),
+			"recipients":  total,
+			"skipped":     skipped,
+			"status":      string(campaign.Status),
+		},
+		Timestamp: now,
+	})
+
+	return s.GetCampaign(ctx, tenantID, id)
+}
+
+func (s *campaignService) createRecipients(ctx context.Context, campaign *Campaign) (int, int, error) {
+	total, skipped := 0, 0
+	for offset := 0; ; offset += recipientBatchSize {
+		page, err := s.segmentService.ListSegmentCustomers(ctx, campaign.TenantID, campaign.SegmentID.String(), offset, recipientBatchSize)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		recipients := make([]CampaignRecipient, 0, len(page))
+		for _, customer := range page {
+			recipient := CampaignRecipient{
+				TenantID:   campaign.TenantID,
+				CampaignID: campaign.ID,
+				CustomerID: customer.ID,
+				Phone:      customer.Phone,
+				Status:     RecipientPending,
+			}
+			if customer.OptedOut() {
+				recipient.Status = RecipientSkipped
+				recipient.Error = "customer opted out"
+				skipped++
+			}
+			recipients = append(recipients, recipient)
+		}
+		if err := s.campaignRepo.CreateRecipients(ctx, recipients); err != nil {
+			return 0, 0, err
+		}
+		total += len(page)
+
+		if len(page) < recipientBatchSize {
+			return total, skipped, nil
+		}
+	}
+}
+
+// CancelCampaign interrompe os envios pendentes; o que já foi enviado continua sendo rastreado
+func (s *campaignService) CancelCampaign(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error) {
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status == CampaignCompleted || campaign.Status == CampaignCanceled {
+		return nil, ErrCampaignFinished
+	}
+
+	now := time.Now()
+	campaign.Status = CampaignCanceled
+	campaign.CompletedAt = &now
+	updated, err := s.campaignRepo.UpdateStatus(ctx, campaign, CampaignDraft, CampaignScheduled, CampaignRunning)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrCampaignFinished
+	}
+
+	return s.GetCampaign(ctx, tenantID, id)
+}
+
+func (s *campaignService) ListRecipients(ctx context.Context, tenantID uuid.UUID, id string, status RecipientStatus, offset, limit int) ([]CampaignRecipient, int64, error) {
+	if status != "" {
+		if _, ok := recipientStatusRank[status]; !ok && status != RecipientFailed && status != RecipientSkipped {
+			return nil, 0, ErrInvalidRecipientStatus
+		}
+	}
+
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.campaignRepo.ListRecipients(ctx, campaign.ID, status, offset, limit)
+}
+
+func (s *campaignService) findCampaign(ctx context.Context, tenantID uuid.UUID, id string) (*Campaign, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrCampaignNotFound
+	}
+
+	campaign, err := s.campaignRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign == nil {
+		return nil, ErrCampaignNotFound
+	}
+	return campaign, nil
+}
+
+func (s *campaignService) HandleEvent(ctx context.Context, event whatsapp.Event) {
+	span, ctx := s.telemetry.StartSpan(ctx, "campaigns.handle_event")
+	defer span.End()
+
+	span.SetTag("event", event.EventName())
+
+	var err error
+	switch e := event.(type) {
+	case whatsapp.StatusUpdateEvent:
+		err = s.handleStatus(ctx, e)
+	case whatsapp.InboundMessageEvent:
+		err = s.handleInbound(ctx, e)
+	}
+	if err != nil {
+		span.SetError(err)
+	}
+}
+
+var recipientStatusFor = map[whatsapp.MessageStatus]RecipientStatus{
+	whatsapp.StatusSent:      RecipientSent,
+	whatsapp.StatusDelivered: RecipientDelivered,
+	whatsapp.StatusRead:      RecipientRead,
+	whatsapp.StatusFailed:    RecipientFailed,
+}
+
+func (s *campaignService) handleStatus(ctx context.Context, event whatsapp.StatusUpdateEvent) error {
+	status, ok := recipientStatusFor[event.Status]
+	if !ok {
+		return nil
+	}
+
+	recipient, err := s.campaignRepo.FindRecipientByWAMessageID(ctx, event.WAMessageID)
+	if err != nil || recipient == nil {
+		return err
+	}
+	if !recipient.Status.CanTransitionTo(status) {
+		return nil
+	}
+
+	recipient.Status = status
+	if status == RecipientFailed {
+		recipient.Error = strings.TrimSpace(event.ErrorCode + " " + event.ErrorMessage)
+	}
+	return s.campaignRepo.UpdateRecipient(ctx, recipient)
+}
+
+func (s *campaignService) handleInbound(ctx context.Context, event whatsapp.InboundMessageEvent) error {
+	message := event.Message
+	phone := customers.NormalizePhone(message.From)
+
+	if optOutKeywords[strings.ToUpper(strings.TrimSpace(message.Body))] {
+		_, err := s.customerService.OptOutByPhone(ctx, event.TenantID, phone)
+		if errors.Is(err, customers.ErrCustomerNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	recipient, err := s.campaignRepo.FindLatestRecipientByPhone(ctx, event.TenantID, phone, message.Timestamp.Add(-replyAttributionWindow))
+	if err != nil || recipient == nil {
+		return err
+	}
+	if !recipient.Status.CanTransitionTo(RecipientReplied) {
+		return nil
+	}
+
+	recipient.Status = RecipientReplied
+	recipient.RepliedAt = &message.Timestamp
+	if err := s.campaignRepo.UpdateRecipient(ctx, recipient); err != nil {
+		return err
+	}
+
+	s.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "campaigns.recipient.replied",
+		Value: 1,
+		Tags:  map[string]string{"campaign_id": recipient.CampaignID.String()},
+	})
+	return nil
+}
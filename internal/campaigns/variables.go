@@ -0,0 +1,53 @@
+package campaigns
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+)
+
+var customerFieldPattern = regexp.MustCompile(`\{\{\s*customer\.(\w+)\s*(?:\|([^}]*))?\}\}`)
+
+// RenderVariables substitui os campos do cliente nas variáveis da campanha
+func RenderVariables(variables whatsapp.TemplateVariables, customer *customers.Customer) whatsapp.TemplateVariables {
+	return whatsapp.TemplateVariables{
+		Header: renderValues(variables.Header, customer),
+		Body:   renderValues(variables.Body, customer),
+	}
+}
+
+func renderValues(values []string, customer *customers.Customer) []string {
+	if values == nil {
+		return nil
+	}
+
+	rendered := make([]string, len(values))
+	for i, value := range values {
+		rendered[i] = customerFieldPattern.ReplaceAllStringFunc(value, func(match string) string {
+			parts := customerFieldPattern.FindStringSubmatch(match)
+			if field := customerField(customer, parts[1]); field != "" {
+				return field
+			}
+			return strings.TrimSpace(parts[2])
+		})
+	}
+	return rendered
+}
+
+func customerField(customer *customers.Customer, field string) string {
+	switch field {
+	case "name":
+		return strings.TrimSpace(customer.Name)
+	case "first_name":
+		if fields := strings.Fields(customer.Name); len(fields) > 0 {
+			return fields[0]
+		}
+	case "phone":
+		return customer.Phone
+	case "email":
+		return customer.Email
+	}
+	return ""
+}
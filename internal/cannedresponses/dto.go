@@ -0,0 +1,26 @@
+package cannedresponses
+
+type SaveCannedResponseRequest struct {
+	Scope    Scope  `json:"scope"`
+	Shortcut string `json:"shortcut" binding:"required"`
+	Title    string `json:"title" binding:"required"`
+	Content  string `json:"content" binding:"required"`
+	Category string `json:"category"`
+}
+
+// RenderRequest identifica o cliente pela conversa ou diretamente
+type RenderRequest struct {
+	ConversationID string `form:"conversation_id" json:"conversation_id" binding:"omitempty,uuid"`
+	CustomerID     string `form:"customer_id" json:"customer_id" binding:"omitempty,uuid"`
+}
+
+type SearchRequest struct {
+	RenderRequest
+	Query    string `form:"q"`
+	Category string `form:"category"`
+	Limit    int    `form:"limit"`
+}
+
+type CannedResponseListResponse struct {
+	Items []CannedResponse `json:"items"`
+}
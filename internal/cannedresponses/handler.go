@@ -0,0 +1,142 @@
+package cannedresponses
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/gin-gonic/gin"
+)
+
+type CannedResponseHandler struct {
+	responseService CannedResponseService
+}
+
+func NewCannedResponseHandler(responseService CannedResponseService) *CannedResponseHandler {
+	return &CannedResponseHandler{
+		responseService: responseService,
+	}
+}
+
+func (h *CannedResponseHandler) Create(c *gin.Context) {
+	var req SaveCannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	response, err := h.responseService.CreateResponse(c.Request.Context(), web.TenantID(c), web.UserID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *CannedResponseHandler) List(c *gin.Context) {
+	items, err := h.responseService.ListResponses(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Query("category"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, CannedResponseListResponse{Items: items})
+}
+
+func (h *CannedResponseHandler) Categories(c *gin.Context) {
+	categories, err := h.responseService.ListCategories(c.Request.Context(), web.TenantID(c), web.UserID(c))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": categories})
+}
+
+// Search é chamado pelo composer a cada tecla: ?q=/sau&conversation_id=...
+func (h *CannedResponseHandler) Search(c *gin.Context) {
+	var req SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	items, err := h.responseService.Search(c.Request.Context(), web.TenantID(c), web.UserID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, CannedResponseListResponse{Items: items})
+}
+
+func (h *CannedResponseHandler) Get(c *gin.Context) {
+	response, err := h.responseService.GetResponse(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CannedResponseHandler) Update(c *gin.Context) {
+	var req SaveCannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	response, err := h.responseService.UpdateResponse(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CannedResponseHandler) Delete(c *gin.Context) {
+	if err := h.responseService.DeleteResponse(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Param("id")); err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CannedResponseHandler) Render(c *gin.Context) {
+	var req RenderRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	response, err := h.responseService.Render(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrCannedResponseNotFound),
+		errors.Is(err, whatsapp.ErrConversationNotFound),
+		errors.Is(err, customers.ErrCustomerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrShortcutTaken):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidShortcut),
+		errors.Is(err, ErrInvalidScope),
+		errors.Is(err, ErrInvalidContent),
+		errors.Is(err, ErrUnknownPlaceholder):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,31 @@
+package cannedresponses
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type CannedResponseRepository interface {
+	Create(ctx context.Context, response *CannedResponse) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*CannedResponse, error)
+	// FindByShortcut busca no escopo exato: ownerID nil para as compartilhadas
+	FindByShortcut(ctx context.Context, tenantID uuid.UUID, ownerID *uuid.UUID, shortcut string) (*CannedResponse, error)
+	// List retorna as respostas do tenant e as pessoais do usuário do filtro
+	List(ctx context.Context, filter Filter) ([]CannedResponse, error)
+	Categories(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error)
+	Update(ctx context.Context, response *CannedResponse) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+}
+
+type CannedResponseService interface {
+	CreateResponse(ctx context.Context, tenantID, userID uuid.UUID, req SaveCannedResponseRequest) (*CannedResponse, error)
+	GetResponse(ctx context.Context, tenantID, userID uuid.UUID, id string) (*CannedResponse, error)
+	ListResponses(ctx context.Context, tenantID, userID uuid.UUID, category string) ([]CannedResponse, error)
+	ListCategories(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error)
+	UpdateResponse(ctx context.Context, tenantID, userID uuid.UUID, id string, req SaveCannedResponseRequest) (*CannedResponse, error)
+	DeleteResponse(ctx context.Context, tenantID, userID uuid.UUID, id string) error
+	// Search atende o composer da caixa de entrada: "/atalho" ou texto livre,
+	// já renderizando para a conversa quando informada
+	Search(ctx context.Context, tenantID, userID uuid.UUID, req SearchRequest) ([]CannedResponse, error)
+	Render(ctx context.Context, tenantID, userID uuid.UUID, id string, req RenderRequest) (*CannedResponse, error)
+}
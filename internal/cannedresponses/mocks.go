@@ -0,0 +1,66 @@
+package cannedresponses
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// MockCannedResponseRepository para testes
+type MockCannedResponseRepository struct {
+	CreateFunc         func(ctx context.Context, response *CannedResponse) error
+	FindByIDFunc       func(ctx context.Context, tenantID uuid.UUID, id string) (*CannedResponse, error)
+	FindByShortcutFunc func(ctx context.Context, tenantID uuid.UUID, ownerID *uuid.UUID, shortcut string) (*CannedResponse, error)
+	ListFunc           func(ctx context.Context, filter Filter) ([]CannedResponse, error)
+	CategoriesFunc     func(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error)
+	UpdateFunc         func(ctx context.Context, response *CannedResponse) error
+	DeleteFunc         func(ctx context.Context, tenantID, id uuid.UUID) error
+}
+
+func (m *MockCannedResponseRepository) Create(ctx context.Context, response *CannedResponse) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, response)
+	}
+	return nil
+}
+
+func (m *MockCannedResponseRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*CannedResponse, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockCannedResponseRepository) FindByShortcut(ctx context.Context, tenantID uuid.UUID, ownerID *uuid.UUID, shortcut string) (*CannedResponse, error) {
+	if m.FindByShortcutFunc != nil {
+		return m.FindByShortcutFunc(ctx, tenantID, ownerID, shortcut)
+	}
+	return nil, nil
+}
+
+func (m *MockCannedResponseRepository) List(ctx context.Context, filter Filter) ([]CannedResponse, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *MockCannedResponseRepository) Categories(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error) {
+	if m.CategoriesFunc != nil {
+		return m.CategoriesFunc(ctx, tenantID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockCannedResponseRepository) Update(ctx context.Context, response *CannedResponse) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, response)
+	}
+	return nil
+}
+
+func (m *MockCannedResponseRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID, id)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package cannedresponses
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCannedResponseNotFound = errors.New("canned response not found")
+	ErrInvalidShortcut        = errors.New("shortcut must have 1-32 lowercase letters, digits, '-' or '_'")
+	ErrShortcutTaken          = errors.New("shortcut already in use")
+	ErrInvalidScope           = errors.New("scope must be tenant or personal")
+	ErrInvalidContent         = errors.New("content must have between 1 and 4096 characters")
+	ErrUnknownPlaceholder     = errors.New("unknown placeholder")
+)
+
+type Scope string
+
+const (
+	ScopeTenant   Scope = "tenant"
+	ScopePersonal Scope = "personal"
+)
+
+// Limite de texto de uma mensagem do WhatsApp
+const maxContentLength = 4096
+
+// CannedResponse é uma resposta pronta, compartilhada com o tenant ou pessoal do agente
+type CannedResponse struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;index:idx_canned_responses_shortcut,priority:1" json:"tenant_id"`
+	// OwnerID nulo indica resposta compartilhada com todo o tenant
+	OwnerID     *uuid.UUID `gorm:"type:uuid;index" json:"owner_id,omitempty"`
+	Shortcut    string     `gorm:"type:varchar(32);not null;index:idx_canned_responses_shortcut,priority:2" json:"shortcut"`
+	Title       string     `gorm:"type:varchar(255);not null" json:"title"`
+	Content     string     `gorm:"type:text;not null" json:"content"`
+	Category    string     `gorm:"type:varchar(64);index" json:"category,omitempty"`
+	CreatedByID uuid.UUID  `gorm:"type:uuid;not null" json:"created_by_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Rendered traz o conteúdo com os placeholders resolvidos para a conversa pedida
+	Rendered string `gorm:"-" json:"rendered,omitempty"`
+}
+
+func (r *CannedResponse) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+func (r *CannedResponse) Scope() Scope {
+	if r.OwnerID != nil {
+		return ScopePersonal
+	}
+	return ScopeTenant
+}
+
+// VisibleTo indica se o agente pode usar a resposta
+func (r *CannedResponse) VisibleTo(userID uuid.UUID) bool {
+	return r.OwnerID == nil || *r.OwnerID == userID
+}
+
+var shortcutPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-]{0,31}$`)
+
+// NormalizeShortcut aceita o atalho digitado no composer ("/Saudacao") e grava sem a barra
+func NormalizeShortcut(shortcut string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(shortcut), "/"))
+}
+
+func ValidShortcut(shortcut string) bool {
+	return shortcutPattern.MatchString(shortcut)
+}
+
+type Filter struct {
+	TenantID uuid.UUID
+	UserID   uuid.UUID
+	Category string
+	// ShortcutPrefix filtra pelo início do atalho; Query busca em atalho, título e conteúdo
+	ShortcutPrefix string
+	Query          string
+	Limit          int
+}
@@ -0,0 +1,94 @@
+package cannedresponses
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z_]+\.[a-z_]+)\s*\}\}`)
+
+// Placeholders suportados no conteúdo das respostas
+var knownPlaceholders = map[string]bool{
+	"customer.name":       true,
+	"customer.first_name": true,
+	"customer.phone":      true,
+	"customer.email":      true,
+	"agent.name":          true,
+	"agent.first_name":    true,
+	"agent.email":         true,
+	"tenant.name":         true,
+}
+
+func validatePlaceholders(content string) error {
+	for _, match := range placeholderPattern.FindAllStringSubmatch(content, -1) {
+		if !knownPlaceholders[match[1]] {
+			return fmt.Errorf("%w: %s", ErrUnknownPlaceholder, match[1])
+		}
+	}
+	return nil
+}
+
+// RenderContext reúne os registros usados para resolver os placeholders; qualquer um pode faltar
+type RenderContext struct {
+	Customer *customers.Customer
+	Agent    *auth.User
+	Tenant   *tenants.Tenant
+}
+
+// Render substitui os placeholders conhecidos. Os que não têm valor ficam no texto
+// para o agente completar antes de enviar.
+func Render(content string, rc RenderContext) string {
+	return placeholderPattern.ReplaceAllStringFunc(content, func(match string) string {
+		if value := rc.value(placeholderPattern.FindStringSubmatch(match)[1]); value != "" {
+			return value
+		}
+		return match
+	})
+}
+
+func (rc RenderContext) value(key string) string {
+	switch key {
+	case "customer.name", "customer.first_name", "customer.phone", "customer.email":
+		if rc.Customer == nil {
+			return ""
+		}
+		switch key {
+		case "customer.name":
+			return strings.TrimSpace(rc.Customer.Name)
+		case "customer.first_name":
+			return firstName(rc.Customer.Name)
+		case "customer.phone":
+			return rc.Customer.Phone
+		}
+		return rc.Customer.Email
+	case "agent.name", "agent.first_name", "agent.email":
+		if rc.Agent == nil {
+			return ""
+		}
+		switch key {
+		case "agent.name":
+			return strings.TrimSpace(rc.Agent.Name)
+		case "agent.first_name":
+			return firstName(rc.Agent.Name)
+		}
+		return rc.Agent.Email
+	case "tenant.name":
+		if rc.Tenant == nil {
+			return ""
+		}
+		return rc.Tenant.Name
+	}
+	return ""
+}
+
+func firstName(name string) string {
+	if fields := strings.Fields(name); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
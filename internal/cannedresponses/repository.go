@@ -0,0 +1,216 @@
+package cannedresponses
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type cannedResponseRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newCannedResponseRepositoryBase(db *gorm.DB) *cannedResponseRepositoryBase {
+	return &cannedResponseRepositoryBase{db: db}
+}
+
+func (r *cannedResponseRepositoryBase) create(ctx context.Context, response *CannedResponse) error {
+	return r.db.WithContext(ctx).Create(response).Error
+}
+
+func (r *cannedResponseRepositoryBase) find(ctx context.Context, query *gorm.DB) (*CannedResponse, error) {
+	var response CannedResponse
+	err := query.First(&response).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (r *cannedResponseRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*CannedResponse, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id))
+}
+
+func (r *cannedResponseRepositoryBase) findByShortcut(ctx context.Context, tenantID uuid.UUID, ownerID *uuid.UUID, shortcut string) (*CannedResponse, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ? AND shortcut = ?", tenantID, shortcut)
+	if ownerID == nil {
+		query = query.Where("owner_id IS NULL")
+	} else {
+		query = query.Where("owner_id = ?", *ownerID)
+	}
+	return r.find(ctx, query)
+}
+
+func (r *cannedResponseRepositoryBase) visible(ctx context.Context, tenantID, userID uuid.UUID) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&CannedResponse{}).
+		Where("tenant_id = ? AND (owner_id IS NULL OR owner_id = ?)", tenantID, userID)
+}
+
+func (r *cannedResponseRepositoryBase) list(ctx context.Context, filter Filter) ([]CannedResponse, error) {
+	query := r.visible(ctx, filter.TenantID, filter.UserID)
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.ShortcutPrefix != "" {
+		query = query.Where(`shortcut LIKE ? ESCAPE '\'`, escapeLike(filter.ShortcutPrefix)+"%")
+	}
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.Query)) + "%"
+		query = query.Where(`(shortcut LIKE ? ESCAPE '\' OR LOWER(title) LIKE ? ESCAPE '\' OR LOWER(content) LIKE ? ESCAPE '\')`, pattern, pattern, pattern)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	// Pessoais primeiro: sobrepõem as do tenant com o mesmo atalho
+	var items []CannedResponse
+	err := query.Order("owner_id IS NULL, shortcut ASC").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *cannedResponseRepositoryBase) categories(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error) {
+	var categories []string
+	err := r.visible(ctx, tenantID, userID).
+		Where("category <> ''").
+		Distinct().
+		Order("category ASC").
+		Pluck("category", &categories).Error
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *cannedResponseRepositoryBase) update(ctx context.Context, response *CannedResponse) error {
+	return r.db.WithContext(ctx).Save(response).Error
+}
+
+func (r *cannedResponseRepositoryBase) delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&CannedResponse{}).Error
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// Repository com telemetria (decorator)
+type cannedResponseRepository struct {
+	base      *cannedResponseRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewCannedResponseRepository(db *gorm.DB, telemetry telemetry.TelemetryService) CannedResponseRepository {
+	return &cannedResponseRepository{
+		base:      newCannedResponseRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *cannedResponseRepository) Create(ctx context.Context, response *CannedResponse) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.canned_response.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", response.TenantID.String())
+
+	err := r.base.create(ctx, response)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *cannedResponseRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*CannedResponse, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.canned_response.find_by_id")
+	defer span.End()
+
+	span.SetTag("canned_response_id", id)
+
+	response, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return response, nil
+}
+
+func (r *cannedResponseRepository) FindByShortcut(ctx context.Context, tenantID uuid.UUID, ownerID *uuid.UUID, shortcut string) (*CannedResponse, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.canned_response.find_by_shortcut")
+	defer span.End()
+
+	span.SetTag("shortcut", shortcut)
+
+	response, err := r.base.findByShortcut(ctx, tenantID, ownerID, shortcut)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return response, nil
+}
+
+func (r *cannedResponseRepository) List(ctx context.Context, filter Filter) ([]CannedResponse, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.canned_response.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", filter.TenantID.String())
+
+	items, err := r.base.list(ctx, filter)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *cannedResponseRepository) Categories(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.canned_response.categories")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	categories, err := r.base.categories(ctx, tenantID, userID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *cannedResponseRepository) Update(ctx context.Context, response *CannedResponse) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.canned_response.update")
+	defer span.End()
+
+	span.SetTag("canned_response_id", response.ID.String())
+
+	err := r.base.update(ctx, response)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *cannedResponseRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.canned_response.delete")
+	defer span.End()
+
+	span.SetTag("canned_response_id", id.String())
+
+	err := r.base.delete(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
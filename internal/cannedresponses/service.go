@@ -0,0 +1,257 @@
+package cannedresponses
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+type cannedResponseService struct {
+	responseRepo        CannedResponseRepository
+	customerRepo        customers.CustomerRepository
+	userRepo            auth.UserRepository
+	tenantRepo          tenants.TenantRepository
+	conversationService whatsapp.ConversationService
+	telemetry           telemetry.TelemetryService
+}
+
+func NewCannedResponseService(
+	responseRepo CannedResponseRepository,
+	customerRepo customers.CustomerRepository,
+	userRepo auth.UserRepository,
+	tenantRepo tenants.TenantRepository,
+	conversationService whatsapp.ConversationService,
+	telemetry telemetry.TelemetryService,
+) CannedResponseService {
+	return &cannedResponseService{
+		responseRepo:        responseRepo,
+		customerRepo:        customerRepo,
+		userRepo:            userRepo,
+		tenantRepo:          tenantRepo,
+		conversationService: conversationService,
+		telemetry:           telemetry,
+	}
+}
+
+func (s *cannedResponseService) CreateResponse(ctx context.Context, tenantID, userID uuid.UUID, req SaveCannedResponseRequest) (*CannedResponse, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "canned_responses.create")
+	defer span.End()
+
+	response := &CannedResponse{TenantID: tenantID, CreatedByID: userID}
+	switch req.Scope {
+	case ScopeTenant, "":
+	case ScopePersonal:
+		response.OwnerID = &userID
+	default:
+		return nil, ErrInvalidScope
+	}
+
+	if err := s.apply(ctx, response, req); err != nil {
+		return nil, err
+	}
+	if err := s.responseRepo.Create(ctx, response); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "canned_responses.created",
+		Properties: map[string]interface{}{
+			"canned_response_id": response.ID.String(),
+			"tenant_id":          tenantID.String(),
+			"scope":              string(response.Scope()),
+		},
+		Timestamp: time.Now(),
+	})
+
+	return response, nil
+}
+
+// apply valida e copia os campos editáveis; o escopo não muda depois de criado
+func (s *cannedResponseService) apply(ctx context.Context, response *CannedResponse, req SaveCannedResponseRequest) error {
+	shortcut := NormalizeShortcut(req.Shortcut)
+	if !ValidShortcut(shortcut) {
+		return ErrInvalidShortcut
+	}
+	content := strings.TrimSpace(req.Content)
+	if content == "" || len([]rune(content)) > maxContentLength {
+		return ErrInvalidContent
+	}
+	if err := validatePlaceholders(content); err != nil {
+		return err
+	}
+
+	existing, err := s.responseRepo.FindByShortcut(ctx, response.TenantID, response.OwnerID, shortcut)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != response.ID {
+		return ErrShortcutTaken
+	}
+
+	response.Shortcut = shortcut
+	response.Title = strings.TrimSpace(req.Title)
+	response.Content = content
+	response.Category = strings.TrimSpace(req.Category)
+	return nil
+}
+
+func (s *cannedResponseService) GetResponse(ctx context.Context, tenantID, userID uuid.UUID, id string) (*CannedResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrCannedResponseNotFound
+	}
+
+	response, err := s.responseRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	// Respostas pessoais de outros agentes não são expostas
+	if response == nil || !response.VisibleTo(userID) {
+		return nil, ErrCannedResponseNotFound
+	}
+	return response, nil
+}
+
+func (s *cannedResponseService) ListResponses(ctx context.Context, tenantID, userID uuid.UUID, category string) ([]CannedResponse, error) {
+	return s.responseRepo.List(ctx, Filter{TenantID: tenantID, UserID: userID, Category: category})
+}
+
+func (s *cannedResponseService) ListCategories(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error) {
+	return s.responseRepo.Categories(ctx, tenantID, userID)
+}
+
+func (s *cannedResponseService) UpdateResponse(ctx context.Context, tenantID, userID uuid.UUID, id string, req SaveCannedResponseRequest) (*CannedResponse, error) {
+	response, err := s.GetResponse(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Scope != "" && req.Scope != response.Scope() {
+		return nil, ErrInvalidScope
+	}
+
+	if err := s.apply(ctx, response, req); err != nil {
+		return nil, err
+	}
+	if err := s.responseRepo.Update(ctx, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *cannedResponseService) DeleteResponse(ctx context.Context, tenantID, userID uuid.UUID, id string) error {
+	response, err := s.GetResponse(ctx, tenantID, userID, id)
+	if err != nil {
+		return err
+	}
+	return s.responseRepo.Delete(ctx, tenantID, response.ID)
+}
+
+func (s *cannedResponseService) Search(ctx context.Context, tenantID, userID uuid.UUID, req SearchRequest) ([]CannedResponse, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "canned_responses.search")
+	defer span.End()
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	filter := Filter{TenantID: tenantID, UserID: userID, Category: req.Category, Limit: limit}
+	query := strings.TrimSpace(req.Query)
+	if strings.HasPrefix(query, "/") {
+		filter.ShortcutPrefix = NormalizeShortcut(query)
+	} else {
+		filter.Query = query
+	}
+
+	items, err := s.responseRepo.List(ctx, filter)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	if req.ConversationID != "" || req.CustomerID != "" {
+		rc, err := s.renderContext(ctx, tenantID, userID, req.RenderRequest)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			items[i].Rendered = Render(items[i].Content, rc)
+		}
+	}
+
+	s.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "canned_responses.search.results",
+		Value: float64(len(items)),
+		Kind:  telemetry.MetricHistogram,
+	})
+
+	return items, nil
+}
+
+func (s *cannedResponseService) Render(ctx context.Context, tenantID, userID uuid.UUID, id string, req RenderRequest) (*CannedResponse, error) {
+	response, err := s.GetResponse(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := s.renderContext(ctx, tenantID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	response.Rendered = Render(response.Content, rc)
+	return response, nil
+}
+
+func (s *cannedResponseService) renderContext(ctx context.Context, tenantID, userID uuid.UUID, req RenderRequest) (RenderContext, error) {
+	var rc RenderContext
+
+	customerID := req.CustomerID
+	if req.ConversationID != "" {
+		conversation, err := s.conversationService.GetConversation(ctx, tenantID, req.ConversationID)
+		if err != nil {
+			return rc, err
+		}
+		customerID = conversation.CustomerID.String()
+	}
+	if customerID != "" {
+		customer, err := s.customerRepo.FindByID(ctx, tenantID, customerID)
+		if err != nil {
+			return rc, err
+		}
+		if customer == nil {
+			return rc, customers.ErrCustomerNotFound
+		}
+		rc.Customer = customer
+	}
+
+	agent, err := s.userRepo.FindByID(ctx, userID.String())
+	if err != nil {
+		return rc, err
+	}
+	if agent != nil && agent.TenantID == tenantID {
+		rc.Agent = agent
+	}
+
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID.String())
+	if err != nil {
+		return rc, err
+	}
+	rc.Tenant = tenant
+
+	return rc, nil
+}
@@ -0,0 +1,22 @@
+package chatbot
+
+import (
+	"os"
+	"time"
+)
+
+const defaultHTTPBudget = 15 * time.Second
+
+type Config struct {
+	// HTTPBudget é o tempo total dos nós HTTP para cada mensagem recebida: o fluxo
+	// roda no worker da fila do webhook, que atende outros contatos (e tenants)
+	HTTPBudget time.Duration
+}
+
+func LoadConfig() Config {
+	budget, err := time.ParseDuration(os.Getenv("CHATBOT_HTTP_BUDGET"))
+	if err != nil || budget <= 0 {
+		budget = defaultHTTPBudget
+	}
+	return Config{HTTPBudget: budget}
+}
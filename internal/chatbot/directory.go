@@ -0,0 +1,25 @@
+package chatbot
+
+import (
+	"context"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+// flowDirectory valida o fluxo padrão dos canais de WhatsApp
+type flowDirectory struct {
+	flowRepo FlowRepository
+}
+
+func NewFlowDirectory(flowRepo FlowRepository) whatsapp.FlowDirectory {
+	return &flowDirectory{flowRepo: flowRepo}
+}
+
+func (d *flowDirectory) FlowInTenant(ctx context.Context, tenantID, flowID uuid.UUID) (bool, error) {
+	flow, err := d.flowRepo.FindByID(ctx, tenantID, flowID.String())
+	if err != nil {
+		return false, err
+	}
+	return flow != nil, nil
+}
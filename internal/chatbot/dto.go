@@ -0,0 +1,11 @@
+package chatbot
+
+type SaveFlowRequest struct {
+	Name       string         `json:"name" binding:"required"`
+	Definition FlowDefinition `json:"definition"`
+}
+
+type ValidateFlowResponse struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,661 @@
+package chatbot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+const (
+	// Limite de nós automáticos por mensagem recebida, por segurança contra fluxos mal formados
+	maxStepsPerInput    = 50
+	httpNodeTimeout     = 10 * time.Second
+	maxHTTPResponseSize = 64 << 10
+	defaultErrorMessage = "Não entendi sua resposta. Pode tentar novamente?"
+)
+
+// botUserID identifica as mensagens enviadas pelo chatbot
+var botUserID = uuid.Nil
+
+// httpNodeTransport propaga o trace para os webhooks chamados pelos nós HTTP e só
+// disca para endereços públicos. A checagem roda no dial, depois da resolução de
+// DNS, então vale também para cada redirect seguido pelo cliente
+var httpNodeTransport = telemetry.NewTransport(&http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout: httpNodeTimeout,
+		Control: refuseInternalAddress,
+	}).DialContext,
+	TLSHandshakeTimeout: httpNodeTimeout,
+	MaxIdleConns:        100,
+	IdleConnTimeout:     90 * time.Second,
+})
+
+var (
+	ErrInternalAddress    = errors.New("chatbot: http node target is not a public address")
+	ErrHTTPBudgetExceeded = errors.New("chatbot: http nodes exceeded the time budget for this message")
+)
+
+// internalPrefixes complementa os testes de netip com faixas que não são públicas
+var internalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// refuseInternalAddress barra loopback, redes privadas, link-local (onde fica o
+// metadata da nuvem) e afins: a URL do nó é do tenant, a rede é nossa
+func refuseInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return ErrInternalAddress
+	}
+	for _, prefix := range internalPrefixes {
+		if prefix.Contains(ip) {
+			return ErrInternalAddress
+		}
+	}
+	return nil
+}
+
+type engine struct {
+	config              Config
+	flowRepo            FlowRepository
+	sessionRepo         SessionRepository
+	conversationService whatsapp.ConversationService
+	customerRepo        customers.CustomerRepository
+	httpClient          *http.Client
+	telemetry           telemetry.TelemetryService
+}
+
+func NewEngine(
+	config Config,
+	flowRepo FlowRepository,
+	sessionRepo SessionRepository,
+	conversationService whatsapp.ConversationService,
+	customerRepo customers.CustomerRepository,
+	httpClient *http.Client,
+	telemetry telemetry.TelemetryService,
+) Engine {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: httpNodeTimeout, Transport: httpNodeTransport}
+	}
+	if config.HTTPBudget <= 0 {
+		config.HTTPBudget = defaultHTTPBudget
+	}
+	return &engine{
+		config:              config,
+		flowRepo:            flowRepo,
+		sessionRepo:         sessionRepo,
+		conversationService: conversationService,
+		customerRepo:        customerRepo,
+		httpClient:          httpClient,
+		telemetry:           telemetry,
+	}
+}
+
+func (e *engine) HandleEvent(ctx context.Context, event whatsapp.Event) {
+	inbound, ok := event.(whatsapp.InboundMessageEvent)
+	if !ok || inbound.Message.ConversationID == nil {
+		return
+	}
+
+	span, ctx := e.telemetry.StartSpan(ctx, "chatbot.handle_inbound")
+	defer span.End()
+
+	if err := e.handleInbound(ctx, inbound); err != nil {
+		span.SetError(err)
+	}
+}
+
+func (e *engine) handleInbound(ctx context.Context, event whatsapp.InboundMessageEvent) error {
+	now := time.Now()
+	conversation, err := e.conversationService.GetConversation(ctx, event.TenantID, event.Message.ConversationID.String())
+	if err != nil {
+		return err
+	}
+
+	session, err := e.sessionRepo.FindActive(ctx, conversation.ID)
+	if err != nil {
+		return err
+	}
+	if session != nil && !session.ExpiresAt.After(now) {
+		if _, err := e.sessionRepo.MarkTimedOut(ctx, session.ID, now); err != nil {
+			return err
+		}
+		session = nil
+	}
+
+	// Atendente assumiu a conversa: o bot sai de cena
+	if conversation.AssigneeID != nil {
+		if session == nil {
+			return nil
+		}
+		session.end(SessionHandedOff, now)
+		return e.sessionRepo.Update(ctx, session)
+	}
+
+	var flow *Flow
+	if session == nil {
+		if session, flow, err = e.startSession(ctx, conversation, event.Channel, now); err != nil || session == nil {
+			return err
+		}
+		return e.run(ctx, conversation, session, flow, nil)
+	}
+
+	flow, err = e.flowRepo.FindByID(ctx, session.TenantID, session.FlowID.String())
+	if err != nil {
+		return err
+	}
+	if flow == nil {
+		// Fluxo removido durante a sessão
+		session.end(SessionCompleted, now)
+		return e.sessionRepo.Update(ctx, session)
+	}
+	return e.run(ctx, conversation, session, flow, event.Message)
+}
+
+// flowFor usa o fluxo configurado no número que recebeu a mensagem ou, sem ele, o fluxo ativo do tenant
+func (e *engine) flowFor(ctx context.Context, tenantID uuid.UUID, channel *whatsapp.Channel) (*Flow, error) {
+	if channel != nil && channel.DefaultFlowID != nil {
+		flow, err := e.flowRepo.FindByID(ctx, tenantID, channel.DefaultFlowID.String())
+		if err != nil || flow != nil {
+			return flow, err
+		}
+	}
+	return e.flowRepo.FindActive(ctx, tenantID)
+}
+
+func (e *engine) startSession(ctx context.Context, conversation *whatsapp.Conversation, channel *whatsapp.Channel, now time.Time) (*Session, *Flow, error) {
+	latest, err := e.sessionRepo.FindLatest(ctx, conversation.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Depois do handoff, só volta a atender quando a conversa for resolvida
+	if latest != nil && latest.Status == SessionHandedOff &&
+		(conversation.ResolvedAt == nil || latest.EndedAt.After(*conversation.ResolvedAt)) {
+		return nil, nil, nil
+	}
+
+	flow, err := e.flowFor(ctx, conversation.TenantID, channel)
+	if err != nil || flow == nil {
+		return nil, nil, err
+	}
+
+	session := &Session{
+		TenantID:       conversation.TenantID,
+		ConversationID: conversation.ID,
+		CustomerID:     conversation.CustomerID,
+		FlowID:         flow.ID,
+		Status:         SessionActive,
+		CurrentNodeID:  flow.Definition.Start,
+		Variables:      map[string]string{},
+		ExpiresAt:      now.Add(flow.Definition.Timeout()),
+	}
+	if err := e.sessionRepo.Create(ctx, session); err != nil {
+		return nil, nil, err
+	}
+
+	e.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "chatbot.session_started",
+		Properties: map[string]interface{}{
+			"tenant_id":       conversation.TenantID.String(),
+			"conversation_id": conversation.ID.String(),
+			"flow_id":         flow.ID.String(),
+		},
+		Timestamp: now,
+	})
+
+	return session, flow, nil
+}
+
+// execution guarda o estado de uma rodada do fluxo
+type execution struct {
+	*engine
+	ctx          context.Context
+	conversation *whatsapp.Conversation
+	session      *Session
+	definition   *FlowDefinition
+	input        string
+	customer     *customers.Customer
+	// httpDeadline encerra os nós HTTP desta mensagem (Config.HTTPBudget)
+	httpDeadline time.Time
+}
+
+// run processa a resposta do cliente (se houver) e avança até o próximo nó que aguarda entrada
+func (e *engine) run(ctx context.Context, conversation *whatsapp.Conversation, session *Session, flow *Flow, input *whatsapp.Message) error {
+	x := &execution{
+		engine:       e,
+		ctx:          ctx,
+		conversation: conversation,
+		session:      session,
+		definition:   &flow.Definition,
+		httpDeadline: time.Now().Add(e.config.HTTPBudget),
+	}
+	if session.Variables == nil {
+		session.Variables = map[string]string{}
+	}
+
+	err := x.advance(input)
+	if session.Status == SessionActive {
+		session.ExpiresAt = time.Now().Add(flow.Definition.Timeout())
+	}
+	if updateErr := e.sessionRepo.Update(ctx, session); err == nil {
+		err = updateErr
+	}
+	return err
+}
+
+func (x *execution) advance(input *whatsapp.Message) error {
+	current := x.session.CurrentNodeID
+
+	if input != nil {
+		x.input = strings.TrimSpace(input.Body)
+		node := x.definition.Node(current)
+		if node != nil && node.Type.WaitsForInput() {
+			next, wait, err := x.receive(node)
+			if err != nil || wait {
+				return err
+			}
+			current = next
+		}
+	}
+
+	for step := 0; step < maxStepsPerInput; step++ {
+		node := x.definition.Node(current)
+		if node == nil {
+			x.finish(SessionCompleted)
+			return nil
+		}
+		x.session.CurrentNodeID = node.ID
+
+		switch node.Type {
+		case NodeMessage:
+			if err := x.sendText(node.Text); err != nil {
+				return err
+			}
+			current = node.Next
+		case NodeQuestion:
+			return x.sendText(node.Text)
+		case NodeButtons:
+			return x.sendButtons(node)
+		case NodeCondition:
+			current = x.evaluate(node)
+		case NodeSetField:
+			if err := x.setField(node); err != nil {
+				return err
+			}
+			current = node.Next
+		case NodeHTTP:
+			current = x.callHTTP(node)
+		case NodeHandoff:
+			return x.handoff(node)
+		default:
+			x.finish(SessionCompleted)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("chatbot: flow %s exceeded %d steps", x.session.FlowID, maxStepsPerInput)
+}
+
+// receive valida a resposta ao nó atual; wait indica que o fluxo continua parado nele
+func (x *execution) receive(node *Node) (string, bool, error) {
+	value, next, ok := x.accept(node)
+	if ok {
+		x.session.Variables[node.SaveAs] = value
+		x.session.Attempts = 0
+		return next, false, nil
+	}
+
+	x.session.Attempts++
+	x.telemetry.TrackMetric(x.ctx, telemetry.Metric{
+		Name:  "chatbot.input.invalid",
+		Value: 1,
+		Tags:  map[string]string{"node_type": string(node.Type)},
+	})
+
+	if x.session.Attempts >= node.maxAttempts() {
+		x.session.Attempts = 0
+		if node.Fallback != "" {
+			return node.Fallback, false, nil
+		}
+		return "", true, x.handoff(&Node{ID: node.ID, Type: NodeHandoff})
+	}
+
+	message := node.ErrorMessage
+	if message == "" {
+		message = defaultErrorMessage
+	}
+	if err := x.sendText(message); err != nil {
+		return "", true, err
+	}
+	if node.Type == NodeButtons {
+		return "", true, x.sendButtons(node)
+	}
+	return "", true, nil
+}
+
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+func (x *execution) accept(node *Node) (string, string, bool) {
+	if node.Type == NodeButtons {
+		for i, button := range node.Buttons {
+			if strings.EqualFold(x.input, button.Title) || strings.EqualFold(x.input, button.ID) || x.input == strconv.Itoa(i+1) {
+				next := button.Next
+				if next == "" {
+					next = node.Next
+				}
+				return button.ID, next, true
+			}
+		}
+		return "", "", false
+	}
+
+	value := x.input
+	if value == "" {
+		return "", "", false
+	}
+
+	if rule := node.Validation; rule != nil {
+		length := len([]rune(value))
+		if length < rule.MinLength || (rule.MaxLength > 0 && length > rule.MaxLength) {
+			return "", "", false
+		}
+
+		switch rule.Type {
+		case ValidateNumber:
+			if _, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64); err != nil {
+				return "", "", false
+			}
+		case ValidateEmail:
+			if !emailPattern.MatchString(value) {
+				return "", "", false
+			}
+			value = strings.ToLower(value)
+		case ValidatePhone:
+			value = customers.NormalizePhone(value)
+			if len(value) < 10 || len(value) > 15 {
+				return "", "", false
+			}
+		case ValidateRegex:
+			if pattern, err := regexp.Compile(rule.Pattern); err != nil || !pattern.MatchString(value) {
+				return "", "", false
+			}
+		}
+	}
+
+	return value, node.Next, true
+}
+
+func (x *execution) evaluate(node *Node) string {
+	for _, rule := range node.Rules {
+		value, found := x.lookup(rule.Variable)
+		var matched bool
+		switch rule.Operator {
+		case OpEquals:
+			matched = strings.EqualFold(value, x.render(rule.Value))
+		case OpNotEquals:
+			matched = !strings.EqualFold(value, x.render(rule.Value))
+		case OpContains:
+			matched = strings.Contains(strings.ToLower(value), strings.ToLower(x.render(rule.Value)))
+		case OpMatches:
+			pattern, err := regexp.Compile(rule.Value)
+			matched = err == nil && pattern.MatchString(value)
+		case OpExists:
+			matched = found && value != ""
+		}
+		if matched {
+			return rule.Next
+		}
+	}
+	return node.Default
+}
+
+func (x *execution) setField(node *Node) error {
+	value := x.render(node.Value)
+
+	if name, ok := strings.CutPrefix(node.Field, "vars."); ok {
+		x.session.Variables[name] = value
+		return nil
+	}
+
+	customer, err := x.loadCustomer()
+	if err != nil || customer == nil {
+		return err
+	}
+	switch node.Field {
+	case "customer.name":
+		customer.Name = value
+	case "customer.email":
+		customer.Email = value
+	}
+	return x.customerRepo.Update(x.ctx, customer)
+}
+
+// callHTTP chama a integração do tenant e grava <save_as>_status e os campos do JSON de resposta
+func (x *execution) callHTTP(node *Node) string {
+	span, ctx := x.telemetry.StartSpan(x.ctx, "chatbot.http_node")
+	defer span.End()
+
+	failed := func(err error) string {
+		span.SetError(err)
+		if node.OnError != "" {
+			return node.OnError
+		}
+		return node.Next
+	}
+
+	request := node.Request
+	var body io.Reader
+	if request.Body != "" {
+		body = strings.NewReader(x.renderWith(request.Body, escapeJSON))
+	}
+
+	// Cada nó tem seu timeout, mas todos dividem o prazo da mensagem
+	deadline := time.Now().Add(httpNodeTimeout)
+	if x.httpDeadline.Before(deadline) {
+		deadline = x.httpDeadline
+	}
+	if !time.Now().Before(deadline) {
+		return failed(ErrHTTPBudgetExceeded)
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(request.Method), x.renderWith(request.URL, escapeURL), body)
+	if err != nil {
+		return failed(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range request.Headers {
+		req.Header.Set(key, x.render(value))
+	}
+
+	resp, err := x.httpClient.Do(req)
+	if err != nil {
+		return failed(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseSize))
+	if err != nil {
+		return failed(err)
+	}
+
+	if node.SaveAs != "" {
+		x.session.Variables[node.SaveAs+"_status"] = strconv.Itoa(resp.StatusCode)
+		var fields map[string]interface{}
+		if json.Unmarshal(bytes.TrimSpace(data), &fields) == nil {
+			for key, value := range fields {
+				switch v := value.(type) {
+				case string:
+					x.session.Variables[node.SaveAs+"_"+key] = v
+				case float64, bool:
+					x.session.Variables[node.SaveAs+"_"+key] = fmt.Sprint(v)
+				}
+			}
+		}
+	}
+
+	if resp.StatusCode >= 300 {
+		return failed(fmt.Errorf("chatbot: http node %s returned %d", node.ID, resp.StatusCode))
+	}
+	return node.Next
+}
+
+func (x *execution) handoff(node *Node) error {
+	if node.Text != "" {
+		if err := x.sendText(node.Text); err != nil {
+			return err
+		}
+	}
+	x.finish(SessionHandedOff)
+
+	// As variáveis guardam o que o cliente digitou e o que os nós HTTP devolveram
+	// (nome, CPF, e-mail...): a telemetria leva só a quantidade, nunca os valores
+	x.telemetry.TrackEvent(x.ctx, telemetry.Event{
+		Name: "chatbot.handoff",
+		Properties: map[string]interface{}{
+			"tenant_id":       x.session.TenantID.String(),
+			"conversation_id": x.session.ConversationID.String(),
+			"flow_id":         x.session.FlowID.String(),
+			"node_id":         node.ID,
+			"variables":       len(x.session.Variables),
+		},
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+func (x *execution) finish(status SessionStatus) {
+	x.session.end(status, time.Now())
+	if status == SessionCompleted {
+		x.telemetry.TrackMetric(x.ctx, telemetry.Metric{
+			Name:  "chatbot.session.completed",
+			Value: 1,
+		})
+	}
+}
+
+func (x *execution) sendText(text string) error {
+	_, err := x.conversationService.SendMessage(x.ctx, x.session.TenantID, botUserID, x.conversation.ID.String(), whatsapp.SendMessageRequest{
+		Type: whatsapp.TypeText,
+		Text: &whatsapp.TextContent{Body: x.render(text)},
+	})
+	return err
+}
+
+func (x *execution) sendButtons(node *Node) error {
+	buttons := make([]whatsapp.ReplyButton, len(node.Buttons))
+	for i, button := range node.Buttons {
+		buttons[i] = whatsapp.ReplyButton{ID: button.ID, Title: x.render(button.Title)}
+	}
+	_, err := x.conversationService.SendMessage(x.ctx, x.session.TenantID, botUserID, x.conversation.ID.String(), whatsapp.SendMessageRequest{
+		Type: whatsapp.TypeInteractive,
+		Interactive: &whatsapp.InteractiveContent{
+			Type:    whatsapp.InteractiveButton,
+			Body:    x.render(node.Text),
+			Buttons: buttons,
+		},
+	})
+	return err
+}
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z_]+(?:\.[a-z0-9_]+)?)\s*\}\}`)
+
+func (x *execution) render(text string) string {
+	return x.renderWith(text, nil)
+}
+
+// renderWith escapa só os valores dos placeholders, nunca o texto do fluxo: o que
+// o cliente digita não pode mudar a estrutura da URL ou do JSON
+func (x *execution) renderWith(text string, escape func(string) string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		value, _ := x.lookup(placeholderPattern.FindStringSubmatch(match)[1])
+		if escape != nil {
+			return escape(value)
+		}
+		return value
+	})
+}
+
+// escapeURL serve tanto no path quanto na query: %20 no lugar do + de QueryEscape
+func escapeURL(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// escapeJSON devolve o conteúdo de uma string JSON, sem as aspas: o placeholder
+// fica entre aspas no corpo do fluxo
+func escapeJSON(value string) string {
+	data, _ := json.Marshal(value)
+	return string(data[1 : len(data)-1])
+}
+
+// lookup resolve input, vars.<nome> (ou só <nome>) e customer.<campo>
+func (x *execution) lookup(key string) (string, bool) {
+	if key == "input" {
+		return x.input, true
+	}
+	if field, ok := strings.CutPrefix(key, "customer."); ok {
+		customer, err := x.loadCustomer()
+		if err != nil || customer == nil {
+			return "", false
+		}
+		switch field {
+		case "name":
+			return customer.Name, true
+		case "first_name":
+			if fields := strings.Fields(customer.Name); len(fields) > 0 {
+				return fields[0], true
+			}
+			return "", true
+		case "phone":
+			return customer.Phone, true
+		case "email":
+			return customer.Email, true
+		}
+		return "", false
+	}
+
+	value, ok := x.session.Variables[strings.TrimPrefix(key, "vars.")]
+	return value, ok
+}
+
+func (x *execution) loadCustomer() (*customers.Customer, error) {
+	if x.customer != nil {
+		return x.customer, nil
+	}
+	customer, err := x.customerRepo.FindByID(x.ctx, x.session.TenantID, x.session.CustomerID.String())
+	if err != nil {
+		return nil, err
+	}
+	x.customer = customer
+	return customer, nil
+}
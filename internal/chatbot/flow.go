@@ -0,0 +1,410 @@
+package chatbot
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidFlow = errors.New("invalid flow definition")
+
+type NodeType string
+
+const (
+	NodeMessage   NodeType = "message"
+	NodeQuestion  NodeType = "question"
+	NodeButtons   NodeType = "buttons"
+	NodeCondition NodeType = "condition"
+	NodeSetField  NodeType = "set_field"
+	NodeHandoff   NodeType = "handoff"
+	NodeHTTP      NodeType = "http"
+)
+
+// WaitsForInput indica os nós que pausam o fluxo até a próxima mensagem do cliente
+func (t NodeType) WaitsForInput() bool {
+	return t == NodeQuestion || t == NodeButtons
+}
+
+type ValidationType string
+
+const (
+	ValidateText   ValidationType = "text"
+	ValidateNumber ValidationType = "number"
+	ValidateEmail  ValidationType = "email"
+	ValidatePhone  ValidationType = "phone"
+	ValidateRegex  ValidationType = "regex"
+)
+
+type Operator string
+
+const (
+	OpEquals    Operator = "equals"
+	OpNotEquals Operator = "not_equals"
+	OpContains  Operator = "contains"
+	OpMatches   Operator = "matches"
+	OpExists    Operator = "exists"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultTimeoutMinutes = 30
+	maxNodes              = 100
+)
+
+// FlowDefinition é o formato JSON editado pelo tenant. Textos e valores aceitam
+// {{vars.<nome>}}, {{customer.name}}, {{customer.first_name}}, {{customer.phone}},
+// {{customer.email}} e {{input}} (última mensagem do cliente).
+type FlowDefinition struct {
+	Start string `json:"start"`
+	Nodes []Node `json:"nodes"`
+
+	// Sem resposta do cliente por TimeoutMinutes, a sessão expira
+	TimeoutMinutes int    `json:"timeout_minutes,omitempty"`
+	TimeoutMessage string `json:"timeout_message,omitempty"`
+}
+
+type Node struct {
+	ID   string   `json:"id"`
+	Type NodeType `json:"type"`
+	Next string   `json:"next,omitempty"`
+
+	// message, question, buttons e handoff
+	Text string `json:"text,omitempty"`
+
+	// question e buttons: variável que recebe a resposta
+	SaveAs       string      `json:"save_as,omitempty"`
+	Validation   *Validation `json:"validation,omitempty"`
+	ErrorMessage string      `json:"error_message,omitempty"`
+	MaxAttempts  int         `json:"max_attempts,omitempty"`
+	// Fallback recebe o fluxo quando as tentativas se esgotam; vazio encerra com handoff
+	Fallback string `json:"fallback,omitempty"`
+
+	Buttons []Button `json:"buttons,omitempty"`
+
+	Rules   []Rule `json:"rules,omitempty"`
+	Default string `json:"default,omitempty"`
+
+	// set_field: "vars.<nome>", "customer.name" ou "customer.email"
+	Field string `json:"field,omitempty"`
+	Value string `json:"value,omitempty"`
+
+	Request *HTTPRequest `json:"request,omitempty"`
+	OnError string       `json:"on_error,omitempty"`
+}
+
+type Validation struct {
+	Type      ValidationType `json:"type"`
+	Pattern   string         `json:"pattern,omitempty"`
+	MinLength int            `json:"min_length,omitempty"`
+	MaxLength int            `json:"max_length,omitempty"`
+}
+
+type Button struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Next  string `json:"next,omitempty"`
+}
+
+type Rule struct {
+	Variable string   `json:"variable"`
+	Operator Operator `json:"operator"`
+	Value    string   `json:"value,omitempty"`
+	Next     string   `json:"next"`
+}
+
+type HTTPRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+func (d *FlowDefinition) Node(id string) *Node {
+	for i := range d.Nodes {
+		if d.Nodes[i].ID == id {
+			return &d.Nodes[i]
+		}
+	}
+	return nil
+}
+
+func (d *FlowDefinition) Timeout() time.Duration {
+	if d.TimeoutMinutes <= 0 {
+		return defaultTimeoutMinutes * time.Minute
+	}
+	return time.Duration(d.TimeoutMinutes) * time.Minute
+}
+
+func (n *Node) maxAttempts() int {
+	if n.MaxAttempts <= 0 {
+		return defaultMaxAttempts
+	}
+	return n.MaxAttempts
+}
+
+var (
+	nodeIDPattern   = regexp.MustCompile(`^[a-zA-Z0-9_\-]{1,64}$`)
+	variablePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+)
+
+// Validate confere a estrutura do fluxo e reporta todos os problemas encontrados
+func (d *FlowDefinition) Validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if len(d.Nodes) == 0 {
+		add("flow has no nodes")
+	}
+	if len(d.Nodes) > maxNodes {
+		add("flow has more than %d nodes", maxNodes)
+	}
+
+	ids := make(map[string]bool, len(d.Nodes))
+	for _, node := range d.Nodes {
+		if !nodeIDPattern.MatchString(node.ID) {
+			add("invalid node id %q", node.ID)
+		}
+		if ids[node.ID] {
+			add("duplicate node id %q", node.ID)
+		}
+		ids[node.ID] = true
+	}
+	if d.Start == "" || !ids[d.Start] {
+		add("start node %q not found", d.Start)
+	}
+
+	ref := func(node Node, field, target string, required bool) {
+		if target == "" {
+			if required {
+				add("node %q: %s is required", node.ID, field)
+			}
+			return
+		}
+		if !ids[target] {
+			add("node %q: %s references unknown node %q", node.ID, field, target)
+		}
+	}
+
+	for _, node := range d.Nodes {
+		switch node.Type {
+		case NodeMessage:
+			if node.Text == "" {
+				add("node %q: text is required", node.ID)
+			}
+			ref(node, "next", node.Next, false)
+		case NodeQuestion:
+			if node.Text == "" {
+				add("node %q: text is required", node.ID)
+			}
+			d.validateSaveAs(node, add)
+			if node.Validation != nil {
+				validateInputRule(node, *node.Validation, add)
+			}
+			ref(node, "next", node.Next, false)
+			ref(node, "fallback", node.Fallback, false)
+		case NodeButtons:
+			d.validateSaveAs(node, add)
+			validateButtons(node, add)
+			for _, button := range node.Buttons {
+				ref(node, "button next", button.Next, false)
+			}
+			ref(node, "next", node.Next, false)
+			ref(node, "fallback", node.Fallback, false)
+		case NodeCondition:
+			if len(node.Rules) == 0 {
+				add("node %q: at least one rule is required", node.ID)
+			}
+			for _, rule := range node.Rules {
+				validateRule(node, rule, add)
+				ref(node, "rule next", rule.Next, true)
+			}
+			ref(node, "default", node.Default, true)
+		case NodeSetField:
+			if !validField(node.Field) {
+				add("node %q: invalid field %q", node.ID, node.Field)
+			}
+			ref(node, "next", node.Next, false)
+		case NodeHTTP:
+			validateRequest(node, add)
+			if node.SaveAs != "" && !variablePattern.MatchString(node.SaveAs) {
+				add("node %q: invalid save_as %q", node.ID, node.SaveAs)
+			}
+			ref(node, "next", node.Next, false)
+			ref(node, "on_error", node.OnError, false)
+		case NodeHandoff:
+			// Nó terminal
+		default:
+			add("node %q: unknown type %q", node.ID, node.Type)
+		}
+	}
+
+	if len(problems) == 0 {
+		if cycle := d.findAutomaticCycle(); cycle != "" {
+			add("nodes starting at %q loop without waiting for the customer", cycle)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidFlow, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (d *FlowDefinition) validateSaveAs(node Node, add func(string, ...interface{})) {
+	if !variablePattern.MatchString(node.SaveAs) {
+		add("node %q: save_as must be a lowercase variable name", node.ID)
+	}
+}
+
+func validateInputRule(node Node, validation Validation, add func(string, ...interface{})) {
+	switch validation.Type {
+	case ValidateText, ValidateNumber, ValidateEmail, ValidatePhone:
+	case ValidateRegex:
+		if _, err := regexp.Compile(validation.Pattern); err != nil || validation.Pattern == "" {
+			add("node %q: invalid validation pattern", node.ID)
+		}
+	default:
+		add("node %q: unknown validation type %q", node.ID, validation.Type)
+	}
+	if validation.MaxLength > 0 && validation.MinLength > validation.MaxLength {
+		add("node %q: min_length greater than max_length", node.ID)
+	}
+}
+
+// validateButtons reaproveita os limites da Cloud API para mensagens interativas
+func validateButtons(node Node, add func(string, ...interface{})) {
+	buttons := make([]whatsapp.ReplyButton, len(node.Buttons))
+	for i, button := range node.Buttons {
+		buttons[i] = whatsapp.ReplyButton{ID: button.ID, Title: button.Title}
+	}
+	message := whatsapp.OutboundMessage{
+		To:   "0",
+		Type: whatsapp.TypeInteractive,
+		Interactive: &whatsapp.InteractiveContent{
+			Type:    whatsapp.InteractiveButton,
+			Body:    node.Text,
+			Buttons: buttons,
+		},
+	}
+	if err := message.Validate(); err != nil {
+		add("node %q: %s", node.ID, strings.TrimPrefix(err.Error(), whatsapp.ErrInvalidMessage.Error()+": "))
+	}
+}
+
+func validateRule(node Node, rule Rule, add func(string, ...interface{})) {
+	if rule.Variable == "" {
+		add("node %q: rule variable is required", node.ID)
+	}
+	switch rule.Operator {
+	case OpEquals, OpNotEquals, OpContains, OpExists:
+	case OpMatches:
+		if _, err := regexp.Compile(rule.Value); err != nil {
+			add("node %q: invalid rule pattern", node.ID)
+		}
+	default:
+		add("node %q: unknown operator %q", node.ID, rule.Operator)
+	}
+}
+
+func validField(field string) bool {
+	if name, ok := strings.CutPrefix(field, "vars."); ok {
+		return variablePattern.MatchString(name)
+	}
+	return field == "customer.name" || field == "customer.email"
+}
+
+func validateRequest(node Node, add func(string, ...interface{})) {
+	if node.Request == nil {
+		add("node %q: request is required", node.ID)
+		return
+	}
+	switch strings.ToUpper(node.Request.Method) {
+	case "GET", "POST", "PUT", "PATCH", "DELETE":
+	default:
+		add("node %q: unsupported method %q", node.ID, node.Request.Method)
+	}
+	parsed, err := url.Parse(node.Request.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		add("node %q: request url must be an absolute http(s) url", node.ID)
+	}
+}
+
+// findAutomaticCycle detecta ciclos formados só por nós que não aguardam o cliente
+func (d *FlowDefinition) findAutomaticCycle() string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(d.Nodes))
+
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		node := d.Node(id)
+		if node == nil || node.Type.WaitsForInput() {
+			return false
+		}
+		switch state[id] {
+		case visiting:
+			return true
+		case done:
+			return false
+		}
+		state[id] = visiting
+		for _, next := range node.successors() {
+			if visit(next) {
+				return true
+			}
+		}
+		state[id] = done
+		return false
+	}
+
+	for _, node := range d.Nodes {
+		if state[node.ID] == unvisited && visit(node.ID) {
+			return node.ID
+		}
+	}
+	return ""
+}
+
+func (n *Node) successors() []string {
+	next := []string{n.Next, n.Default, n.OnError}
+	for _, rule := range n.Rules {
+		next = append(next, rule.Next)
+	}
+	result := next[:0]
+	for _, id := range next {
+		if id != "" {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// Flow é um fluxo de atendimento automático do tenant; só um fica ativo por vez
+type Flow struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name       string         `gorm:"type:varchar(255);not null" json:"name"`
+	Definition FlowDefinition `gorm:"type:text;serializer:json" json:"definition"`
+	Active     bool           `gorm:"not null;default:false;index" json:"active"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+func (f *Flow) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package chatbot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type flowRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newFlowRepositoryBase(db *gorm.DB) *flowRepositoryBase {
+	return &flowRepositoryBase{db: db}
+}
+
+func (r *flowRepositoryBase) create(ctx context.Context, flow *Flow) error {
+	return r.db.WithContext(ctx).Create(flow).Error
+}
+
+func (r *flowRepositoryBase) find(ctx context.Context, query *gorm.DB) (*Flow, error) {
+	var flow Flow
+	err := query.First(&flow).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &flow, nil
+}
+
+func (r *flowRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id))
+}
+
+func (r *flowRepositoryBase) findActive(ctx context.Context, tenantID uuid.UUID) (*Flow, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("tenant_id = ? AND active = ?", tenantID, true))
+}
+
+func (r *flowRepositoryBase) list(ctx context.Context, tenantID uuid.UUID) ([]Flow, error) {
+	var items []Flow
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("name ASC").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *flowRepositoryBase) update(ctx context.Context, flow *Flow) error {
+	return r.db.WithContext(ctx).Save(flow).Error
+}
+
+func (r *flowRepositoryBase) activate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Flow{}).
+			Where("tenant_id = ? AND id <> ? AND active = ?", tenantID, id, true).
+			Update("active", false).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Flow{}).
+			Where("tenant_id = ? AND id = ?", tenantID, id).
+			Update("active", true).Error
+	})
+}
+
+func (r *flowRepositoryBase) delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&Flow{}).Error
+}
+
+// Repository com telemetria (decorator)
+type flowRepository struct {
+	base      *flowRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewFlowRepository(db *gorm.DB, telemetry telemetry.TelemetryService) FlowRepository {
+	return &flowRepository{
+		base:      newFlowRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *flowRepository) Create(ctx context.Context, flow *Flow) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_flow.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", flow.TenantID.String())
+
+	err := r.base.create(ctx, flow)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *flowRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_flow.find_by_id")
+	defer span.End()
+
+	span.SetTag("flow_id", id)
+
+	flow, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return flow, nil
+}
+
+func (r *flowRepository) FindActive(ctx context.Context, tenantID uuid.UUID) (*Flow, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_flow.find_active")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	flow, err := r.base.findActive(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return flow, nil
+}
+
+func (r *flowRepository) List(ctx context.Context, tenantID uuid.UUID) ([]Flow, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_flow.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	items, err := r.base.list(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *flowRepository) Update(ctx context.Context, flow *Flow) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_flow.update")
+	defer span.End()
+
+	span.SetTag("flow_id", flow.ID.String())
+
+	err := r.base.update(ctx, flow)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *flowRepository) Activate(ctx context.Context, tenantID, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_flow.activate")
+	defer span.End()
+
+	span.SetTag("flow_id", id.String())
+
+	err := r.base.activate(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *flowRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_flow.delete")
+	defer span.End()
+
+	span.SetTag("flow_id", id.String())
+
+	err := r.base.delete(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
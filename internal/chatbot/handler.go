@@ -0,0 +1,125 @@
+package chatbot
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+)
+
+type FlowHandler struct {
+	flowService FlowService
+}
+
+func NewFlowHandler(flowService FlowService) *FlowHandler {
+	return &FlowHandler{
+		flowService: flowService,
+	}
+}
+
+func (h *FlowHandler) Create(c *gin.Context) {
+	var req SaveFlowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	flow, err := h.flowService.CreateFlow(c.Request.Context(), web.TenantID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, flow)
+}
+
+func (h *FlowHandler) List(c *gin.Context) {
+	items, err := h.flowService.ListFlows(c.Request.Context(), web.TenantID(c))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (h *FlowHandler) Get(c *gin.Context) {
+	flow, err := h.flowService.GetFlow(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, flow)
+}
+
+func (h *FlowHandler) Update(c *gin.Context) {
+	var req SaveFlowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	flow, err := h.flowService.UpdateFlow(c.Request.Context(), web.TenantID(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, flow)
+}
+
+func (h *FlowHandler) Delete(c *gin.Context) {
+	if err := h.flowService.DeleteFlow(c.Request.Context(), web.TenantID(c), c.Param("id")); err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FlowHandler) Activate(c *gin.Context) {
+	flow, err := h.flowService.ActivateFlow(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, flow)
+}
+
+func (h *FlowHandler) Deactivate(c *gin.Context) {
+	flow, err := h.flowService.DeactivateFlow(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, flow)
+}
+
+// Validate confere uma definição sem salvar, para o editor de fluxos
+func (h *FlowHandler) Validate(c *gin.Context) {
+	var definition FlowDefinition
+	if err := c.ShouldBindJSON(&definition); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	if err := definition.Validate(); err != nil {
+		c.JSON(http.StatusOK, ValidateFlowResponse{Valid: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, ValidateFlowResponse{Valid: true})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrFlowNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidFlow):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
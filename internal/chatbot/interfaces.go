@@ -0,0 +1,46 @@
+package chatbot
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+type FlowRepository interface {
+	Create(ctx context.Context, flow *Flow) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error)
+	FindActive(ctx context.Context, tenantID uuid.UUID) (*Flow, error)
+	List(ctx context.Context, tenantID uuid.UUID) ([]Flow, error)
+	Update(ctx context.Context, flow *Flow) error
+	// Activate ativa o fluxo e desativa os demais do tenant
+	Activate(ctx context.Context, tenantID, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	FindActive(ctx context.Context, conversationID uuid.UUID) (*Session, error)
+	FindLatest(ctx context.Context, conversationID uuid.UUID) (*Session, error)
+	Update(ctx context.Context, session *Session) error
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]Session, error)
+	// MarkTimedOut encerra a sessão se ainda estiver ativa; retorna false se outra instância já o fez
+	MarkTimedOut(ctx context.Context, id uuid.UUID, now time.Time) (bool, error)
+}
+
+type FlowService interface {
+	CreateFlow(ctx context.Context, tenantID uuid.UUID, req SaveFlowRequest) (*Flow, error)
+	GetFlow(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error)
+	ListFlows(ctx context.Context, tenantID uuid.UUID) ([]Flow, error)
+	UpdateFlow(ctx context.Context, tenantID uuid.UUID, id string, req SaveFlowRequest) (*Flow, error)
+	DeleteFlow(ctx context.Context, tenantID uuid.UUID, id string) error
+	ActivateFlow(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error)
+	DeactivateFlow(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error)
+}
+
+// Engine executa o fluxo ativo nas conversas sem atendente
+type Engine interface {
+	// HandleEvent é registrado como listener do WebhookService, depois da caixa de entrada
+	HandleEvent(ctx context.Context, event whatsapp.Event)
+}
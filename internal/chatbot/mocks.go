@@ -0,0 +1,120 @@
+package chatbot
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MockFlowRepository para testes
+type MockFlowRepository struct {
+	CreateFunc     func(ctx context.Context, flow *Flow) error
+	FindByIDFunc   func(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error)
+	FindActiveFunc func(ctx context.Context, tenantID uuid.UUID) (*Flow, error)
+	ListFunc       func(ctx context.Context, tenantID uuid.UUID) ([]Flow, error)
+	UpdateFunc     func(ctx context.Context, flow *Flow) error
+	ActivateFunc   func(ctx context.Context, tenantID, id uuid.UUID) error
+	DeleteFunc     func(ctx context.Context, tenantID, id uuid.UUID) error
+}
+
+func (m *MockFlowRepository) Create(ctx context.Context, flow *Flow) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, flow)
+	}
+	return nil
+}
+
+func (m *MockFlowRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockFlowRepository) FindActive(ctx context.Context, tenantID uuid.UUID) (*Flow, error) {
+	if m.FindActiveFunc != nil {
+		return m.FindActiveFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockFlowRepository) List(ctx context.Context, tenantID uuid.UUID) ([]Flow, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockFlowRepository) Update(ctx context.Context, flow *Flow) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, flow)
+	}
+	return nil
+}
+
+func (m *MockFlowRepository) Activate(ctx context.Context, tenantID, id uuid.UUID) error {
+	if m.ActivateFunc != nil {
+		return m.ActivateFunc(ctx, tenantID, id)
+	}
+	return nil
+}
+
+func (m *MockFlowRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID, id)
+	}
+	return nil
+}
+
+// MockSessionRepository para testes
+type MockSessionRepository struct {
+	CreateFunc       func(ctx context.Context, session *Session) error
+	FindActiveFunc   func(ctx context.Context, conversationID uuid.UUID) (*Session, error)
+	FindLatestFunc   func(ctx context.Context, conversationID uuid.UUID) (*Session, error)
+	UpdateFunc       func(ctx context.Context, session *Session) error
+	FindExpiredFunc  func(ctx context.Context, now time.Time, limit int) ([]Session, error)
+	MarkTimedOutFunc func(ctx context.Context, id uuid.UUID, now time.Time) (bool, error)
+}
+
+func (m *MockSessionRepository) Create(ctx context.Context, session *Session) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, session)
+	}
+	return nil
+}
+
+func (m *MockSessionRepository) FindActive(ctx context.Context, conversationID uuid.UUID) (*Session, error) {
+	if m.FindActiveFunc != nil {
+		return m.FindActiveFunc(ctx, conversationID)
+	}
+	return nil, nil
+}
+
+func (m *MockSessionRepository) FindLatest(ctx context.Context, conversationID uuid.UUID) (*Session, error) {
+	if m.FindLatestFunc != nil {
+		return m.FindLatestFunc(ctx, conversationID)
+	}
+	return nil, nil
+}
+
+func (m *MockSessionRepository) Update(ctx context.Context, session *Session) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, session)
+	}
+	return nil
+}
+
+func (m *MockSessionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]Session, error) {
+	if m.FindExpiredFunc != nil {
+		return m.FindExpiredFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockSessionRepository) MarkTimedOut(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	if m.MarkTimedOutFunc != nil {
+		return m.MarkTimedOutFunc(ctx, id, now)
+	}
+	return false, nil
+}
@@ -0,0 +1,126 @@
+package chatbot
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+var ErrFlowNotFound = errors.New("flow not found")
+
+type flowService struct {
+	flowRepo  FlowRepository
+	telemetry telemetry.TelemetryService
+}
+
+func NewFlowService(flowRepo FlowRepository, telemetry telemetry.TelemetryService) FlowService {
+	return &flowService{
+		flowRepo:  flowRepo,
+		telemetry: telemetry,
+	}
+}
+
+func (s *flowService) CreateFlow(ctx context.Context, tenantID uuid.UUID, req SaveFlowRequest) (*Flow, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "chatbot.create_flow")
+	defer span.End()
+
+	if err := req.Definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	flow := &Flow{
+		TenantID:   tenantID,
+		Name:       req.Name,
+		Definition: req.Definition,
+	}
+	if err := s.flowRepo.Create(ctx, flow); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "chatbot.flow_created",
+		Properties: map[string]interface{}{
+			"flow_id":   flow.ID.String(),
+			"tenant_id": tenantID.String(),
+			"nodes":     len(flow.Definition.Nodes),
+		},
+		Timestamp: time.Now(),
+	})
+
+	return flow, nil
+}
+
+func (s *flowService) GetFlow(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrFlowNotFound
+	}
+
+	flow, err := s.flowRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if flow == nil {
+		return nil, ErrFlowNotFound
+	}
+	return flow, nil
+}
+
+func (s *flowService) ListFlows(ctx context.Context, tenantID uuid.UUID) ([]Flow, error) {
+	return s.flowRepo.List(ctx, tenantID)
+}
+
+// UpdateFlow vale também para o fluxo ativo; sessões em andamento seguem pelos IDs dos nós
+func (s *flowService) UpdateFlow(ctx context.Context, tenantID uuid.UUID, id string, req SaveFlowRequest) (*Flow, error) {
+	flow, err := s.GetFlow(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	flow.Name = req.Name
+	flow.Definition = req.Definition
+	if err := s.flowRepo.Update(ctx, flow); err != nil {
+		return nil, err
+	}
+	return flow, nil
+}
+
+func (s *flowService) DeleteFlow(ctx context.Context, tenantID uuid.UUID, id string) error {
+	flow, err := s.GetFlow(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	return s.flowRepo.Delete(ctx, tenantID, flow.ID)
+}
+
+func (s *flowService) ActivateFlow(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error) {
+	flow, err := s.GetFlow(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.flowRepo.Activate(ctx, tenantID, flow.ID); err != nil {
+		return nil, err
+	}
+
+	flow.Active = true
+	return flow, nil
+}
+
+func (s *flowService) DeactivateFlow(ctx context.Context, tenantID uuid.UUID, id string) (*Flow, error) {
+	flow, err := s.GetFlow(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	flow.Active = false
+	if err := s.flowRepo.Update(ctx, flow); err != nil {
+		return nil, err
+	}
+	return flow, nil
+}
@@ -0,0 +1,47 @@
+package chatbot
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SessionStatus string
+
+const (
+	SessionActive    SessionStatus = "active"
+	SessionCompleted SessionStatus = "completed"
+	SessionHandedOff SessionStatus = "handed_off"
+	SessionTimedOut  SessionStatus = "timed_out"
+)
+
+// Session é a execução de um fluxo em uma conversa
+type Session struct {
+	ID             uuid.UUID         `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID       uuid.UUID         `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	ConversationID uuid.UUID         `gorm:"type:uuid;not null;index:idx_chatbot_sessions_conversation,priority:1" json:"conversation_id"`
+	CustomerID     uuid.UUID         `gorm:"type:uuid;not null" json:"customer_id"`
+	FlowID         uuid.UUID         `gorm:"type:uuid;not null" json:"flow_id"`
+	Status         SessionStatus     `gorm:"type:varchar(16);not null;index:idx_chatbot_sessions_conversation,priority:2;index:idx_chatbot_sessions_expiry,priority:1" json:"status"`
+	CurrentNodeID  string            `gorm:"type:varchar(64)" json:"current_node_id,omitempty"`
+	Variables      map[string]string `gorm:"type:text;serializer:json" json:"variables"`
+	Attempts       int               `gorm:"not null;default:0" json:"attempts"`
+	ExpiresAt      time.Time         `gorm:"not null;index:idx_chatbot_sessions_expiry,priority:2" json:"expires_at"`
+	EndedAt        *time.Time        `json:"ended_at,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+func (s *Session) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s *Session) end(status SessionStatus, now time.Time) {
+	s.Status = status
+	s.CurrentNodeID = ""
+	s.EndedAt = &now
+}
@@ -0,0 +1,170 @@
+package chatbot
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type sessionRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newSessionRepositoryBase(db *gorm.DB) *sessionRepositoryBase {
+	return &sessionRepositoryBase{db: db}
+}
+
+func (r *sessionRepositoryBase) create(ctx context.Context, session *Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *sessionRepositoryBase) find(ctx context.Context, query *gorm.DB) (*Session, error) {
+	var session Session
+	err := query.First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepositoryBase) findActive(ctx context.Context, conversationID uuid.UUID) (*Session, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("conversation_id = ? AND status = ?", conversationID, SessionActive))
+}
+
+func (r *sessionRepositoryBase) findLatest(ctx context.Context, conversationID uuid.UUID) (*Session, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("conversation_id = ?", conversationID).Order("created_at DESC"))
+}
+
+func (r *sessionRepositoryBase) update(ctx context.Context, session *Session) error {
+	return r.db.WithContext(ctx).Save(session).Error
+}
+
+func (r *sessionRepositoryBase) findExpired(ctx context.Context, now time.Time, limit int) ([]Session, error) {
+	var items []Session
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", SessionActive, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *sessionRepositoryBase) markTimedOut(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Session{}).
+		Where("id = ? AND status = ?", id, SessionActive).
+		Updates(map[string]interface{}{
+			"status":          SessionTimedOut,
+			"current_node_id": "",
+			"ended_at":        now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Repository com telemetria (decorator)
+type sessionRepository struct {
+	base      *sessionRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewSessionRepository(db *gorm.DB, telemetry telemetry.TelemetryService) SessionRepository {
+	return &sessionRepository{
+		base:      newSessionRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *Session) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_session.create")
+	defer span.End()
+
+	span.SetTag("conversation_id", session.ConversationID.String())
+
+	err := r.base.create(ctx, session)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepository) FindActive(ctx context.Context, conversationID uuid.UUID) (*Session, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_session.find_active")
+	defer span.End()
+
+	span.SetTag("conversation_id", conversationID.String())
+
+	session, err := r.base.findActive(ctx, conversationID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *sessionRepository) FindLatest(ctx context.Context, conversationID uuid.UUID) (*Session, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_session.find_latest")
+	defer span.End()
+
+	span.SetTag("conversation_id", conversationID.String())
+
+	session, err := r.base.findLatest(ctx, conversationID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *sessionRepository) Update(ctx context.Context, session *Session) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_session.update")
+	defer span.End()
+
+	span.SetTag("session_id", session.ID.String())
+
+	err := r.base.update(ctx, session)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]Session, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_session.find_expired")
+	defer span.End()
+
+	items, err := r.base.findExpired(ctx, now, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *sessionRepository) MarkTimedOut(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.chatbot_session.mark_timed_out")
+	defer span.End()
+
+	span.SetTag("session_id", id.String())
+
+	claimed, err := r.base.markTimedOut(ctx, id, now)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return claimed, nil
+}
@@ -0,0 +1,113 @@
+package chatbot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+)
+
+const timeoutBatchSize = 100
+
+// TimeoutWorker encerra as sessões sem resposta do cliente e envia a mensagem de timeout do fluxo
+type TimeoutWorker struct {
+	sessionRepo         SessionRepository
+	flowRepo            FlowRepository
+	conversationService whatsapp.ConversationService
+	telemetry           telemetry.TelemetryService
+	interval            time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewTimeoutWorker(
+	sessionRepo SessionRepository,
+	flowRepo FlowRepository,
+	conversationService whatsapp.ConversationService,
+	telemetry telemetry.TelemetryService,
+	interval time.Duration,
+) *TimeoutWorker {
+	return &TimeoutWorker{
+		sessionRepo:         sessionRepo,
+		flowRepo:            flowRepo,
+		conversationService: conversationService,
+		telemetry:           telemetry,
+		interval:            interval,
+	}
+}
+
+func (w *TimeoutWorker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				w.RunOnce(ctx, now)
+			}
+		}
+	}()
+}
+
+func (w *TimeoutWorker) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+}
+
+// RunOnce expira as sessões vencidas até now e retorna quantas foram encerradas
+func (w *TimeoutWorker) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	span, ctx := w.telemetry.StartSpan(ctx, "chatbot.timeout_worker.run")
+	defer span.End()
+
+	expired, err := w.sessionRepo.FindExpired(ctx, now, timeoutBatchSize)
+	if err != nil {
+		span.SetError(err)
+		return 0, err
+	}
+
+	timedOut := 0
+	for _, session := range expired {
+		claimed, err := w.sessionRepo.MarkTimedOut(ctx, session.ID, now)
+		if err != nil {
+			span.SetError(err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		timedOut++
+
+		flow, err := w.flowRepo.FindByID(ctx, session.TenantID, session.FlowID.String())
+		if err != nil || flow == nil || flow.Definition.TimeoutMessage == "" {
+			continue
+		}
+		_, err = w.conversationService.SendMessage(ctx, session.TenantID, botUserID, session.ConversationID.String(), whatsapp.SendMessageRequest{
+			Type: whatsapp.TypeText,
+			Text: &whatsapp.TextContent{Body: flow.Definition.TimeoutMessage},
+		})
+		if err != nil {
+			// Janela de 24h pode ter fechado; a sessão continua encerrada
+			span.SetError(err)
+		}
+	}
+
+	w.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "chatbot.sessions.timed_out",
+		Value: float64(timedOut),
+	})
+
+	return timedOut, nil
+}
@@ -1,59 +1,333 @@
 package container
 
 import (
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/activities"
 	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/campaigns"
+	"github.com/claudineijrdev/sib-crm-backend/internal/cannedresponses"
+	"github.com/claudineijrdev/sib-crm-backend/internal/chatbot"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/database"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/logging"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/secrets"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/storage"
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/sla"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tasks"
+	"github.com/claudineijrdev/sib-crm-backend/internal/teams"
 	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 type Container struct {
 	// Infraestrutura compartilhada
-	DB             *gorm.DB
-	Telemetry      telemetry.TelemetryService
-	Cache          cache.CacheService
-	
+	DB        *gorm.DB
+	Telemetry telemetry.TelemetryService
+	Cache     cache.Store
+	// Eventos em tempo real para o navegador
+	RealtimeBroker realtime.Broker
+	RealtimeHub    *realtime.Hub
+
 	// Repositórios
-	UserRepo       auth.UserRepository
-	TenantRepo     tenants.TenantRepository
-	
+	UserRepo         auth.UserRepository
+	TenantRepo       tenants.TenantRepository
+	ActivityRepo     activities.ActivityRepository
+	TaskRepo         tasks.TaskRepository
+	MessageRepo      whatsapp.MessageRepository
+	CustomerRepo     customers.CustomerRepository
+	ConversationRepo whatsapp.ConversationRepository
+	TemplateRepo     whatsapp.TemplateRepository
+	MediaRepo        whatsapp.MediaRepository
+	SegmentRepo      customers.SegmentRepository
+	CampaignRepo     campaigns.CampaignRepository
+	FlowRepo         chatbot.FlowRepository
+	SessionRepo      chatbot.SessionRepository
+	CannedRepo       cannedresponses.CannedResponseRepository
+	CalendarRepo     businesshours.CalendarRepository
+	SLAPolicyRepo    sla.PolicyRepository
+	SLATimerRepo     sla.TimerRepository
+	ChannelRepo      whatsapp.ChannelRepository
+	TeamRepo         teams.TeamRepository
+	PresenceRepo     teams.PresenceRepository
+	TransferRepo     teams.TransferRepository
+
 	// Services
-	AuthService    auth.AuthService
-	
+	AuthService         auth.AuthService
+	ActivityService     activities.ActivityService
+	TaskService         tasks.TaskService
+	WebhookService      whatsapp.WebhookService
+	MessageService      whatsapp.MessageService
+	CustomerService     customers.CustomerService
+	ConversationService whatsapp.ConversationService
+	TemplateService     whatsapp.TemplateService
+	MediaService        whatsapp.MediaService
+	SegmentService      customers.SegmentService
+	CampaignService     campaigns.CampaignService
+	FlowService         chatbot.FlowService
+	ChatbotEngine       chatbot.Engine
+	CannedService       cannedresponses.CannedResponseService
+	HoursService        businesshours.BusinessHoursService
+	AwayMessageService  whatsapp.AwayMessageService
+	SLAService          sla.SLAService
+	ChannelService      whatsapp.ChannelService
+	TeamService         teams.TeamService
+	TeamRouter          teams.Router
+	RealtimeBridge      realtime.WhatsAppBridge
+
+	// Identity autentica as rotas do tenant pelo token de sessão do login
+	Identity gin.HandlerFunc
+
 	// Handlers
-	AuthHandler    *auth.AuthHandler
+	AuthHandler         *auth.AuthHandler
+	ActivityHandler     *activities.ActivityHandler
+	TaskHandler         *tasks.TaskHandler
+	WebhookHandler      *whatsapp.WebhookHandler
+	MessageHandler      *whatsapp.MessageHandler
+	ConversationHandler *whatsapp.ConversationHandler
+	TemplateHandler     *whatsapp.TemplateHandler
+	MediaHandler        *whatsapp.MediaHandler
+	CustomerHandler     *customers.CustomerHandler
+	CampaignHandler     *campaigns.CampaignHandler
+	FlowHandler         *chatbot.FlowHandler
+	CannedHandler       *cannedresponses.CannedResponseHandler
+	HoursHandler        *businesshours.BusinessHoursHandler
+	SLAHandler          *sla.SLAHandler
+	ChannelHandler      *whatsapp.ChannelHandler
+	TeamHandler         *teams.TeamHandler
+	RealtimeHandler     *realtime.RealtimeHandler
+
+	// Workers
+	AdminServer        *telemetry.AdminServer
+	ReminderScheduler  *tasks.ReminderScheduler
+	WebhookEvents      *whatsapp.EventQueue
+	CampaignDispatcher *campaigns.Dispatcher
+	ChatbotTimeouts    *chatbot.TimeoutWorker
+	SLAMonitor         *sla.BreachMonitor
+	TeamDistributor    *teams.Distributor
 }
 
 func NewContainer(db *gorm.DB) *Container {
 	// Inicializar serviços de infraestrutura
-	telemetryService := telemetry.NewTelemetryService(true) // enabled
-	cacheService := cache.NewCacheService(nil) // nil client por enquanto
-	
+	telemetryConfig := telemetry.LoadConfig()
+	metricsRegistry := telemetry.NewRegistry()
+	telemetryService := telemetry.NewPrometheusService(telemetry.NewTelemetryService(true), metricsRegistry)
+	adminServer := telemetry.NewAdminServer(telemetryConfig.MetricsAddr, metricsRegistry)
+	cacheService, err := cache.NewStore(cache.LoadConfig(), telemetryService)
+	if err != nil {
+		logging.Fatal("failed to configure cache", err)
+	}
+	whatsappConfig := whatsapp.LoadConfig()
+	whatsappProvider := whatsapp.NewProvider(whatsappConfig)
+	storageConfig := storage.LoadConfig()
+	blobStore := storage.NewBlobStore(storageConfig)
+	urlSigner, err := storage.NewURLSigner(storageConfig.URLSecret, storageConfig.PublicBaseURL, storageConfig.URLTTL)
+	if err != nil {
+		logging.Fatal("failed to configure storage url signer", err)
+	}
+	campaignConfig := campaigns.LoadConfig()
+	cipher, err := secrets.NewCipher(secrets.LoadConfig())
+	if err != nil {
+		logging.Fatal("failed to load secrets cipher", err)
+	}
+	realtimeConfig := realtime.LoadConfig()
+	realtimeBroker := realtime.NewBroker(realtimeConfig, db, database.DSN(), telemetryService)
+	realtimeHub := realtime.NewHub(realtimeBroker, telemetryService, realtimeConfig.ClientBuffer)
+	realtimeTickets := realtime.NewTicketer(realtimeConfig.TicketSecret, realtimeConfig.TicketTTL)
+	sessions, err := web.NewSessions(web.LoadSessionConfig())
+	if err != nil {
+		logging.Fatal("failed to configure sessions", err)
+	}
+
 	// Criar repositórios (com cache e telemetria)
 	userRepo := auth.NewUserRepository(db, cacheService, telemetryService)
 	tenantRepo := tenants.NewTenantRepository(db, cacheService, telemetryService)
-	
+	identity := web.IdentityMiddleware(sessions, auth.NewUserDirectory(userRepo))
+	activityRepo := activities.NewActivityRepository(db, telemetryService)
+	taskRepo := tasks.NewTaskRepository(db, telemetryService)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	customerRepo := customers.NewCustomerRepository(db, telemetryService)
+	conversationRepo := whatsapp.NewConversationRepository(db, telemetryService)
+	templateRepo := whatsapp.NewTemplateRepository(db, telemetryService)
+	mediaRepo := whatsapp.NewMediaRepository(db, telemetryService)
+	pendingEventRepo := whatsapp.NewPendingEventRepository(db, telemetryService)
+	segmentRepo := customers.NewSegmentRepository(db, telemetryService)
+	campaignRepo := campaigns.NewCampaignRepository(db, telemetryService)
+	flowRepo := chatbot.NewFlowRepository(db, telemetryService)
+	sessionRepo := chatbot.NewSessionRepository(db, telemetryService)
+	cannedRepo := cannedresponses.NewCannedResponseRepository(db, telemetryService)
+	calendarRepo := businesshours.NewCalendarRepository(db, telemetryService)
+	slaPolicyRepo := sla.NewPolicyRepository(db, telemetryService)
+	slaTimerRepo := sla.NewTimerRepository(db, telemetryService)
+	channelRepo := whatsapp.NewChannelRepository(db, telemetryService)
+	teamRepo := teams.NewTeamRepository(db, telemetryService)
+	presenceRepo := teams.NewPresenceRepository(db, telemetryService)
+	transferRepo := teams.NewTransferRepository(db, telemetryService)
+
 	// Criar services (só telemetria, cache já está no repository)
 	authService := auth.NewAuthService(userRepo, tenantRepo, db, telemetryService)
-	
+	activityService := activities.NewActivityService(activityRepo, telemetryService)
+	taskService := tasks.NewTaskService(taskRepo, userRepo, telemetryService)
+	channelService := whatsapp.NewChannelService(channelRepo, tenantRepo, whatsappProvider, teams.NewTeamDirectory(teamRepo), chatbot.NewFlowDirectory(flowRepo), cipher, telemetryService)
+	webhookService := whatsapp.NewWebhookService(messageRepo, channelService, telemetryService)
+	messageService := whatsapp.NewMessageService(messageRepo, channelService, whatsappProvider, telemetryService)
+	customerService := customers.NewCustomerService(customerRepo, telemetryService)
+	conversationService := whatsapp.NewConversationService(conversationRepo, messageRepo, messageService, customerService, userRepo, telemetryService)
+	templateService := whatsapp.NewTemplateService(templateRepo, channelService, whatsappProvider, messageService, conversationService, telemetryService)
+	mediaService := whatsapp.NewMediaService(mediaRepo, messageRepo, channelService, whatsappProvider, blobStore, urlSigner, telemetryService)
+	segmentService := customers.NewSegmentService(segmentRepo, customerRepo, telemetryService)
+	campaignService := campaigns.NewCampaignService(campaignRepo, templateService, segmentService, customerService, telemetryService)
+	flowService := chatbot.NewFlowService(flowRepo, telemetryService)
+	chatbotEngine := chatbot.NewEngine(chatbot.LoadConfig(), flowRepo, sessionRepo, conversationService, customerRepo, nil, telemetryService)
+	cannedService := cannedresponses.NewCannedResponseService(cannedRepo, customerRepo, userRepo, tenantRepo, conversationService, telemetryService)
+	hoursService := businesshours.NewBusinessHoursService(calendarRepo, telemetryService)
+	awayMessageService := whatsapp.NewAwayMessageService(conversationRepo, conversationService, hoursService, telemetryService)
+	slaNotifier := sla.NewRealtimeNotifier(realtimeHub, telemetryService)
+	slaService := sla.NewSLAService(slaPolicyRepo, slaTimerRepo, conversationRepo, messageRepo, hoursService, slaNotifier, telemetryService)
+	teamService := teams.NewTeamService(teamRepo, presenceRepo, transferRepo, conversationRepo, sessionRepo, realtimeHub, userRepo, telemetryService)
+	teamRouter := teams.NewRouter(teamRepo, presenceRepo, transferRepo, conversationRepo, sessionRepo, realtimeHub, telemetryService)
+
+	// O webhook só grava e enfileira; os listeners rodam nos workers da fila e o que
+	// ficou pendente numa queda é reentregue quando a fila sobe
+	webhookEvents := whatsapp.NewEventQueue(whatsappConfig, pendingEventRepo, messageRepo, channelService, telemetryService)
+	webhookService.Subscribe(webhookEvents.Enqueue)
+	// Mensagens recebidas alimentam a caixa de entrada compartilhada
+	webhookEvents.Subscribe(conversationService.HandleEvent)
+	// Fora do expediente o cliente recebe a mensagem de ausência do calendário
+	webhookEvents.Subscribe(awayMessageService.HandleEvent)
+	// Timers de SLA começam na mensagem do cliente, já vinculada à conversa
+	webhookEvents.Subscribe(slaService.HandleEvent)
+	// Chatbot roda depois da caixa de entrada, que vincula a mensagem à conversa
+	webhookEvents.Subscribe(chatbotEngine.HandleEvent)
+	// Fila do time roda depois do chatbot: o handoff na mesma mensagem já distribui
+	webhookEvents.Subscribe(teamRouter.HandleEvent)
+	// Aprovações/rejeições de templates chegam pelo mesmo webhook
+	webhookEvents.Subscribe(templateService.HandleEvent)
+	// Mídias recebidas são copiadas para o storage próprio
+	webhookEvents.Subscribe(mediaService.HandleEvent)
+	// Status e respostas alimentam as estatísticas das campanhas; "SAIR" registra o opt-out
+	webhookEvents.Subscribe(campaignService.HandleEvent)
+	// Mensagens, status e atribuições chegam ao navegador sem recarregar a caixa de entrada
+	realtimeBridge := realtime.NewWhatsAppBridge(realtimeHub)
+	webhookEvents.Subscribe(realtimeBridge.HandleEvent)
+	conversationService.Subscribe(realtimeBridge.HandleEvent)
+
 	// Criar handlers
-	authHandler := auth.NewAuthHandler(authService)
-	
+	authHandler := auth.NewAuthHandler(authService, sessions)
+	activityHandler := activities.NewActivityHandler(activityService)
+	taskHandler := tasks.NewTaskHandler(taskService)
+	webhookHandler := whatsapp.NewWebhookHandler(whatsappConfig, webhookService)
+	messageHandler := whatsapp.NewMessageHandler(messageService)
+	conversationHandler := whatsapp.NewConversationHandler(conversationService)
+	templateHandler := whatsapp.NewTemplateHandler(templateService)
+	mediaHandler := whatsapp.NewMediaHandler(mediaService)
+	customerHandler := customers.NewCustomerHandler(customerService, segmentService)
+	campaignHandler := campaigns.NewCampaignHandler(campaignService)
+	flowHandler := chatbot.NewFlowHandler(flowService)
+	cannedHandler := cannedresponses.NewCannedResponseHandler(cannedService)
+	hoursHandler := businesshours.NewBusinessHoursHandler(hoursService)
+	slaHandler := sla.NewSLAHandler(slaService)
+	channelHandler := whatsapp.NewChannelHandler(channelService)
+	teamHandler := teams.NewTeamHandler(teamService)
+	realtimeHandler := realtime.NewRealtimeHandler(realtimeHub, realtimeTickets, identity, realtimeConfig.Heartbeat, realtimeConfig.AllowedOrigins)
+
+	// Criar workers
+	reminderScheduler := tasks.NewReminderScheduler(taskRepo, tasks.NewRealtimeNotifier(realtimeHub, telemetryService), telemetryService, time.Minute)
+	campaignDispatcher := campaigns.NewDispatcher(campaignRepo, customerRepo, templateService, channelService, telemetryService, campaignConfig, time.Second)
+	chatbotTimeouts := chatbot.NewTimeoutWorker(sessionRepo, flowRepo, conversationService, telemetryService, time.Minute)
+	slaMonitor := sla.NewBreachMonitor(slaTimerRepo, conversationRepo, messageRepo, hoursService, slaNotifier, telemetryService, time.Minute)
+	teamDistributor := teams.NewDistributor(teamRepo, presenceRepo, transferRepo, conversationRepo, sessionRepo, realtimeHub, telemetryService, 30*time.Second)
+
 	return &Container{
 		// Infraestrutura
 		DB:        db,
 		Telemetry: telemetryService,
 		Cache:     cacheService,
-		
+
+		RealtimeBroker: realtimeBroker,
+		RealtimeHub:    realtimeHub,
+
 		// Repositórios
-		UserRepo:   userRepo,
-		TenantRepo: tenantRepo,
-		
+		UserRepo:         userRepo,
+		TenantRepo:       tenantRepo,
+		ActivityRepo:     activityRepo,
+		TaskRepo:         taskRepo,
+		MessageRepo:      messageRepo,
+		CustomerRepo:     customerRepo,
+		ConversationRepo: conversationRepo,
+		TemplateRepo:     templateRepo,
+		MediaRepo:        mediaRepo,
+		SegmentRepo:      segmentRepo,
+		CampaignRepo:     campaignRepo,
+		FlowRepo:         flowRepo,
+		SessionRepo:      sessionRepo,
+		CannedRepo:       cannedRepo,
+		CalendarRepo:     calendarRepo,
+		SLAPolicyRepo:    slaPolicyRepo,
+		SLATimerRepo:     slaTimerRepo,
+		ChannelRepo:      channelRepo,
+		TeamRepo:         teamRepo,
+		PresenceRepo:     presenceRepo,
+		TransferRepo:     transferRepo,
+
 		// Services
-		AuthService: authService,
-		
+		AuthService:         authService,
+		ActivityService:     activityService,
+		TaskService:         taskService,
+		WebhookService:      webhookService,
+		MessageService:      messageService,
+		CustomerService:     customerService,
+		ConversationService: conversationService,
+		TemplateService:     templateService,
+		MediaService:        mediaService,
+		SegmentService:      segmentService,
+		CampaignService:     campaignService,
+		FlowService:         flowService,
+		ChatbotEngine:       chatbotEngine,
+		CannedService:       cannedService,
+		HoursService:        hoursService,
+		AwayMessageService:  awayMessageService,
+		SLAService:          slaService,
+		ChannelService:      channelService,
+		TeamService:         teamService,
+		TeamRouter:          teamRouter,
+		RealtimeBridge:      realtimeBridge,
+
+		Identity: identity,
+
 		// Handlers
-		AuthHandler: authHandler,
+		AuthHandler:         authHandler,
+		ActivityHandler:     activityHandler,
+		TaskHandler:         taskHandler,
+		WebhookHandler:      webhookHandler,
+		MessageHandler:      messageHandler,
+		ConversationHandler: conversationHandler,
+		TemplateHandler:     templateHandler,
+		MediaHandler:        mediaHandler,
+		CustomerHandler:     customerHandler,
+		CampaignHandler:     campaignHandler,
+		FlowHandler:         flowHandler,
+		CannedHandler:       cannedHandler,
+		HoursHandler:        hoursHandler,
+		SLAHandler:          slaHandler,
+		ChannelHandler:      channelHandler,
+		TeamHandler:         teamHandler,
+		RealtimeHandler:     realtimeHandler,
+
+		// Workers
+		AdminServer:        adminServer,
+		ReminderScheduler:  reminderScheduler,
+		WebhookEvents:      webhookEvents,
+		CampaignDispatcher: campaignDispatcher,
+		ChatbotTimeouts:    chatbotTimeouts,
+		SLAMonitor:         slaMonitor,
+		TeamDistributor:    teamDistributor,
 	}
-} 
\ No newline at end of file
+}
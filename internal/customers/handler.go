@@ -0,0 +1,102 @@
+package customers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+)
+
+type CustomerHandler struct {
+	customerService CustomerService
+	segmentService  SegmentService
+}
+
+func NewCustomerHandler(customerService CustomerService, segmentService SegmentService) *CustomerHandler {
+	return &CustomerHandler{
+		customerService: customerService,
+		segmentService:  segmentService,
+	}
+}
+
+func (h *CustomerHandler) Get(c *gin.Context) {
+	customer, err := h.customerService.GetCustomer(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, customer)
+}
+
+func (h *CustomerHandler) OptOut(c *gin.Context) {
+	h.setOptOut(c, true)
+}
+
+func (h *CustomerHandler) OptIn(c *gin.Context) {
+	h.setOptOut(c, false)
+}
+
+func (h *CustomerHandler) setOptOut(c *gin.Context, optedOut bool) {
+	customer, err := h.customerService.SetWhatsAppOptOut(c.Request.Context(), web.TenantID(c), c.Param("id"), optedOut)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, customer)
+}
+
+func (h *CustomerHandler) CreateSegment(c *gin.Context) {
+	var req CreateSegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	segment, err := h.segmentService.CreateSegment(c.Request.Context(), web.TenantID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, segment)
+}
+
+func (h *CustomerHandler) ListSegments(c *gin.Context) {
+	items, err := h.segmentService.ListSegments(c.Request.Context(), web.TenantID(c))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// SegmentCustomers pré-visualiza quem o segmento atinge hoje
+func (h *CustomerHandler) SegmentCustomers(c *gin.Context) {
+	pagination := web.ParsePagination(c)
+
+	items, err := h.segmentService.ListSegmentCustomers(c.Request.Context(), web.TenantID(c), c.Param("id"), pagination.Offset(), pagination.PageSize)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":     items,
+		"page":      pagination.Page,
+		"page_size": pagination.PageSize,
+	})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrCustomerNotFound), errors.Is(err, ErrSegmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidPhone), errors.Is(err, ErrInvalidSegment):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,37 @@
+package customers
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type CustomerRepository interface {
+	Create(ctx context.Context, customer *Customer) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Customer, error)
+	FindByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (*Customer, error)
+	Update(ctx context.Context, customer *Customer) error
+	// FindBySegment lista os clientes que atendem aos critérios, em ordem estável
+	FindBySegment(ctx context.Context, tenantID uuid.UUID, criteria SegmentCriteria, offset, limit int) ([]Customer, error)
+}
+
+type SegmentRepository interface {
+	Create(ctx context.Context, segment *Segment) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Segment, error)
+	List(ctx context.Context, tenantID uuid.UUID) ([]Segment, error)
+}
+
+type CustomerService interface {
+	// FindOrCreateByPhone retorna o cliente do telefone, criando-o no primeiro contato
+	FindOrCreateByPhone(ctx context.Context, tenantID uuid.UUID, phone, name string) (*Customer, error)
+	GetCustomer(ctx context.Context, tenantID uuid.UUID, id string) (*Customer, error)
+	SetWhatsAppOptOut(ctx context.Context, tenantID uuid.UUID, id string, optedOut bool) (*Customer, error)
+	// OptOutByPhone é usado quando o cliente pede descadastro pela própria conversa
+	OptOutByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (*Customer, error)
+}
+
+type SegmentService interface {
+	CreateSegment(ctx context.Context, tenantID uuid.UUID, req CreateSegmentRequest) (*Segment, error)
+	GetSegment(ctx context.Context, tenantID uuid.UUID, id string) (*Segment, error)
+	ListSegments(ctx context.Context, tenantID uuid.UUID) ([]Segment, error)
+	ListSegmentCustomers(ctx context.Context, tenantID uuid.UUID, id string, offset, limit int) ([]Customer, error)
+}
@@ -0,0 +1,78 @@
+package customers
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// MockCustomerRepository para testes
+type MockCustomerRepository struct {
+	CreateFunc        func(ctx context.Context, customer *Customer) error
+	FindByIDFunc      func(ctx context.Context, tenantID uuid.UUID, id string) (*Customer, error)
+	FindByPhoneFunc   func(ctx context.Context, tenantID uuid.UUID, phone string) (*Customer, error)
+	UpdateFunc        func(ctx context.Context, customer *Customer) error
+	FindBySegmentFunc func(ctx context.Context, tenantID uuid.UUID, criteria SegmentCriteria, offset, limit int) ([]Customer, error)
+}
+
+func (m *MockCustomerRepository) Create(ctx context.Context, customer *Customer) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, customer)
+	}
+	return nil
+}
+
+func (m *MockCustomerRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Customer, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) FindByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (*Customer, error) {
+	if m.FindByPhoneFunc != nil {
+		return m.FindByPhoneFunc(ctx, tenantID, phone)
+	}
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) Update(ctx context.Context, customer *Customer) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, customer)
+	}
+	return nil
+}
+
+func (m *MockCustomerRepository) FindBySegment(ctx context.Context, tenantID uuid.UUID, criteria SegmentCriteria, offset, limit int) ([]Customer, error) {
+	if m.FindBySegmentFunc != nil {
+		return m.FindBySegmentFunc(ctx, tenantID, criteria, offset, limit)
+	}
+	return nil, nil
+}
+
+// MockSegmentRepository para testes
+type MockSegmentRepository struct {
+	CreateFunc   func(ctx context.Context, segment *Segment) error
+	FindByIDFunc func(ctx context.Context, tenantID uuid.UUID, id string) (*Segment, error)
+	ListFunc     func(ctx context.Context, tenantID uuid.UUID) ([]Segment, error)
+}
+
+func (m *MockSegmentRepository) Create(ctx context.Context, segment *Segment) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, segment)
+	}
+	return nil
+}
+
+func (m *MockSegmentRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Segment, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockSegmentRepository) List(ctx context.Context, tenantID uuid.UUID) ([]Segment, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,44 @@
+package customers
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Customer struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_customers_tenant_phone,priority:1" json:"tenant_id"`
+	Name      string    `gorm:"type:varchar(255)" json:"name"`
+	Phone     string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_customers_tenant_phone,priority:2" json:"phone"`
+	Email     string    `gorm:"type:varchar(255)" json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Cliente pediu para não receber mensagens ativas (campanhas) no WhatsApp
+	WhatsAppOptOutAt *time.Time `gorm:"column:whatsapp_opt_out_at" json:"whatsapp_opt_out_at,omitempty"`
+}
+
+func (c *Customer) OptedOut() bool {
+	return c.WhatsAppOptOutAt != nil
+}
+
+func (c *Customer) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// NormalizePhone mantém apenas os dígitos (formato E.164 sem "+", como o WhatsApp envia)
+func NormalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+}
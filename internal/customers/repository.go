@@ -0,0 +1,159 @@
+package customers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type customerRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newCustomerRepositoryBase(db *gorm.DB) *customerRepositoryBase {
+	return &customerRepositoryBase{db: db}
+}
+
+func (r *customerRepositoryBase) create(ctx context.Context, customer *Customer) error {
+	return r.db.WithContext(ctx).Create(customer).Error
+}
+
+func (r *customerRepositoryBase) findOne(ctx context.Context, query string, args ...interface{}) (*Customer, error) {
+	var customer Customer
+	err := r.db.WithContext(ctx).Where(query, args...).First(&customer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (r *customerRepositoryBase) update(ctx context.Context, customer *Customer) error {
+	return r.db.WithContext(ctx).Save(customer).Error
+}
+
+func (r *customerRepositoryBase) findBySegment(ctx context.Context, tenantID uuid.UUID, criteria SegmentCriteria, offset, limit int) ([]Customer, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+
+	if criteria.NameContains != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(criteria.NameContains)+"%")
+	}
+	if criteria.PhonePrefix != "" {
+		query = query.Where("phone LIKE ?", NormalizePhone(criteria.PhonePrefix)+"%")
+	}
+	if criteria.EmailDomain != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%@"+strings.ToLower(strings.TrimPrefix(criteria.EmailDomain, "@")))
+	}
+	if criteria.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *criteria.CreatedAfter)
+	}
+	if criteria.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *criteria.CreatedBefore)
+	}
+	if len(criteria.CustomerIDs) > 0 {
+		query = query.Where("id IN ?", criteria.CustomerIDs)
+	}
+
+	var items []Customer
+	err := query.Order("created_at ASC, id ASC").Offset(offset).Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Repository com telemetria (decorator)
+type customerRepository struct {
+	base      *customerRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewCustomerRepository(db *gorm.DB, telemetry telemetry.TelemetryService) CustomerRepository {
+	return &customerRepository{
+		base:      newCustomerRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *customerRepository) Create(ctx context.Context, customer *Customer) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.customer.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", customer.TenantID.String())
+
+	err := r.base.create(ctx, customer)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+
+	r.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "repository.customer.create.success",
+		Value: 1,
+	})
+
+	return nil
+}
+
+func (r *customerRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Customer, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.customer.find_by_id")
+	defer span.End()
+
+	span.SetTag("customer_id", id)
+
+	customer, err := r.base.findOne(ctx, "tenant_id = ? AND id = ?", tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return customer, nil
+}
+
+func (r *customerRepository) FindByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (*Customer, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.customer.find_by_phone")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	customer, err := r.base.findOne(ctx, "tenant_id = ? AND phone = ?", tenantID, phone)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return customer, nil
+}
+
+func (r *customerRepository) Update(ctx context.Context, customer *Customer) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.customer.update")
+	defer span.End()
+
+	span.SetTag("customer_id", customer.ID.String())
+
+	err := r.base.update(ctx, customer)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *customerRepository) FindBySegment(ctx context.Context, tenantID uuid.UUID, criteria SegmentCriteria, offset, limit int) ([]Customer, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.customer.find_by_segment")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	items, err := r.base.findBySegment(ctx, tenantID, criteria, offset, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
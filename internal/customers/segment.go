@@ -0,0 +1,51 @@
+package customers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSegmentNotFound = errors.New("segment not found")
+	ErrInvalidSegment  = errors.New("segment must have a name and at least one criterion")
+)
+
+// SegmentCriteria são combinados com AND; campos vazios são ignorados
+type SegmentCriteria struct {
+	NameContains  string      `json:"name_contains,omitempty"`
+	PhonePrefix   string      `json:"phone_prefix,omitempty"`
+	EmailDomain   string      `json:"email_domain,omitempty"`
+	CreatedAfter  *time.Time  `json:"created_after,omitempty"`
+	CreatedBefore *time.Time  `json:"created_before,omitempty"`
+	CustomerIDs   []uuid.UUID `json:"customer_ids,omitempty"`
+}
+
+func (c SegmentCriteria) IsEmpty() bool {
+	return c.NameContains == "" && c.PhonePrefix == "" && c.EmailDomain == "" &&
+		c.CreatedAfter == nil && c.CreatedBefore == nil && len(c.CustomerIDs) == 0
+}
+
+// Segment é um recorte salvo da base de clientes, reavaliado a cada uso
+type Segment struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID  uuid.UUID       `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name      string          `gorm:"type:varchar(255);not null" json:"name"`
+	Criteria  SegmentCriteria `gorm:"type:text;serializer:json" json:"criteria"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+func (s *Segment) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+type CreateSegmentRequest struct {
+	Name     string          `json:"name" binding:"required"`
+	Criteria SegmentCriteria `json:"criteria"`
+}
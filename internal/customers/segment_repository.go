@@ -0,0 +1,99 @@
+package customers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type segmentRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newSegmentRepositoryBase(db *gorm.DB) *segmentRepositoryBase {
+	return &segmentRepositoryBase{db: db}
+}
+
+func (r *segmentRepositoryBase) create(ctx context.Context, segment *Segment) error {
+	return r.db.WithContext(ctx).Create(segment).Error
+}
+
+func (r *segmentRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Segment, error) {
+	var segment Segment
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&segment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &segment, nil
+}
+
+func (r *segmentRepositoryBase) list(ctx context.Context, tenantID uuid.UUID) ([]Segment, error) {
+	var items []Segment
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("name ASC").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Repository com telemetria (decorator)
+type segmentRepository struct {
+	base      *segmentRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewSegmentRepository(db *gorm.DB, telemetry telemetry.TelemetryService) SegmentRepository {
+	return &segmentRepository{
+		base:      newSegmentRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *segmentRepository) Create(ctx context.Context, segment *Segment) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.segment.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", segment.TenantID.String())
+
+	err := r.base.create(ctx, segment)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *segmentRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Segment, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.segment.find_by_id")
+	defer span.End()
+
+	span.SetTag("segment_id", id)
+
+	segment, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return segment, nil
+}
+
+func (r *segmentRepository) List(ctx context.Context, tenantID uuid.UUID) ([]Segment, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.segment.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	items, err := r.base.list(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
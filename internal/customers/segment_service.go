@@ -0,0 +1,61 @@
+package customers
+
+import (
+	"context"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+type segmentService struct {
+	segmentRepo  SegmentRepository
+	customerRepo CustomerRepository
+	telemetry    telemetry.TelemetryService
+}
+
+func NewSegmentService(segmentRepo SegmentRepository, customerRepo CustomerRepository, telemetry telemetry.TelemetryService) SegmentService {
+	return &segmentService{
+		segmentRepo:  segmentRepo,
+		customerRepo: customerRepo,
+		telemetry:    telemetry,
+	}
+}
+
+func (s *segmentService) CreateSegment(ctx context.Context, tenantID uuid.UUID, req CreateSegmentRequest) (*Segment, error) {
+	// Segmento sem critério algum seria a base inteira por acidente
+	if req.Name == "" || req.Criteria.IsEmpty() {
+		return nil, ErrInvalidSegment
+	}
+
+	segment := &Segment{TenantID: tenantID, Name: req.Name, Criteria: req.Criteria}
+	if err := s.segmentRepo.Create(ctx, segment); err != nil {
+		return nil, err
+	}
+	return segment, nil
+}
+
+func (s *segmentService) GetSegment(ctx context.Context, tenantID uuid.UUID, id string) (*Segment, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrSegmentNotFound
+	}
+
+	segment, err := s.segmentRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if segment == nil {
+		return nil, ErrSegmentNotFound
+	}
+	return segment, nil
+}
+
+func (s *segmentService) ListSegments(ctx context.Context, tenantID uuid.UUID) ([]Segment, error) {
+	return s.segmentRepo.List(ctx, tenantID)
+}
+
+func (s *segmentService) ListSegmentCustomers(ctx context.Context, tenantID uuid.UUID, id string, offset, limit int) ([]Customer, error) {
+	segment, err := s.GetSegment(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.customerRepo.FindBySegment(ctx, tenantID, segment.Criteria, offset, limit)
+}
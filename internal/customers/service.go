@@ -0,0 +1,128 @@
+package customers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrInvalidPhone     = errors.New("invalid phone number")
+)
+
+type customerService struct {
+	customerRepo CustomerRepository
+	telemetry    telemetry.TelemetryService
+}
+
+func NewCustomerService(customerRepo CustomerRepository, telemetry telemetry.TelemetryService) CustomerService {
+	return &customerService{
+		customerRepo: customerRepo,
+		telemetry:    telemetry,
+	}
+}
+
+func (s *customerService) FindOrCreateByPhone(ctx context.Context, tenantID uuid.UUID, phone, name string) (*Customer, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "customers.find_or_create_by_phone")
+	defer span.End()
+
+	phone = NormalizePhone(phone)
+	if phone == "" {
+		return nil, ErrInvalidPhone
+	}
+
+	customer, err := s.customerRepo.FindByPhone(ctx, tenantID, phone)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if customer != nil {
+		return customer, nil
+	}
+
+	customer = &Customer{TenantID: tenantID, Phone: phone, Name: name}
+	if err := s.customerRepo.Create(ctx, customer); err != nil {
+		// Outra requisição pode ter criado o mesmo telefone em paralelo
+		if existing, findErr := s.customerRepo.FindByPhone(ctx, tenantID, phone); findErr == nil && existing != nil {
+			return existing, nil
+		}
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "customers.auto_created",
+		Properties: map[string]interface{}{
+			"customer_id": customer.ID.String(),
+			"tenant_id":   tenantID.String(),
+		},
+		Timestamp: time.Now(),
+	})
+
+	return customer, nil
+}
+
+func (s *customerService) GetCustomer(ctx context.Context, tenantID uuid.UUID, id string) (*Customer, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	customer, err := s.customerRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, ErrCustomerNotFound
+	}
+	return customer, nil
+}
+
+func (s *customerService) SetWhatsAppOptOut(ctx context.Context, tenantID uuid.UUID, id string, optedOut bool) (*Customer, error) {
+	customer, err := s.GetCustomer(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return customer, s.setOptOut(ctx, customer, optedOut)
+}
+
+func (s *customerService) OptOutByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (*Customer, error) {
+	customer, err := s.customerRepo.FindByPhone(ctx, tenantID, NormalizePhone(phone))
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, ErrCustomerNotFound
+	}
+	return customer, s.setOptOut(ctx, customer, true)
+}
+
+func (s *customerService) setOptOut(ctx context.Context, customer *Customer, optedOut bool) error {
+	if customer.OptedOut() == optedOut {
+		return nil
+	}
+
+	if optedOut {
+		now := time.Now()
+		customer.WhatsAppOptOutAt = &now
+	} else {
+		customer.WhatsAppOptOutAt = nil
+	}
+	if err := s.customerRepo.Update(ctx, customer); err != nil {
+		return err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "customers.whatsapp_opt_out_changed",
+		Properties: map[string]interface{}{
+			"customer_id": customer.ID.String(),
+			"tenant_id":   customer.TenantID.String(),
+			"opted_out":   optedOut,
+		},
+		Timestamp: time.Now(),
+	})
+	return nil
+}
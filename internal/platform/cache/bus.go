@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/pubsub"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+)
+
+// Bus leva as mensagens de invalidação a todas as instâncias, inclusive a que publicou
+type Bus interface {
+	Publish(ctx context.Context, payload []byte) error
+	Subscribe(handler func(payload []byte))
+	Start(ctx context.Context)
+	Stop()
+}
+
+type localBus struct {
+	pubsub.Handlers
+}
+
+// NewLocalBus entrega no próprio processo; vários TieredCache no mesmo bus se
+// comportam como instâncias separadas (útil em testes)
+func NewLocalBus() Bus {
+	return &localBus{}
+}
+
+func (b *localBus) Publish(ctx context.Context, payload []byte) error {
+	b.Deliver(payload)
+	return nil
+}
+
+func (b *localBus) Start(ctx context.Context) {}
+
+func (b *localBus) Stop() {}
+
+// redisBus usa PUBLISH/SUBSCRIBE, com uma conexão dedicada para o SUBSCRIBE
+type redisBus struct {
+	pubsub.Handlers
+	*pubsub.Listener
+	client  *RedisClient
+	channel string
+}
+
+func NewRedisBus(client *RedisClient, channel string, telemetry telemetry.TelemetryService) Bus {
+	b := &redisBus{
+		client:  client,
+		channel: channel,
+	}
+	b.Listener = pubsub.NewListener("cache.bus.reconnect", b.listen, telemetry)
+	return b
+}
+
+func (b *redisBus) Publish(ctx context.Context, payload []byte) error {
+	_, err := b.client.Do(ctx, "PUBLISH", b.channel, string(payload))
+	return err
+}
+
+// listen mantém a conexão no SUBSCRIBE até ela cair; retorna se chegou a assinar
+func (b *redisBus) listen(ctx context.Context) (bool, error) {
+	conn, err := b.client.dial(ctx)
+	if err != nil {
+		return false, err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// a leitura bloqueia sem prazo; fechar a conexão é o que a interrompe
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	if _, err := conn.do(ctx, b.client.timeout, "SUBSCRIBE", b.channel); err != nil {
+		return false, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	for {
+		reply, err := conn.read()
+		if err != nil {
+			return true, err
+		}
+		message, ok := reply.([]interface{})
+		if !ok || len(message) != 3 {
+			return true, ErrRedisProtocol
+		}
+		kind, _ := message[0].([]byte)
+		payload, _ := message[2].([]byte)
+		if string(kind) == "message" {
+			b.Deliver(payload)
+		}
+	}
+}
@@ -2,9 +2,23 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"os"
+	"strconv"
 	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendTiered = "tiered"
 )
 
+// ErrCacheMiss indica chave ausente ou expirada; um valor nil gravado é um acerto
+var ErrCacheMiss = errors.New("cache miss")
+
 type CacheService interface {
 	Get(ctx context.Context, key string) (interface{}, error)
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
@@ -12,33 +26,101 @@ type CacheService interface {
 	Exists(ctx context.Context, key string) (bool, error)
 }
 
-type cacheService struct {
-	// Implementação específica (Redis, Memory, etc.)
-	client interface{}
+// Store é o cache da aplicação com o ciclo de vida do backend
+type Store interface {
+	CacheService
+	Tagger
+	Start(ctx context.Context)
+	Stop()
 }
 
-func NewCacheService(client interface{}) CacheService {
-	return &cacheService{
-		client: client,
+// NewStore cria o backend configurado; o Redis só conecta no primeiro comando
+func NewStore(config Config, telemetry telemetry.TelemetryService) (Store, error) {
+	if config.Backend != BackendRedis && config.Backend != BackendTiered {
+		return NewMemoryCache(config, telemetry), nil
 	}
-}
 
-func (c *cacheService) Get(ctx context.Context, key string) (interface{}, error) {
-	// Implementação real aqui
-	return nil, nil
-}
+	options, err := ParseRedisURL(config.RedisURL)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := NewCodec(config.Codec)
+	if err != nil {
+		return nil, err
+	}
+	client := NewRedisClient(options, config.RedisPoolSize, config.RedisTimeout)
+	shared := NewRedisCache(client, codec, telemetry)
+	if config.Backend == BackendRedis {
+		return shared, nil
+	}
 
-func (c *cacheService) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	// Implementação real aqui
-	return nil
+	bus := NewRedisBus(client, config.InvalidationChannel, telemetry)
+	return NewTieredCache(NewMemoryCache(config, telemetry), shared, bus, config.LocalTTL, telemetry), nil
 }
 
-func (c *cacheService) Delete(ctx context.Context, key string) error {
-	// Implementação real aqui
-	return nil
+type Config struct {
+	// Backend: "memory" (padrão, por instância), "redis" (compartilhado) ou
+	// "tiered" (memória na frente do Redis, invalidada entre instâncias)
+	Backend string
+
+	// MaxEntries limita o cache em memória; acima disso sai o menos usado recentemente
+	MaxEntries int
+	// JanitorInterval é de quanto em quanto tempo as chaves expiradas são varridas
+	JanitorInterval time.Duration
+
+	RedisURL      string
+	RedisPoolSize int
+	RedisTimeout  time.Duration
+	// Codec serializa os valores no Redis: "json" (padrão) ou "msgpack"
+	Codec string
+
+	// LocalTTL limita quanto tempo o L1 do tiered guarda uma chave sem ouvir o bus
+	LocalTTL time.Duration
+	// InvalidationChannel é o canal do PUBLISH compartilhado pelas instâncias
+	InvalidationChannel string
 }
 
-func (c *cacheService) Exists(ctx context.Context, key string) (bool, error) {
-	// Implementação real aqui
-	return false, nil
-} 
\ No newline at end of file
+func LoadConfig() Config {
+	maxEntries, err := strconv.Atoi(os.Getenv("CACHE_MAX_ENTRIES"))
+	if err != nil || maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	interval, err := time.ParseDuration(os.Getenv("CACHE_JANITOR_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = time.Minute
+	}
+	redisURL := os.Getenv("REDIS_URL")
+	if redisURL == "" {
+		redisURL = "redis://localhost:6379/0"
+	}
+	poolSize, err := strconv.Atoi(os.Getenv("REDIS_POOL_SIZE"))
+	if err != nil || poolSize <= 0 {
+		poolSize = 10
+	}
+	timeout, err := time.ParseDuration(os.Getenv("REDIS_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+
+	localTTL, err := time.ParseDuration(os.Getenv("CACHE_LOCAL_TTL"))
+	if err != nil || localTTL <= 0 {
+		localTTL = 30 * time.Second
+	}
+	channel := os.Getenv("CACHE_INVALIDATION_CHANNEL")
+	if channel == "" {
+		channel = "cache_invalidation"
+	}
+
+	return Config{
+		Backend:         os.Getenv("CACHE_BACKEND"),
+		MaxEntries:      maxEntries,
+		JanitorInterval: interval,
+		RedisURL:        redisURL,
+		RedisPoolSize:   poolSize,
+		RedisTimeout:    timeout,
+		Codec:           os.Getenv("CACHE_CODEC"),
+
+		LocalTTL:            localTTL,
+		InvalidationChannel: channel,
+	}
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	CodecJSON    = "json"
+	CodecMsgpack = "msgpack"
+)
+
+// Codec serializa os valores guardados fora do processo
+type Codec interface {
+	Name() string
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(data []byte, value interface{}) error
+}
+
+// NewCodec devolve o codec pelo nome; vazio assume JSON
+func NewCodec(name string) (Codec, error) {
+	switch name {
+	case "", CodecJSON:
+		return NewJSONCodec(), nil
+	case CodecMsgpack:
+		return NewMsgpackCodec(), nil
+	}
+	return nil, fmt.Errorf("cache: unknown codec %q", name)
+}
+
+// tagKeys: a tag cache tem precedência sobre json, para guardar campos que a API
+// esconde (ex.: `json:"-" cache:"password_hash"`)
+var tagKeys = []string{"cache", "codec", "json"}
+
+type JSONCodec struct {
+	handle *codec.JsonHandle
+}
+
+func NewJSONCodec() JSONCodec {
+	handle := &codec.JsonHandle{}
+	handle.TypeInfos = codec.NewTypeInfos(tagKeys)
+	return JSONCodec{handle: handle}
+}
+
+func (JSONCodec) Name() string { return CodecJSON }
+
+func (c JSONCodec) Marshal(value interface{}) ([]byte, error) {
+	return encode(c.handle, value)
+}
+
+func (c JSONCodec) Unmarshal(data []byte, value interface{}) error {
+	return codec.NewDecoderBytes(data, c.handle).Decode(value)
+}
+
+// MsgpackCodec é mais compacto que JSON
+type MsgpackCodec struct {
+	handle *codec.MsgpackHandle
+}
+
+func NewMsgpackCodec() MsgpackCodec {
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	handle.TypeInfos = codec.NewTypeInfos(tagKeys)
+	return MsgpackCodec{handle: handle}
+}
+
+func (MsgpackCodec) Name() string { return CodecMsgpack }
+
+func (c MsgpackCodec) Marshal(value interface{}) ([]byte, error) {
+	return encode(c.handle, value)
+}
+
+func (c MsgpackCodec) Unmarshal(data []byte, value interface{}) error {
+	return codec.NewDecoderBytes(data, c.handle).Decode(value)
+}
+
+func encode(handle codec.Handle, value interface{}) ([]byte, error) {
+	var data []byte
+	err := codec.NewEncoderBytes(&data, handle).Encode(value)
+	return data, err
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"strings"
+)
+
+// Tagger é implementado pelos backends que agrupam chaves por tag, para
+// invalidar de uma vez tudo o que depende de um registro ou de um tenant
+type Tagger interface {
+	// Tag associa uma chave já gravada às tags
+	Tag(ctx context.Context, key string, tags ...string) error
+	// InvalidateTags apaga todas as chaves associadas a qualquer uma das tags
+	InvalidateTags(ctx context.Context, tags ...string) error
+	// TagMembers lista as chaves associadas às tags
+	TagMembers(ctx context.Context, tags ...string) ([]string, error)
+}
+
+// KeyBuilder monta as chaves de uma entidade no formato {namespace}:{entidade}:{campo}:{valor}.
+// O namespace é "t:<tenant>" para dados de um tenant e "g" para buscas que ainda
+// não conhecem o tenant (login por e-mail, webhook pelo número do WhatsApp)
+type KeyBuilder struct {
+	entity string
+}
+
+func NewKeyBuilder(entity string) KeyBuilder {
+	return KeyBuilder{entity: entity}
+}
+
+func (b KeyBuilder) Tenant(tenantID, field, value string) string {
+	return join("t", tenantID, b.entity, field, value)
+}
+
+func (b KeyBuilder) Global(field, value string) string {
+	return join("g", b.entity, field, value)
+}
+
+// RecordTag agrupa todas as chaves de um registro, qualquer que seja o campo da busca
+func (b KeyBuilder) RecordTag(id string) string {
+	return join(b.entity, id)
+}
+
+// TenantTag agrupa tudo o que foi gravado em nome de um tenant
+func TenantTag(tenantID string) string {
+	return join("t", tenantID)
+}
+
+func join(parts ...string) string {
+	return strings.Join(parts, ":")
+}
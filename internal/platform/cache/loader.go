@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"golang.org/x/sync/singleflight"
+)
+
+type LoaderOptions struct {
+	TTL time.Duration
+	// NegativeTTL > 0 grava a ausência do registro; zero volta ao banco a cada consulta
+	NegativeTTL time.Duration
+	// Jitter espalha as expirações em ±fração do TTL, para chaves gravadas juntas
+	// não vencerem juntas
+	Jitter float64
+}
+
+// Loader é o cache-aside dos repositórios: lê do cache, e no miss uma única
+// chamada por chave vai ao banco enquanto as concorrentes esperam o mesmo resultado
+type Loader[T any] struct {
+	cache     *Typed[T]
+	tagger    Tagger
+	tags      func(value T) []string
+	options   LoaderOptions
+	group     singleflight.Group
+	telemetry telemetry.TelemetryService
+}
+
+func NewLoader[T any](cache CacheService, options LoaderOptions, telemetry telemetry.TelemetryService) *Loader[T] {
+	tagger, _ := cache.(Tagger)
+	return &Loader[T]{
+		cache:     NewTyped[T](cache),
+		tagger:    tagger,
+		options:   options,
+		telemetry: telemetry,
+	}
+}
+
+// WithTags define as tags de cada valor gravado (ex.: o registro e o seu tenant);
+// entradas negativas recebem tags(zero). Sem Tagger no backend, as tags são ignoradas
+func (l *Loader[T]) WithTags(tags func(value T) []string) *Loader[T] {
+	l.tags = tags
+	return l
+}
+
+// Load devolve o valor e se ele veio do cache. load sinaliza "não encontrado"
+// devolvendo o zero de T (ex.: ponteiro nil), que vira uma entrada negativa
+func (l *Loader[T]) Load(ctx context.Context, key string, load func() (T, error)) (T, bool, error) {
+	value, err := l.cache.Get(ctx, key)
+	if err == nil || errors.Is(err, ErrNotFound) {
+		return value, true, nil
+	}
+
+	result, err, shared := l.group.Do(key, func() (interface{}, error) {
+		value, err := load()
+		if err != nil {
+			return value, err
+		}
+
+		// falha ao gravar só custa uma ida a mais ao banco
+		var stored error
+		if !isZero(value) {
+			stored = l.cache.Set(ctx, key, value, Jitter(l.options.TTL, l.options.Jitter))
+		} else if l.options.NegativeTTL > 0 {
+			stored = l.cache.SetNegative(ctx, key, Jitter(l.options.NegativeTTL, l.options.Jitter))
+		} else {
+			return value, nil
+		}
+		if stored == nil && l.tagger != nil && l.tags != nil {
+			if tags := l.tags(value); len(tags) > 0 {
+				l.tagger.Tag(ctx, key, tags...)
+			}
+		}
+		return value, nil
+	})
+	if shared {
+		l.telemetry.TrackMetric(ctx, telemetry.Metric{Name: "cache.load.coalesced", Value: 1})
+	}
+
+	value, _ = result.(T)
+	return value, false, err
+}
+
+// Delete invalida a chave; a próxima leitura volta ao banco
+func (l *Loader[T]) Delete(ctx context.Context, key string) error {
+	return l.cache.Delete(ctx, key)
+}
+
+// InvalidateTags apaga as chaves das tags; sem Tagger no backend não faz nada
+func (l *Loader[T]) InvalidateTags(ctx context.Context, tags ...string) error {
+	if l.tagger == nil {
+		return nil
+	}
+	return l.tagger.InvalidateTags(ctx, tags...)
+}
+
+// Jitter devolve ttl ± fraction*ttl; fraction <= 0 devolve o próprio ttl
+func Jitter(ttl time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || ttl <= 0 {
+		return ttl
+	}
+	jittered := ttl + time.Duration((rand.Float64()*2-1)*fraction*float64(ttl))
+	if jittered <= 0 {
+		return ttl
+	}
+	return jittered
+}
+
+func isZero[T any](value T) bool {
+	return reflect.ValueOf(&value).Elem().IsZero()
+}
@@ -0,0 +1,281 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+)
+
+// Motivos de remoção reportados na métrica cache.eviction
+const (
+	evictionCapacity = "capacity"
+	evictionExpired  = "expired"
+)
+
+type entry struct {
+	key   string
+	value interface{}
+	// expiresAt zero nunca expira
+	expiresAt time.Time
+	tags      []string
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// Stats são os contadores acumulados desde a criação do cache
+type Stats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Size      int
+}
+
+// MemoryCache é um LRU com TTL por chave, seguro para uso concorrente. Os valores
+// são guardados por referência: quem lê não deve alterar o objeto devolvido
+type MemoryCache struct {
+	maxEntries int
+	interval   time.Duration
+	telemetry  telemetry.TelemetryService
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List // mais recente na frente
+	tags  map[string]map[string]struct{}
+	stats Stats
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewMemoryCache(config Config, telemetry telemetry.TelemetryService) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: config.MaxEntries,
+		interval:   config.JanitorInterval,
+		telemetry:  telemetry,
+		items:      map[string]*list.Element{},
+		order:      list.New(),
+		tags:       map[string]map[string]struct{}{},
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (interface{}, error) {
+	c.mu.Lock()
+	element, ok := c.items[key]
+	expired := ok && element.Value.(*entry).expired(time.Now())
+	if !ok || expired {
+		if expired {
+			c.remove(element)
+			c.stats.Evictions++
+		}
+		c.stats.Misses++
+		c.mu.Unlock()
+
+		if expired {
+			c.trackEviction(ctx, evictionExpired, 1)
+		}
+		c.track(ctx, "cache.miss")
+		return nil, ErrCacheMiss
+	}
+
+	c.order.MoveToFront(element)
+	c.stats.Hits++
+	value := element.Value.(*entry).value
+	c.mu.Unlock()
+
+	c.track(ctx, "cache.hit")
+	return value, nil
+}
+
+// Set grava o valor; ttl <= 0 mantém a chave até ser removida ou sair pelo LRU
+func (c *MemoryCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	if element, ok := c.items[key]; ok {
+		e := element.Value.(*entry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.items[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	evicted := 0
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+		evicted++
+	}
+	c.stats.Evictions += int64(evicted)
+	c.mu.Unlock()
+
+	if evicted > 0 {
+		c.trackEviction(ctx, evictionCapacity, evicted)
+	}
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+	return nil
+}
+
+// Exists não altera a ordem do LRU nem conta como acerto
+func (c *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	return ok && !element.Value.(*entry).expired(time.Now()), nil
+}
+
+// Tag ignora chaves ausentes: não há o que invalidar depois
+func (c *MemoryCache) Tag(ctx context.Context, key string, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	e := element.Value.(*entry)
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = map[string]struct{}{}
+			c.tags[tag] = keys
+		}
+		if _, tagged := keys[key]; !tagged {
+			keys[key] = struct{}{}
+			e.tags = append(e.tags, tag)
+		}
+	}
+	return nil
+}
+
+func (c *MemoryCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if element, ok := c.items[key]; ok {
+				c.remove(element)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *MemoryCache) TagMembers(ctx context.Context, tags ...string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (c *MemoryCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+// DeleteExpired remove as chaves vencidas e retorna quantas saíram
+func (c *MemoryCache) DeleteExpired(now time.Time) int {
+	c.mu.Lock()
+	removed := 0
+	for _, element := range c.items {
+		if element.Value.(*entry).expired(now) {
+			c.remove(element)
+			removed++
+		}
+	}
+	c.stats.Evictions += int64(removed)
+	size := c.order.Len()
+	c.mu.Unlock()
+
+	ctx := context.Background()
+	if removed > 0 {
+		c.trackEviction(ctx, evictionExpired, removed)
+	}
+	c.telemetry.TrackMetric(ctx, telemetry.Metric{Name: "cache.size", Value: float64(size), Kind: telemetry.MetricGauge})
+	return removed
+}
+
+// Start inicia a varredura periódica das chaves expiradas; sem ela, uma chave
+// vencida só sai quando é lida ou pelo LRU
+func (c *MemoryCache) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				c.DeleteExpired(now)
+			}
+		}
+	}()
+}
+
+func (c *MemoryCache) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+}
+
+// remove exige c.mu travado
+func (c *MemoryCache) remove(element *list.Element) {
+	e := element.Value.(*entry)
+	c.order.Remove(element)
+	delete(c.items, e.key)
+
+	for _, tag := range e.tags {
+		delete(c.tags[tag], e.key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
+
+func (c *MemoryCache) track(ctx context.Context, name string) {
+	c.telemetry.TrackMetric(ctx, telemetry.Metric{Name: name, Value: 1, Tags: map[string]string{"backend": "memory"}})
+}
+
+func (c *MemoryCache) trackEviction(ctx context.Context, reason string, count int) {
+	c.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "cache.eviction",
+		Value: float64(count),
+		Tags:  map[string]string{"backend": "memory", "reason": reason},
+	})
+}
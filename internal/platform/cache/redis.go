@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+)
+
+// RedisCache guarda os valores serializados pelo codec; Get devolve os bytes
+// crus, e Typed decodifica no tipo concreto
+type RedisCache struct {
+	client    *RedisClient
+	codec     Codec
+	telemetry telemetry.TelemetryService
+}
+
+func NewRedisCache(client *RedisClient, codec Codec, telemetry telemetry.TelemetryService) *RedisCache {
+	return &RedisCache{
+		client:    client,
+		codec:     codec,
+		telemetry: telemetry,
+	}
+}
+
+func (c *RedisCache) Codec() Codec {
+	return c.codec
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) (interface{}, error) {
+	reply, err := c.client.Do(ctx, "GET", key)
+	if err != nil {
+		c.trackError(ctx, "get")
+		return nil, err
+	}
+	if reply == nil {
+		c.track(ctx, "cache.miss")
+		return nil, ErrCacheMiss
+	}
+
+	c.track(ctx, "cache.hit")
+	return reply, nil
+}
+
+// Set aceita []byte já serializado ou qualquer valor que o codec saiba codificar
+func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, ok := value.([]byte)
+	if !ok {
+		var err error
+		if data, err = c.codec.Marshal(value); err != nil {
+			return err
+		}
+	}
+
+	args := []string{"SET", key, string(data)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	if _, err := c.client.Do(ctx, args...); err != nil {
+		c.trackError(ctx, "set")
+		return err
+	}
+	return nil
+}
+
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	if _, err := c.client.Do(ctx, "DEL", key); err != nil {
+		c.trackError(ctx, "delete")
+		return err
+	}
+	return nil
+}
+
+func (c *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
+	reply, err := c.client.Do(ctx, "EXISTS", key)
+	if err != nil {
+		c.trackError(ctx, "exists")
+		return false, err
+	}
+	count, _ := reply.(int64)
+	return count > 0, nil
+}
+
+// Tag guarda as chaves de cada tag num set tag:{tag}; o set não expira, as chaves
+// vencidas só saem dele quando a tag é invalidada
+func (c *RedisCache) Tag(ctx context.Context, key string, tags ...string) error {
+	for _, tag := range tags {
+		if _, err := c.client.Do(ctx, "SADD", tagKey(tag), key); err != nil {
+			c.trackError(ctx, "tag")
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RedisCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		keys, err := c.TagMembers(ctx, tag)
+		if err != nil {
+			return err
+		}
+		if _, err := c.client.Do(ctx, append([]string{"DEL", tagKey(tag)}, keys...)...); err != nil {
+			c.trackError(ctx, "invalidate")
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RedisCache) TagMembers(ctx context.Context, tags ...string) ([]string, error) {
+	var keys []string
+	for _, tag := range tags {
+		reply, err := c.client.Do(ctx, "SMEMBERS", tagKey(tag))
+		if err != nil {
+			c.trackError(ctx, "tag_members")
+			return nil, err
+		}
+		members, _ := reply.([]interface{})
+		for _, member := range members {
+			if key, ok := member.([]byte); ok {
+				keys = append(keys, string(key))
+			}
+		}
+	}
+	return keys, nil
+}
+
+// Start existe para RedisCache e MemoryCache serem intercambiáveis; o Redis expira sozinho
+func (c *RedisCache) Start(ctx context.Context) {}
+
+func (c *RedisCache) Stop() {
+	c.client.Close()
+}
+
+func (c *RedisCache) track(ctx context.Context, name string) {
+	c.telemetry.TrackMetric(ctx, telemetry.Metric{Name: name, Value: 1, Tags: map[string]string{"backend": "redis"}})
+}
+
+func (c *RedisCache) trackError(ctx context.Context, op string) {
+	c.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "cache.error",
+		Value: 1,
+		Tags:  map[string]string{"backend": "redis", "op": op},
+	})
+}
+
+func tagKey(tag string) string {
+	return "tag:" + tag
+}
@@ -0,0 +1,219 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RedisError é a resposta de erro do servidor (-ERR ...); a conexão continua válida
+type RedisError string
+
+func (e RedisError) Error() string { return string(e) }
+
+var ErrRedisProtocol = errors.New("redis: protocol error")
+
+// RedisOptions vem de uma URL redis://[:senha@]host:porta[/db]
+type RedisOptions struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+func ParseRedisURL(raw string) (RedisOptions, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return RedisOptions{}, fmt.Errorf("redis: invalid url: %w", err)
+	}
+	if u.Scheme != "redis" {
+		return RedisOptions{}, fmt.Errorf("redis: unsupported scheme %q", u.Scheme)
+	}
+
+	options := RedisOptions{Addr: u.Host}
+	if u.Port() == "" {
+		options.Addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		options.Password, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if options.DB, err = strconv.Atoi(db); err != nil {
+			return RedisOptions{}, fmt.Errorf("redis: invalid db %q", db)
+		}
+	}
+	return options, nil
+}
+
+// RedisClient fala RESP2 com um pool simples de conexões. Cobre só os comandos
+// usados pelo cache; respostas chegam como string, int64, []byte, []interface{} ou nil
+type RedisClient struct {
+	options RedisOptions
+	timeout time.Duration
+	pool    chan *redisConn
+}
+
+func NewRedisClient(options RedisOptions, poolSize int, timeout time.Duration) *RedisClient {
+	return &RedisClient{
+		options: options,
+		timeout: timeout,
+		pool:    make(chan *redisConn, poolSize),
+	}
+}
+
+func (c *RedisClient) Do(ctx context.Context, args ...string) (interface{}, error) {
+	conn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, c.timeout, args...)
+	var redisErr RedisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// erro de rede ou de protocolo deixa a conexão em estado desconhecido
+		conn.Close()
+		return nil, err
+	}
+	c.put(conn)
+	return reply, err
+}
+
+// Close fecha as conexões ociosas; as que estão em uso fecham ao voltar
+func (c *RedisClient) Close() {
+	for {
+		select {
+		case conn := <-c.pool:
+			conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+func (c *RedisClient) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-c.pool:
+		return conn, nil
+	default:
+		return c.dial(ctx)
+	}
+}
+
+func (c *RedisClient) put(conn *redisConn) {
+	select {
+	case c.pool <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+// dial abre uma conexão fora do pool, já autenticada e no db configurado
+func (c *RedisClient) dial(ctx context.Context) (*redisConn, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.options.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &redisConn{Conn: netConn, reader: bufio.NewReader(netConn)}
+	if c.options.Password != "" {
+		if _, err := conn.do(ctx, c.timeout, "AUTH", c.options.Password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if c.options.DB != 0 {
+		if _, err := conn.do(ctx, c.timeout, "SELECT", strconv.Itoa(c.options.DB)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+type redisConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *redisConn) do(ctx context.Context, timeout time.Duration, args ...string) (interface{}, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.SetDeadline(deadline)
+
+	if err := c.write(args...); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+func (c *redisConn) write(args ...string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := io.WriteString(c.Conn, b.String())
+	return err
+}
+
+func (c *redisConn) read() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, ErrRedisProtocol
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, RedisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, ErrRedisProtocol
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	case '*':
+		size, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, ErrRedisProtocol
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, size)
+		for i := range items {
+			if items[i], err = c.read(); err != nil {
+				var redisErr RedisError
+				if !errors.As(err, &redisErr) {
+					return nil, err
+				}
+				items[i] = redisErr
+			}
+		}
+		return items, nil
+	}
+	return nil, ErrRedisProtocol
+}
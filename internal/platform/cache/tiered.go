@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+// invalidation é a mensagem publicada no bus a cada escrita
+type invalidation struct {
+	// Origin identifica a instância que publicou; ela já aplicou a mudança no próprio L1
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// TieredCache põe um L1 em memória na frente de um L2 compartilhado. Toda escrita
+// vai ao L2 e é anunciada no bus para as outras instâncias tirarem a chave do L1.
+// Uma mensagem perdida (bus fora do ar, corrida entre leitura e invalidação) deixa
+// o L1 desatualizado por no máximo localTTL
+type TieredCache struct {
+	id        string
+	local     *MemoryCache
+	shared    Store
+	bus       Bus
+	localTTL  time.Duration
+	telemetry telemetry.TelemetryService
+}
+
+func NewTieredCache(local *MemoryCache, shared Store, bus Bus, localTTL time.Duration, telemetry telemetry.TelemetryService) *TieredCache {
+	c := &TieredCache{
+		id:        uuid.New().String(),
+		local:     local,
+		shared:    shared,
+		bus:       bus,
+		localTTL:  localTTL,
+		telemetry: telemetry,
+	}
+	bus.Subscribe(c.receive)
+	return c
+}
+
+// Codec é o do L2: o L1 guarda o que o L2 devolve, e Typed decodifica os dois igual
+func (c *TieredCache) Codec() Codec {
+	if provider, ok := c.shared.(codecProvider); ok {
+		return provider.Codec()
+	}
+	return NewJSONCodec()
+}
+
+func (c *TieredCache) Get(ctx context.Context, key string) (interface{}, error) {
+	if value, err := c.local.Get(ctx, key); err == nil {
+		return value, nil
+	}
+
+	value, err := c.shared.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	c.local.Set(ctx, key, value, c.localTTL)
+	return value, nil
+}
+
+func (c *TieredCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := c.shared.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	c.local.Set(ctx, key, value, c.localDuration(ttl))
+	c.publish(ctx, invalidation{Keys: []string{key}})
+	return nil
+}
+
+func (c *TieredCache) Delete(ctx context.Context, key string) error {
+	if err := c.shared.Delete(ctx, key); err != nil {
+		return err
+	}
+	c.local.Delete(ctx, key)
+	c.publish(ctx, invalidation{Keys: []string{key}})
+	return nil
+}
+
+func (c *TieredCache) Exists(ctx context.Context, key string) (bool, error) {
+	if exists, _ := c.local.Exists(ctx, key); exists {
+		return true, nil
+	}
+	return c.shared.Exists(ctx, key)
+}
+
+func (c *TieredCache) Tag(ctx context.Context, key string, tags ...string) error {
+	if err := c.shared.Tag(ctx, key, tags...); err != nil {
+		return err
+	}
+	return c.local.Tag(ctx, key, tags...)
+}
+
+// InvalidateTags anuncia também as chaves das tags: nas outras instâncias o L1 foi
+// preenchido a partir do L2 e não conhece as tags das entradas
+func (c *TieredCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	keys, err := c.shared.TagMembers(ctx, tags...)
+	if err != nil {
+		return err
+	}
+	if err := c.shared.InvalidateTags(ctx, tags...); err != nil {
+		return err
+	}
+	c.local.InvalidateTags(ctx, tags...)
+	for _, key := range keys {
+		c.local.Delete(ctx, key)
+	}
+	c.publish(ctx, invalidation{Keys: keys, Tags: tags})
+	return nil
+}
+
+func (c *TieredCache) TagMembers(ctx context.Context, tags ...string) ([]string, error) {
+	return c.shared.TagMembers(ctx, tags...)
+}
+
+func (c *TieredCache) Start(ctx context.Context) {
+	c.local.Start(ctx)
+	c.bus.Start(ctx)
+}
+
+func (c *TieredCache) Stop() {
+	c.bus.Stop()
+	c.local.Stop()
+	c.shared.Stop()
+}
+
+// publish não falha a escrita: o L2 já está certo, e sem a mensagem as outras
+// instâncias só demoram até localTTL para ver a mudança
+func (c *TieredCache) publish(ctx context.Context, message invalidation) {
+	message.Origin = c.id
+	payload, _ := json.Marshal(message)
+	if err := c.bus.Publish(ctx, payload); err != nil {
+		c.telemetry.TrackMetric(ctx, telemetry.Metric{Name: "cache.invalidation.error", Value: 1})
+	}
+}
+
+func (c *TieredCache) receive(payload []byte) {
+	var message invalidation
+	if err := json.Unmarshal(payload, &message); err != nil || message.Origin == c.id {
+		return
+	}
+
+	ctx := context.Background()
+	for _, key := range message.Keys {
+		c.local.Delete(ctx, key)
+	}
+	c.local.InvalidateTags(ctx, message.Tags...)
+	c.telemetry.TrackMetric(ctx, telemetry.Metric{Name: "cache.invalidation.received", Value: 1})
+}
+
+// localDuration limita o L1 a localTTL, mesmo quando o L2 guarda por mais tempo
+func (c *TieredCache) localDuration(ttl time.Duration) time.Duration {
+	if ttl > 0 && ttl < c.localTTL {
+		return ttl
+	}
+	return c.localTTL
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound é o acerto de uma entrada negativa: o cache sabe que o registro não existe
+var ErrNotFound = errors.New("cache: negative entry")
+
+// negativeEntry é o sentinela gravado por SetNegative, igual em todos os backends
+var negativeEntry = []byte("\x00cache:not-found")
+
+// codecProvider é implementado pelos backends que guardam valores serializados
+type codecProvider interface {
+	Codec() Codec
+}
+
+// Typed devolve valores já no tipo concreto, qualquer que seja o backend: em
+// memória o valor volta como foi gravado; no Redis os bytes são decodificados
+type Typed[T any] struct {
+	cache CacheService
+	codec Codec
+}
+
+func NewTyped[T any](cache CacheService) *Typed[T] {
+	var codec Codec = NewJSONCodec()
+	if provider, ok := cache.(codecProvider); ok {
+		codec = provider.Codec()
+	}
+	return &Typed[T]{cache: cache, codec: codec}
+}
+
+// Get devolve ErrCacheMiss quando a chave não existe e ErrNotFound numa entrada
+// negativa; um nil gravado com Set volta como o zero de T
+func (t *Typed[T]) Get(ctx context.Context, key string) (T, error) {
+	var value T
+	raw, err := t.cache.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	if data, ok := raw.([]byte); ok && bytes.Equal(data, negativeEntry) {
+		return value, ErrNotFound
+	}
+
+	switch v := raw.(type) {
+	case nil:
+		return value, nil
+	case T:
+		return v, nil
+	case []byte:
+		if err := t.codec.Unmarshal(v, &value); err != nil {
+			return value, fmt.Errorf("cache: decode %s: %w", key, err)
+		}
+		return value, nil
+	}
+	return value, fmt.Errorf("cache: %s holds %T, want %T", key, raw, value)
+}
+
+func (t *Typed[T]) Set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	return t.cache.Set(ctx, key, value, ttl)
+}
+
+// SetNegative grava que o registro não existe, para não consultar a origem a cada miss
+func (t *Typed[T]) SetNegative(ctx context.Context, key string, ttl time.Duration) error {
+	return t.cache.Set(ctx, key, negativeEntry, ttl)
+}
+
+func (t *Typed[T]) Delete(ctx context.Context, key string) error {
+	return t.cache.Delete(ctx, key)
+}
+
+func (t *Typed[T]) Exists(ctx context.Context, key string) (bool, error) {
+	return t.cache.Exists(ctx, key)
+}
@@ -2,30 +2,41 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/logging"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
-// Connect initializes the database connection.
-func Connect() {
-	var err error
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
+// DSN builds the Postgres connection string from the environment.
+func DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
 		os.Getenv("POSTGRES_HOST"),
 		os.Getenv("POSTGRES_USER"),
 		os.Getenv("POSTGRES_PASSWORD"),
 		os.Getenv("POSTGRES_DB"),
 		os.Getenv("POSTGRES_PORT"),
 	)
+}
 
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// Connect initializes the database connection.
+func Connect() {
+	var err error
+	DB, err = gorm.Open(postgres.Open(DSN()), &gorm.Config{})
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		logging.Fatal("failed to connect to database", err)
 	}
 
-	fmt.Println("Database connection successful.")
+	slog.Info("database connection successful")
+}
+
+// Migrate creates or updates the tables for the given models.
+func Migrate(models ...interface{}) {
+	if err := DB.AutoMigrate(models...); err != nil {
+		logging.Fatal("failed to migrate database", err)
+	}
 }
@@ -0,0 +1,117 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Redacted substitui o valor dos campos sensíveis
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys são comparadas por substring, sem diferenciar maiúsculas:
+// "token" pega access_token e refresh_token, "password" pega password_hash
+var sensitiveKeys = []string{"password", "secret", "token", "authorization", "cookie", "api_key", "apikey", "email"}
+
+type Config struct {
+	Level slog.Level
+}
+
+func LoadConfig() Config {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(os.Getenv("LOG_LEVEL"))); err != nil {
+		level = slog.LevelInfo
+	}
+	return Config{Level: level}
+}
+
+// New devolve um logger JSON que mascara os campos sensíveis
+func New(w io.Writer, config Config) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       config.Level,
+		ReplaceAttr: redact,
+	}))
+}
+
+// Setup instala o logger como padrão do slog; o pacote log passa a escrever
+// por ele também
+func Setup(config Config) *slog.Logger {
+	logger := New(os.Stdout, config)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// Fatal registra o erro e encerra o processo, no lugar de log.Fatal
+func Fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+type loggerKey struct{}
+
+// WithLogger guarda em ctx o logger da requisição
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext devolve o logger da requisição, ou o padrão fora de uma
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With acrescenta atributos ao logger de ctx (ex.: tenant e usuário depois da autenticação)
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
+
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	if sensitive(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+	// mapas passados com slog.Any não passam por ReplaceAttr campo a campo
+	switch value := attr.Value.Any().(type) {
+	case map[string]any:
+		return slog.Any(attr.Key, redactMap(value))
+	case map[string]string:
+		redacted := make(map[string]string, len(value))
+		for key, v := range value {
+			if sensitive(key) {
+				v = Redacted
+			}
+			redacted[key] = v
+		}
+		return slog.Any(attr.Key, redacted)
+	}
+	return attr
+}
+
+func redactMap(values map[string]any) map[string]any {
+	redacted := make(map[string]any, len(values))
+	for key, value := range values {
+		switch {
+		case sensitive(key):
+			value = Redacted
+		default:
+			if nested, ok := value.(map[string]any); ok {
+				value = redactMap(nested)
+			}
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+func sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/logging"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/gin-gonic/gin"
+)
+
+// LoggingMiddleware cria o logger da requisição e registra o access log no lugar
+// do gin.Logger. Vem depois do TelemetryMiddleware, que define o request ID e o trace
+func LoggingMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		ctx := c.Request.Context()
+
+		requestLogger := logger.With(
+			"request_id", telemetry.RequestID(ctx),
+			"trace_id", telemetry.TraceID(ctx),
+		)
+		c.Request = c.Request.WithContext(logging.WithLogger(ctx, requestLogger))
+
+		c.Next()
+
+		// o IdentityMiddleware acrescenta tenant e usuário ao logger durante o Next
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		attrs := []any{
+			"method", c.Request.Method,
+			"route", route,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "error", c.Errors.String())
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case c.Writer.Status() >= 500:
+			level = slog.LevelError
+		case c.Writer.Status() >= 400:
+			level = slog.LevelWarn
+		}
+		logging.FromContext(c.Request.Context()).Log(c.Request.Context(), level, "http.request", attrs...)
+	}
+}
@@ -6,20 +6,42 @@ import (
 
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
+// Chaves do gin.Context com a correlação da requisição
+const (
+	RequestIDKey = "request_id"
+	TraceIDKey   = "trace_id"
+)
+
+// maxRequestIDLength evita que um header enorme vá parar em todo log e span
+const maxRequestIDLength = 128
+
 func TelemetryMiddleware(telemetryService telemetry.TelemetryService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		
-		// Criar span para a requisição
-		span, ctx := telemetryService.StartSpan(c.Request.Context(), "http.request")
+
+		// Request ID do cliente (ou do proxy) quando válido; senão um novo
+		requestID := c.GetHeader(telemetry.RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Header(telemetry.RequestIDHeader, requestID)
+
+		// Criar span para a requisição, filho do traceparent recebido se houver
+		ctx := telemetry.Extract(c.Request.Context(), c.Request.Header)
+		ctx = telemetry.WithRequestID(ctx, requestID)
+		span, ctx := telemetryService.StartSpan(ctx, "http.request")
 		defer span.End()
 		
-		// Adicionar contexto ao gin
+		// Adicionar contexto ao gin; as chaves também ficam no gin.Context
 		c.Request = c.Request.WithContext(ctx)
+		c.Set(RequestIDKey, requestID)
+		c.Set(TraceIDKey, telemetry.TraceID(ctx))
 		
 		// Adicionar tags básicas
+		span.SetTag("http.request_id", requestID)
 		span.SetTag("http.method", c.Request.Method)
 		span.SetTag("http.url", c.Request.URL.Path)
 		span.SetTag("http.user_agent", c.Request.UserAgent())
@@ -31,16 +53,22 @@ func TelemetryMiddleware(telemetryService telemetry.TelemetryService) gin.Handle
 		span.SetTag("http.status_code", strconv.Itoa(c.Writer.Status()))
 		span.SetTag("http.response_size", strconv.Itoa(c.Writer.Size()))
 		
-		// Track metric de duração
+		// Track metric de duração; a rota (/api/tasks/:id) e não o path, para os IDs
+		// não virarem uma série por requisição
 		duration := time.Since(start).Milliseconds()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
 		telemetryService.TrackMetric(ctx, telemetry.Metric{
 			Name:  "http.request.duration",
 			Value: float64(duration),
 			Tags: map[string]string{
 				"method": c.Request.Method,
-				"path":   c.Request.URL.Path,
+				"path":   route,
 				"status": strconv.Itoa(c.Writer.Status()),
 			},
+			Kind: telemetry.MetricHistogram,
 		})
 		
 		// Track event de requisição
@@ -60,4 +88,17 @@ func TelemetryMiddleware(telemetryService telemetry.TelemetryService) gin.Handle
 			span.SetError(c.Errors.Last().Err)
 		}
 	}
-} 
\ No newline at end of file
+}
+
+// validRequestID aceita só ASCII visível, para o valor ecoado não quebrar headers e logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Handlers guarda os assinantes de um canal de pub/sub
+type Handlers struct {
+	mu   sync.RWMutex
+	list []func(payload []byte)
+}
+
+func (h *Handlers) Subscribe(handler func(payload []byte)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.list = append(h.list, handler)
+}
+
+// Deliver entrega o payload a todos os assinantes, na ordem de registro
+func (h *Handlers) Deliver(payload []byte) {
+	h.mu.RLock()
+	list := h.list
+	h.mu.RUnlock()
+
+	for _, handler := range list {
+		handler(payload)
+	}
+}
+
+// ListenFunc mantém uma conexão assinando o canal até ela cair; retorna se chegou a assinar
+type ListenFunc func(ctx context.Context) (bool, error)
+
+// Listener roda uma ListenFunc em background e reconecta com backoff exponencial,
+// que volta ao mínimo sempre que a assinatura chega a ser feita
+type Listener struct {
+	listen    ListenFunc
+	spanName  string
+	telemetry telemetry.TelemetryService
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewListener registra cada reconexão como um span spanName com o erro que a causou
+func NewListener(spanName string, listen ListenFunc, telemetry telemetry.TelemetryService) *Listener {
+	return &Listener{
+		listen:    listen,
+		spanName:  spanName,
+		telemetry: telemetry,
+	}
+}
+
+func (l *Listener) Start(ctx context.Context) {
+	ctx, l.cancel = context.WithCancel(ctx)
+
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+
+		delay := minReconnectDelay
+		for {
+			connected, err := l.listen(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if connected {
+				delay = minReconnectDelay
+			}
+
+			span, _ := l.telemetry.StartSpan(context.Background(), l.spanName)
+			span.SetError(err)
+			span.End()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxReconnectDelay)
+		}
+	}()
+}
+
+func (l *Listener) Stop() {
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.wg.Wait()
+}
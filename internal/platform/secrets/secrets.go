@@ -0,0 +1,110 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Prefixo de versão permite trocar algoritmo ou chave sem ambiguidade no que já foi gravado
+const versionPrefix = "v1:"
+
+var (
+	ErrNoKey             = errors.New("secrets encryption key not configured")
+	ErrInvalidKey        = errors.New("secrets encryption key must be 32 bytes (base64)")
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// Cipher cifra credenciais guardadas no banco (tokens de API de terceiros)
+type Cipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+type Config struct {
+	// Chave AES-256 em base64 (SECRETS_ENCRYPTION_KEY); gere com `openssl rand -base64 32`
+	Key string
+}
+
+func LoadConfig() Config {
+	return Config{Key: os.Getenv("SECRETS_ENCRYPTION_KEY")}
+}
+
+// NewCipher cria o Cipher configurado; sem chave, cifrar e decifrar falham com ErrNoKey
+func NewCipher(config Config) (Cipher, error) {
+	if config.Key == "" {
+		return Disabled(), nil
+	}
+	key, err := base64.StdEncoding.DecodeString(config.Key)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return NewAESCipher(key)
+}
+
+type aesCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESCipher usa AES-256-GCM com nonce aleatório por valor
+func NewAESCipher(key []byte) (Cipher, error) {
+	if len(key) != 32 {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesCipher{aead: aead}, nil
+}
+
+func (c *aesCipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return versionPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *aesCipher) Decrypt(ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, versionPrefix) {
+		return "", ErrInvalidCiphertext
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(ciphertext, versionPrefix))
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, data := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	return string(plaintext), nil
+}
+
+type disabledCipher struct{}
+
+// Disabled é usado quando não há chave: nada é gravado em texto puro por engano
+func Disabled() Cipher {
+	return disabledCipher{}
+}
+
+func (disabledCipher) Encrypt(plaintext string) (string, error) {
+	return "", ErrNoKey
+}
+
+func (disabledCipher) Decrypt(ciphertext string) (string, error) {
+	return "", ErrNoKey
+}
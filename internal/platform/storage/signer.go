@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid url signature")
+	ErrURLExpired       = errors.New("signed url expired")
+	ErrMissingURLSecret = errors.New("storage url secret is required")
+)
+
+// URLSigner gera URLs de download com validade, dispensando autenticação no GET
+type URLSigner struct {
+	secret  []byte
+	baseURL string
+	ttl     time.Duration
+}
+
+// NewURLSigner falha sem segredo: com a chave vazia qualquer um assinaria uma URL
+func NewURLSigner(secret, baseURL string, ttl time.Duration) (*URLSigner, error) {
+	if secret == "" {
+		return nil, ErrMissingURLSecret
+	}
+	return &URLSigner{secret: []byte(secret), baseURL: baseURL, ttl: ttl}, nil
+}
+
+// SignedURL retorna a URL assinada para o path e quando ela expira
+func (s *URLSigner) SignedURL(path string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	url := fmt.Sprintf("%s%s?expires=%d&signature=%s", s.baseURL, path, expiresAt.Unix(), s.sign(path, expiresAt.Unix()))
+	return url, expiresAt
+}
+
+// Verify confere a assinatura e a validade recebidas na query string
+func (s *URLSigner) Verify(path, expires, signature string, now time.Time) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(s.sign(path, unix)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > unix {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *URLSigner) sign(path string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(path + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
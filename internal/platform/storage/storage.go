@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+	ErrInvalidKey   = errors.New("invalid blob key")
+)
+
+// BlobStore guarda objetos binários (mídias, anexos) por chave
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+type Config struct {
+	// Driver: "local" (padrão) ou "s3"
+	Driver   string
+	LocalDir string
+
+	// S3 ou compatível (MinIO, R2...), sempre com path-style
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+
+	// Assinatura das URLs públicas de download
+	URLSecret     string
+	PublicBaseURL string
+	URLTTL        time.Duration
+}
+
+func LoadConfig() Config {
+	ttl, err := time.ParseDuration(os.Getenv("STORAGE_URL_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 15 * time.Minute
+	}
+	localDir := os.Getenv("STORAGE_LOCAL_DIR")
+	if localDir == "" {
+		localDir = "./data/blobs"
+	}
+
+	return Config{
+		Driver:        os.Getenv("STORAGE_DRIVER"),
+		LocalDir:      localDir,
+		S3Endpoint:    os.Getenv("STORAGE_S3_ENDPOINT"),
+		S3Region:      os.Getenv("STORAGE_S3_REGION"),
+		S3Bucket:      os.Getenv("STORAGE_S3_BUCKET"),
+		S3AccessKey:   os.Getenv("STORAGE_S3_ACCESS_KEY"),
+		S3SecretKey:   os.Getenv("STORAGE_S3_SECRET_KEY"),
+		URLSecret:     os.Getenv("STORAGE_URL_SECRET"),
+		PublicBaseURL: strings.TrimRight(os.Getenv("STORAGE_PUBLIC_BASE_URL"), "/"),
+		URLTTL:        ttl,
+	}
+}
+
+// NewBlobStore cria o BlobStore configurado
+func NewBlobStore(config Config) BlobStore {
+	if config.Driver == DriverS3 {
+		return NewS3BlobStore(S3Options{
+			Endpoint:  config.S3Endpoint,
+			Region:    config.S3Region,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+		}, nil)
+	}
+	return NewLocalBlobStore(config.LocalDir)
+}
+
+// validateKey impede chaves absolutas ou que escapem do diretório/bucket
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// AdminServer serve /metrics numa porta separada da API, que não deve ficar
+// exposta junto com as rotas públicas
+type AdminServer struct {
+	addr    string
+	handler http.Handler
+
+	mu       sync.Mutex
+	server   *http.Server
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewAdminServer com addr vazio não sobe nada: Start e Stop viram no-op
+func NewAdminServer(addr string, registry *prometheus.Registry) *AdminServer {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+	return &AdminServer{addr: addr, handler: mux}
+}
+
+// Start só devolve erro se não conseguir abrir a porta; a partir daí serve em
+// background até Stop
+func (s *AdminServer) Start(ctx context.Context) error {
+	if s.addr == "" {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("admin server: listen %s: %w", s.addr, err)
+	}
+
+	s.mu.Lock()
+	s.listener = listener
+	s.server = &http.Server{Handler: s.handler, ReadHeaderTimeout: 5 * time.Second}
+	server := s.server
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("admin server stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+func (s *AdminServer) Stop() {
+	s.mu.Lock()
+	server := s.server
+	s.server = nil
+	s.mu.Unlock()
+	if server == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server.Shutdown(ctx)
+	s.wg.Wait()
+}
+
+// Addr é o endereço efetivo, útil quando a porta configurada é ":0"
+func (s *AdminServer) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
@@ -0,0 +1,133 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	ExporterNone = "none"
+	ExporterOTLP = "otlp"
+)
+
+type Config struct {
+	// Exporter: "none" (padrão, nada sai do processo) ou "otlp" (HTTP). Endpoint,
+	// headers e TLS vêm das variáveis padrão OTEL_EXPORTER_OTLP_*
+	Exporter       string
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+
+	// SampleRatio é a fração dos traces iniciados aqui que são gravados; traces
+	// que chegam de outro serviço seguem a decisão de quem começou
+	SampleRatio    float64
+	MetricInterval time.Duration
+
+	// MetricsAddr é onde o AdminServer serve /metrics; vazio desliga. O padrão só
+	// escuta na loopback: expor para o scraper exige configurar o endereço
+	MetricsAddr string
+}
+
+func LoadConfig() Config {
+	exporter := os.Getenv("TELEMETRY_EXPORTER")
+	if exporter == "" {
+		exporter = ExporterNone
+	}
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "sib-crm-backend"
+	}
+	ratio, err := strconv.ParseFloat(os.Getenv("TELEMETRY_SAMPLE_RATIO"), 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		ratio = 1
+	}
+	interval, err := time.ParseDuration(os.Getenv("TELEMETRY_METRIC_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = time.Minute
+	}
+
+	metricsAddr, ok := os.LookupEnv("METRICS_ADDR")
+	if !ok {
+		metricsAddr = "127.0.0.1:9090"
+	}
+
+	return Config{
+		Exporter:       exporter,
+		ServiceName:    serviceName,
+		ServiceVersion: os.Getenv("SERVICE_VERSION"),
+		Environment:    os.Getenv("APP_ENV"),
+		SampleRatio:    ratio,
+		MetricInterval: interval,
+		MetricsAddr:    metricsAddr,
+	}
+}
+
+// Setup instala os providers globais do OpenTelemetry; o shutdown devolvido
+// descarrega spans e métricas pendentes. Com Exporter "none" nada sai do processo,
+// mas os spans continuam ganhando IDs para correlacionar logs e respostas de erro
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))
+
+	switch config.Exporter {
+	case ExporterNone:
+		tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler))
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagator)
+		return tracerProvider.Shutdown, nil
+	case ExporterOTLP:
+	default:
+		return noop, fmt.Errorf("telemetry: unknown exporter %q", config.Exporter)
+	}
+
+	attributes := []attribute.KeyValue{semconv.ServiceName(config.ServiceName)}
+	if config.ServiceVersion != "" {
+		attributes = append(attributes, semconv.ServiceVersion(config.ServiceVersion))
+	}
+	if config.Environment != "" {
+		attributes = append(attributes, semconv.DeploymentEnvironment(config.Environment))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, attributes...))
+	if err != nil {
+		return noop, err
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, err
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithSampler(sampler),
+	)
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(config.MetricInterval))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	otel.SetTextMapPropagator(propagator)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	}, nil
+}
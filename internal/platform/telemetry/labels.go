@@ -0,0 +1,44 @@
+package telemetry
+
+import "strings"
+
+// maxLabelValues limita as séries por label: valores novos além disso viram
+// overflowLabelValue em vez de uma série nova
+const (
+	maxLabelValues     = 100
+	overflowLabelValue = "other"
+)
+
+// droppedLabels identificam uma pessoa ou um registro; numa label virariam uma
+// série por usuário. Tags terminadas em "_id" também são descartadas
+var droppedLabels = map[string]bool{
+	"email":         true,
+	"phone":         true,
+	"contact_phone": true,
+	"tenant_name":   true,
+}
+
+func allowedLabel(key string) bool {
+	return !droppedLabels[key] && !strings.HasSuffix(key, "_id") && key != "id"
+}
+
+// labelLimiter guarda os valores já vistos de cada label de uma métrica; vale
+// para o Prometheus e para o OpenTelemetry, que sofrem igual com cardinalidade
+type labelLimiter map[string]map[string]bool
+
+// value devolve o valor a registrar: o próprio, se couber no limite, ou overflowLabelValue
+func (l labelLimiter) value(label, value string) string {
+	seen, ok := l[label]
+	if !ok {
+		seen = map[string]bool{}
+		l[label] = seen
+	}
+	if seen[value] {
+		return value
+	}
+	if len(seen) >= maxLabelValues {
+		return overflowLabelValue
+	}
+	seen[value] = true
+	return value
+}
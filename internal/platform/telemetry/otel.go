@@ -0,0 +1,198 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifica o tracer e o meter da aplicação nos backends
+const instrumentationName = "github.com/claudineijrdev/sib-crm-backend"
+
+// openTelemetryService cria os instrumentos sob demanda, um por nome de métrica
+type openTelemetryService struct {
+	tracer trace.Tracer
+	meter  metric.Meter
+
+	mu         sync.Mutex
+	counters   map[string]metric.Float64Counter
+	histograms map[string]metric.Float64Histogram
+	gauges     map[string]metric.Float64Gauge
+	limiters   map[string]labelLimiter
+}
+
+func NewOpenTelemetryService(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) TelemetryService {
+	return &openTelemetryService{
+		tracer:     tracerProvider.Tracer(instrumentationName),
+		meter:      meterProvider.Meter(instrumentationName),
+		counters:   map[string]metric.Float64Counter{},
+		histograms: map[string]metric.Float64Histogram{},
+		gauges:     map[string]metric.Float64Gauge{},
+		limiters:   map[string]labelLimiter{},
+	}
+}
+
+// TrackEvent vira um evento no span ativo; fora de um span (workers, listeners)
+// o evento ganha um span próprio para não se perder
+func (t *openTelemetryService) TrackEvent(ctx context.Context, event Event) error {
+	options := []trace.EventOption{trace.WithAttributes(propertyAttributes(event.Properties)...)}
+	if !event.Timestamp.IsZero() {
+		options = append(options, trace.WithTimestamp(event.Timestamp))
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if span.IsRecording() {
+		span.AddEvent(event.Name, options...)
+		return nil
+	}
+
+	_, span = t.tracer.Start(ctx, event.Name)
+	span.AddEvent(event.Name, options...)
+	span.End()
+	return nil
+}
+
+func (t *openTelemetryService) TrackMetric(ctx context.Context, m Metric) error {
+	attributes := metric.WithAttributes(t.metricAttributes(m.Name, m.Tags)...)
+
+	switch m.Kind {
+	case MetricHistogram:
+		histogram, err := t.histogram(m.Name)
+		if err != nil {
+			return err
+		}
+		histogram.Record(ctx, m.Value, attributes)
+	case MetricGauge:
+		gauge, err := t.gauge(m.Name)
+		if err != nil {
+			return err
+		}
+		gauge.Record(ctx, m.Value, attributes)
+	default:
+		counter, err := t.counter(m.Name)
+		if err != nil {
+			return err
+		}
+		counter.Add(ctx, m.Value, attributes)
+	}
+	return nil
+}
+
+func (t *openTelemetryService) StartSpan(ctx context.Context, name string) (Span, context.Context) {
+	ctx, span := t.tracer.Start(ctx, name)
+	return &otelSpan{span: span}, ctx
+}
+
+// metricAttributes aplica às tags a mesma política de labels do Prometheus:
+// descarta identificadores e limita os valores distintos por atributo
+func (t *openTelemetryService) metricAttributes(name string, tags map[string]string) []attribute.KeyValue {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	limiter, ok := t.limiters[name]
+	if !ok {
+		limiter = labelLimiter{}
+		t.limiters[name] = limiter
+	}
+	attributes := make([]attribute.KeyValue, 0, len(tags))
+	for key, value := range tags {
+		if allowedLabel(key) {
+			attributes = append(attributes, attribute.String(key, limiter.value(key, value)))
+		}
+	}
+	return attributes
+}
+
+func (t *openTelemetryService) counter(name string) (metric.Float64Counter, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if counter, ok := t.counters[name]; ok {
+		return counter, nil
+	}
+	counter, err := t.meter.Float64Counter(name)
+	if err != nil {
+		return nil, err
+	}
+	t.counters[name] = counter
+	return counter, nil
+}
+
+func (t *openTelemetryService) histogram(name string) (metric.Float64Histogram, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if histogram, ok := t.histograms[name]; ok {
+		return histogram, nil
+	}
+	histogram, err := t.meter.Float64Histogram(name)
+	if err != nil {
+		return nil, err
+	}
+	t.histograms[name] = histogram
+	return histogram, nil
+}
+
+func (t *openTelemetryService) gauge(name string) (metric.Float64Gauge, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if gauge, ok := t.gauges[name]; ok {
+		return gauge, nil
+	}
+	gauge, err := t.meter.Float64Gauge(name)
+	if err != nil {
+		return nil, err
+	}
+	t.gauges[name] = gauge
+	return gauge, nil
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s *otelSpan) End() {
+	s.span.End()
+}
+
+func (s *otelSpan) SetTag(key, value string) {
+	s.span.SetAttributes(attribute.String(key, value))
+}
+
+// SetError aceita nil para quem repassa o erro sem checar (ex.: reconexões)
+func (s *otelSpan) SetError(err error) {
+	if err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func propertyAttributes(properties map[string]interface{}) []attribute.KeyValue {
+	attributes := make([]attribute.KeyValue, 0, len(properties))
+	for key, value := range properties {
+		switch v := value.(type) {
+		case string:
+			attributes = append(attributes, attribute.String(key, v))
+		case bool:
+			attributes = append(attributes, attribute.Bool(key, v))
+		case int:
+			attributes = append(attributes, attribute.Int(key, v))
+		case int64:
+			attributes = append(attributes, attribute.Int64(key, v))
+		case float64:
+			attributes = append(attributes, attribute.Float64(key, v))
+		case fmt.Stringer:
+			attributes = append(attributes, attribute.String(key, v.String()))
+		default:
+			attributes = append(attributes, attribute.String(key, fmt.Sprint(v)))
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,158 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// histogramBuckets por métrica, na unidade que cada uma registra
+var histogramBuckets = map[string][]float64{
+	"http.request.duration":       {5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+	"whatsapp.media.stored_bytes": prometheus.ExponentialBuckets(1024, 4, 10),
+	"sla.timer.elapsed_seconds":   {30, 60, 300, 900, 1800, 3600, 7200, 14400, 28800, 86400},
+}
+
+var defaultBuckets = prometheus.ExponentialBuckets(1, 2, 12)
+
+// NewRegistry devolve um registry com os coletores do runtime e do processo
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// prometheusService registra as métricas no registry e repassa tudo (inclusive
+// as métricas) para next, que continua responsável por spans e eventos
+type prometheusService struct {
+	next     TelemetryService
+	registry prometheus.Registerer
+
+	mu      sync.Mutex
+	metrics map[string]*prometheusMetric
+}
+
+// prometheusMetric fixa as labels na primeira chamada: o Prometheus exige o
+// mesmo conjunto de labels em todas as séries de uma métrica
+type prometheusMetric struct {
+	kind      MetricKind
+	labels    []string
+	seen      labelLimiter
+	counter   *prometheus.CounterVec
+	histogram *prometheus.HistogramVec
+	gauge     *prometheus.GaugeVec
+}
+
+func NewPrometheusService(next TelemetryService, registry prometheus.Registerer) TelemetryService {
+	return &prometheusService{
+		next:     next,
+		registry: registry,
+		metrics:  map[string]*prometheusMetric{},
+	}
+}
+
+func (s *prometheusService) TrackEvent(ctx context.Context, event Event) error {
+	return s.next.TrackEvent(ctx, event)
+}
+
+func (s *prometheusService) StartSpan(ctx context.Context, name string) (Span, context.Context) {
+	return s.next.StartSpan(ctx, name)
+}
+
+func (s *prometheusService) TrackMetric(ctx context.Context, m Metric) error {
+	if err := s.record(m); err != nil {
+		return err
+	}
+	return s.next.TrackMetric(ctx, m)
+}
+
+func (s *prometheusService) record(m Metric) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metric, ok := s.metrics[m.Name]
+	if !ok {
+		var err error
+		if metric, err = s.register(m); err != nil {
+			return err
+		}
+		s.metrics[m.Name] = metric
+	}
+	if metric.kind != m.Kind {
+		return fmt.Errorf("telemetry: metric %s already registered as %q", m.Name, metric.kind)
+	}
+
+	values := metric.labelValues(m.Tags)
+	switch metric.kind {
+	case MetricHistogram:
+		metric.histogram.WithLabelValues(values...).Observe(m.Value)
+	case MetricGauge:
+		metric.gauge.WithLabelValues(values...).Set(m.Value)
+	default:
+		metric.counter.WithLabelValues(values...).Add(m.Value)
+	}
+	return nil
+}
+
+func (s *prometheusService) register(m Metric) (*prometheusMetric, error) {
+	labels := make([]string, 0, len(m.Tags))
+	for key := range m.Tags {
+		if allowedLabel(key) {
+			labels = append(labels, key)
+		}
+	}
+	sort.Strings(labels)
+
+	metric := &prometheusMetric{kind: m.Kind, labels: labels, seen: labelLimiter{}}
+	name := metricName(m.Name)
+	var collector prometheus.Collector
+
+	switch m.Kind {
+	case MetricHistogram:
+		buckets, ok := histogramBuckets[m.Name]
+		if !ok {
+			buckets = defaultBuckets
+		}
+		metric.histogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: m.Name, Buckets: buckets}, labels)
+		collector = metric.histogram
+	case MetricGauge:
+		metric.gauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: m.Name}, labels)
+		collector = metric.gauge
+	default:
+		metric.counter = prometheus.NewCounterVec(prometheus.CounterOpts{Name: name + "_total", Help: m.Name}, labels)
+		collector = metric.counter
+	}
+
+	if err := s.registry.Register(collector); err != nil {
+		return nil, fmt.Errorf("telemetry: register metric %s: %w", m.Name, err)
+	}
+	return metric, nil
+}
+
+// labelValues segue a ordem das labels da métrica: tags ausentes ficam vazias e
+// tags que não existiam na primeira chamada são ignoradas
+func (m *prometheusMetric) labelValues(tags map[string]string) []string {
+	values := make([]string, len(m.labels))
+	for i, label := range m.labels {
+		values[i] = m.seen.value(label, tags[label])
+	}
+	return values
+}
+
+// metricName converte "http.request.duration" em "http_request_duration"
+func metricName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
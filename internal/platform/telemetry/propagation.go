@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// propagator lê e escreve os headers traceparent/tracestate (W3C) e baggage.
+// Setup também o instala como global para bibliotecas que usam o do otel
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Extract devolve ctx com o span remoto dos headers, para o próximo span ser filho dele
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Inject escreve nos headers o span ativo e o request ID de ctx
+func Inject(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	if id := RequestID(ctx); id != "" && header.Get(RequestIDHeader) == "" {
+		header.Set(RequestIDHeader, id)
+	}
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// TraceID do span ativo; vazio quando não há trace (telemetria desligada)
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// transport propaga o trace e o request ID nas chamadas HTTP de saída
+type transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport envolve base (http.DefaultTransport quando nil) para os serviços
+// chamados continuarem o trace da requisição que originou a chamada
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip não pode alterar o request recebido
+	req = req.Clone(req.Context())
+	Inject(req.Context(), req.Header)
+	return t.base.RoundTrip(req)
+}
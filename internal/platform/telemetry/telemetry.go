@@ -3,6 +3,8 @@ package telemetry
 import (
 	"context"
 	"time"
+
+	"go.opentelemetry.io/otel"
 )
 
 type TelemetryService interface {
@@ -12,15 +14,26 @@ type TelemetryService interface {
 }
 
 type Event struct {
-	Name      string                 `json:"name"`
+	Name       string                 `json:"name"`
 	Properties map[string]interface{} `json:"properties"`
-	Timestamp time.Time              `json:"timestamp"`
+	Timestamp  time.Time              `json:"timestamp"`
 }
 
+// MetricKind define o instrumento: contadores somam Value, histogramas registram
+// a distribuição (durações, tamanhos) e gauges guardam o último valor
+type MetricKind string
+
+const (
+	MetricCounter   MetricKind = ""
+	MetricHistogram MetricKind = "histogram"
+	MetricGauge     MetricKind = "gauge"
+)
+
 type Metric struct {
-	Name   string  `json:"name"`
-	Value  float64 `json:"value"`
-	Tags   map[string]string `json:"tags"`
+	Name  string            `json:"name"`
+	Value float64           `json:"value"`
+	Tags  map[string]string `json:"tags"`
+	Kind  MetricKind        `json:"kind,omitempty"`
 }
 
 type Span interface {
@@ -29,45 +42,31 @@ type Span interface {
 	SetError(err error)
 }
 
-// Implementação concreta
-type telemetryService struct {
-	// Configurações do serviço
-	enabled bool
-	// Dependências internas
-}
-
+// NewTelemetryService usa os providers globais do OpenTelemetry, instalados por
+// Setup; sem Setup (ou com enabled=false) tudo vira no-op
 func NewTelemetryService(enabled bool) TelemetryService {
-	return &telemetryService{
-		enabled: enabled,
+	if !enabled {
+		return &noopService{}
 	}
+	return NewOpenTelemetryService(otel.GetTracerProvider(), otel.GetMeterProvider())
 }
 
-func (t *telemetryService) TrackEvent(ctx context.Context, event Event) error {
-	if !t.enabled {
-		return nil
-	}
-	// Implementação real aqui
+type noopService struct{}
+
+func (s *noopService) TrackEvent(ctx context.Context, event Event) error {
 	return nil
 }
 
-func (t *telemetryService) TrackMetric(ctx context.Context, metric Metric) error {
-	if !t.enabled {
-		return nil
-	}
-	// Implementação real aqui
+func (s *noopService) TrackMetric(ctx context.Context, metric Metric) error {
 	return nil
 }
 
-func (t *telemetryService) StartSpan(ctx context.Context, name string) (Span, context.Context) {
-	if !t.enabled {
-		return &noopSpan{}, ctx
-	}
-	// Implementação real aqui
+func (s *noopService) StartSpan(ctx context.Context, name string) (Span, context.Context) {
 	return &noopSpan{}, ctx
 }
 
 type noopSpan struct{}
 
-func (s *noopSpan) End() {}
+func (s *noopSpan) End()                     {}
 func (s *noopSpan) SetTag(key, value string) {}
-func (s *noopSpan) SetError(err error) {} 
\ No newline at end of file
+func (s *noopSpan) SetError(err error)       {}
@@ -0,0 +1,20 @@
+package web
+
+import (
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorBody é o corpo padrão das respostas de erro. O request ID e o trace ID
+// deixam o suporte achar a requisição nos logs e no backend de traces
+func ErrorBody(c *gin.Context, message string) gin.H {
+	body := gin.H{"error": message}
+	ctx := c.Request.Context()
+	if id := telemetry.RequestID(ctx); id != "" {
+		body["request_id"] = id
+	}
+	if id := telemetry.TraceID(ctx); id != "" {
+		body["trace_id"] = id
+	}
+	return body
+}
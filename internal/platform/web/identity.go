@@ -0,0 +1,87 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	tenantIDKey = "tenant_id"
+	userIDKey   = "user_id"
+
+	bearerPrefix = "Bearer "
+)
+
+// UserDirectory confirma que o usuário da sessão ainda existe no tenant; uma
+// sessão válida de um usuário removido não pode continuar agindo pelo tenant
+type UserDirectory interface {
+	UserInTenant(ctx context.Context, tenantID, userID uuid.UUID) (bool, error)
+}
+
+// UserDirectoryFunc adapta uma função a UserDirectory
+type UserDirectoryFunc func(ctx context.Context, tenantID, userID uuid.UUID) (bool, error)
+
+func (f UserDirectoryFunc) UserInTenant(ctx context.Context, tenantID, userID uuid.UUID) (bool, error) {
+	return f(ctx, tenantID, userID)
+}
+
+// IdentityMiddleware resolve o tenant e o usuário a partir do token de sessão
+// emitido pelo login (Authorization: Bearer <token>).
+func IdentityMiddleware(sessions *Sessions, users UserDirectory) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, bearerPrefix) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorBody(c, "missing session"))
+			return
+		}
+
+		tenantID, userID, err := sessions.Verify(strings.TrimPrefix(header, bearerPrefix), time.Now())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorBody(c, err.Error()))
+			return
+		}
+
+		ok, err := users.UserInTenant(c.Request.Context(), tenantID, userID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorBody(c, err.Error()))
+			return
+		}
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorBody(c, ErrInvalidSession.Error()))
+			return
+		}
+
+		SetIdentity(c, tenantID, userID)
+		c.Next()
+	}
+}
+
+// SetIdentity grava o tenant e o usuário no contexto do gin e no logger da requisição.
+func SetIdentity(c *gin.Context, tenantID, userID uuid.UUID) {
+	c.Set(tenantIDKey, tenantID)
+	c.Set(userIDKey, userID)
+	c.Request = c.Request.WithContext(logging.With(c.Request.Context(),
+		"tenant_id", tenantID.String(),
+		"user_id", userID.String(),
+	))
+}
+
+func TenantID(c *gin.Context) uuid.UUID {
+	if id, ok := c.Get(tenantIDKey); ok {
+		return id.(uuid.UUID)
+	}
+	return uuid.Nil
+}
+
+func UserID(c *gin.Context) uuid.UUID {
+	if id, ok := c.Get(userIDKey); ok {
+		return id.(uuid.UUID)
+	}
+	return uuid.Nil
+}
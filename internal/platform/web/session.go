@@ -0,0 +1,92 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMissingSessionSecret = errors.New("session secret is required")
+	ErrInvalidSession       = errors.New("invalid session")
+	ErrSessionExpired       = errors.New("session expired")
+)
+
+type SessionConfig struct {
+	// Secret assina as sessões; precisa ser o mesmo em todas as réplicas
+	Secret string
+	TTL    time.Duration
+}
+
+func LoadSessionConfig() SessionConfig {
+	ttl, err := time.ParseDuration(os.Getenv("SESSION_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 12 * time.Hour
+	}
+	return SessionConfig{
+		Secret: os.Getenv("SESSION_SECRET"),
+		TTL:    ttl,
+	}
+}
+
+// Sessions emite e verifica os tokens de sessão entregues pelo login, no formato
+// tenant.usuário.expiração.assinatura (HMAC-SHA256)
+type Sessions struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSessions falha sem segredo: com a chave vazia qualquer um forjaria uma sessão
+func NewSessions(config SessionConfig) (*Sessions, error) {
+	if config.Secret == "" {
+		return nil, ErrMissingSessionSecret
+	}
+	return &Sessions{secret: []byte(config.Secret), ttl: config.TTL}, nil
+}
+
+func (s *Sessions) Issue(tenantID, userID uuid.UUID, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	claims := tenantID.String() + "." + userID.String() + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return claims + "." + s.sign(claims), expiresAt
+}
+
+func (s *Sessions) Verify(token string, now time.Time) (uuid.UUID, uuid.UUID, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return uuid.Nil, uuid.Nil, ErrInvalidSession
+	}
+	claims := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(s.sign(claims)), []byte(parts[3])) {
+		return uuid.Nil, uuid.Nil, ErrInvalidSession
+	}
+
+	tenantID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrInvalidSession
+	}
+	userID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrInvalidSession
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrInvalidSession
+	}
+	if now.Unix() > expires {
+		return uuid.Nil, uuid.Nil, ErrSessionExpired
+	}
+	return tenantID, userID, nil
+}
+
+func (s *Sessions) sign(claims string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(claims))
+	return hex.EncodeToString(mac.Sum(nil))
+}
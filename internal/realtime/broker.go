@@ -0,0 +1,45 @@
+package realtime
+
+import (
+	"context"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/pubsub"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"gorm.io/gorm"
+)
+
+// Broker leva os eventos já serializados a todas as instâncias do servidor;
+// cada instância entrega às conexões que ela mesma mantém
+type Broker interface {
+	Publish(ctx context.Context, payload []byte) error
+	// Subscribe registra quem recebe os eventos publicados por qualquer instância
+	Subscribe(handler func(payload []byte))
+	Start(ctx context.Context)
+	Stop()
+}
+
+// NewBroker cria o Broker configurado; o postgres escuta por uma conexão própria (dsn)
+func NewBroker(config Config, db *gorm.DB, dsn string, telemetry telemetry.TelemetryService) Broker {
+	if config.Broker == BrokerPostgres {
+		return NewPostgresBroker(db, dsn, config.Channel, telemetry)
+	}
+	return NewLocalBroker()
+}
+
+type localBroker struct {
+	pubsub.Handlers
+}
+
+// NewLocalBroker entrega na própria instância; serve para uma réplica só e para testes
+func NewLocalBroker() Broker {
+	return &localBroker{}
+}
+
+func (b *localBroker) Publish(ctx context.Context, payload []byte) error {
+	b.Deliver(payload)
+	return nil
+}
+
+func (b *localBroker) Start(ctx context.Context) {}
+
+func (b *localBroker) Stop() {}
@@ -0,0 +1,68 @@
+package realtime
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	BrokerLocal    = "local"
+	BrokerPostgres = "postgres"
+)
+
+type Config struct {
+	// Broker: "local" (padrão, uma instância) ou "postgres" (LISTEN/NOTIFY entre instâncias)
+	Broker string
+	// Channel é o canal do NOTIFY compartilhado pelas instâncias
+	Channel string
+
+	// ClientBuffer é quantos eventos uma conexão acumula antes de ser derrubada por lentidão
+	ClientBuffer int
+	Heartbeat    time.Duration
+
+	// Tickets de conexão: WebSocket e EventSource não enviam os headers de identidade
+	TicketSecret string
+	TicketTTL    time.Duration
+
+	// AllowedOrigins são as origens do frontend aceitas no WebSocket (ex.: https://app.exemplo.com);
+	// vazio aceita só a mesma origem da API
+	AllowedOrigins []string
+}
+
+func LoadConfig() Config {
+	channel := os.Getenv("REALTIME_CHANNEL")
+	if channel == "" {
+		channel = "realtime_events"
+	}
+	buffer, err := strconv.Atoi(os.Getenv("REALTIME_CLIENT_BUFFER"))
+	if err != nil || buffer <= 0 {
+		buffer = 64
+	}
+	heartbeat, err := time.ParseDuration(os.Getenv("REALTIME_HEARTBEAT"))
+	if err != nil || heartbeat <= 0 {
+		heartbeat = 25 * time.Second
+	}
+	ttl, err := time.ParseDuration(os.Getenv("REALTIME_TICKET_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = time.Minute
+	}
+
+	var origins []string
+	for _, origin := range strings.Split(os.Getenv("REALTIME_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+
+	return Config{
+		Broker:         os.Getenv("REALTIME_BROKER"),
+		Channel:        channel,
+		ClientBuffer:   buffer,
+		Heartbeat:      heartbeat,
+		TicketSecret:   os.Getenv("REALTIME_TICKET_SECRET"),
+		TicketTTL:      ttl,
+		AllowedOrigins: origins,
+	}
+}
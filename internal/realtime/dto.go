@@ -0,0 +1,8 @@
+package realtime
+
+import "time"
+
+type TicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,53 @@
+package realtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de evento entregues ao cliente web
+const (
+	EventMessageCreated       = "message.created"
+	EventMessageStatus        = "message.status"
+	EventConversationUpdated  = "conversation.updated"
+	EventConversationAssigned = "conversation.assigned"
+	EventPresenceChanged      = "presence.changed"
+	EventTaskReminder         = "task.reminder"
+	EventSLABreached          = "sla.breached"
+	// "lead.moved" entra quando existir o módulo de leads (internal/leads ainda
+	// está vazio): hoje não há mudança de etapa de lead para publicar
+
+	// eventPing mantém a conexão viva em proxies que derrubam conexões ociosas
+	eventPing = "ping"
+)
+
+// Event é o que chega ao navegador; sempre escopado a um tenant
+type Event struct {
+	ID       uuid.UUID `json:"id"`
+	Type     string    `json:"type"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	// UserID restringe o evento às conexões de um usuário; nil vale para o tenant todo
+	UserID    *uuid.UUID  `json:"user_id,omitempty"`
+	Data      interface{} `json:"data"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// NewEvent cria um evento para todas as conexões do tenant
+func NewEvent(tenantID uuid.UUID, eventType string, data interface{}) Event {
+	return Event{
+		ID:        uuid.New(),
+		Type:      eventType,
+		TenantID:  tenantID,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Publisher é o que os módulos de domínio usam para avisar os clientes conectados.
+// Publicar nunca falha para quem chama: sem conexões o evento é descartado. O ctx
+// leva o trace da operação que gerou o evento
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
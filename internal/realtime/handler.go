@@ -0,0 +1,180 @@
+package realtime
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+const writeTimeout = 10 * time.Second
+
+var ErrOriginNotAllowed = errors.New("websocket origin not allowed")
+
+type RealtimeHandler struct {
+	hub            *Hub
+	tickets        *Ticketer
+	identity       gin.HandlerFunc
+	heartbeat      time.Duration
+	allowedOrigins []string
+}
+
+// identity é o web.IdentityMiddleware das rotas autenticadas, usado quando a
+// conexão chega sem ticket
+func NewRealtimeHandler(hub *Hub, tickets *Ticketer, identity gin.HandlerFunc, heartbeat time.Duration, allowedOrigins []string) *RealtimeHandler {
+	return &RealtimeHandler{
+		hub:            hub,
+		tickets:        tickets,
+		identity:       identity,
+		heartbeat:      heartbeat,
+		allowedOrigins: allowedOrigins,
+	}
+}
+
+// IssueTicket troca a identidade da requisição por um ticket para abrir a conexão
+func (h *RealtimeHandler) IssueTicket(c *gin.Context) {
+	ticket, expiresAt := h.tickets.Issue(web.TenantID(c), web.UserID(c), time.Now())
+	c.JSON(http.StatusCreated, TicketResponse{Ticket: ticket, ExpiresAt: expiresAt})
+}
+
+// Authenticate aceita ?ticket= (navegador) ou o token de sessão (demais clientes)
+func (h *RealtimeHandler) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticket := c.Query("ticket")
+		if ticket == "" {
+			h.identity(c)
+			return
+		}
+
+		tenantID, userID, err := h.tickets.Verify(ticket, time.Now())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, web.ErrorBody(c, err.Error()))
+			return
+		}
+		web.SetIdentity(c, tenantID, userID)
+		c.Next()
+	}
+}
+
+// WebSocket abre o canal de eventos: ?types=message.created,conversation.assigned
+func (h *RealtimeHandler) WebSocket(c *gin.Context) {
+	sub := subscription(c)
+	server := websocket.Server{
+		Handshake: h.checkOrigin,
+		Handler:   func(conn *websocket.Conn) { h.serveWebSocket(conn, sub) },
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkOrigin barra páginas de outros sites (cross-site WebSocket hijacking), que
+// abririam a conexão com um ticket vazado na URL; clientes fora do navegador não
+// enviam Origin
+func (h *RealtimeHandler) checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin == nil {
+		return nil
+	}
+	config.Origin = origin
+
+	if len(h.allowedOrigins) == 0 {
+		if strings.EqualFold(origin.Host, req.Host) {
+			return nil
+		}
+		return ErrOriginNotAllowed
+	}
+	for _, allowed := range h.allowedOrigins {
+		if strings.EqualFold(origin.Scheme+"://"+origin.Host, allowed) {
+			return nil
+		}
+	}
+	return ErrOriginNotAllowed
+}
+
+func (h *RealtimeHandler) serveWebSocket(conn *websocket.Conn, sub Subscription) {
+	defer conn.Close()
+
+	client := h.hub.Subscribe(sub)
+	defer h.hub.Unsubscribe(client)
+
+	// O cliente não envia comandos; a leitura só detecta o fechamento da conexão
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard string
+		for websocket.Message.Receive(conn, &discard) == nil {
+		}
+	}()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		var payload []byte
+		select {
+		case <-closed:
+			return
+		case <-client.Done():
+			return
+		case frame := <-client.Frames():
+			payload = frame.Payload
+		case <-ticker.C:
+			payload = []byte(`{"type":"` + eventPing + `"}`)
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := websocket.Message.Send(conn, string(payload)); err != nil {
+			return
+		}
+	}
+}
+
+// Events é o fallback em Server-Sent Events, com os mesmos filtros do WebSocket
+func (h *RealtimeHandler) Events(c *gin.Context) {
+	client := h.hub.Subscribe(subscription(c))
+	defer h.hub.Unsubscribe(client)
+
+	w := c.Writer
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Desliga o buffer do nginx, que seguraria os eventos
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+	w.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-client.Done():
+			return
+		case frame := <-client.Frames():
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", frame.ID, frame.Type, frame.Payload)
+		case <-ticker.C:
+			fmt.Fprint(w, ": "+eventPing+"\n\n")
+		}
+		w.Flush()
+	}
+}
+
+func subscription(c *gin.Context) Subscription {
+	sub := Subscription{TenantID: web.TenantID(c), UserID: web.UserID(c)}
+	for _, t := range strings.Split(c.Query("types"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			sub.Types = append(sub.Types, t)
+		}
+	}
+	return sub
+}
@@ -0,0 +1,226 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+// Subscription identifica a conexão e filtra o que ela recebe
+type Subscription struct {
+	TenantID uuid.UUID
+	UserID   uuid.UUID
+	// Types limita os tipos de evento; vazio recebe todos
+	Types []string
+}
+
+func (s Subscription) accepts(eventType string, userID *uuid.UUID) bool {
+	if userID != nil && *userID != s.UserID {
+		return false
+	}
+	if len(s.Types) == 0 {
+		return true
+	}
+	for _, t := range s.Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Frame é o evento pronto para escrever na conexão
+type Frame struct {
+	ID      string
+	Type    string
+	Payload []byte
+}
+
+// Client é uma conexão (WebSocket ou SSE) registrada no Hub
+type Client struct {
+	Subscription
+	frames chan Frame
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Frames entrega os eventos na ordem de publicação
+func (c *Client) Frames() <-chan Frame {
+	return c.frames
+}
+
+// Done fecha quando o Hub derruba a conexão por não acompanhar o ritmo dos eventos
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Client) offer(frame Frame) bool {
+	select {
+	case c.frames <- frame:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Client) close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// Hub mantém as conexões desta instância e recebe do Broker os eventos de todas elas
+type Hub struct {
+	broker     Broker
+	telemetry  telemetry.TelemetryService
+	bufferSize int
+
+	mu      sync.RWMutex
+	clients map[uuid.UUID]map[*Client]struct{}
+}
+
+func NewHub(broker Broker, telemetry telemetry.TelemetryService, bufferSize int) *Hub {
+	h := &Hub{
+		broker:     broker,
+		telemetry:  telemetry,
+		bufferSize: bufferSize,
+		clients:    map[uuid.UUID]map[*Client]struct{}{},
+	}
+	broker.Subscribe(h.dispatch)
+	return h
+}
+
+func (h *Hub) Publish(ctx context.Context, event Event) {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	// O evento já aconteceu: o cancelamento da requisição não pode descartá-lo no broker
+	span, ctx := h.telemetry.StartSpan(context.WithoutCancel(ctx), "realtime.publish")
+	defer span.End()
+
+	span.SetTag("tenant_id", event.TenantID.String())
+	span.SetTag("type", event.Type)
+
+	payload, err := json.Marshal(event)
+	if err == nil {
+		err = h.broker.Publish(ctx, payload)
+	}
+	if err != nil {
+		span.SetError(err)
+		h.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "realtime.publish.failed",
+			Value: 1,
+			Tags:  map[string]string{"type": event.Type},
+		})
+	}
+}
+
+// Subscribe registra a conexão; quem chama deve sempre chamar Unsubscribe ao encerrar
+func (h *Hub) Subscribe(sub Subscription) *Client {
+	client := &Client{
+		Subscription: sub,
+		frames:       make(chan Frame, h.bufferSize),
+		done:         make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	if h.clients[sub.TenantID] == nil {
+		h.clients[sub.TenantID] = map[*Client]struct{}{}
+	}
+	h.clients[sub.TenantID][client] = struct{}{}
+	h.mu.Unlock()
+
+	h.trackConnections()
+	return client
+}
+
+func (h *Hub) Unsubscribe(client *Client) {
+	h.mu.Lock()
+	if clients, ok := h.clients[client.TenantID]; ok {
+		delete(clients, client)
+		if len(clients) == 0 {
+			delete(h.clients, client.TenantID)
+		}
+	}
+	h.mu.Unlock()
+
+	client.close()
+	h.trackConnections()
+}
+
+// Close derruba as conexões desta instância; no encerramento do servidor os
+// streams abertos não deixariam o Shutdown terminar
+func (h *Hub) Close() {
+	h.mu.Lock()
+	clients := h.clients
+	h.clients = map[uuid.UUID]map[*Client]struct{}{}
+	h.mu.Unlock()
+
+	for _, tenantClients := range clients {
+		for client := range tenantClients {
+			client.close()
+		}
+	}
+	h.trackConnections()
+}
+
+// Connections conta as conexões do tenant nesta instância
+func (h *Hub) Connections(tenantID uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients[tenantID])
+}
+
+// dispatch entrega sem bloquear: conexão com o buffer cheio é derrubada e o
+// cliente reconecta e recarrega a tela, em vez de atrasar os demais
+func (h *Hub) dispatch(payload []byte) {
+	var header struct {
+		ID       string     `json:"id"`
+		Type     string     `json:"type"`
+		TenantID uuid.UUID  `json:"tenant_id"`
+		UserID   *uuid.UUID `json:"user_id"`
+	}
+	if err := json.Unmarshal(payload, &header); err != nil {
+		h.telemetry.TrackMetric(context.Background(), telemetry.Metric{Name: "realtime.event.invalid", Value: 1})
+		return
+	}
+
+	h.mu.RLock()
+	var targets []*Client
+	for client := range h.clients[header.TenantID] {
+		if client.accepts(header.Type, header.UserID) {
+			targets = append(targets, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	frame := Frame{ID: header.ID, Type: header.Type, Payload: payload}
+	for _, client := range targets {
+		if client.offer(frame) {
+			continue
+		}
+		h.Unsubscribe(client)
+		h.telemetry.TrackMetric(context.Background(), telemetry.Metric{
+			Name:  "realtime.client.dropped",
+			Value: 1,
+			Tags:  map[string]string{"reason": "slow_consumer"},
+		})
+	}
+}
+
+func (h *Hub) trackConnections() {
+	h.mu.RLock()
+	total := 0
+	for _, clients := range h.clients {
+		total += len(clients)
+	}
+	h.mu.RUnlock()
+
+	h.telemetry.TrackMetric(context.Background(), telemetry.Metric{Name: "realtime.connections", Value: float64(total), Kind: telemetry.MetricGauge})
+}
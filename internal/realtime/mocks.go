@@ -0,0 +1,32 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// MockPublisher para testes; guarda os eventos publicados
+type MockPublisher struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, event Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+}
+
+// Events retorna os eventos publicados, opcionalmente filtrados pelo tipo
+func (m *MockPublisher) Events(eventType string) []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var events []Event
+	for _, event := range m.events {
+		if eventType == "" || event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+	return events
+}
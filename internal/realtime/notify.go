@@ -0,0 +1,107 @@
+package realtime
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// O Postgres recusa payloads de NOTIFY a partir de 8000 bytes; eventos maiores
+// viajam em pedaços e são remontados por quem escuta
+const maxNotifyPayload = 7999
+
+// maxEventSize limita o evento remontado; acima disso o evento é recusado
+const maxEventSize = 1 << 20
+
+const chunkPrefix = "chunk:"
+
+var ErrPayloadTooLarge = errors.New("realtime event payload too large")
+
+// SplitNotify divide o payload em mensagens de até limit bytes. Payloads que cabem
+// seguem inteiros; os demais viram chunk:<id>:<seq>:<total>:<dados>
+func SplitNotify(payload []byte, limit int) ([]string, error) {
+	if len(payload) <= limit {
+		return []string{string(payload)}, nil
+	}
+	if len(payload) > maxEventSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	header := chunkPrefix + uuid.NewString() + ":%d:%d:"
+	// reserva o cabeçalho com os maiores números possíveis
+	room := limit - len(fmt.Sprintf(header, maxEventSize, maxEventSize))
+	if room <= utf8.UTFMax {
+		return nil, ErrPayloadTooLarge
+	}
+
+	var parts [][]byte
+	for len(payload) > 0 {
+		n := min(room, len(payload))
+		// não corta um caractere no meio: o NOTIFY exige texto válido
+		for n < len(payload) && !utf8.RuneStart(payload[n]) {
+			n--
+		}
+		parts = append(parts, payload[:n])
+		payload = payload[n:]
+	}
+
+	messages := make([]string, len(parts))
+	for i, part := range parts {
+		messages[i] = fmt.Sprintf(header, i, len(parts)) + string(part)
+	}
+	return messages, nil
+}
+
+// NotifyAssembler remonta os pedaços de SplitNotify; uma instância por conexão,
+// sem uso concorrente
+type NotifyAssembler struct {
+	pending map[string][][]byte
+}
+
+func NewNotifyAssembler() *NotifyAssembler {
+	return &NotifyAssembler{pending: map[string][][]byte{}}
+}
+
+// Add devolve o payload quando a mensagem é inteira ou completa um evento em pedaços
+func (a *NotifyAssembler) Add(message string) ([]byte, bool) {
+	if !strings.HasPrefix(message, chunkPrefix) {
+		return []byte(message), true
+	}
+
+	fields := strings.SplitN(strings.TrimPrefix(message, chunkPrefix), ":", 4)
+	if len(fields) != 4 {
+		return nil, false
+	}
+	id := fields[0]
+	seq, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, false
+	}
+	total, err := strconv.Atoi(fields[2])
+	if err != nil || total <= 0 || total > maxEventSize || seq < 0 || seq >= total {
+		return nil, false
+	}
+
+	parts, ok := a.pending[id]
+	if !ok {
+		parts = make([][]byte, total)
+		a.pending[id] = parts
+	}
+	if len(parts) != total {
+		return nil, false
+	}
+	parts[seq] = []byte(fields[3])
+	for _, part := range parts {
+		if part == nil {
+			return nil, false
+		}
+	}
+
+	delete(a.pending, id)
+	return bytes.Join(parts, nil), true
+}
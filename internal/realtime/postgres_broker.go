@@ -0,0 +1,74 @@
+package realtime
+
+import (
+	"context"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/pubsub"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// postgresBroker usa LISTEN/NOTIFY: toda instância escuta o canal, inclusive a que publicou
+type postgresBroker struct {
+	pubsub.Handlers
+	*pubsub.Listener
+	db      *gorm.DB
+	dsn     string
+	channel string
+}
+
+func NewPostgresBroker(db *gorm.DB, dsn, channel string, telemetry telemetry.TelemetryService) Broker {
+	b := &postgresBroker{
+		db:      db,
+		dsn:     dsn,
+		channel: channel,
+	}
+	b.Listener = pubsub.NewListener("realtime.broker.reconnect", b.listen, telemetry)
+	return b
+}
+
+func (b *postgresBroker) Publish(ctx context.Context, payload []byte) error {
+	messages, err := SplitNotify(payload, maxNotifyPayload)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 1 {
+		return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", b.channel, messages[0]).Error
+	}
+
+	// Os NOTIFY de uma transação chegam juntos e em ordem a quem escuta
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, message := range messages {
+			if err := tx.Exec("SELECT pg_notify(?, ?)", b.channel, message).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// listen mantém uma conexão dedicada no LISTEN até ela cair; retorna se chegou a escutar
+func (b *postgresBroker) listen(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return false, err
+	}
+
+	// pedaços pendentes morrem com a conexão: a transação chega inteira ou não chega
+	assembler := NewNotifyAssembler()
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+		if payload, ok := assembler.Add(notification.Payload); ok {
+			b.Deliver(payload)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package realtime
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidTicket = errors.New("invalid realtime ticket")
+	ErrTicketExpired = errors.New("realtime ticket expired")
+)
+
+// Ticketer emite tickets curtos que autenticam a abertura do WebSocket/SSE pela query string
+type Ticketer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTicketer sem segredo gera um aleatório: serve para uma instância, mas com
+// várias réplicas REALTIME_TICKET_SECRET precisa ser o mesmo em todas
+func NewTicketer(secret string, ttl time.Duration) *Ticketer {
+	key := []byte(secret)
+	if secret == "" {
+		key = make([]byte, 32)
+		rand.Read(key)
+	}
+	return &Ticketer{secret: key, ttl: ttl}
+}
+
+// Issue retorna o ticket no formato tenant.usuário.expiração.assinatura
+func (t *Ticketer) Issue(tenantID, userID uuid.UUID, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(t.ttl).Truncate(time.Second)
+	claims := tenantID.String() + "." + userID.String() + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return claims + "." + t.sign(claims), expiresAt
+}
+
+func (t *Ticketer) Verify(ticket string, now time.Time) (uuid.UUID, uuid.UUID, error) {
+	parts := strings.Split(ticket, ".")
+	if len(parts) != 4 {
+		return uuid.Nil, uuid.Nil, ErrInvalidTicket
+	}
+	claims := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(t.sign(claims)), []byte(parts[3])) {
+		return uuid.Nil, uuid.Nil, ErrInvalidTicket
+	}
+
+	tenantID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrInvalidTicket
+	}
+	userID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrInvalidTicket
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrInvalidTicket
+	}
+	if now.Unix() > expires {
+		return uuid.Nil, uuid.Nil, ErrTicketExpired
+	}
+	return tenantID, userID, nil
+}
+
+func (t *Ticketer) sign(claims string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(claims))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,67 @@
+package realtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+// MessageData acompanha message.created
+type MessageData struct {
+	ConversationID *uuid.UUID        `json:"conversation_id"`
+	Message        *whatsapp.Message `json:"message"`
+}
+
+// MessageStatusData acompanha message.status; o cliente localiza a mensagem pelo wa_message_id
+type MessageStatusData struct {
+	WAMessageID  string                 `json:"wa_message_id"`
+	Status       whatsapp.MessageStatus `json:"status"`
+	ErrorCode    string                 `json:"error_code,omitempty"`
+	ErrorMessage string                 `json:"error_message,omitempty"`
+	Timestamp    time.Time              `json:"timestamp"`
+}
+
+// WhatsAppBridge traduz os eventos do WhatsApp para a caixa de entrada no navegador
+type WhatsAppBridge interface {
+	// HandleEvent é registrado no WebhookService (depois da caixa de entrada) e no ConversationService
+	HandleEvent(ctx context.Context, event whatsapp.Event)
+}
+
+type whatsAppBridge struct {
+	publisher Publisher
+}
+
+func NewWhatsAppBridge(publisher Publisher) WhatsAppBridge {
+	return &whatsAppBridge{publisher: publisher}
+}
+
+func (b *whatsAppBridge) HandleEvent(ctx context.Context, event whatsapp.Event) {
+	switch e := event.(type) {
+	case whatsapp.InboundMessageEvent:
+		if e.Message.ConversationID == nil {
+			return
+		}
+		b.publisher.Publish(ctx, NewEvent(e.TenantID, EventMessageCreated, MessageData{ConversationID: e.Message.ConversationID, Message: e.Message}))
+
+	case whatsapp.MessageSentEvent:
+		b.publisher.Publish(ctx, NewEvent(e.TenantID, EventMessageCreated, MessageData{ConversationID: &e.Conversation.ID, Message: e.Message}))
+
+	case whatsapp.StatusUpdateEvent:
+		b.publisher.Publish(ctx, NewEvent(e.TenantID, EventMessageStatus, MessageStatusData{
+			WAMessageID:  e.WAMessageID,
+			Status:       e.Status,
+			ErrorCode:    e.ErrorCode,
+			ErrorMessage: e.ErrorMessage,
+			Timestamp:    e.Timestamp,
+		}))
+
+	case whatsapp.ConversationUpdatedEvent:
+		eventType := EventConversationUpdated
+		if e.Change == whatsapp.ConversationAssigned {
+			eventType = EventConversationAssigned
+		}
+		b.publisher.Publish(ctx, NewEvent(e.TenantID, eventType, e.Conversation))
+	}
+}
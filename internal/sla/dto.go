@@ -0,0 +1,28 @@
+package sla
+
+import "github.com/google/uuid"
+
+type SavePolicyRequest struct {
+	Enabled              *bool `json:"enabled"`
+	FirstResponseMinutes int   `json:"first_response_minutes"`
+	NextResponseMinutes  int   `json:"next_response_minutes"`
+	ResolutionMinutes    int   `json:"resolution_minutes"`
+	BusinessHoursOnly    *bool `json:"business_hours_only"`
+}
+
+type TimerListResponse struct {
+	Items []Timer `json:"items"`
+}
+
+type BreachListResponse struct {
+	Items    []Timer `json:"items"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"page_size"`
+	Total    int64   `json:"total"`
+}
+
+// BreachNotice é o que os navegadores recebem quando uma meta é violada
+type BreachNotice struct {
+	Timer      *Timer     `json:"timer"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+}
@@ -0,0 +1,95 @@
+package sla
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/gin-gonic/gin"
+)
+
+type SLAHandler struct {
+	slaService SLAService
+}
+
+func NewSLAHandler(slaService SLAService) *SLAHandler {
+	return &SLAHandler{
+		slaService: slaService,
+	}
+}
+
+func (h *SLAHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.slaService.GetPolicy(c.Request.Context(), web.TenantID(c))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+func (h *SLAHandler) SavePolicy(c *gin.Context) {
+	var req SavePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	policy, err := h.slaService.SavePolicy(c.Request.Context(), web.TenantID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+func (h *SLAHandler) DeletePolicy(c *gin.Context) {
+	if err := h.slaService.DeletePolicy(c.Request.Context(), web.TenantID(c)); err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SLAHandler) ConversationTimers(c *gin.Context) {
+	timers, err := h.slaService.ConversationTimers(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, TimerListResponse{Items: timers})
+}
+
+// Breaches lista as violações mais recentes: ?kind=first_response&page=1
+func (h *SLAHandler) Breaches(c *gin.Context) {
+	pagination := web.ParsePagination(c)
+
+	items, total, err := h.slaService.ListBreaches(c.Request.Context(), web.TenantID(c), TimerKind(c.Query("kind")), pagination.Offset(), pagination.PageSize)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, BreachListResponse{
+		Items:    items,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+		Total:    total,
+	})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrPolicyNotFound),
+		errors.Is(err, whatsapp.ErrConversationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidPolicy),
+		errors.Is(err, ErrInvalidKind):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,51 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+type PolicyRepository interface {
+	FindByTenant(ctx context.Context, tenantID uuid.UUID) (*Policy, error)
+	// Save cria ou atualiza a política do tenant
+	Save(ctx context.Context, policy *Policy) error
+	Delete(ctx context.Context, tenantID uuid.UUID) error
+}
+
+type TimerRepository interface {
+	Create(ctx context.Context, timer *Timer) error
+	Update(ctx context.Context, timer *Timer) error
+	FindOpenByConversation(ctx context.Context, conversationID uuid.UUID) ([]Timer, error)
+	// FindDue retorna os timers abertos, vencidos até now e ainda sem violação registrada
+	FindDue(ctx context.Context, now time.Time, limit int) ([]Timer, error)
+	// ExistsSince indica se a conversa teve timer do tipo iniciado a partir de since
+	ExistsSince(ctx context.Context, conversationID uuid.UUID, kind TimerKind, since time.Time) (bool, error)
+	// MarkBreached registra a violação e retorna false se outra instância já registrou
+	MarkBreached(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Timer, error)
+	ListBreached(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error)
+}
+
+type SLAService interface {
+	// HandleEvent é registrado como listener do WebhookService, depois da caixa de entrada
+	HandleEvent(ctx context.Context, event whatsapp.Event)
+	GetPolicy(ctx context.Context, tenantID uuid.UUID) (*Policy, error)
+	SavePolicy(ctx context.Context, tenantID uuid.UUID, req SavePolicyRequest) (*Policy, error)
+	DeletePolicy(ctx context.Context, tenantID uuid.UUID) error
+	ConversationTimers(ctx context.Context, tenantID uuid.UUID, conversationID string) ([]Timer, error)
+	ListBreaches(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error)
+}
+
+// Breach é o contexto entregue ao Notifier quando uma meta é violada
+type Breach struct {
+	Timer        *Timer
+	Conversation *whatsapp.Conversation
+}
+
+// Notifier avisa gestores e responsáveis sobre violações de SLA
+type Notifier interface {
+	NotifyBreach(ctx context.Context, breach Breach) error
+}
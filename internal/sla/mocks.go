@@ -0,0 +1,168 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+// MockPolicyRepository para testes
+type MockPolicyRepository struct {
+	FindByTenantFunc func(ctx context.Context, tenantID uuid.UUID) (*Policy, error)
+	SaveFunc         func(ctx context.Context, policy *Policy) error
+	DeleteFunc       func(ctx context.Context, tenantID uuid.UUID) error
+}
+
+func (m *MockPolicyRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) (*Policy, error) {
+	if m.FindByTenantFunc != nil {
+		return m.FindByTenantFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyRepository) Save(ctx context.Context, policy *Policy) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, policy)
+	}
+	return nil
+}
+
+func (m *MockPolicyRepository) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID)
+	}
+	return nil
+}
+
+// MockTimerRepository para testes
+type MockTimerRepository struct {
+	CreateFunc                 func(ctx context.Context, timer *Timer) error
+	UpdateFunc                 func(ctx context.Context, timer *Timer) error
+	FindOpenByConversationFunc func(ctx context.Context, conversationID uuid.UUID) ([]Timer, error)
+	FindDueFunc                func(ctx context.Context, now time.Time, limit int) ([]Timer, error)
+	ExistsSinceFunc            func(ctx context.Context, conversationID uuid.UUID, kind TimerKind, since time.Time) (bool, error)
+	MarkBreachedFunc           func(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	ListByConversationFunc     func(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Timer, error)
+	ListBreachedFunc           func(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error)
+}
+
+func (m *MockTimerRepository) Create(ctx context.Context, timer *Timer) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, timer)
+	}
+	return nil
+}
+
+func (m *MockTimerRepository) Update(ctx context.Context, timer *Timer) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, timer)
+	}
+	return nil
+}
+
+func (m *MockTimerRepository) FindOpenByConversation(ctx context.Context, conversationID uuid.UUID) ([]Timer, error) {
+	if m.FindOpenByConversationFunc != nil {
+		return m.FindOpenByConversationFunc(ctx, conversationID)
+	}
+	return nil, nil
+}
+
+func (m *MockTimerRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]Timer, error) {
+	if m.FindDueFunc != nil {
+		return m.FindDueFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockTimerRepository) ExistsSince(ctx context.Context, conversationID uuid.UUID, kind TimerKind, since time.Time) (bool, error) {
+	if m.ExistsSinceFunc != nil {
+		return m.ExistsSinceFunc(ctx, conversationID, kind, since)
+	}
+	return false, nil
+}
+
+func (m *MockTimerRepository) MarkBreached(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	if m.MarkBreachedFunc != nil {
+		return m.MarkBreachedFunc(ctx, id, at)
+	}
+	return false, nil
+}
+
+func (m *MockTimerRepository) ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Timer, error) {
+	if m.ListByConversationFunc != nil {
+		return m.ListByConversationFunc(ctx, tenantID, conversationID)
+	}
+	return nil, nil
+}
+
+func (m *MockTimerRepository) ListBreached(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error) {
+	if m.ListBreachedFunc != nil {
+		return m.ListBreachedFunc(ctx, tenantID, kind, offset, limit)
+	}
+	return nil, 0, nil
+}
+
+// MockSLAService para testes
+type MockSLAService struct {
+	HandleEventFunc        func(ctx context.Context, event whatsapp.Event)
+	GetPolicyFunc          func(ctx context.Context, tenantID uuid.UUID) (*Policy, error)
+	SavePolicyFunc         func(ctx context.Context, tenantID uuid.UUID, req SavePolicyRequest) (*Policy, error)
+	DeletePolicyFunc       func(ctx context.Context, tenantID uuid.UUID) error
+	ConversationTimersFunc func(ctx context.Context, tenantID uuid.UUID, conversationID string) ([]Timer, error)
+	ListBreachesFunc       func(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error)
+}
+
+func (m *MockSLAService) HandleEvent(ctx context.Context, event whatsapp.Event) {
+	if m.HandleEventFunc != nil {
+		m.HandleEventFunc(ctx, event)
+	}
+}
+
+func (m *MockSLAService) GetPolicy(ctx context.Context, tenantID uuid.UUID) (*Policy, error) {
+	if m.GetPolicyFunc != nil {
+		return m.GetPolicyFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockSLAService) SavePolicy(ctx context.Context, tenantID uuid.UUID, req SavePolicyRequest) (*Policy, error) {
+	if m.SavePolicyFunc != nil {
+		return m.SavePolicyFunc(ctx, tenantID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockSLAService) DeletePolicy(ctx context.Context, tenantID uuid.UUID) error {
+	if m.DeletePolicyFunc != nil {
+		return m.DeletePolicyFunc(ctx, tenantID)
+	}
+	return nil
+}
+
+func (m *MockSLAService) ConversationTimers(ctx context.Context, tenantID uuid.UUID, conversationID string) ([]Timer, error) {
+	if m.ConversationTimersFunc != nil {
+		return m.ConversationTimersFunc(ctx, tenantID, conversationID)
+	}
+	return nil, nil
+}
+
+func (m *MockSLAService) ListBreaches(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error) {
+	if m.ListBreachesFunc != nil {
+		return m.ListBreachesFunc(ctx, tenantID, kind, offset, limit)
+	}
+	return nil, 0, nil
+}
+
+// MockNotifier para testes
+type MockNotifier struct {
+	NotifyBreachFunc func(ctx context.Context, breach Breach) error
+}
+
+func (m *MockNotifier) NotifyBreach(ctx context.Context, breach Breach) error {
+	if m.NotifyBreachFunc != nil {
+		return m.NotifyBreachFunc(ctx, breach)
+	}
+	return nil
+}
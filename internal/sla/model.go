@@ -0,0 +1,110 @@
+package sla
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPolicyNotFound = errors.New("sla policy not found")
+	ErrInvalidPolicy  = errors.New("invalid sla policy")
+	ErrInvalidKind    = errors.New("invalid sla timer kind")
+)
+
+// Policy define as metas de atendimento do tenant; meta zerada fica desligada
+type Policy struct {
+	ID                   uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID             uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"tenant_id"`
+	Enabled              bool      `gorm:"not null" json:"enabled"`
+	FirstResponseMinutes int       `gorm:"not null;default:0" json:"first_response_minutes"`
+	NextResponseMinutes  int       `gorm:"not null;default:0" json:"next_response_minutes"`
+	ResolutionMinutes    int       `gorm:"not null;default:0" json:"resolution_minutes"`
+	// BusinessHoursOnly conta os prazos só dentro do horário de atendimento do tenant
+	BusinessHoursOnly bool      `gorm:"not null" json:"business_hours_only"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func (p *Policy) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// Target retorna a meta do tipo de timer; zero significa sem meta
+func (p *Policy) Target(kind TimerKind) time.Duration {
+	switch kind {
+	case KindFirstResponse:
+		return time.Duration(p.FirstResponseMinutes) * time.Minute
+	case KindNextResponse:
+		return time.Duration(p.NextResponseMinutes) * time.Minute
+	case KindResolution:
+		return time.Duration(p.ResolutionMinutes) * time.Minute
+	}
+	return 0
+}
+
+type TimerKind string
+
+const (
+	// KindFirstResponse conta da primeira mensagem do cliente até a primeira resposta de um agente
+	KindFirstResponse TimerKind = "first_response"
+	// KindNextResponse conta de cada nova mensagem do cliente, já atendido, até a próxima resposta
+	KindNextResponse TimerKind = "next_response"
+	// KindResolution conta da abertura (ou reabertura) até a conversa ser resolvida
+	KindResolution TimerKind = "resolution"
+)
+
+func (k TimerKind) Valid() bool {
+	switch k {
+	case KindFirstResponse, KindNextResponse, KindResolution:
+		return true
+	}
+	return false
+}
+
+func (k TimerKind) IsResponse() bool {
+	return k == KindFirstResponse || k == KindNextResponse
+}
+
+type TimerStatus string
+
+const (
+	TimerRunning   TimerStatus = "running"
+	TimerMet       TimerStatus = "met"
+	TimerBreached  TimerStatus = "breached"
+	TimerCancelled TimerStatus = "cancelled" // resolvida sem resposta de agente
+)
+
+// Timer acompanha uma meta de SLA em uma conversa; aberto enquanto StoppedAt for nil
+type Timer struct {
+	ID             uuid.UUID   `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID       uuid.UUID   `gorm:"type:uuid;not null;index:idx_sla_timers_breaches,priority:1" json:"tenant_id"`
+	ConversationID uuid.UUID   `gorm:"type:uuid;not null;index" json:"conversation_id"`
+	Kind           TimerKind   `gorm:"type:varchar(32);not null" json:"kind"`
+	Status         TimerStatus `gorm:"type:varchar(16);not null" json:"status"`
+	BusinessHours  bool        `gorm:"not null;default:false" json:"business_hours"`
+	StartedAt      time.Time   `gorm:"not null" json:"started_at"`
+	DueAt          time.Time   `gorm:"not null;index:idx_sla_timers_open,priority:2" json:"due_at"`
+	StoppedAt      *time.Time  `gorm:"index:idx_sla_timers_open,priority:1" json:"stopped_at,omitempty"`
+	BreachedAt     *time.Time  `gorm:"index:idx_sla_timers_breaches,priority:2" json:"breached_at,omitempty"`
+	// ElapsedSeconds é o tempo até a parada, contado como a meta (expediente ou corrido)
+	ElapsedSeconds int64     `gorm:"not null;default:0" json:"elapsed_seconds"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (t *Timer) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+func (t *Timer) Open() bool {
+	return t.StoppedAt == nil
+}
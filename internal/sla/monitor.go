@@ -0,0 +1,176 @@
+package sla
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+const monitorBatchSize = 200
+
+// BreachMonitor verifica periodicamente os timers vencidos: encerra os que foram
+// cumpridos a tempo e registra e notifica as violações.
+type BreachMonitor struct {
+	*tracker
+	conversationRepo whatsapp.ConversationRepository
+	interval         time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBreachMonitor(
+	timerRepo TimerRepository,
+	conversationRepo whatsapp.ConversationRepository,
+	messageRepo whatsapp.MessageRepository,
+	hoursService businesshours.BusinessHoursService,
+	notifier Notifier,
+	telemetry telemetry.TelemetryService,
+	interval time.Duration,
+) *BreachMonitor {
+	return &BreachMonitor{
+		tracker: &tracker{
+			timerRepo:    timerRepo,
+			messageRepo:  messageRepo,
+			hoursService: hoursService,
+			notifier:     notifier,
+			telemetry:    telemetry,
+		},
+		conversationRepo: conversationRepo,
+		interval:         interval,
+	}
+}
+
+func (m *BreachMonitor) Start(ctx context.Context) {
+	ctx, m.cancel = context.WithCancel(ctx)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				m.RunOnce(ctx, now)
+			}
+		}
+	}()
+}
+
+func (m *BreachMonitor) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.wg.Wait()
+}
+
+// RunOnce processa os timers vencidos até now e retorna quantas violações foram registradas
+func (m *BreachMonitor) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	span, ctx := m.telemetry.StartSpan(ctx, "sla.breach_monitor.run")
+	defer span.End()
+
+	due, err := m.timerRepo.FindDue(ctx, now, monitorBatchSize)
+	if err != nil {
+		span.SetError(err)
+		return 0, err
+	}
+
+	conversations := map[uuid.UUID]*whatsapp.Conversation{}
+	calendars := map[uuid.UUID]*businesshours.Calendar{}
+
+	breaches := 0
+	for i := range due {
+		timer := &due[i]
+
+		conversation, ok := conversations[timer.ConversationID]
+		if !ok {
+			conversation, err = m.conversationRepo.FindByID(ctx, timer.TenantID, timer.ConversationID.String())
+			if err != nil {
+				span.SetError(err)
+				continue
+			}
+			conversations[timer.ConversationID] = conversation
+		}
+		if conversation == nil {
+			// Conversa removida: nada a medir
+			stoppedAt := now
+			timer.StoppedAt = &stoppedAt
+			timer.Status = TimerCancelled
+			if err := m.timerRepo.Update(ctx, timer); err != nil {
+				span.SetError(err)
+			}
+			continue
+		}
+
+		calendar, ok := calendars[timer.TenantID]
+		if !ok {
+			calendar, err = m.loadCalendar(ctx, conversation)
+			if err != nil {
+				span.SetError(err)
+				continue
+			}
+			calendars[timer.TenantID] = calendar
+		}
+
+		if err := m.reconcile(ctx, timer, conversation, calendar, now); err != nil {
+			span.SetError(err)
+			continue
+		}
+		if timer.BreachedAt != nil {
+			breaches++
+		}
+	}
+
+	m.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "sla.breach_monitor.breaches",
+		Value: float64(breaches),
+	})
+
+	return breaches, nil
+}
+
+// realtimeNotifier avisa as conexões do tenant sobre a violação. O auth ainda não
+// tem papéis, então o evento vai para o tenant inteiro (gestores inclusive) e o
+// assignee_id deixa o atendente responsável destacar as suas conversas.
+type realtimeNotifier struct {
+	publisher realtime.Publisher
+	telemetry telemetry.TelemetryService
+}
+
+func NewRealtimeNotifier(publisher realtime.Publisher, telemetry telemetry.TelemetryService) Notifier {
+	return &realtimeNotifier{publisher: publisher, telemetry: telemetry}
+}
+
+func (n *realtimeNotifier) NotifyBreach(ctx context.Context, breach Breach) error {
+	n.publisher.Publish(ctx, realtime.NewEvent(breach.Timer.TenantID, realtime.EventSLABreached, BreachNotice{
+		Timer:      breach.Timer,
+		AssigneeID: breach.Conversation.AssigneeID,
+	}))
+
+	properties := map[string]interface{}{
+		"sla_timer_id":    breach.Timer.ID.String(),
+		"conversation_id": breach.Timer.ConversationID.String(),
+		"tenant_id":       breach.Timer.TenantID.String(),
+		"kind":            string(breach.Timer.Kind),
+		"due_at":          breach.Timer.DueAt,
+	}
+	if breach.Conversation.AssigneeID != nil {
+		properties["assignee_id"] = breach.Conversation.AssigneeID.String()
+	}
+	return n.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name:       "sla.breach_notification",
+		Properties: properties,
+		Timestamp:  time.Now(),
+	})
+}
@@ -0,0 +1,98 @@
+package sla
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type policyRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newPolicyRepositoryBase(db *gorm.DB) *policyRepositoryBase {
+	return &policyRepositoryBase{db: db}
+}
+
+func (r *policyRepositoryBase) findByTenant(ctx context.Context, tenantID uuid.UUID) (*Policy, error) {
+	var policy Policy
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *policyRepositoryBase) save(ctx context.Context, policy *Policy) error {
+	if policy.ID == uuid.Nil {
+		return r.db.WithContext(ctx).Create(policy).Error
+	}
+	// Select("*") grava também os booleanos falsos
+	return r.db.WithContext(ctx).Select("*").Save(policy).Error
+}
+
+func (r *policyRepositoryBase) delete(ctx context.Context, tenantID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Delete(&Policy{}).Error
+}
+
+// Repository com telemetria (decorator)
+type policyRepository struct {
+	base      *policyRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewPolicyRepository(db *gorm.DB, telemetry telemetry.TelemetryService) PolicyRepository {
+	return &policyRepository{
+		base:      newPolicyRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *policyRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) (*Policy, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_policy.find_by_tenant")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	policy, err := r.base.findByTenant(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (r *policyRepository) Save(ctx context.Context, policy *Policy) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_policy.save")
+	defer span.End()
+
+	span.SetTag("tenant_id", policy.TenantID.String())
+
+	err := r.base.save(ctx, policy)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *policyRepository) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_policy.delete")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	err := r.base.delete(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+type slaService struct {
+	*tracker
+	policyRepo       PolicyRepository
+	conversationRepo whatsapp.ConversationRepository
+}
+
+func NewSLAService(
+	policyRepo PolicyRepository,
+	timerRepo TimerRepository,
+	conversationRepo whatsapp.ConversationRepository,
+	messageRepo whatsapp.MessageRepository,
+	hoursService businesshours.BusinessHoursService,
+	notifier Notifier,
+	telemetry telemetry.TelemetryService,
+) SLAService {
+	return &slaService{
+		tracker: &tracker{
+			timerRepo:    timerRepo,
+			messageRepo:  messageRepo,
+			hoursService: hoursService,
+			notifier:     notifier,
+			telemetry:    telemetry,
+		},
+		policyRepo:       policyRepo,
+		conversationRepo: conversationRepo,
+	}
+}
+
+func (s *slaService) HandleEvent(ctx context.Context, event whatsapp.Event) {
+	inbound, ok := event.(whatsapp.InboundMessageEvent)
+	if !ok || inbound.Message == nil || inbound.Message.ConversationID == nil {
+		return
+	}
+
+	span, ctx := s.telemetry.StartSpan(ctx, "sla.handle_inbound")
+	defer span.End()
+
+	if err := s.trackInbound(ctx, inbound); err != nil {
+		span.SetError(err)
+	}
+}
+
+// trackInbound encerra o que a conversa já cumpriu e inicia os timers que a nova mensagem abre
+func (s *slaService) trackInbound(ctx context.Context, event whatsapp.InboundMessageEvent) error {
+	policy, err := s.policyRepo.FindByTenant(ctx, event.TenantID)
+	if err != nil || policy == nil || !policy.Enabled {
+		return err
+	}
+
+	conversation, err := s.conversationRepo.FindByID(ctx, event.TenantID, event.Message.ConversationID.String())
+	if err != nil || conversation == nil {
+		return err
+	}
+	calendar, err := s.loadCalendar(ctx, conversation)
+	if err != nil {
+		return err
+	}
+
+	running, err := s.reconcileConversation(ctx, conversation, calendar)
+	if err != nil {
+		return err
+	}
+
+	// Conta do recebimento (relógio do servidor, o mesmo das respostas), não do timestamp
+	// da Meta, que tem resolução de segundos
+	at := event.Message.CreatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if !running[KindResolution] {
+		if err := s.start(ctx, policy, conversation, calendar, KindResolution, at); err != nil {
+			return err
+		}
+	}
+
+	// Cliente aguardando: primeira resposta do ciclo (desde a última resolução) ou próxima resposta
+	if running[KindFirstResponse] || running[KindNextResponse] {
+		return nil
+	}
+	var cycleStart time.Time
+	if conversation.ResolvedAt != nil {
+		cycleStart = *conversation.ResolvedAt
+	}
+	answered, err := s.timerRepo.ExistsSince(ctx, conversation.ID, KindFirstResponse, cycleStart)
+	if err != nil {
+		return err
+	}
+	kind := KindFirstResponse
+	if answered {
+		kind = KindNextResponse
+	}
+	return s.start(ctx, policy, conversation, calendar, kind, at)
+}
+
+// reconcileConversation atualiza os timers abertos e retorna os tipos que seguem correndo
+func (s *slaService) reconcileConversation(ctx context.Context, conversation *whatsapp.Conversation, calendar *businesshours.Calendar) (map[TimerKind]bool, error) {
+	open, err := s.timerRepo.FindOpenByConversation(ctx, conversation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	running := map[TimerKind]bool{}
+	now := time.Now()
+	for i := range open {
+		timer := &open[i]
+		if err := s.reconcile(ctx, timer, conversation, calendar, now); err != nil {
+			return nil, err
+		}
+		if timer.Open() {
+			running[timer.Kind] = true
+		}
+	}
+	return running, nil
+}
+
+func (s *slaService) GetPolicy(ctx context.Context, tenantID uuid.UUID) (*Policy, error) {
+	policy, err := s.policyRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, ErrPolicyNotFound
+	}
+	return policy, nil
+}
+
+func (s *slaService) SavePolicy(ctx context.Context, tenantID uuid.UUID, req SavePolicyRequest) (*Policy, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "sla.save_policy")
+	defer span.End()
+
+	if req.FirstResponseMinutes < 0 || req.NextResponseMinutes < 0 || req.ResolutionMinutes < 0 {
+		return nil, ErrInvalidPolicy
+	}
+
+	policy, err := s.policyRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if policy == nil {
+		policy = &Policy{TenantID: tenantID}
+	}
+
+	policy.Enabled = req.Enabled == nil || *req.Enabled
+	policy.FirstResponseMinutes = req.FirstResponseMinutes
+	policy.NextResponseMinutes = req.NextResponseMinutes
+	policy.ResolutionMinutes = req.ResolutionMinutes
+	policy.BusinessHoursOnly = req.BusinessHoursOnly == nil || *req.BusinessHoursOnly
+
+	if err := s.policyRepo.Save(ctx, policy); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "sla.policy_saved",
+		Properties: map[string]interface{}{
+			"tenant_id":              tenantID.String(),
+			"enabled":                policy.Enabled,
+			"first_response_minutes": policy.FirstResponseMinutes,
+			"next_response_minutes":  policy.NextResponseMinutes,
+			"resolution_minutes":     policy.ResolutionMinutes,
+			"business_hours_only":    policy.BusinessHoursOnly,
+		},
+		Timestamp: time.Now(),
+	})
+
+	return policy, nil
+}
+
+func (s *slaService) DeletePolicy(ctx context.Context, tenantID uuid.UUID) error {
+	if _, err := s.GetPolicy(ctx, tenantID); err != nil {
+		return err
+	}
+	return s.policyRepo.Delete(ctx, tenantID)
+}
+
+// ConversationTimers reconcilia antes de listar, para não exibir como "running" uma meta já cumprida
+func (s *slaService) ConversationTimers(ctx context.Context, tenantID uuid.UUID, conversationID string) ([]Timer, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "sla.conversation_timers")
+	defer span.End()
+
+	if _, err := uuid.Parse(conversationID); err != nil {
+		return nil, whatsapp.ErrConversationNotFound
+	}
+	conversation, err := s.conversationRepo.FindByID(ctx, tenantID, conversationID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if conversation == nil {
+		return nil, whatsapp.ErrConversationNotFound
+	}
+
+	calendar, err := s.loadCalendar(ctx, conversation)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if _, err := s.reconcileConversation(ctx, conversation, calendar); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return s.timerRepo.ListByConversation(ctx, tenantID, conversation.ID)
+}
+
+func (s *slaService) ListBreaches(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error) {
+	if kind != "" && !kind.Valid() {
+		return nil, 0, ErrInvalidKind
+	}
+	return s.timerRepo.ListBreached(ctx, tenantID, kind, offset, limit)
+}
@@ -0,0 +1,226 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type timerRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newTimerRepositoryBase(db *gorm.DB) *timerRepositoryBase {
+	return &timerRepositoryBase{db: db}
+}
+
+func (r *timerRepositoryBase) create(ctx context.Context, timer *Timer) error {
+	return r.db.WithContext(ctx).Create(timer).Error
+}
+
+func (r *timerRepositoryBase) update(ctx context.Context, timer *Timer) error {
+	return r.db.WithContext(ctx).Save(timer).Error
+}
+
+func (r *timerRepositoryBase) findOpenByConversation(ctx context.Context, conversationID uuid.UUID) ([]Timer, error) {
+	var timers []Timer
+	err := r.db.WithContext(ctx).Where("conversation_id = ? AND stopped_at IS NULL", conversationID).
+		Order("started_at ASC").
+		Find(&timers).Error
+	if err != nil {
+		return nil, err
+	}
+	return timers, nil
+}
+
+func (r *timerRepositoryBase) findDue(ctx context.Context, now time.Time, limit int) ([]Timer, error) {
+	var timers []Timer
+	err := r.db.WithContext(ctx).Where("stopped_at IS NULL AND breached_at IS NULL AND due_at <= ?", now).
+		Order("due_at ASC").
+		Limit(limit).
+		Find(&timers).Error
+	if err != nil {
+		return nil, err
+	}
+	return timers, nil
+}
+
+func (r *timerRepositoryBase) existsSince(ctx context.Context, conversationID uuid.UUID, kind TimerKind, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Timer{}).
+		Where("conversation_id = ? AND kind = ? AND started_at >= ?", conversationID, kind, since).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *timerRepositoryBase) markBreached(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Timer{}).
+		Where("id = ? AND breached_at IS NULL", id).
+		Updates(map[string]interface{}{"breached_at": at, "status": TimerBreached})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *timerRepositoryBase) listByConversation(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Timer, error) {
+	var timers []Timer
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND conversation_id = ?", tenantID, conversationID).
+		Order("started_at DESC").
+		Find(&timers).Error
+	if err != nil {
+		return nil, err
+	}
+	return timers, nil
+}
+
+func (r *timerRepositoryBase) listBreached(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Timer{}).Where("tenant_id = ? AND breached_at IS NOT NULL", tenantID)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var timers []Timer
+	err := query.Order("breached_at DESC").Offset(offset).Limit(limit).Find(&timers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return timers, total, nil
+}
+
+// Repository com telemetria (decorator)
+type timerRepository struct {
+	base      *timerRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewTimerRepository(db *gorm.DB, telemetry telemetry.TelemetryService) TimerRepository {
+	return &timerRepository{
+		base:      newTimerRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *timerRepository) Create(ctx context.Context, timer *Timer) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_timer.create")
+	defer span.End()
+
+	span.SetTag("conversation_id", timer.ConversationID.String())
+	span.SetTag("kind", string(timer.Kind))
+
+	err := r.base.create(ctx, timer)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *timerRepository) Update(ctx context.Context, timer *Timer) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_timer.update")
+	defer span.End()
+
+	span.SetTag("sla_timer_id", timer.ID.String())
+
+	err := r.base.update(ctx, timer)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *timerRepository) FindOpenByConversation(ctx context.Context, conversationID uuid.UUID) ([]Timer, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_timer.find_open_by_conversation")
+	defer span.End()
+
+	span.SetTag("conversation_id", conversationID.String())
+
+	timers, err := r.base.findOpenByConversation(ctx, conversationID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return timers, nil
+}
+
+func (r *timerRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]Timer, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_timer.find_due")
+	defer span.End()
+
+	timers, err := r.base.findDue(ctx, now, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return timers, nil
+}
+
+func (r *timerRepository) ExistsSince(ctx context.Context, conversationID uuid.UUID, kind TimerKind, since time.Time) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_timer.exists_since")
+	defer span.End()
+
+	span.SetTag("conversation_id", conversationID.String())
+	span.SetTag("kind", string(kind))
+
+	exists, err := r.base.existsSince(ctx, conversationID, kind, since)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *timerRepository) MarkBreached(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_timer.mark_breached")
+	defer span.End()
+
+	span.SetTag("sla_timer_id", id.String())
+
+	claimed, err := r.base.markBreached(ctx, id, at)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return claimed, nil
+}
+
+func (r *timerRepository) ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Timer, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_timer.list_by_conversation")
+	defer span.End()
+
+	span.SetTag("conversation_id", conversationID.String())
+
+	timers, err := r.base.listByConversation(ctx, tenantID, conversationID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return timers, nil
+}
+
+func (r *timerRepository) ListBreached(ctx context.Context, tenantID uuid.UUID, kind TimerKind, offset, limit int) ([]Timer, int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.sla_timer.list_breached")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	timers, total, err := r.base.listBreached(ctx, tenantID, kind, offset, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, 0, err
+	}
+	return timers, total, nil
+}
@@ -0,0 +1,158 @@
+package sla
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+)
+
+// tracker concentra o ciclo de vida dos timers; usado pelo listener do webhook e pelo BreachMonitor
+type tracker struct {
+	timerRepo    TimerRepository
+	messageRepo  whatsapp.MessageRepository
+	hoursService businesshours.BusinessHoursService
+	notifier     Notifier
+	telemetry    telemetry.TelemetryService
+}
+
+// calendar retorna nil (sempre aberto) quando o tenant não configurou horário
+func (t *tracker) calendar(timer *Timer, calendar *businesshours.Calendar) *businesshours.Calendar {
+	if !timer.BusinessHours {
+		return nil
+	}
+	return calendar
+}
+
+func (t *tracker) loadCalendar(ctx context.Context, conversation *whatsapp.Conversation) (*businesshours.Calendar, error) {
+	calendar, err := t.hoursService.GetCalendar(ctx, conversation.TenantID)
+	if errors.Is(err, businesshours.ErrCalendarNotFound) {
+		return nil, nil
+	}
+	return calendar, err
+}
+
+func (t *tracker) start(ctx context.Context, policy *Policy, conversation *whatsapp.Conversation, calendar *businesshours.Calendar, kind TimerKind, at time.Time) error {
+	target := policy.Target(kind)
+	if target <= 0 {
+		return nil
+	}
+
+	timer := &Timer{
+		TenantID:       conversation.TenantID,
+		ConversationID: conversation.ID,
+		Kind:           kind,
+		Status:         TimerRunning,
+		BusinessHours:  policy.BusinessHoursOnly,
+		StartedAt:      at,
+	}
+	timer.DueAt = t.calendar(timer, calendar).AddBusinessTime(at, target)
+	return t.timerRepo.Create(ctx, timer)
+}
+
+// reconcile encerra o timer se a meta foi atendida (ou perdeu o sentido) e registra a violação vencida
+func (t *tracker) reconcile(ctx context.Context, timer *Timer, conversation *whatsapp.Conversation, calendar *businesshours.Calendar, now time.Time) error {
+	stoppedAt, status, err := t.stopPoint(ctx, timer, conversation)
+	if err != nil {
+		return err
+	}
+
+	if stoppedAt == nil {
+		if now.Before(timer.DueAt) || timer.BreachedAt != nil {
+			return nil
+		}
+		return t.breach(ctx, timer, conversation)
+	}
+
+	late := stoppedAt.After(timer.DueAt)
+	if late && timer.BreachedAt == nil {
+		if err := t.breach(ctx, timer, conversation); err != nil {
+			return err
+		}
+	}
+
+	timer.StoppedAt = stoppedAt
+	timer.ElapsedSeconds = int64(t.calendar(timer, calendar).BusinessDuration(timer.StartedAt, *stoppedAt) / time.Second)
+	switch {
+	case late || timer.BreachedAt != nil:
+		timer.Status = TimerBreached
+	default:
+		timer.Status = status
+	}
+	if err := t.timerRepo.Update(ctx, timer); err != nil {
+		return err
+	}
+
+	t.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "sla.timer.elapsed_seconds",
+		Value: float64(timer.ElapsedSeconds),
+		Tags:  map[string]string{"kind": string(timer.Kind), "status": string(timer.Status)},
+		Kind:  telemetry.MetricHistogram,
+	})
+	return nil
+}
+
+// stopPoint encontra o momento em que o timer deixou de correr, se já deixou
+func (t *tracker) stopPoint(ctx context.Context, timer *Timer, conversation *whatsapp.Conversation) (*time.Time, TimerStatus, error) {
+	if timer.Kind.IsResponse() {
+		reply, err := t.messageRepo.FirstAgentReplySince(ctx, timer.ConversationID, timer.StartedAt)
+		if err != nil {
+			return nil, "", err
+		}
+		if reply != nil {
+			return &reply.Timestamp, TimerMet, nil
+		}
+	}
+
+	resolvedAt := conversation.ResolvedAt
+	if resolvedAt == nil || resolvedAt.Before(timer.StartedAt) {
+		return nil, "", nil
+	}
+	if timer.Kind == KindResolution {
+		return resolvedAt, TimerMet, nil
+	}
+	// Resolvida sem resposta de agente (ex.: cliente só agradeceu)
+	return resolvedAt, TimerCancelled, nil
+}
+
+// breach registra a violação no vencimento; só a instância que marcar primeiro notifica
+func (t *tracker) breach(ctx context.Context, timer *Timer, conversation *whatsapp.Conversation) error {
+	claimed, err := t.timerRepo.MarkBreached(ctx, timer.ID, timer.DueAt)
+	if err != nil {
+		return err
+	}
+	// Mesmo sem vencer a marcação, o timer em memória precisa refletir o banco antes de um Update
+	breachedAt := timer.DueAt
+	timer.BreachedAt = &breachedAt
+	timer.Status = TimerBreached
+	if !claimed {
+		return nil
+	}
+
+	properties := map[string]interface{}{
+		"sla_timer_id":    timer.ID.String(),
+		"conversation_id": timer.ConversationID.String(),
+		"tenant_id":       timer.TenantID.String(),
+		"kind":            string(timer.Kind),
+		"started_at":      timer.StartedAt,
+		"due_at":          timer.DueAt,
+	}
+	if conversation.AssigneeID != nil {
+		properties["assignee_id"] = conversation.AssigneeID.String()
+	}
+	t.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name:       "sla.breached",
+		Properties: properties,
+		Timestamp:  time.Now(),
+	})
+	t.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "sla.breaches",
+		Value: 1,
+		Tags:  map[string]string{"kind": string(timer.Kind)},
+	})
+
+	return t.notifier.NotifyBreach(ctx, Breach{Timer: timer, Conversation: conversation})
+}
@@ -0,0 +1,29 @@
+package tasks
+
+import "time"
+
+type CreateTaskRequest struct {
+	Title          string     `json:"title" binding:"required"`
+	Description    string     `json:"description"`
+	AssigneeID     string     `json:"assignee_id" binding:"omitempty,uuid"`
+	Priority       Priority   `json:"priority"`
+	DueAt          time.Time  `json:"due_at" binding:"required"`
+	RemindAt       *time.Time `json:"remind_at"`
+	RecurrenceRule string     `json:"recurrence_rule"`
+}
+
+type CompleteTaskResponse struct {
+	Task *Task `json:"task"`
+	Next *Task `json:"next,omitempty"`
+}
+
+type TaskListResponse struct {
+	Items    []Task `json:"items"`
+	Page     int    `json:"page"`
+	PageSize int    `json:"page_size"`
+	Total    int64  `json:"total"`
+}
+
+type ReminderListResponse struct {
+	Items []Task `json:"items"`
+}
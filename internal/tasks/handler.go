@@ -0,0 +1,117 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TaskHandler struct {
+	taskService TaskService
+}
+
+func NewTaskHandler(taskService TaskService) *TaskHandler {
+	return &TaskHandler{
+		taskService: taskService,
+	}
+}
+
+func (h *TaskHandler) Create(c *gin.Context) {
+	var req CreateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	task, err := h.taskService.CreateTask(c.Request.Context(), web.TenantID(c), web.UserID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+func (h *TaskHandler) Get(c *gin.Context) {
+	task, err := h.taskService.GetTask(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) Complete(c *gin.Context) {
+	task, next, err := h.taskService.CompleteTask(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, CompleteTaskResponse{Task: task, Next: next})
+}
+
+func (h *TaskHandler) Mine(c *gin.Context) {
+	h.list(c, h.taskService.MyTasks)
+}
+
+func (h *TaskHandler) Overdue(c *gin.Context) {
+	h.list(c, h.taskService.OverdueTasks)
+}
+
+// Reminders lista os lembretes vencidos que o usuário ainda não confirmou
+func (h *TaskHandler) Reminders(c *gin.Context) {
+	items, err := h.taskService.PendingReminders(c.Request.Context(), web.TenantID(c), web.UserID(c))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, ReminderListResponse{Items: items})
+}
+
+func (h *TaskHandler) AcknowledgeReminder(c *gin.Context) {
+	if err := h.taskService.AcknowledgeReminder(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Param("id")); err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TaskHandler) list(c *gin.Context, fetch func(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]Task, int64, error)) {
+	pagination := web.ParsePagination(c)
+
+	items, total, err := fetch(c.Request.Context(), web.TenantID(c), web.UserID(c), pagination.Offset(), pagination.PageSize)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, TaskListResponse{
+		Items:    items,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+		Total:    total,
+	})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTaskAlreadyCompleted):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidPriority),
+		errors.Is(err, ErrInvalidAssignee),
+		errors.Is(err, ErrReminderAfterDue),
+		errors.Is(err, ErrInvalidRecurrence):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
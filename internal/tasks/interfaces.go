@@ -0,0 +1,42 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskRepository interface {
+	Create(ctx context.Context, task *Task) error
+	Update(ctx context.Context, task *Task) error
+	// Complete conclui a task aberta e cria next (se houver) numa transação; false se
+	// a task já não estava aberta
+	Complete(ctx context.Context, task *Task, completedAt time.Time, next *Task) (bool, error)
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Task, error)
+	ListByAssignee(ctx context.Context, tenantID, assigneeID uuid.UUID, onlyOverdue bool, now time.Time, offset, limit int) ([]Task, int64, error)
+	FindDueReminders(ctx context.Context, now time.Time, limit int) ([]Task, error)
+	MarkReminderNotified(ctx context.Context, id uuid.UUID, notifiedAt time.Time) (bool, error)
+	// ReleaseReminder desfaz a marcação para o lembrete voltar na próxima rodada
+	ReleaseReminder(ctx context.Context, id uuid.UUID) error
+	// ListPendingReminders traz os lembretes vencidos que o responsável ainda não confirmou
+	ListPendingReminders(ctx context.Context, tenantID, assigneeID uuid.UUID, now time.Time) ([]Task, error)
+	AcknowledgeReminder(ctx context.Context, tenantID, assigneeID uuid.UUID, id string, sentAt time.Time) (bool, error)
+}
+
+type TaskService interface {
+	CreateTask(ctx context.Context, tenantID, userID uuid.UUID, req CreateTaskRequest) (*Task, error)
+	GetTask(ctx context.Context, tenantID uuid.UUID, id string) (*Task, error)
+	CompleteTask(ctx context.Context, tenantID uuid.UUID, id string) (*Task, *Task, error)
+	MyTasks(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]Task, int64, error)
+	OverdueTasks(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]Task, int64, error)
+	// PendingReminders é como o responsável recebe os lembretes avisados enquanto estava offline
+	PendingReminders(ctx context.Context, tenantID, userID uuid.UUID) ([]Task, error)
+	AcknowledgeReminder(ctx context.Context, tenantID, userID uuid.UUID, id string) error
+}
+
+// Notifier avisa o responsável de um lembrete vencido; a entrega só conta quando
+// ele confirma o lembrete (AcknowledgeReminder)
+type Notifier interface {
+	NotifyReminder(ctx context.Context, task *Task) error
+}
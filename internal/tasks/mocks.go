@@ -0,0 +1,104 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MockTaskRepository para testes
+type MockTaskRepository struct {
+	CreateFunc               func(ctx context.Context, task *Task) error
+	UpdateFunc               func(ctx context.Context, task *Task) error
+	CompleteFunc             func(ctx context.Context, task *Task, completedAt time.Time, next *Task) (bool, error)
+	FindByIDFunc             func(ctx context.Context, tenantID uuid.UUID, id string) (*Task, error)
+	ListByAssigneeFunc       func(ctx context.Context, tenantID, assigneeID uuid.UUID, onlyOverdue bool, now time.Time, offset, limit int) ([]Task, int64, error)
+	FindDueRemindersFunc     func(ctx context.Context, now time.Time, limit int) ([]Task, error)
+	MarkReminderNotifiedFunc func(ctx context.Context, id uuid.UUID, notifiedAt time.Time) (bool, error)
+	ReleaseReminderFunc      func(ctx context.Context, id uuid.UUID) error
+	ListPendingRemindersFunc func(ctx context.Context, tenantID, assigneeID uuid.UUID, now time.Time) ([]Task, error)
+	AcknowledgeReminderFunc  func(ctx context.Context, tenantID, assigneeID uuid.UUID, id string, sentAt time.Time) (bool, error)
+}
+
+func (m *MockTaskRepository) Create(ctx context.Context, task *Task) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, task)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) Update(ctx context.Context, task *Task) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, task)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) Complete(ctx context.Context, task *Task, completedAt time.Time, next *Task) (bool, error) {
+	if m.CompleteFunc != nil {
+		return m.CompleteFunc(ctx, task, completedAt, next)
+	}
+	return true, nil
+}
+
+func (m *MockTaskRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Task, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskRepository) ListByAssignee(ctx context.Context, tenantID, assigneeID uuid.UUID, onlyOverdue bool, now time.Time, offset, limit int) ([]Task, int64, error) {
+	if m.ListByAssigneeFunc != nil {
+		return m.ListByAssigneeFunc(ctx, tenantID, assigneeID, onlyOverdue, now, offset, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockTaskRepository) FindDueReminders(ctx context.Context, now time.Time, limit int) ([]Task, error) {
+	if m.FindDueRemindersFunc != nil {
+		return m.FindDueRemindersFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskRepository) MarkReminderNotified(ctx context.Context, id uuid.UUID, notifiedAt time.Time) (bool, error) {
+	if m.MarkReminderNotifiedFunc != nil {
+		return m.MarkReminderNotifiedFunc(ctx, id, notifiedAt)
+	}
+	return true, nil
+}
+
+func (m *MockTaskRepository) ReleaseReminder(ctx context.Context, id uuid.UUID) error {
+	if m.ReleaseReminderFunc != nil {
+		return m.ReleaseReminderFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) ListPendingReminders(ctx context.Context, tenantID, assigneeID uuid.UUID, now time.Time) ([]Task, error) {
+	if m.ListPendingRemindersFunc != nil {
+		return m.ListPendingRemindersFunc(ctx, tenantID, assigneeID, now)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskRepository) AcknowledgeReminder(ctx context.Context, tenantID, assigneeID uuid.UUID, id string, sentAt time.Time) (bool, error) {
+	if m.AcknowledgeReminderFunc != nil {
+		return m.AcknowledgeReminderFunc(ctx, tenantID, assigneeID, id, sentAt)
+	}
+	return true, nil
+}
+
+// MockNotifier para testes
+type MockNotifier struct {
+	NotifyReminderFunc func(ctx context.Context, task *Task) error
+}
+
+func (m *MockNotifier) NotifyReminder(ctx context.Context, task *Task) error {
+	if m.NotifyReminderFunc != nil {
+		return m.NotifyReminderFunc(ctx, task)
+	}
+	return nil
+}
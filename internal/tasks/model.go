@@ -0,0 +1,71 @@
+package tasks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+func (p Priority) Valid() bool {
+	switch p {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusCompleted Status = "completed"
+)
+
+type Task struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID    uuid.UUID `gorm:"type:uuid;not null;index:idx_tasks_assignee,priority:1" json:"tenant_id"`
+	AssigneeID  uuid.UUID `gorm:"type:uuid;not null;index:idx_tasks_assignee,priority:2" json:"assignee_id"`
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null" json:"created_by_id"`
+	Title       string    `gorm:"type:varchar(255);not null" json:"title"`
+	Description string    `gorm:"type:text" json:"description"`
+	Priority    Priority  `gorm:"type:varchar(16);not null" json:"priority"`
+	Status      Status    `gorm:"type:varchar(16);not null;index:idx_tasks_assignee,priority:3" json:"status"`
+	DueAt       time.Time `gorm:"not null;index" json:"due_at"`
+
+	// Lembrete: ReminderNotifiedAt marca o aviso em tempo real e ReminderSentAt a
+	// confirmação do responsável; até ela o lembrete segue em GET /tasks/reminders
+	RemindAt           *time.Time `gorm:"index" json:"remind_at,omitempty"`
+	ReminderNotifiedAt *time.Time `json:"reminder_notified_at,omitempty"`
+	ReminderSentAt     *time.Time `json:"reminder_sent_at,omitempty"`
+
+	// Recorrência (RRULE) - cada ocorrência é uma task própria da mesma série; o
+	// índice único impede que duas conclusões criem a mesma ocorrência
+	RecurrenceRule string     `gorm:"type:varchar(255)" json:"recurrence_rule,omitempty"`
+	SeriesID       *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_tasks_series_occurrence,priority:1" json:"series_id,omitempty"`
+	Occurrence     int        `gorm:"uniqueIndex:idx_tasks_series_occurrence,priority:2" json:"occurrence,omitempty"`
+
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (t *Task) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.Status == StatusOpen && t.DueAt.Before(now)
+}
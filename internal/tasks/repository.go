@@ -0,0 +1,299 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type taskRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newTaskRepositoryBase(db *gorm.DB) *taskRepositoryBase {
+	return &taskRepositoryBase{db: db}
+}
+
+func (r *taskRepositoryBase) create(ctx context.Context, task *Task) error {
+	return r.db.WithContext(ctx).Create(task).Error
+}
+
+func (r *taskRepositoryBase) update(ctx context.Context, task *Task) error {
+	return r.db.WithContext(ctx).Save(task).Error
+}
+
+// complete conclui a task só se ela ainda estiver aberta e cria a próxima ocorrência
+// na mesma transação: dois pedidos simultâneos não geram duas ocorrências e uma
+// falha ao criar a próxima não deixa a série interrompida
+func (r *taskRepositoryBase) complete(ctx context.Context, task *Task, completedAt time.Time, next *Task) (bool, error) {
+	completed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Task{}).
+			Where("id = ? AND tenant_id = ? AND status = ?", task.ID, task.TenantID, StatusOpen).
+			Updates(map[string]interface{}{"status": StatusCompleted, "completed_at": completedAt})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		completed = true
+
+		if next == nil {
+			return nil
+		}
+		return tx.Create(next).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return completed, nil
+}
+
+func (r *taskRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Task, error) {
+	var task Task
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *taskRepositoryBase) listByAssignee(ctx context.Context, tenantID, assigneeID uuid.UUID, onlyOverdue bool, now time.Time, offset, limit int) ([]Task, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Task{}).
+		Where("tenant_id = ? AND assignee_id = ? AND status = ?", tenantID, assigneeID, StatusOpen)
+	if onlyOverdue {
+		query = query.Where("due_at < ?", now)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []Task
+	err := query.Order("due_at ASC, id ASC").Offset(offset).Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *taskRepositoryBase) findDueReminders(ctx context.Context, now time.Time, limit int) ([]Task, error) {
+	var items []Task
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND remind_at IS NOT NULL AND remind_at <= ? AND reminder_notified_at IS NULL AND reminder_sent_at IS NULL", StatusOpen, now).
+		Order("remind_at ASC").
+		Limit(limit).
+		Find(&items).Error
+	return items, err
+}
+
+// markReminderNotified só atualiza se ainda não foi avisado, evitando avisos duplicados
+func (r *taskRepositoryBase) markReminderNotified(ctx context.Context, id uuid.UUID, notifiedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Task{}).
+		Where("id = ? AND reminder_notified_at IS NULL", id).
+		Update("reminder_notified_at", notifiedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// releaseReminder só é chamado por quem fez a marcação: enquanto ela existe
+// nenhuma outra instância pega o lembrete
+func (r *taskRepositoryBase) releaseReminder(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&Task{}).
+		Where("id = ?", id).
+		Update("reminder_notified_at", nil).Error
+}
+
+func (r *taskRepositoryBase) listPendingReminders(ctx context.Context, tenantID, assigneeID uuid.UUID, now time.Time) ([]Task, error) {
+	var items []Task
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND assignee_id = ? AND status = ?", tenantID, assigneeID, StatusOpen).
+		Where("remind_at IS NOT NULL AND remind_at <= ? AND reminder_sent_at IS NULL", now).
+		Order("remind_at ASC").
+		Find(&items).Error
+	return items, err
+}
+
+// acknowledgeReminder só confirma lembretes vencidos do próprio responsável
+func (r *taskRepositoryBase) acknowledgeReminder(ctx context.Context, tenantID, assigneeID uuid.UUID, id string, sentAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Task{}).
+		Where("tenant_id = ? AND assignee_id = ? AND id = ?", tenantID, assigneeID, id).
+		Where("remind_at IS NOT NULL AND remind_at <= ? AND reminder_sent_at IS NULL", sentAt).
+		Update("reminder_sent_at", sentAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Repository com telemetria (decorator)
+type taskRepository struct {
+	base      *taskRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewTaskRepository(db *gorm.DB, telemetry telemetry.TelemetryService) TaskRepository {
+	return &taskRepository{
+		base:      newTaskRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *taskRepository) Create(ctx context.Context, task *Task) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", task.TenantID.String())
+
+	err := r.base.create(ctx, task)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+
+	r.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "repository.task.create.success",
+		Value: 1,
+		Tags:  map[string]string{"priority": string(task.Priority)},
+	})
+
+	return nil
+}
+
+func (r *taskRepository) Update(ctx context.Context, task *Task) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.update")
+	defer span.End()
+
+	span.SetTag("task_id", task.ID.String())
+
+	err := r.base.update(ctx, task)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *taskRepository) Complete(ctx context.Context, task *Task, completedAt time.Time, next *Task) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.complete")
+	defer span.End()
+
+	span.SetTag("task_id", task.ID.String())
+
+	completed, err := r.base.complete(ctx, task, completedAt, next)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return completed, nil
+}
+
+func (r *taskRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Task, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.find_by_id")
+	defer span.End()
+
+	span.SetTag("task_id", id)
+
+	task, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *taskRepository) ListByAssignee(ctx context.Context, tenantID, assigneeID uuid.UUID, onlyOverdue bool, now time.Time, offset, limit int) ([]Task, int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.list_by_assignee")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+	span.SetTag("assignee_id", assigneeID.String())
+
+	items, total, err := r.base.listByAssignee(ctx, tenantID, assigneeID, onlyOverdue, now, offset, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *taskRepository) FindDueReminders(ctx context.Context, now time.Time, limit int) ([]Task, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.find_due_reminders")
+	defer span.End()
+
+	items, err := r.base.findDueReminders(ctx, now, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *taskRepository) MarkReminderNotified(ctx context.Context, id uuid.UUID, notifiedAt time.Time) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.mark_reminder_notified")
+	defer span.End()
+
+	span.SetTag("task_id", id.String())
+
+	updated, err := r.base.markReminderNotified(ctx, id, notifiedAt)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return updated, nil
+}
+
+func (r *taskRepository) ReleaseReminder(ctx context.Context, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.release_reminder")
+	defer span.End()
+
+	span.SetTag("task_id", id.String())
+
+	err := r.base.releaseReminder(ctx, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *taskRepository) ListPendingReminders(ctx context.Context, tenantID, assigneeID uuid.UUID, now time.Time) ([]Task, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.list_pending_reminders")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+	span.SetTag("assignee_id", assigneeID.String())
+
+	items, err := r.base.listPendingReminders(ctx, tenantID, assigneeID, now)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *taskRepository) AcknowledgeReminder(ctx context.Context, tenantID, assigneeID uuid.UUID, id string, sentAt time.Time) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.task.acknowledge_reminder")
+	defer span.End()
+
+	span.SetTag("task_id", id)
+
+	updated, err := r.base.acknowledgeReminder(ctx, tenantID, assigneeID, id, sentAt)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return updated, nil
+}
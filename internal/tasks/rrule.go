@@ -0,0 +1,175 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Subconjunto suportado da RFC 5545:
+// FREQ=DAILY|WEEKLY|MONTHLY|YEARLY, INTERVAL, COUNT, UNTIL e BYDAY (apenas WEEKLY)
+type Frequency string
+
+const (
+	FreqDaily   Frequency = "DAILY"
+	FreqWeekly  Frequency = "WEEKLY"
+	FreqMonthly Frequency = "MONTHLY"
+	FreqYearly  Frequency = "YEARLY"
+)
+
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+type RecurrenceRule struct {
+	Freq     Frequency
+	Interval int
+	Count    int
+	Until    *time.Time
+	ByDay    []time.Weekday
+}
+
+// ParseRecurrenceRule interpreta strings como "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE".
+// O prefixo "RRULE:" é opcional.
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, ErrInvalidRecurrence
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq := Frequency(strings.ToUpper(val))
+			switch freq {
+			case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+				rule.Freq = freq
+			default:
+				return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRecurrence, val)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRecurrence)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRecurrence)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: UNTIL %q", ErrInvalidRecurrence, val)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("%w: BYDAY %q", ErrInvalidRecurrence, day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+			sort.Slice(rule.ByDay, func(i, j int) bool { return rule.ByDay[i] < rule.ByDay[j] })
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %q", ErrInvalidRecurrence, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrence)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRecurrence)
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != FreqWeekly {
+		return nil, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRecurrence)
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid date")
+}
+
+// Next calcula a ocorrência seguinte a partir da ocorrência atual.
+// occurrence é o número (1-based) da ocorrência atual, usado para respeitar COUNT.
+// Retorna false quando a série terminou.
+func (r *RecurrenceRule) Next(current time.Time, occurrence int) (time.Time, bool) {
+	if r.Count > 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	switch r.Freq {
+	case FreqDaily:
+		next = current.AddDate(0, 0, r.Interval)
+	case FreqWeekly:
+		next = r.nextWeekly(current)
+	case FreqMonthly:
+		next = addMonthsClamped(current, r.Interval)
+	case FreqYearly:
+		next = addMonthsClamped(current, 12*r.Interval)
+	}
+
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r *RecurrenceRule) nextWeekly(current time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return current.AddDate(0, 0, 7*r.Interval)
+	}
+
+	// Próximo dia listado na mesma semana
+	for _, day := range r.ByDay {
+		if day > current.Weekday() {
+			return current.AddDate(0, 0, int(day-current.Weekday()))
+		}
+	}
+
+	// Primeiro dia listado, pulando INTERVAL semanas a partir do domingo da semana atual
+	weekStart := current.AddDate(0, 0, -int(current.Weekday()))
+	return weekStart.AddDate(0, 0, 7*r.Interval+int(r.ByDay[0]))
+}
+
+// addMonthsClamped evita que 31/01 + 1 mês vire 03/03
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := firstOfMonth.AddDate(0, months, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return target.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,134 @@
+package tasks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+)
+
+const reminderBatchSize = 100
+
+// ReminderScheduler verifica periodicamente as tasks com lembrete vencido
+// e avisa o responsável pelo Notifier. Quem estava offline recebe o lembrete
+// depois, em GET /tasks/reminders, até confirmá-lo.
+type ReminderScheduler struct {
+	taskRepo  TaskRepository
+	notifier  Notifier
+	telemetry telemetry.TelemetryService
+	interval  time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewReminderScheduler(taskRepo TaskRepository, notifier Notifier, telemetry telemetry.TelemetryService, interval time.Duration) *ReminderScheduler {
+	return &ReminderScheduler{
+		taskRepo:  taskRepo,
+		notifier:  notifier,
+		telemetry: telemetry,
+		interval:  interval,
+	}
+}
+
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.RunOnce(ctx, now)
+			}
+		}
+	}()
+}
+
+func (s *ReminderScheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// RunOnce avisa os lembretes vencidos até now e retorna quantos foram avisados
+func (s *ReminderScheduler) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "tasks.reminder_scheduler.run")
+	defer span.End()
+
+	due, err := s.taskRepo.FindDueReminders(ctx, now, reminderBatchSize)
+	if err != nil {
+		span.SetError(err)
+		return 0, err
+	}
+
+	notified := 0
+	for i := range due {
+		task := &due[i]
+
+		// Marca antes de notificar para que outra instância não avise o mesmo lembrete;
+		// se a notificação falhar a marca é desfeita e a próxima rodada tenta de novo
+		claimed, err := s.taskRepo.MarkReminderNotified(ctx, task.ID, now)
+		if err != nil {
+			span.SetError(err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		task.ReminderNotifiedAt = &now
+
+		if err := s.notifier.NotifyReminder(ctx, task); err != nil {
+			span.SetError(err)
+			if err := s.taskRepo.ReleaseReminder(ctx, task.ID); err != nil {
+				span.SetError(err)
+			}
+			continue
+		}
+		notified++
+	}
+
+	s.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "tasks.reminders.notified",
+		Value: float64(notified),
+	})
+
+	return notified, nil
+}
+
+// realtimeNotifier avisa as conexões abertas do responsável; sem nenhuma o
+// lembrete continua pendente até ele buscar e confirmar
+type realtimeNotifier struct {
+	publisher realtime.Publisher
+	telemetry telemetry.TelemetryService
+}
+
+func NewRealtimeNotifier(publisher realtime.Publisher, telemetry telemetry.TelemetryService) Notifier {
+	return &realtimeNotifier{publisher: publisher, telemetry: telemetry}
+}
+
+func (n *realtimeNotifier) NotifyReminder(ctx context.Context, task *Task) error {
+	event := realtime.NewEvent(task.TenantID, realtime.EventTaskReminder, task)
+	event.UserID = &task.AssigneeID
+	n.publisher.Publish(ctx, event)
+
+	return n.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "tasks.reminder",
+		Properties: map[string]interface{}{
+			"task_id":     task.ID.String(),
+			"tenant_id":   task.TenantID.String(),
+			"assignee_id": task.AssigneeID.String(),
+		},
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,226 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTaskNotFound         = errors.New("task not found")
+	ErrInvalidPriority      = errors.New("invalid priority")
+	ErrInvalidAssignee      = errors.New("assignee not found in tenant")
+	ErrReminderAfterDue     = errors.New("remind_at must be before due_at")
+	ErrTaskAlreadyCompleted = errors.New("task already completed")
+)
+
+type taskService struct {
+	taskRepo  TaskRepository
+	userRepo  auth.UserRepository
+	telemetry telemetry.TelemetryService
+}
+
+func NewTaskService(taskRepo TaskRepository, userRepo auth.UserRepository, telemetry telemetry.TelemetryService) TaskService {
+	return &taskService{
+		taskRepo:  taskRepo,
+		userRepo:  userRepo,
+		telemetry: telemetry,
+	}
+}
+
+func (s *taskService) CreateTask(ctx context.Context, tenantID, userID uuid.UUID, req CreateTaskRequest) (*Task, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "tasks.create_task")
+	defer span.End()
+
+	priority := req.Priority
+	if priority == "" {
+		priority = PriorityMedium
+	}
+	if !priority.Valid() {
+		return nil, ErrInvalidPriority
+	}
+
+	if req.RecurrenceRule != "" {
+		if _, err := ParseRecurrenceRule(req.RecurrenceRule); err != nil {
+			return nil, err
+		}
+	}
+	if req.RemindAt != nil && req.RemindAt.After(req.DueAt) {
+		return nil, ErrReminderAfterDue
+	}
+
+	// Por padrão a task fica com quem criou
+	assigneeID := userID
+	if req.AssigneeID != "" {
+		assignee, err := s.userRepo.FindByID(ctx, req.AssigneeID)
+		if err != nil {
+			span.SetError(err)
+			return nil, err
+		}
+		if assignee == nil || assignee.TenantID != tenantID {
+			return nil, ErrInvalidAssignee
+		}
+		assigneeID = assignee.ID
+	}
+
+	task := &Task{
+		TenantID:       tenantID,
+		AssigneeID:     assigneeID,
+		CreatedByID:    userID,
+		Title:          req.Title,
+		Description:    req.Description,
+		Priority:       priority,
+		Status:         StatusOpen,
+		DueAt:          req.DueAt,
+		RemindAt:       req.RemindAt,
+		RecurrenceRule: req.RecurrenceRule,
+	}
+	if task.RecurrenceRule != "" {
+		seriesID := uuid.New()
+		task.ID = seriesID
+		task.SeriesID = &seriesID
+		task.Occurrence = 1
+	}
+
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "tasks.created",
+		Properties: map[string]interface{}{
+			"task_id":   task.ID.String(),
+			"tenant_id": tenantID.String(),
+			"recurring": task.RecurrenceRule != "",
+		},
+		Timestamp: time.Now(),
+	})
+
+	return task, nil
+}
+
+func (s *taskService) GetTask(ctx context.Context, tenantID uuid.UUID, id string) (*Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// CompleteTask conclui a task e, se for recorrente, cria a próxima ocorrência
+func (s *taskService) CompleteTask(ctx context.Context, tenantID uuid.UUID, id string) (*Task, *Task, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "tasks.complete_task")
+	defer span.End()
+
+	task, err := s.GetTask(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if task.Status == StatusCompleted {
+		return nil, nil, ErrTaskAlreadyCompleted
+	}
+
+	next, err := s.nextOccurrence(task)
+	if err != nil {
+		span.SetError(err)
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	completed, err := s.taskRepo.Complete(ctx, task, now, next)
+	if err != nil {
+		span.SetError(err)
+		return nil, nil, err
+	}
+	if !completed {
+		// Outro pedido concluiu a task entre a leitura e a atualização
+		return nil, nil, ErrTaskAlreadyCompleted
+	}
+	task.Status = StatusCompleted
+	task.CompletedAt = &now
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "tasks.completed",
+		Properties: map[string]interface{}{
+			"task_id":   task.ID.String(),
+			"tenant_id": tenantID.String(),
+		},
+		Timestamp: now,
+	})
+
+	return task, next, nil
+}
+
+// nextOccurrence monta a próxima ocorrência da série; nil se não for recorrente ou se a série terminou
+func (s *taskService) nextOccurrence(task *Task) (*Task, error) {
+	if task.RecurrenceRule == "" {
+		return nil, nil
+	}
+
+	rule, err := ParseRecurrenceRule(task.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	dueAt, ok := rule.Next(task.DueAt, task.Occurrence)
+	if !ok {
+		return nil, nil
+	}
+
+	next := &Task{
+		TenantID:       task.TenantID,
+		AssigneeID:     task.AssigneeID,
+		CreatedByID:    task.CreatedByID,
+		Title:          task.Title,
+		Description:    task.Description,
+		Priority:       task.Priority,
+		Status:         StatusOpen,
+		DueAt:          dueAt,
+		RecurrenceRule: task.RecurrenceRule,
+		SeriesID:       task.SeriesID,
+		Occurrence:     task.Occurrence + 1,
+	}
+
+	// Mantém a mesma antecedência do lembrete original
+	if task.RemindAt != nil {
+		remindAt := dueAt.Add(task.RemindAt.Sub(task.DueAt))
+		next.RemindAt = &remindAt
+	}
+
+	return next, nil
+}
+
+func (s *taskService) MyTasks(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]Task, int64, error) {
+	return s.taskRepo.ListByAssignee(ctx, tenantID, userID, false, time.Now(), offset, limit)
+}
+
+func (s *taskService) OverdueTasks(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]Task, int64, error) {
+	return s.taskRepo.ListByAssignee(ctx, tenantID, userID, true, time.Now(), offset, limit)
+}
+
+func (s *taskService) PendingReminders(ctx context.Context, tenantID, userID uuid.UUID) ([]Task, error) {
+	return s.taskRepo.ListPendingReminders(ctx, tenantID, userID, time.Now())
+}
+
+// AcknowledgeReminder registra que o responsável recebeu o lembrete
+func (s *taskService) AcknowledgeReminder(ctx context.Context, tenantID, userID uuid.UUID, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrTaskNotFound
+	}
+
+	acknowledged, err := s.taskRepo.AcknowledgeReminder(ctx, tenantID, userID, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !acknowledged {
+		return ErrTaskNotFound
+	}
+	return nil
+}
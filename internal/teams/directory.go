@@ -0,0 +1,25 @@
+package teams
+
+import (
+	"context"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+// teamDirectory valida o time padrão dos canais de WhatsApp
+type teamDirectory struct {
+	teamRepo TeamRepository
+}
+
+func NewTeamDirectory(teamRepo TeamRepository) whatsapp.TeamDirectory {
+	return &teamDirectory{teamRepo: teamRepo}
+}
+
+func (d *teamDirectory) TeamInTenant(ctx context.Context, tenantID, teamID uuid.UUID) (bool, error) {
+	team, err := d.teamRepo.FindByID(ctx, tenantID, teamID.String())
+	if err != nil {
+		return false, err
+	}
+	return team != nil, nil
+}
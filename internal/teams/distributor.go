@@ -0,0 +1,102 @@
+package teams
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/chatbot"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+)
+
+// Distributor esvazia periodicamente as filas dos times: pega as conversas que
+// esperavam atendente online, vaga por limite de carga ou o fim do chatbot
+type Distributor struct {
+	*queue
+	interval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDistributor(
+	teamRepo TeamRepository,
+	presenceRepo PresenceRepository,
+	transferRepo TransferRepository,
+	conversationRepo whatsapp.ConversationRepository,
+	sessionRepo chatbot.SessionRepository,
+	publisher realtime.Publisher,
+	telemetry telemetry.TelemetryService,
+	interval time.Duration,
+) *Distributor {
+	return &Distributor{
+		queue: &queue{
+			teamRepo:         teamRepo,
+			presenceRepo:     presenceRepo,
+			transferRepo:     transferRepo,
+			conversationRepo: conversationRepo,
+			sessionRepo:      sessionRepo,
+			publisher:        publisher,
+			telemetry:        telemetry,
+		},
+		interval: interval,
+	}
+}
+
+func (d *Distributor) Start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				d.RunOnce(ctx, now)
+			}
+		}
+	}()
+}
+
+func (d *Distributor) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+// RunOnce distribui as filas de todos os times e retorna quantas conversas foram atribuídas
+func (d *Distributor) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	span, ctx := d.telemetry.StartSpan(ctx, "teams.distributor.run")
+	defer span.End()
+
+	teams, err := d.teamRepo.ListAll(ctx)
+	if err != nil {
+		span.SetError(err)
+		return 0, err
+	}
+
+	assigned := 0
+	for i := range teams {
+		count, err := d.drain(ctx, &teams[i], now)
+		if err != nil {
+			span.SetError(err)
+		}
+		assigned += count
+	}
+
+	d.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "teams.distributor.assigned",
+		Value: float64(assigned),
+	})
+
+	return assigned, nil
+}
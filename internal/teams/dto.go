@@ -0,0 +1,42 @@
+package teams
+
+import "github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+
+// SaveTeamRequest cria ou substitui o time; distribution vazia usa round_robin
+type SaveTeamRequest struct {
+	Name              string       `json:"name" binding:"required"`
+	Description       string       `json:"description"`
+	Distribution      Distribution `json:"distribution"`
+	MaxActivePerAgent int          `json:"max_active_per_agent"`
+}
+
+type AddMemberRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+type SetPresenceRequest struct {
+	Status PresenceStatus `json:"status" binding:"required"`
+}
+
+// TransferRequest aceita atendente, time ou ambos; só o time devolve a conversa para a fila
+type TransferRequest struct {
+	ToUserID *string `json:"to_user_id" binding:"omitempty,uuid"`
+	ToTeamID *string `json:"to_team_id" binding:"omitempty,uuid"`
+	Reason   string  `json:"reason"`
+}
+
+type TeamListResponse struct {
+	Items []Team `json:"items"`
+}
+
+type QueueResponse struct {
+	Items []whatsapp.Conversation `json:"items"`
+}
+
+type PresenceListResponse struct {
+	Items []Presence `json:"items"`
+}
+
+type TransferListResponse struct {
+	Items []Transfer `json:"items"`
+}
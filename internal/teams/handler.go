@@ -0,0 +1,202 @@
+package teams
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TeamHandler struct {
+	teamService TeamService
+}
+
+func NewTeamHandler(teamService TeamService) *TeamHandler {
+	return &TeamHandler{
+		teamService: teamService,
+	}
+}
+
+func (h *TeamHandler) List(c *gin.Context) {
+	teams, err := h.teamService.ListTeams(c.Request.Context(), web.TenantID(c))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, TeamListResponse{Items: teams})
+}
+
+func (h *TeamHandler) Get(c *gin.Context) {
+	team, err := h.teamService.GetTeam(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) Create(c *gin.Context) {
+	var req SaveTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(c.Request.Context(), web.TenantID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, team)
+}
+
+func (h *TeamHandler) Update(c *gin.Context) {
+	var req SaveTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(c.Request.Context(), web.TenantID(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) Delete(c *gin.Context) {
+	if err := h.teamService.DeleteTeam(c.Request.Context(), web.TenantID(c), c.Param("id")); err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TeamHandler) AddMember(c *gin.Context) {
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	team, err := h.teamService.AddMember(c.Request.Context(), web.TenantID(c), c.Param("id"), uuid.MustParse(req.UserID))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, "invalid user_id"))
+		return
+	}
+
+	team, err := h.teamService.RemoveMember(c.Request.Context(), web.TenantID(c), c.Param("id"), userID)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// Queue lista as conversas aguardando atendente: ?limit=50
+func (h *TeamHandler) Queue(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+
+	items, err := h.teamService.Queue(c.Request.Context(), web.TenantID(c), c.Param("id"), limit)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, QueueResponse{Items: items})
+}
+
+func (h *TeamHandler) ListPresence(c *gin.Context) {
+	items, err := h.teamService.ListPresence(c.Request.Context(), web.TenantID(c))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, PresenceListResponse{Items: items})
+}
+
+// SetPresence altera o status do usuário autenticado; o front reenvia como heartbeat
+func (h *TeamHandler) SetPresence(c *gin.Context) {
+	var req SetPresenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	presence, err := h.teamService.SetPresence(c.Request.Context(), web.TenantID(c), web.UserID(c), req.Status)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, presence)
+}
+
+func (h *TeamHandler) Transfer(c *gin.Context) {
+	var req TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	conversation, err := h.teamService.Transfer(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+func (h *TeamHandler) ListTransfers(c *gin.Context) {
+	items, err := h.teamService.ListTransfers(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, TransferListResponse{Items: items})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrTeamNotFound),
+		errors.Is(err, whatsapp.ErrConversationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidTeam),
+		errors.Is(err, ErrInvalidPresence),
+		errors.Is(err, ErrInvalidTransfer):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrInvalidMember),
+		errors.Is(err, ErrNotTeamMember):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrTransferNoChange):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,66 @@
+package teams
+
+import (
+	"context"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+type TeamRepository interface {
+	Create(ctx context.Context, team *Team) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Team, error)
+	List(ctx context.Context, tenantID uuid.UUID) ([]Team, error)
+	// ListAll percorre os times de todos os tenants (usado pelo Distributor)
+	ListAll(ctx context.Context) ([]Team, error)
+	Update(ctx context.Context, team *Team) error
+	// Delete remove o time e os membros
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+	AddMember(ctx context.Context, member *TeamMember) error
+	RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error
+	// ListMemberIDs retorna os membros na ordem de entrada no time
+	ListMemberIDs(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error)
+	IsMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error)
+	// ListTeamIDsByUser retorna os times de que o usuário participa
+	ListTeamIDsByUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	// SetCursor avança o round-robin para o último atendente que recebeu conversa
+	SetCursor(ctx context.Context, teamID, userID uuid.UUID) error
+}
+
+type PresenceRepository interface {
+	// Save cria ou atualiza a presença do usuário
+	Save(ctx context.Context, presence *Presence) error
+	FindByUser(ctx context.Context, userID uuid.UUID) (*Presence, error)
+	ListByUsers(ctx context.Context, userIDs []uuid.UUID) ([]Presence, error)
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]Presence, error)
+}
+
+type TransferRepository interface {
+	Create(ctx context.Context, transfer *Transfer) error
+	ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Transfer, error)
+}
+
+type TeamService interface {
+	ListTeams(ctx context.Context, tenantID uuid.UUID) ([]Team, error)
+	GetTeam(ctx context.Context, tenantID uuid.UUID, id string) (*Team, error)
+	CreateTeam(ctx context.Context, tenantID uuid.UUID, req SaveTeamRequest) (*Team, error)
+	UpdateTeam(ctx context.Context, tenantID uuid.UUID, id string, req SaveTeamRequest) (*Team, error)
+	DeleteTeam(ctx context.Context, tenantID uuid.UUID, id string) error
+	AddMember(ctx context.Context, tenantID uuid.UUID, id string, userID uuid.UUID) (*Team, error)
+	RemoveMember(ctx context.Context, tenantID uuid.UUID, id string, userID uuid.UUID) (*Team, error)
+	// Queue lista as conversas do time aguardando atendente
+	Queue(ctx context.Context, tenantID uuid.UUID, id string, limit int) ([]whatsapp.Conversation, error)
+
+	// SetPresence também vale como heartbeat; ao ficar online o atendente recebe a fila
+	SetPresence(ctx context.Context, tenantID, userID uuid.UUID, status PresenceStatus) (*Presence, error)
+	ListPresence(ctx context.Context, tenantID uuid.UUID) ([]Presence, error)
+
+	// Transfer move a conversa para outro atendente e/ou time e registra o histórico
+	Transfer(ctx context.Context, tenantID, userID uuid.UUID, conversationID string, req TransferRequest) (*whatsapp.Conversation, error)
+	ListTransfers(ctx context.Context, tenantID uuid.UUID, conversationID string) ([]Transfer, error)
+}
+
+// Router coloca as conversas novas na fila do time do canal e as distribui
+type Router interface {
+	// HandleEvent é registrado como listener do WebhookService, depois do chatbot
+	HandleEvent(ctx context.Context, event whatsapp.Event)
+}
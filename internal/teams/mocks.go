@@ -0,0 +1,162 @@
+package teams
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// MockTeamRepository para testes
+type MockTeamRepository struct {
+	CreateFunc            func(ctx context.Context, team *Team) error
+	FindByIDFunc          func(ctx context.Context, tenantID uuid.UUID, id string) (*Team, error)
+	ListFunc              func(ctx context.Context, tenantID uuid.UUID) ([]Team, error)
+	ListAllFunc           func(ctx context.Context) ([]Team, error)
+	UpdateFunc            func(ctx context.Context, team *Team) error
+	DeleteFunc            func(ctx context.Context, tenantID, id uuid.UUID) error
+	AddMemberFunc         func(ctx context.Context, member *TeamMember) error
+	RemoveMemberFunc      func(ctx context.Context, teamID, userID uuid.UUID) error
+	ListMemberIDsFunc     func(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error)
+	IsMemberFunc          func(ctx context.Context, teamID, userID uuid.UUID) (bool, error)
+	ListTeamIDsByUserFunc func(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	SetCursorFunc         func(ctx context.Context, teamID, userID uuid.UUID) error
+}
+
+func (m *MockTeamRepository) Create(ctx context.Context, team *Team) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, team)
+	}
+	return nil
+}
+
+func (m *MockTeamRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Team, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamRepository) List(ctx context.Context, tenantID uuid.UUID) ([]Team, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, tenantID)
+	}
+	return []Team{}, nil
+}
+
+func (m *MockTeamRepository) ListAll(ctx context.Context) ([]Team, error) {
+	if m.ListAllFunc != nil {
+		return m.ListAllFunc(ctx)
+	}
+	return []Team{}, nil
+}
+
+func (m *MockTeamRepository) Update(ctx context.Context, team *Team) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, team)
+	}
+	return nil
+}
+
+func (m *MockTeamRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID, id)
+	}
+	return nil
+}
+
+func (m *MockTeamRepository) AddMember(ctx context.Context, member *TeamMember) error {
+	if m.AddMemberFunc != nil {
+		return m.AddMemberFunc(ctx, member)
+	}
+	return nil
+}
+
+func (m *MockTeamRepository) RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	if m.RemoveMemberFunc != nil {
+		return m.RemoveMemberFunc(ctx, teamID, userID)
+	}
+	return nil
+}
+
+func (m *MockTeamRepository) ListMemberIDs(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error) {
+	if m.ListMemberIDsFunc != nil {
+		return m.ListMemberIDsFunc(ctx, teamID)
+	}
+	return []uuid.UUID{}, nil
+}
+
+func (m *MockTeamRepository) IsMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error) {
+	if m.IsMemberFunc != nil {
+		return m.IsMemberFunc(ctx, teamID, userID)
+	}
+	return false, nil
+}
+
+func (m *MockTeamRepository) ListTeamIDsByUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	if m.ListTeamIDsByUserFunc != nil {
+		return m.ListTeamIDsByUserFunc(ctx, userID)
+	}
+	return []uuid.UUID{}, nil
+}
+
+func (m *MockTeamRepository) SetCursor(ctx context.Context, teamID, userID uuid.UUID) error {
+	if m.SetCursorFunc != nil {
+		return m.SetCursorFunc(ctx, teamID, userID)
+	}
+	return nil
+}
+
+// MockPresenceRepository para testes
+type MockPresenceRepository struct {
+	SaveFunc         func(ctx context.Context, presence *Presence) error
+	FindByUserFunc   func(ctx context.Context, userID uuid.UUID) (*Presence, error)
+	ListByUsersFunc  func(ctx context.Context, userIDs []uuid.UUID) ([]Presence, error)
+	ListByTenantFunc func(ctx context.Context, tenantID uuid.UUID) ([]Presence, error)
+}
+
+func (m *MockPresenceRepository) Save(ctx context.Context, presence *Presence) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, presence)
+	}
+	return nil
+}
+
+func (m *MockPresenceRepository) FindByUser(ctx context.Context, userID uuid.UUID) (*Presence, error) {
+	if m.FindByUserFunc != nil {
+		return m.FindByUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockPresenceRepository) ListByUsers(ctx context.Context, userIDs []uuid.UUID) ([]Presence, error) {
+	if m.ListByUsersFunc != nil {
+		return m.ListByUsersFunc(ctx, userIDs)
+	}
+	return []Presence{}, nil
+}
+
+func (m *MockPresenceRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]Presence, error) {
+	if m.ListByTenantFunc != nil {
+		return m.ListByTenantFunc(ctx, tenantID)
+	}
+	return []Presence{}, nil
+}
+
+// MockTransferRepository para testes
+type MockTransferRepository struct {
+	CreateFunc             func(ctx context.Context, transfer *Transfer) error
+	ListByConversationFunc func(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Transfer, error)
+}
+
+func (m *MockTransferRepository) Create(ctx context.Context, transfer *Transfer) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, transfer)
+	}
+	return nil
+}
+
+func (m *MockTransferRepository) ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Transfer, error) {
+	if m.ListByConversationFunc != nil {
+		return m.ListByConversationFunc(ctx, tenantID, conversationID)
+	}
+	return []Transfer{}, nil
+}
@@ -0,0 +1,146 @@
+package teams
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PresenceTimeout é o tempo sem heartbeat depois do qual o atendente conta como offline
+const PresenceTimeout = 5 * time.Minute
+
+var (
+	ErrTeamNotFound     = errors.New("team not found")
+	ErrInvalidTeam      = errors.New("invalid team")
+	ErrInvalidMember    = errors.New("user does not belong to tenant")
+	ErrNotTeamMember    = errors.New("user is not a member of the team")
+	ErrInvalidPresence  = errors.New("invalid presence status")
+	ErrInvalidTransfer  = errors.New("transfer requires to_user_id or to_team_id")
+	ErrTransferNoChange = errors.New("conversation already with this agent and team")
+)
+
+// Distribution define como a fila escolhe o atendente entre os online
+type Distribution string
+
+const (
+	DistributionRoundRobin Distribution = "round_robin"
+	DistributionLeastBusy  Distribution = "least_busy"
+)
+
+func (d Distribution) Valid() bool {
+	switch d {
+	case DistributionRoundRobin, DistributionLeastBusy:
+		return true
+	}
+	return false
+}
+
+// Team agrupa atendentes do tenant e é a fila das conversas roteadas para ele
+type Team struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID     uuid.UUID    `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name         string       `gorm:"type:varchar(255);not null" json:"name"`
+	Description  string       `gorm:"type:text" json:"description,omitempty"`
+	Distribution Distribution `gorm:"type:varchar(16);not null" json:"distribution"`
+	// MaxActivePerAgent limita as conversas abertas por atendente; 0 não limita
+	MaxActivePerAgent int `gorm:"not null;default:0" json:"max_active_per_agent"`
+
+	// LastAssignedUserID é o cursor do round-robin
+	LastAssignedUserID *uuid.UUID `gorm:"type:uuid" json:"-"`
+
+	MemberIDs []uuid.UUID `gorm:"-" json:"member_ids"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (t *Team) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+func (t *Team) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTeam)
+	}
+	if !t.Distribution.Valid() {
+		return fmt.Errorf("%w: distribution must be round_robin or least_busy", ErrInvalidTeam)
+	}
+	if t.MaxActivePerAgent < 0 {
+		return fmt.Errorf("%w: max_active_per_agent must not be negative", ErrInvalidTeam)
+	}
+	return nil
+}
+
+// TeamMember liga um auth.User a um time; o mesmo usuário pode estar em vários times
+type TeamMember struct {
+	TeamID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"team_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PresenceStatus string
+
+const (
+	PresenceOnline  PresenceStatus = "online"
+	PresenceAway    PresenceStatus = "away"
+	PresenceOffline PresenceStatus = "offline"
+)
+
+func (s PresenceStatus) Valid() bool {
+	switch s {
+	case PresenceOnline, PresenceAway, PresenceOffline:
+		return true
+	}
+	return false
+}
+
+// Presence é o estado do atendente; só quem está online recebe conversas da fila
+type Presence struct {
+	UserID     uuid.UUID      `gorm:"type:uuid;primaryKey" json:"user_id"`
+	TenantID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Status     PresenceStatus `gorm:"type:varchar(16);not null" json:"status"`
+	LastSeenAt time.Time      `gorm:"not null" json:"last_seen_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// Effective considera offline quem parou de enviar heartbeat (aba fechada, queda de rede)
+func (p *Presence) Effective(now time.Time) PresenceStatus {
+	if p == nil {
+		return PresenceOffline
+	}
+	if p.Status != PresenceOffline && now.Sub(p.LastSeenAt) > PresenceTimeout {
+		return PresenceOffline
+	}
+	return p.Status
+}
+
+// Transfer registra cada mudança de atendente ou time de uma conversa
+type Transfer struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	ConversationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"conversation_id"`
+	FromUserID     *uuid.UUID `gorm:"type:uuid" json:"from_user_id,omitempty"`
+	ToUserID       *uuid.UUID `gorm:"type:uuid" json:"to_user_id,omitempty"`
+	FromTeamID     *uuid.UUID `gorm:"type:uuid" json:"from_team_id,omitempty"`
+	ToTeamID       *uuid.UUID `gorm:"type:uuid" json:"to_team_id,omitempty"`
+	Reason         string     `gorm:"type:text" json:"reason,omitempty"`
+	// Automatic marca as atribuições feitas pela fila; TransferredByID fica vazio
+	Automatic       bool       `gorm:"not null" json:"automatic"`
+	TransferredByID *uuid.UUID `gorm:"type:uuid" json:"transferred_by_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func (t *Transfer) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package teams
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository base (sem telemetria)
+type presenceRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newPresenceRepositoryBase(db *gorm.DB) *presenceRepositoryBase {
+	return &presenceRepositoryBase{db: db}
+}
+
+// save faz upsert pela chave do usuário; heartbeats frequentes não geram linhas novas
+func (r *presenceRepositoryBase) save(ctx context.Context, presence *Presence) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tenant_id", "status", "last_seen_at", "updated_at"}),
+	}).Create(presence).Error
+}
+
+func (r *presenceRepositoryBase) findByUser(ctx context.Context, userID uuid.UUID) (*Presence, error) {
+	var presence Presence
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&presence).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &presence, nil
+}
+
+func (r *presenceRepositoryBase) listByUsers(ctx context.Context, userIDs []uuid.UUID) ([]Presence, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	var items []Presence
+	err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *presenceRepositoryBase) listByTenant(ctx context.Context, tenantID uuid.UUID) ([]Presence, error) {
+	var items []Presence
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("last_seen_at DESC").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Repository com telemetria (decorator)
+type presenceRepository struct {
+	base      *presenceRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewPresenceRepository(db *gorm.DB, telemetry telemetry.TelemetryService) PresenceRepository {
+	return &presenceRepository{
+		base:      newPresenceRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *presenceRepository) Save(ctx context.Context, presence *Presence) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.presence.save")
+	defer span.End()
+
+	span.SetTag("user_id", presence.UserID.String())
+	span.SetTag("status", string(presence.Status))
+
+	err := r.base.save(ctx, presence)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *presenceRepository) FindByUser(ctx context.Context, userID uuid.UUID) (*Presence, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.presence.find_by_user")
+	defer span.End()
+
+	span.SetTag("user_id", userID.String())
+
+	presence, err := r.base.findByUser(ctx, userID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return presence, nil
+}
+
+func (r *presenceRepository) ListByUsers(ctx context.Context, userIDs []uuid.UUID) ([]Presence, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.presence.list_by_users")
+	defer span.End()
+
+	items, err := r.base.listByUsers(ctx, userIDs)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *presenceRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]Presence, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.presence.list_by_tenant")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	items, err := r.base.listByTenant(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
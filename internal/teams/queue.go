@@ -0,0 +1,198 @@
+package teams
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/chatbot"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+const queueBatchSize = 50
+
+// queue concentra a distribuição das conversas; usado pelo Router, pelo TeamService e pelo Distributor
+type queue struct {
+	teamRepo         TeamRepository
+	presenceRepo     PresenceRepository
+	transferRepo     TransferRepository
+	conversationRepo whatsapp.ConversationRepository
+	// sessionRepo pode ser nil quando o chatbot não está em uso
+	sessionRepo chatbot.SessionRepository
+	// publisher avisa os navegadores conectados; pode ser nil
+	publisher realtime.Publisher
+	telemetry telemetry.TelemetryService
+}
+
+// roster é a foto dos atendentes do time no momento da distribuição
+type roster struct {
+	members []uuid.UUID
+	online  map[uuid.UUID]bool
+	loads   map[uuid.UUID]int
+}
+
+func (q *queue) roster(ctx context.Context, team *Team, now time.Time) (*roster, error) {
+	members, err := q.teamRepo.ListMemberIDs(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	presences, err := q.presenceRepo.ListByUsers(ctx, members)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &roster{members: members, online: map[uuid.UUID]bool{}}
+	var online []uuid.UUID
+	for i := range presences {
+		if presences[i].Effective(now) == PresenceOnline {
+			r.online[presences[i].UserID] = true
+			online = append(online, presences[i].UserID)
+		}
+	}
+
+	r.loads, err = q.conversationRepo.CountActiveByAssignee(ctx, team.TenantID, online)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// pick percorre os membros a partir do cursor do round-robin; no least_busy o
+// cursor só desempata entre os atendentes com a mesma carga
+func (q *queue) pick(team *Team, r *roster) *uuid.UUID {
+	start := 0
+	if team.LastAssignedUserID != nil {
+		for i, id := range r.members {
+			if id == *team.LastAssignedUserID {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	var picked *uuid.UUID
+	for i := range r.members {
+		id := r.members[(start+i)%len(r.members)]
+		if !r.online[id] {
+			continue
+		}
+		if team.MaxActivePerAgent > 0 && r.loads[id] >= team.MaxActivePerAgent {
+			continue
+		}
+		if team.Distribution == DistributionRoundRobin {
+			return &id
+		}
+		if picked == nil || r.loads[id] < r.loads[*picked] {
+			candidate := id
+			picked = &candidate
+		}
+	}
+	return picked
+}
+
+// botActive indica que o chatbot ainda está atendendo; a fila espera o handoff
+func (q *queue) botActive(ctx context.Context, conversationID uuid.UUID, now time.Time) (bool, error) {
+	if q.sessionRepo == nil {
+		return false, nil
+	}
+	session, err := q.sessionRepo.FindActive(ctx, conversationID)
+	if err != nil || session == nil {
+		return false, err
+	}
+	return session.ExpiresAt.After(now), nil
+}
+
+// assign escolhe o atendente e atribui; retorna nil quando ninguém está disponível
+func (q *queue) assign(ctx context.Context, team *Team, r *roster, conversation *whatsapp.Conversation) (*uuid.UUID, error) {
+	userID := q.pick(team, r)
+	if userID == nil {
+		q.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "teams.queue.no_agent_available",
+			Value: 1,
+			Tags:  map[string]string{"team_id": team.ID.String()},
+		})
+		return nil, nil
+	}
+
+	claimed, err := q.conversationRepo.ClaimAssignee(ctx, conversation.ID, *userID)
+	if err != nil || !claimed {
+		// Atribuída em paralelo (outro atendente ou outra instância)
+		return nil, err
+	}
+	conversation.AssigneeID = userID
+	r.loads[*userID]++
+
+	team.LastAssignedUserID = userID
+	if err := q.teamRepo.SetCursor(ctx, team.ID, *userID); err != nil {
+		return nil, err
+	}
+
+	err = q.transferRepo.Create(ctx, &Transfer{
+		TenantID:       team.TenantID,
+		ConversationID: conversation.ID,
+		ToUserID:       userID,
+		FromTeamID:     &team.ID,
+		ToTeamID:       &team.ID,
+		Automatic:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "teams.conversation.assigned",
+		Properties: map[string]interface{}{
+			"tenant_id":       team.TenantID.String(),
+			"team_id":         team.ID.String(),
+			"conversation_id": conversation.ID.String(),
+			"assignee_id":     userID.String(),
+			"distribution":    string(team.Distribution),
+		},
+		Timestamp: time.Now(),
+	})
+	q.publish(ctx, realtime.NewEvent(team.TenantID, realtime.EventConversationAssigned, conversation))
+	return userID, nil
+}
+
+func (q *queue) publish(ctx context.Context, event realtime.Event) {
+	if q.publisher != nil {
+		q.publisher.Publish(ctx, event)
+	}
+}
+
+// drain distribui as conversas que aguardam na fila do time e retorna quantas foram atribuídas
+func (q *queue) drain(ctx context.Context, team *Team, now time.Time) (int, error) {
+	queued, err := q.conversationRepo.ListQueued(ctx, team.ID, queueBatchSize)
+	if err != nil || len(queued) == 0 {
+		return 0, err
+	}
+
+	r, err := q.roster(ctx, team, now)
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for i := range queued {
+		conversation := &queued[i]
+		if active, err := q.botActive(ctx, conversation.ID, now); err != nil || active {
+			continue
+		}
+
+		userID, err := q.assign(ctx, team, r, conversation)
+		if err != nil {
+			return assigned, err
+		}
+		if userID == nil {
+			if q.pick(team, r) == nil {
+				// Ninguém disponível: o restante continua na fila
+				break
+			}
+			continue
+		}
+		assigned++
+	}
+	return assigned, nil
+}
@@ -0,0 +1,104 @@
+package teams
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/chatbot"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+)
+
+type router struct {
+	*queue
+}
+
+func NewRouter(
+	teamRepo TeamRepository,
+	presenceRepo PresenceRepository,
+	transferRepo TransferRepository,
+	conversationRepo whatsapp.ConversationRepository,
+	sessionRepo chatbot.SessionRepository,
+	publisher realtime.Publisher,
+	telemetry telemetry.TelemetryService,
+) Router {
+	return &router{
+		queue: &queue{
+			teamRepo:         teamRepo,
+			presenceRepo:     presenceRepo,
+			transferRepo:     transferRepo,
+			conversationRepo: conversationRepo,
+			sessionRepo:      sessionRepo,
+			publisher:        publisher,
+			telemetry:        telemetry,
+		},
+	}
+}
+
+// HandleEvent coloca a conversa sem time na fila do time padrão do canal e tenta
+// distribuí-la; conversas com atendente ou ainda com o chatbot ficam como estão
+func (r *router) HandleEvent(ctx context.Context, event whatsapp.Event) {
+	inbound, ok := event.(whatsapp.InboundMessageEvent)
+	if !ok || inbound.Message.ConversationID == nil {
+		return
+	}
+
+	span, ctx := r.telemetry.StartSpan(ctx, "teams.route")
+	defer span.End()
+
+	span.SetTag("tenant_id", inbound.TenantID.String())
+	span.SetTag("conversation_id", inbound.Message.ConversationID.String())
+
+	if err := r.route(ctx, inbound, time.Now()); err != nil {
+		span.SetError(err)
+	}
+}
+
+func (r *router) route(ctx context.Context, event whatsapp.InboundMessageEvent, now time.Time) error {
+	conversation, err := r.conversationRepo.FindByID(ctx, event.TenantID, event.Message.ConversationID.String())
+	if err != nil || conversation == nil || conversation.AssigneeID != nil {
+		return err
+	}
+
+	teamID := conversation.TeamID
+	if teamID == nil && event.Channel != nil {
+		teamID = event.Channel.DefaultTeamID
+	}
+	if teamID == nil {
+		// Sem time: a conversa fica na caixa compartilhada (não atribuídas)
+		return nil
+	}
+
+	team, err := r.teamRepo.FindByID(ctx, event.TenantID, teamID.String())
+	if err != nil || team == nil {
+		return err
+	}
+
+	if conversation.TeamID == nil {
+		if err := r.conversationRepo.Route(ctx, conversation.ID, &team.ID, nil); err != nil {
+			return err
+		}
+		conversation.TeamID = &team.ID
+		r.telemetry.TrackEvent(ctx, telemetry.Event{
+			Name: "teams.conversation.queued",
+			Properties: map[string]interface{}{
+				"tenant_id":       team.TenantID.String(),
+				"team_id":         team.ID.String(),
+				"conversation_id": conversation.ID.String(),
+			},
+			Timestamp: now,
+		})
+	}
+
+	if active, err := r.botActive(ctx, conversation.ID, now); err != nil || active {
+		return err
+	}
+
+	roster, err := r.roster(ctx, team, now)
+	if err != nil {
+		return err
+	}
+	_, err = r.assign(ctx, team, roster, conversation)
+	return err
+}
@@ -0,0 +1,394 @@
+package teams
+
+import (
+	"context"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/chatbot"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+)
+
+type teamService struct {
+	*queue
+	userRepo auth.UserRepository
+}
+
+func NewTeamService(
+	teamRepo TeamRepository,
+	presenceRepo PresenceRepository,
+	transferRepo TransferRepository,
+	conversationRepo whatsapp.ConversationRepository,
+	sessionRepo chatbot.SessionRepository,
+	publisher realtime.Publisher,
+	userRepo auth.UserRepository,
+	telemetry telemetry.TelemetryService,
+) TeamService {
+	return &teamService{
+		queue: &queue{
+			teamRepo:         teamRepo,
+			presenceRepo:     presenceRepo,
+			transferRepo:     transferRepo,
+			conversationRepo: conversationRepo,
+			sessionRepo:      sessionRepo,
+			publisher:        publisher,
+			telemetry:        telemetry,
+		},
+		userRepo: userRepo,
+	}
+}
+
+func (s *teamService) ListTeams(ctx context.Context, tenantID uuid.UUID) ([]Team, error) {
+	teams, err := s.teamRepo.List(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range teams {
+		if teams[i].MemberIDs, err = s.teamRepo.ListMemberIDs(ctx, teams[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return teams, nil
+}
+
+func (s *teamService) GetTeam(ctx context.Context, tenantID uuid.UUID, id string) (*Team, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTeamNotFound
+	}
+
+	team, err := s.teamRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+	if team.MemberIDs, err = s.teamRepo.ListMemberIDs(ctx, team.ID); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *teamService) CreateTeam(ctx context.Context, tenantID uuid.UUID, req SaveTeamRequest) (*Team, error) {
+	team := &Team{TenantID: tenantID}
+	if err := applyTeam(team, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.teamRepo.Create(ctx, team); err != nil {
+		return nil, err
+	}
+	team.MemberIDs = []uuid.UUID{}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "teams.created",
+		Properties: map[string]interface{}{
+			"tenant_id":    tenantID.String(),
+			"team_id":      team.ID.String(),
+			"distribution": string(team.Distribution),
+		},
+		Timestamp: time.Now(),
+	})
+
+	return team, nil
+}
+
+func (s *teamService) UpdateTeam(ctx context.Context, tenantID uuid.UUID, id string, req SaveTeamRequest) (*Team, error) {
+	team, err := s.GetTeam(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTeam(team, req); err != nil {
+		return nil, err
+	}
+	if err := s.teamRepo.Update(ctx, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func applyTeam(team *Team, req SaveTeamRequest) error {
+	team.Name = req.Name
+	team.Description = req.Description
+	team.Distribution = req.Distribution
+	if team.Distribution == "" {
+		team.Distribution = DistributionRoundRobin
+	}
+	team.MaxActivePerAgent = req.MaxActivePerAgent
+	return team.Validate()
+}
+
+func (s *teamService) DeleteTeam(ctx context.Context, tenantID uuid.UUID, id string) error {
+	team, err := s.GetTeam(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	return s.teamRepo.Delete(ctx, tenantID, team.ID)
+}
+
+func (s *teamService) AddMember(ctx context.Context, tenantID uuid.UUID, id string, userID uuid.UUID) (*Team, error) {
+	team, err := s.GetTeam(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUser(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	err = s.teamRepo.AddMember(ctx, &TeamMember{TeamID: team.ID, UserID: userID, TenantID: tenantID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Um novo membro online já pode receber a fila
+	if _, err := s.drain(ctx, team, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.GetTeam(ctx, tenantID, id)
+}
+
+func (s *teamService) RemoveMember(ctx context.Context, tenantID uuid.UUID, id string, userID uuid.UUID) (*Team, error) {
+	team, err := s.GetTeam(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.teamRepo.RemoveMember(ctx, team.ID, userID); err != nil {
+		return nil, err
+	}
+	return s.GetTeam(ctx, tenantID, id)
+}
+
+func (s *teamService) Queue(ctx context.Context, tenantID uuid.UUID, id string, limit int) ([]whatsapp.Conversation, error) {
+	team, err := s.GetTeam(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.conversationRepo.ListQueued(ctx, team.ID, limit)
+}
+
+func (s *teamService) SetPresence(ctx context.Context, tenantID, userID uuid.UUID, status PresenceStatus) (*Presence, error) {
+	if !status.Valid() {
+		return nil, ErrInvalidPresence
+	}
+
+	span, ctx := s.telemetry.StartSpan(ctx, "teams.set_presence")
+	defer span.End()
+
+	span.SetTag("user_id", userID.String())
+	span.SetTag("status", string(status))
+
+	now := time.Now()
+	previous, err := s.presenceRepo.FindByUser(ctx, userID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	presence := &Presence{UserID: userID, TenantID: tenantID, Status: status, LastSeenAt: now, UpdatedAt: now}
+	if err := s.presenceRepo.Save(ctx, presence); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	if previous.Effective(now) == status {
+		// Heartbeat: nada mudou
+		return presence, nil
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "teams.presence.changed",
+		Properties: map[string]interface{}{
+			"tenant_id": tenantID.String(),
+			"user_id":   userID.String(),
+			"status":    string(status),
+		},
+		Timestamp: now,
+	})
+	s.publish(ctx, realtime.NewEvent(tenantID, realtime.EventPresenceChanged, presence))
+
+	// Atendente que fica online recebe o que aguardava nas filas dos seus times
+	if status == PresenceOnline {
+		if err := s.drainUserTeams(ctx, tenantID, userID, now); err != nil {
+			span.SetError(err)
+			return nil, err
+		}
+	}
+	return presence, nil
+}
+
+func (s *teamService) drainUserTeams(ctx context.Context, tenantID, userID uuid.UUID, now time.Time) error {
+	teamIDs, err := s.teamRepo.ListTeamIDsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, teamID := range teamIDs {
+		team, err := s.teamRepo.FindByID(ctx, tenantID, teamID.String())
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			continue
+		}
+		if _, err := s.drain(ctx, team, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListPresence devolve o status efetivo: sem heartbeat recente o atendente aparece offline
+func (s *teamService) ListPresence(ctx context.Context, tenantID uuid.UUID) ([]Presence, error) {
+	items, err := s.presenceRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range items {
+		items[i].Status = items[i].Effective(now)
+	}
+	return items, nil
+}
+
+func (s *teamService) Transfer(ctx context.Context, tenantID, userID uuid.UUID, conversationID string, req TransferRequest) (*whatsapp.Conversation, error) {
+	if req.ToUserID == nil && req.ToTeamID == nil {
+		return nil, ErrInvalidTransfer
+	}
+
+	span, ctx := s.telemetry.StartSpan(ctx, "teams.transfer")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+	span.SetTag("conversation_id", conversationID)
+
+	conversation, err := s.findConversation(ctx, tenantID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var team *Team
+	teamID := conversation.TeamID
+	if req.ToTeamID != nil {
+		if team, err = s.GetTeam(ctx, tenantID, *req.ToTeamID); err != nil {
+			return nil, err
+		}
+		teamID = &team.ID
+	}
+
+	var assigneeID *uuid.UUID
+	if req.ToUserID != nil {
+		id := uuid.MustParse(*req.ToUserID)
+		if err := s.ensureUser(ctx, tenantID, id); err != nil {
+			return nil, err
+		}
+		if team != nil {
+			member, err := s.teamRepo.IsMember(ctx, team.ID, id)
+			if err != nil {
+				span.SetError(err)
+				return nil, err
+			}
+			if !member {
+				return nil, ErrNotTeamMember
+			}
+		}
+		assigneeID = &id
+	}
+
+	if sameID(conversation.AssigneeID, assigneeID) && sameID(conversation.TeamID, teamID) {
+		return nil, ErrTransferNoChange
+	}
+
+	if err := s.conversationRepo.Route(ctx, conversation.ID, teamID, assigneeID); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	transfer := &Transfer{
+		TenantID:        tenantID,
+		ConversationID:  conversation.ID,
+		FromUserID:      conversation.AssigneeID,
+		ToUserID:        assigneeID,
+		FromTeamID:      conversation.TeamID,
+		ToTeamID:        teamID,
+		Reason:          req.Reason,
+		TransferredByID: &userID,
+	}
+	if err := s.transferRepo.Create(ctx, transfer); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "teams.conversation.transferred",
+		Properties: map[string]interface{}{
+			"tenant_id":       tenantID.String(),
+			"conversation_id": conversation.ID.String(),
+			"transfer_id":     transfer.ID.String(),
+			"to_user":         assigneeID != nil,
+			"to_team":         req.ToTeamID != nil,
+		},
+		Timestamp: time.Now(),
+	})
+
+	conversation.TeamID = teamID
+	conversation.AssigneeID = assigneeID
+	s.publish(ctx, realtime.NewEvent(tenantID, realtime.EventConversationAssigned, conversation))
+
+	// Transferida só para o time: volta para a fila e já tenta um atendente
+	if assigneeID == nil && team != nil {
+		now := time.Now()
+		roster, err := s.roster(ctx, team, now)
+		if err != nil {
+			span.SetError(err)
+			return nil, err
+		}
+		if _, err := s.assign(ctx, team, roster, conversation); err != nil {
+			span.SetError(err)
+			return nil, err
+		}
+	}
+
+	return conversation, nil
+}
+
+func (s *teamService) ListTransfers(ctx context.Context, tenantID uuid.UUID, conversationID string) ([]Transfer, error) {
+	conversation, err := s.findConversation(ctx, tenantID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.transferRepo.ListByConversation(ctx, tenantID, conversation.ID)
+}
+
+func (s *teamService) findConversation(ctx context.Context, tenantID uuid.UUID, id string) (*whatsapp.Conversation, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, whatsapp.ErrConversationNotFound
+	}
+	conversation, err := s.conversationRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if conversation == nil {
+		return nil, whatsapp.ErrConversationNotFound
+	}
+	return conversation, nil
+}
+
+func (s *teamService) ensureUser(ctx context.Context, tenantID, userID uuid.UUID) error {
+	user, err := s.userRepo.FindByID(ctx, userID.String())
+	if err != nil {
+		return err
+	}
+	if user == nil || user.TenantID != tenantID {
+		return ErrInvalidMember
+	}
+	return nil
+}
+
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,292 @@
+package teams
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository base (sem telemetria)
+type teamRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newTeamRepositoryBase(db *gorm.DB) *teamRepositoryBase {
+	return &teamRepositoryBase{db: db}
+}
+
+func (r *teamRepositoryBase) create(ctx context.Context, team *Team) error {
+	return r.db.WithContext(ctx).Create(team).Error
+}
+
+func (r *teamRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Team, error) {
+	var team Team
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&team).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *teamRepositoryBase) list(ctx context.Context, tenantID uuid.UUID) ([]Team, error) {
+	var teams []Team
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("name ASC").Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *teamRepositoryBase) listAll(ctx context.Context) ([]Team, error) {
+	var teams []Team
+	err := r.db.WithContext(ctx).Order("created_at ASC").Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *teamRepositoryBase) update(ctx context.Context, team *Team) error {
+	return r.db.WithContext(ctx).Select("*").Save(team).Error
+}
+
+func (r *teamRepositoryBase) delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("team_id = ?", id).Delete(&TeamMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&Team{}).Error
+	})
+}
+
+func (r *teamRepositoryBase) addMember(ctx context.Context, member *TeamMember) error {
+	// Adicionar quem já é membro não é erro
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(member).Error
+}
+
+func (r *teamRepositoryBase) removeMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("team_id = ? AND user_id = ?", teamID, userID).Delete(&TeamMember{}).Error
+}
+
+func (r *teamRepositoryBase) listMemberIDs(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&TeamMember{}).
+		Where("team_id = ?", teamID).
+		Order("created_at ASC, user_id ASC").
+		Pluck("user_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *teamRepositoryBase) isMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&TeamMember{}).Where("team_id = ? AND user_id = ?", teamID, userID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *teamRepositoryBase) listTeamIDsByUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&TeamMember{}).Where("user_id = ?", userID).Pluck("team_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *teamRepositoryBase) setCursor(ctx context.Context, teamID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&Team{}).Where("id = ?", teamID).Update("last_assigned_user_id", userID).Error
+}
+
+// Repository com telemetria (decorator)
+type teamRepository struct {
+	base      *teamRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewTeamRepository(db *gorm.DB, telemetry telemetry.TelemetryService) TeamRepository {
+	return &teamRepository{
+		base:      newTeamRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *teamRepository) Create(ctx context.Context, team *Team) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", team.TenantID.String())
+
+	err := r.base.create(ctx, team)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *teamRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Team, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.find_by_id")
+	defer span.End()
+
+	span.SetTag("team_id", id)
+
+	team, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return team, nil
+}
+
+func (r *teamRepository) List(ctx context.Context, tenantID uuid.UUID) ([]Team, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	teams, err := r.base.list(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *teamRepository) ListAll(ctx context.Context) ([]Team, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.list_all")
+	defer span.End()
+
+	teams, err := r.base.listAll(ctx)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *teamRepository) Update(ctx context.Context, team *Team) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.update")
+	defer span.End()
+
+	span.SetTag("team_id", team.ID.String())
+
+	err := r.base.update(ctx, team)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *teamRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.delete")
+	defer span.End()
+
+	span.SetTag("team_id", id.String())
+
+	err := r.base.delete(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *teamRepository) AddMember(ctx context.Context, member *TeamMember) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.add_member")
+	defer span.End()
+
+	span.SetTag("team_id", member.TeamID.String())
+	span.SetTag("user_id", member.UserID.String())
+
+	err := r.base.addMember(ctx, member)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *teamRepository) RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.remove_member")
+	defer span.End()
+
+	span.SetTag("team_id", teamID.String())
+	span.SetTag("user_id", userID.String())
+
+	err := r.base.removeMember(ctx, teamID, userID)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *teamRepository) ListMemberIDs(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.list_member_ids")
+	defer span.End()
+
+	span.SetTag("team_id", teamID.String())
+
+	ids, err := r.base.listMemberIDs(ctx, teamID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *teamRepository) IsMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.is_member")
+	defer span.End()
+
+	span.SetTag("team_id", teamID.String())
+	span.SetTag("user_id", userID.String())
+
+	member, err := r.base.isMember(ctx, teamID, userID)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return member, nil
+}
+
+func (r *teamRepository) ListTeamIDsByUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.list_team_ids_by_user")
+	defer span.End()
+
+	span.SetTag("user_id", userID.String())
+
+	ids, err := r.base.listTeamIDsByUser(ctx, userID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *teamRepository) SetCursor(ctx context.Context, teamID, userID uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.team.set_cursor")
+	defer span.End()
+
+	span.SetTag("team_id", teamID.String())
+
+	err := r.base.setCursor(ctx, teamID, userID)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
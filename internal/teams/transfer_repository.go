@@ -0,0 +1,74 @@
+package teams
+
+import (
+	"context"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type transferRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newTransferRepositoryBase(db *gorm.DB) *transferRepositoryBase {
+	return &transferRepositoryBase{db: db}
+}
+
+func (r *transferRepositoryBase) create(ctx context.Context, transfer *Transfer) error {
+	return r.db.WithContext(ctx).Create(transfer).Error
+}
+
+func (r *transferRepositoryBase) listByConversation(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Transfer, error) {
+	var items []Transfer
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND conversation_id = ?", tenantID, conversationID).
+		Order("created_at ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Repository com telemetria (decorator)
+type transferRepository struct {
+	base      *transferRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewTransferRepository(db *gorm.DB, telemetry telemetry.TelemetryService) TransferRepository {
+	return &transferRepository{
+		base:      newTransferRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *transferRepository) Create(ctx context.Context, transfer *Transfer) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.transfer.create")
+	defer span.End()
+
+	span.SetTag("conversation_id", transfer.ConversationID.String())
+
+	err := r.base.create(ctx, transfer)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *transferRepository) ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID) ([]Transfer, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.transfer.list_by_conversation")
+	defer span.End()
+
+	span.SetTag("conversation_id", conversationID.String())
+
+	items, err := r.base.listByConversation(ctx, tenantID, conversationID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
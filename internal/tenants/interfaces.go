@@ -1,6 +1,11 @@
 package tenants
 
+import "context"
+
 type TenantRepository interface {
-	Create(tenant *Tenant) error
-	FindByID(id string) (*Tenant, error)
+	Create(ctx context.Context, tenant *Tenant) error
+	Update(ctx context.Context, tenant *Tenant) error
+	Delete(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (*Tenant, error)
+	FindByWhatsAppPhoneNumberID(ctx context.Context, phoneNumberID string) (*Tenant, error)
 } 
\ No newline at end of file
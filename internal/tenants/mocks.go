@@ -1,21 +1,47 @@
 package tenants
 
+import "context"
+
 // MockTenantRepository para testes
 type MockTenantRepository struct {
-	CreateFunc func(tenant *Tenant) error
-	FindByIDFunc func(id string) (*Tenant, error)
+	CreateFunc func(ctx context.Context, tenant *Tenant) error
+	UpdateFunc func(ctx context.Context, tenant *Tenant) error
+	DeleteFunc func(ctx context.Context, id string) error
+	FindByIDFunc func(ctx context.Context, id string) (*Tenant, error)
+	FindByWhatsAppPhoneNumberIDFunc func(ctx context.Context, phoneNumberID string) (*Tenant, error)
 }
 
-func (m *MockTenantRepository) Create(tenant *Tenant) error {
+func (m *MockTenantRepository) Create(ctx context.Context, tenant *Tenant) error {
 	if m.CreateFunc != nil {
-		return m.CreateFunc(tenant)
+		return m.CreateFunc(ctx, tenant)
 	}
 	return nil
 }
 
-func (m *MockTenantRepository) FindByID(id string) (*Tenant, error) {
+func (m *MockTenantRepository) Update(ctx context.Context, tenant *Tenant) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, tenant)
+	}
+	return nil
+}
+
+func (m *MockTenantRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockTenantRepository) FindByID(ctx context.Context, id string) (*Tenant, error) {
 	if m.FindByIDFunc != nil {
-		return m.FindByIDFunc(id)
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockTenantRepository) FindByWhatsAppPhoneNumberID(ctx context.Context, phoneNumberID string) (*Tenant, error) {
+	if m.FindByWhatsAppPhoneNumberIDFunc != nil {
+		return m.FindByWhatsAppPhoneNumberIDFunc(ctx, phoneNumberID)
 	}
 	return nil, nil
 } 
\ No newline at end of file
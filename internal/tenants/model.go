@@ -11,4 +11,9 @@ type Tenant struct {
 	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Phone number ID da WhatsApp Cloud API que recebe as mensagens do tenant
+	WhatsAppPhoneNumberID *string `gorm:"column:whatsapp_phone_number_id;type:varchar(64);uniqueIndex" json:"whatsapp_phone_number_id,omitempty"`
+	// WhatsApp Business Account (WABA) dona dos templates de mensagem
+	WhatsAppBusinessAccountID *string `gorm:"column:whatsapp_business_account_id;type:varchar(64)" json:"whatsapp_business_account_id,omitempty"`
 }
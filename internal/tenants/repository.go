@@ -3,7 +3,7 @@ package tenants
 import (
 	"context"
 	"errors"
-	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
@@ -11,6 +11,17 @@ import (
 	"gorm.io/gorm"
 )
 
+var tenantKeys = cache.NewKeyBuilder("tenant")
+
+// tenantTags liga cada entrada ao registro e ao namespace do tenant
+func tenantTags(tenant *Tenant) []string {
+	if tenant == nil {
+		return nil
+	}
+	id := tenant.ID.String()
+	return []string{tenantKeys.RecordTag(id), cache.TenantTag(id)}
+}
+
 // Repository base (sem cache/telemetria)
 type tenantRepositoryBase struct {
 	db *gorm.DB
@@ -20,13 +31,33 @@ func newTenantRepositoryBase(db *gorm.DB) *tenantRepositoryBase {
 	return &tenantRepositoryBase{db: db}
 }
 
-func (r *tenantRepositoryBase) create(tenant *Tenant) error {
-	return r.db.Create(tenant).Error
+func (r *tenantRepositoryBase) create(ctx context.Context, tenant *Tenant) error {
+	return r.db.WithContext(ctx).Create(tenant).Error
+}
+
+func (r *tenantRepositoryBase) update(ctx context.Context, tenant *Tenant) error {
+	return r.db.WithContext(ctx).Save(tenant).Error
+}
+
+func (r *tenantRepositoryBase) delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&Tenant{}).Error
+}
+
+func (r *tenantRepositoryBase) findByID(ctx context.Context, id string) (*Tenant, error) {
+	var tenant Tenant
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&tenant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tenant, nil
 }
 
-func (r *tenantRepositoryBase) findByID(id string) (*Tenant, error) {
+func (r *tenantRepositoryBase) findByWhatsAppPhoneNumberID(ctx context.Context, phoneNumberID string) (*Tenant, error) {
 	var tenant Tenant
-	err := r.db.Where("id = ?", id).First(&tenant).Error
+	err := r.db.WithContext(ctx).Where("whatsapp_phone_number_id = ?", phoneNumberID).First(&tenant).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -39,35 +70,45 @@ func (r *tenantRepositoryBase) findByID(id string) (*Tenant, error) {
 // Repository com cache e telemetria (decorator)
 type tenantRepository struct {
 	base      *tenantRepositoryBase
-	cache     cache.CacheService
 	telemetry telemetry.TelemetryService
+
+	// cache grava também a ausência do registro; lookup só grava o que existe
+	cache  *cache.Loader[*Tenant]
+	lookup *cache.Loader[*Tenant]
 }
 
-func NewTenantRepository(db *gorm.DB, cache cache.CacheService, telemetry telemetry.TelemetryService) TenantRepository {
+func NewTenantRepository(db *gorm.DB, cacheService cache.CacheService, telemetry telemetry.TelemetryService) TenantRepository {
 	return &tenantRepository{
 		base:      newTenantRepositoryBase(db),
-		cache:     cache,
 		telemetry: telemetry,
+		cache: cache.NewLoader[*Tenant](cacheService, cache.LoaderOptions{
+			TTL:         10 * time.Minute,
+			NegativeTTL: 5 * time.Minute,
+			Jitter:      0.1,
+		}, telemetry).WithTags(tenantTags),
+		lookup: cache.NewLoader[*Tenant](cacheService, cache.LoaderOptions{
+			TTL:    10 * time.Minute,
+			Jitter: 0.1,
+		}, telemetry).WithTags(tenantTags),
 	}
 }
 
-func (r *tenantRepository) Create(tenant *Tenant) error {
-	ctx := context.Background()
+func (r *tenantRepository) Create(ctx context.Context, tenant *Tenant) error {
 	span, ctx := r.telemetry.StartSpan(ctx, "repository.tenant.create")
 	defer span.End()
 
 	span.SetTag("tenant_id", tenant.ID.String())
 	span.SetTag("tenant_name", tenant.Name)
 
-	err := r.base.create(tenant)
+	err := r.base.create(ctx, tenant)
 	if err != nil {
 		span.SetError(err)
 		return err
 	}
 
-	// Invalidate cache
-	cacheKey := fmt.Sprintf("tenant:id:%s", tenant.ID.String())
-	r.cache.Delete(ctx, cacheKey)
+	// Invalidate cache: a busca por ID pode ter gravado a ausência do tenant
+	id := tenant.ID.String()
+	r.cache.Delete(ctx, tenantKeys.Tenant(id, "id", id))
 
 	// Track metric
 	r.telemetry.TrackMetric(ctx, telemetry.Metric{
@@ -79,47 +120,111 @@ func (r *tenantRepository) Create(tenant *Tenant) error {
 	return nil
 }
 
-func (r *tenantRepository) FindByID(id string) (*Tenant, error) {
-	ctx := context.Background()
-	span, ctx := r.telemetry.StartSpan(ctx, "repository.tenant.find_by_id")
+func (r *tenantRepository) Update(ctx context.Context, tenant *Tenant) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.tenant.update")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenant.ID.String())
+
+	if err := r.base.update(ctx, tenant); err != nil {
+		span.SetError(err)
+		return err
+	}
+
+	// Invalidate cache: o registro sai de todas as chaves, inclusive a do número antigo
+	r.cache.InvalidateTags(ctx, tenantKeys.RecordTag(tenant.ID.String()))
+
+	r.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "repository.tenant.update.success",
+		Value: 1,
+		Tags:  map[string]string{"tenant_id": tenant.ID.String()},
+	})
+
+	return nil
+}
+
+// Delete apaga o tenant e tudo o que foi gravado no cache em nome dele
+func (r *tenantRepository) Delete(ctx context.Context, id string) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.tenant.delete")
 	defer span.End()
 
 	span.SetTag("tenant_id", id)
 
-	// Try cache first
-	cacheKey := fmt.Sprintf("tenant:id:%s", id)
-	if cached, err := r.cache.Get(ctx, cacheKey); err == nil && cached != nil {
-		span.SetTag("cache_hit", "true")
-		if tenant, ok := cached.(*Tenant); ok {
-			r.telemetry.TrackMetric(ctx, telemetry.Metric{
-				Name:  "repository.tenant.find_by_id.cache_hit",
-				Value: 1,
-				Tags:  map[string]string{"tenant_id": id},
-			})
-			return tenant, nil
-		}
+	if err := r.base.delete(ctx, id); err != nil {
+		span.SetError(err)
+		return err
 	}
 
-	// Cache miss - query database
-	span.SetTag("cache_hit", "false")
-	tenant, err := r.base.findByID(id)
+	// Invalidate cache
+	r.cache.InvalidateTags(ctx, cache.TenantTag(id))
+
+	r.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "repository.tenant.delete.success",
+		Value: 1,
+		Tags:  map[string]string{"tenant_id": id},
+	})
+
+	return nil
+}
+
+func (r *tenantRepository) FindByID(ctx context.Context, id string) (*Tenant, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.tenant.find_by_id")
+	defer span.End()
+
+	span.SetTag("tenant_id", id)
+
+	// Cache-aside com coalescência: misses concorrentes da mesma chave fazem uma consulta só
+	// (a consulta é compartilhada, então não herda o cancelamento de quem a iniciou)
+	cacheKey := tenantKeys.Tenant(id, "id", id)
+	tenant, hit, err := r.cache.Load(ctx, cacheKey, func() (*Tenant, error) {
+		return r.base.findByID(context.WithoutCancel(ctx), id)
+	})
 	if err != nil {
 		span.SetError(err)
 		return nil, err
 	}
 
-	// Cache the result (even if nil to avoid cache penetration)
-	ttl := 10 * time.Minute
-	if tenant == nil {
-		ttl = 5 * time.Minute // Shorter TTL for non-existent tenants
+	result := "cache_miss"
+	if hit {
+		result = "cache_hit"
 	}
-	r.cache.Set(ctx, cacheKey, tenant, ttl)
-
+	span.SetTag("cache_hit", strconv.FormatBool(hit))
 	r.telemetry.TrackMetric(ctx, telemetry.Metric{
-		Name:  "repository.tenant.find_by_id.cache_miss",
+		Name:  "repository.tenant.find_by_id." + result,
 		Value: 1,
 		Tags:  map[string]string{"tenant_id": id},
 	})
 
 	return tenant, nil
-} 
\ No newline at end of file
+}
+
+func (r *tenantRepository) FindByWhatsAppPhoneNumberID(ctx context.Context, phoneNumberID string) (*Tenant, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.tenant.find_by_whatsapp_phone_number_id")
+	defer span.End()
+
+	span.SetTag("phone_number_id", phoneNumberID)
+
+	// Cache-aside com coalescência: misses concorrentes da mesma chave fazem uma consulta só
+	// (a consulta é compartilhada, então não herda o cancelamento de quem a iniciou)
+	cacheKey := tenantKeys.Global("whatsapp", phoneNumberID)
+	tenant, hit, err := r.lookup.Load(ctx, cacheKey, func() (*Tenant, error) {
+		return r.base.findByWhatsAppPhoneNumberID(context.WithoutCancel(ctx), phoneNumberID)
+	})
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	result := "cache_miss"
+	if hit {
+		result = "cache_hit"
+	}
+	span.SetTag("cache_hit", strconv.FormatBool(hit))
+	r.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "repository.tenant.find_by_whatsapp_phone_number_id." + result,
+		Value: 1,
+		Tags:  map[string]string{"phone_number_id": phoneNumberID},
+	})
+
+	return tenant, nil
+}
@@ -0,0 +1,105 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+// NextOpeningPlaceholder é trocado pela próxima abertura ("segunda-feira, 02/06 às 08:00")
+const NextOpeningPlaceholder = "{{next_opening}}"
+
+// awayMessageService responde automaticamente quando o cliente escreve fora do expediente
+type awayMessageService struct {
+	conversationRepo    ConversationRepository
+	conversationService ConversationService
+	hoursService        businesshours.BusinessHoursService
+	telemetry           telemetry.TelemetryService
+}
+
+func NewAwayMessageService(
+	conversationRepo ConversationRepository,
+	conversationService ConversationService,
+	hoursService businesshours.BusinessHoursService,
+	telemetry telemetry.TelemetryService,
+) AwayMessageService {
+	return &awayMessageService{
+		conversationRepo:    conversationRepo,
+		conversationService: conversationService,
+		hoursService:        hoursService,
+		telemetry:           telemetry,
+	}
+}
+
+func (s *awayMessageService) HandleEvent(ctx context.Context, event Event) {
+	inbound, ok := event.(InboundMessageEvent)
+	if !ok || inbound.Message == nil || inbound.Message.ConversationID == nil {
+		return
+	}
+
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.away_message.handle_inbound")
+	defer span.End()
+
+	sent, err := s.reply(ctx, inbound.TenantID, *inbound.Message.ConversationID)
+	if err != nil {
+		span.SetError(err)
+		return
+	}
+	if sent {
+		s.telemetry.TrackEvent(ctx, telemetry.Event{
+			Name: "whatsapp.away_message.sent",
+			Properties: map[string]interface{}{
+				"conversation_id": inbound.Message.ConversationID.String(),
+				"tenant_id":       inbound.TenantID.String(),
+			},
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+func (s *awayMessageService) reply(ctx context.Context, tenantID, conversationID uuid.UUID) (bool, error) {
+	calendar, err := s.hoursService.GetCalendar(ctx, tenantID)
+	if errors.Is(err, businesshours.ErrCalendarNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	if !calendar.AwayMessageEnabled || calendar.IsOpen(now) {
+		return false, nil
+	}
+
+	cooldown := time.Duration(calendar.AwayCooldownMinutes) * time.Minute
+	claimed, err := s.conversationRepo.ClaimAwayMessage(ctx, conversationID, now, now.Add(-cooldown))
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	_, err = s.conversationService.SendMessage(ctx, tenantID, uuid.Nil, conversationID.String(), SendMessageRequest{
+		Type: TypeText,
+		Text: &TextContent{Body: renderAwayMessage(calendar, now)},
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func renderAwayMessage(calendar *businesshours.Calendar, now time.Time) string {
+	if !strings.Contains(calendar.AwayMessage, NextOpeningPlaceholder) {
+		return calendar.AwayMessage
+	}
+
+	next := ""
+	if opening, ok := calendar.NextOpening(now); ok {
+		next = calendar.FormatOpening(opening)
+	}
+	return strings.ReplaceAll(calendar.AwayMessage, NextOpeningPlaceholder, next)
+}
@@ -0,0 +1,75 @@
+package whatsapp
+
+import (
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrChannelNotFound     = errors.New("whatsapp channel not found")
+	ErrPhoneNumberTaken    = errors.New("phone number already registered")
+	ErrPhoneNumberNotOwned = errors.New("phone number not found in the whatsapp business account")
+	ErrInvalidDefaultTeam  = errors.New("default team not found in tenant")
+	ErrInvalidDefaultFlow  = errors.New("default flow not found in tenant")
+)
+
+// Channel é um número de WhatsApp do tenant (ex.: vendas e suporte em números separados)
+type Channel struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID          uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	PhoneNumberID     string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"phone_number_id"`
+	DisplayName       string    `gorm:"type:varchar(255);not null" json:"display_name"`
+	DisplayPhone      string    `gorm:"type:varchar(32)" json:"display_phone,omitempty"`
+	BusinessAccountID string    `gorm:"type:varchar(64)" json:"business_account_id,omitempty"`
+	// IsDefault marca o canal dos envios que não indicam número
+	IsDefault bool `gorm:"not null" json:"is_default"`
+
+	// Token próprio da Cloud API, cifrado; vazio usa o token global (WHATSAPP_ACCESS_TOKEN)
+	AccessTokenEncrypted string `gorm:"type:text" json:"-"`
+	HasAccessToken       bool   `gorm:"-" json:"has_access_token"`
+
+	// Roteamento das conversas que chegam pelo canal
+	DefaultTeamID *uuid.UUID `gorm:"type:uuid" json:"default_team_id,omitempty"`
+	DefaultFlowID *uuid.UUID `gorm:"type:uuid" json:"default_flow_id,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *Channel) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+func (c *Channel) AfterFind(tx *gorm.DB) error {
+	c.HasAccessToken = c.AccessTokenEncrypted != ""
+	return nil
+}
+
+// Legacy indica o canal derivado do cadastro do tenant, anterior ao registro de canais
+func (c *Channel) Legacy() bool {
+	return c.ID == uuid.Nil
+}
+
+// legacyChannel adapta os campos WhatsApp do tenant para quem ainda não cadastrou canais
+func legacyChannel(tenant *tenants.Tenant) *Channel {
+	if tenant == nil || tenant.WhatsAppPhoneNumberID == nil {
+		return nil
+	}
+	channel := &Channel{
+		TenantID:      tenant.ID,
+		PhoneNumberID: *tenant.WhatsAppPhoneNumberID,
+		DisplayName:   tenant.Name,
+		IsDefault:     true,
+	}
+	if tenant.WhatsAppBusinessAccountID != nil {
+		channel.BusinessAccountID = *tenant.WhatsAppBusinessAccountID
+	}
+	return channel
+}
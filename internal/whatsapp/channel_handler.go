@@ -0,0 +1,110 @@
+package whatsapp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/secrets"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+)
+
+type ChannelHandler struct {
+	channelService ChannelService
+}
+
+func NewChannelHandler(channelService ChannelService) *ChannelHandler {
+	return &ChannelHandler{
+		channelService: channelService,
+	}
+}
+
+func (h *ChannelHandler) List(c *gin.Context) {
+	items, err := h.channelService.ListChannels(c.Request.Context(), web.TenantID(c))
+	if err != nil {
+		c.JSON(channelStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, ListChannelsResponse{Items: items})
+}
+
+func (h *ChannelHandler) Get(c *gin.Context) {
+	channel, err := h.channelService.GetChannel(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(channelStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+func (h *ChannelHandler) Create(c *gin.Context) {
+	var req CreateChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	channel, err := h.channelService.CreateChannel(c.Request.Context(), web.TenantID(c), req)
+	if err != nil {
+		c.JSON(channelStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, channel)
+}
+
+func (h *ChannelHandler) Update(c *gin.Context) {
+	var req UpdateChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	channel, err := h.channelService.UpdateChannel(c.Request.Context(), web.TenantID(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(channelStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+func (h *ChannelHandler) Delete(c *gin.Context) {
+	if err := h.channelService.DeleteChannel(c.Request.Context(), web.TenantID(c), c.Param("id")); err != nil {
+		c.JSON(channelStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetDefault define o número usado nos envios que não indicam canal
+func (h *ChannelHandler) SetDefault(c *gin.Context) {
+	channel, err := h.channelService.SetDefault(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(channelStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+func channelStatusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrChannelNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrPhoneNumberTaken):
+		return http.StatusConflict
+	case errors.Is(err, ErrPhoneNumberNotOwned):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidDefaultTeam),
+		errors.Is(err, ErrInvalidDefaultFlow):
+		return http.StatusBadRequest
+	case errors.Is(err, secrets.ErrNoKey):
+		// Token próprio exige SECRETS_ENCRYPTION_KEY configurada
+		return http.StatusUnprocessableEntity
+	}
+	return statusFor(err)
+}
@@ -0,0 +1,203 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type channelRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newChannelRepositoryBase(db *gorm.DB) *channelRepositoryBase {
+	return &channelRepositoryBase{db: db}
+}
+
+func (r *channelRepositoryBase) create(ctx context.Context, channel *Channel) error {
+	return r.db.WithContext(ctx).Create(channel).Error
+}
+
+func (r *channelRepositoryBase) find(ctx context.Context, query *gorm.DB) (*Channel, error) {
+	var channel Channel
+	err := query.First(&channel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &channel, nil
+}
+
+func (r *channelRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id))
+}
+
+func (r *channelRepositoryBase) findByPhoneNumberID(ctx context.Context, phoneNumberID string) (*Channel, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("phone_number_id = ?", phoneNumberID))
+}
+
+// findDefault cai no canal mais antigo quando nenhum foi marcado como padrão
+func (r *channelRepositoryBase) findDefault(ctx context.Context, tenantID uuid.UUID) (*Channel, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("is_default DESC, created_at ASC"))
+}
+
+func (r *channelRepositoryBase) list(ctx context.Context, tenantID uuid.UUID) ([]Channel, error) {
+	var channels []Channel
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("is_default DESC, display_name ASC").
+		Find(&channels).Error
+	if err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+func (r *channelRepositoryBase) update(ctx context.Context, channel *Channel) error {
+	return r.db.WithContext(ctx).Select("*").Save(channel).Error
+}
+
+func (r *channelRepositoryBase) delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&Channel{}).Error
+}
+
+// setDefault troca o canal padrão numa transação, mantendo um único por tenant
+func (r *channelRepositoryBase) setDefault(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Channel{}).Where("tenant_id = ? AND id <> ?", tenantID, id).Update("is_default", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Channel{}).Where("tenant_id = ? AND id = ?", tenantID, id).Update("is_default", true).Error
+	})
+}
+
+// Repository com telemetria (decorator)
+type channelRepository struct {
+	base      *channelRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewChannelRepository(db *gorm.DB, telemetry telemetry.TelemetryService) ChannelRepository {
+	return &channelRepository{
+		base:      newChannelRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *channelRepository) Create(ctx context.Context, channel *Channel) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_channel.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", channel.TenantID.String())
+	span.SetTag("phone_number_id", channel.PhoneNumberID)
+
+	err := r.base.create(ctx, channel)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *channelRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_channel.find_by_id")
+	defer span.End()
+
+	span.SetTag("channel_id", id)
+
+	channel, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (r *channelRepository) FindByPhoneNumberID(ctx context.Context, phoneNumberID string) (*Channel, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_channel.find_by_phone_number_id")
+	defer span.End()
+
+	span.SetTag("phone_number_id", phoneNumberID)
+
+	channel, err := r.base.findByPhoneNumberID(ctx, phoneNumberID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (r *channelRepository) FindDefault(ctx context.Context, tenantID uuid.UUID) (*Channel, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_channel.find_default")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	channel, err := r.base.findDefault(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (r *channelRepository) List(ctx context.Context, tenantID uuid.UUID) ([]Channel, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_channel.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	channels, err := r.base.list(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return channels, nil
+}
+
+func (r *channelRepository) Update(ctx context.Context, channel *Channel) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_channel.update")
+	defer span.End()
+
+	span.SetTag("channel_id", channel.ID.String())
+
+	err := r.base.update(ctx, channel)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *channelRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_channel.delete")
+	defer span.End()
+
+	span.SetTag("channel_id", id.String())
+
+	err := r.base.delete(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *channelRepository) SetDefault(ctx context.Context, tenantID, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_channel.set_default")
+	defer span.End()
+
+	span.SetTag("channel_id", id.String())
+
+	err := r.base.setDefault(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,389 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/secrets"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/google/uuid"
+)
+
+type channelService struct {
+	channelRepo ChannelRepository
+	tenantRepo  tenants.TenantRepository
+	provider    Provider
+	teams       TeamDirectory
+	flows       FlowDirectory
+	cipher      secrets.Cipher
+	telemetry   telemetry.TelemetryService
+}
+
+// NewChannelService com cipher nil aceita canais sem token próprio e recusa os que trazem token;
+// com teams ou flows nil nenhum time ou fluxo padrão é aceito
+func NewChannelService(
+	channelRepo ChannelRepository,
+	tenantRepo tenants.TenantRepository,
+	provider Provider,
+	teams TeamDirectory,
+	flows FlowDirectory,
+	cipher secrets.Cipher,
+	telemetry telemetry.TelemetryService,
+) ChannelService {
+	if cipher == nil {
+		cipher = secrets.Disabled()
+	}
+	return &channelService{
+		channelRepo: channelRepo,
+		tenantRepo:  tenantRepo,
+		provider:    provider,
+		teams:       teams,
+		flows:       flows,
+		cipher:      cipher,
+		telemetry:   telemetry,
+	}
+}
+
+func (s *channelService) ListChannels(ctx context.Context, tenantID uuid.UUID) ([]Channel, error) {
+	return s.channelRepo.List(ctx, tenantID)
+}
+
+func (s *channelService) GetChannel(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrChannelNotFound
+	}
+
+	channel, err := s.channelRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if channel == nil {
+		return nil, ErrChannelNotFound
+	}
+	return channel, nil
+}
+
+func (s *channelService) CreateChannel(ctx context.Context, tenantID uuid.UUID, req CreateChannelRequest) (*Channel, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.channel.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+	span.SetTag("phone_number_id", req.PhoneNumberID)
+
+	phoneNumberID := strings.TrimSpace(req.PhoneNumberID)
+	if err := s.ensureAvailable(ctx, tenantID, phoneNumberID); err != nil {
+		return nil, err
+	}
+	defaultTeamID, err := s.defaultTeam(ctx, tenantID, req.DefaultTeamID)
+	if err != nil {
+		return nil, err
+	}
+	defaultFlowID, err := s.defaultFlow(ctx, tenantID, req.DefaultFlowID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sem token próprio a verificação usa o token global, o mesmo dos envios do canal
+	verifyCtx := WithAccessToken(ctx, strings.TrimSpace(req.AccessToken))
+	if err := s.verifyOwnership(verifyCtx, phoneNumberID, req.BusinessAccountID); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	existing, err := s.channelRepo.List(ctx, tenantID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	channel := &Channel{
+		TenantID:          tenantID,
+		PhoneNumberID:     phoneNumberID,
+		DisplayName:       req.DisplayName,
+		DisplayPhone:      req.DisplayPhone,
+		BusinessAccountID: req.BusinessAccountID,
+		// O primeiro canal do tenant vira o padrão
+		IsDefault:     req.IsDefault || len(existing) == 0,
+		DefaultTeamID: defaultTeamID,
+		DefaultFlowID: defaultFlowID,
+	}
+	if err := s.setAccessToken(channel, req.AccessToken); err != nil {
+		return nil, err
+	}
+
+	if err := s.channelRepo.Create(ctx, channel); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if channel.IsDefault && len(existing) > 0 {
+		if err := s.channelRepo.SetDefault(ctx, tenantID, channel.ID); err != nil {
+			span.SetError(err)
+			return nil, err
+		}
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "whatsapp.channel.created",
+		Properties: map[string]interface{}{
+			"tenant_id":       tenantID.String(),
+			"channel_id":      channel.ID.String(),
+			"phone_number_id": channel.PhoneNumberID,
+		},
+		Timestamp: time.Now(),
+	})
+
+	return channel, nil
+}
+
+func (s *channelService) UpdateChannel(ctx context.Context, tenantID uuid.UUID, id string, req UpdateChannelRequest) (*Channel, error) {
+	channel, err := s.GetChannel(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trocar a WABA ou o token exige que o número continue acessível pelas novas credenciais
+	if req.BusinessAccountID != nil || req.AccessToken != nil {
+		verifyCtx := ctx
+		if req.AccessToken != nil {
+			verifyCtx = WithAccessToken(ctx, strings.TrimSpace(*req.AccessToken))
+		} else if verifyCtx, err = s.WithCredentials(ctx, channel); err != nil {
+			return nil, err
+		}
+		businessAccountID := channel.BusinessAccountID
+		if req.BusinessAccountID != nil {
+			businessAccountID = *req.BusinessAccountID
+		}
+		if err := s.verifyOwnership(verifyCtx, channel.PhoneNumberID, businessAccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.DisplayName != nil {
+		channel.DisplayName = *req.DisplayName
+	}
+	if req.DisplayPhone != nil {
+		channel.DisplayPhone = *req.DisplayPhone
+	}
+	if req.BusinessAccountID != nil {
+		channel.BusinessAccountID = *req.BusinessAccountID
+	}
+	if req.AccessToken != nil {
+		if err := s.setAccessToken(channel, *req.AccessToken); err != nil {
+			return nil, err
+		}
+	}
+	if req.DefaultTeamID != nil {
+		if channel.DefaultTeamID, err = s.defaultTeam(ctx, tenantID, req.DefaultTeamID); err != nil {
+			return nil, err
+		}
+	}
+	if req.DefaultFlowID != nil {
+		if channel.DefaultFlowID, err = s.defaultFlow(ctx, tenantID, req.DefaultFlowID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.channelRepo.Update(ctx, channel); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (s *channelService) DeleteChannel(ctx context.Context, tenantID uuid.UUID, id string) error {
+	channel, err := s.GetChannel(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	return s.channelRepo.Delete(ctx, tenantID, channel.ID)
+}
+
+func (s *channelService) SetDefault(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error) {
+	channel, err := s.GetChannel(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.channelRepo.SetDefault(ctx, tenantID, channel.ID); err != nil {
+		return nil, err
+	}
+	channel.IsDefault = true
+	return channel, nil
+}
+
+func (s *channelService) ResolveInbound(ctx context.Context, phoneNumberID string) (*Channel, error) {
+	channel, err := s.channelRepo.FindByPhoneNumberID(ctx, phoneNumberID)
+	if err != nil || channel != nil {
+		return channel, err
+	}
+
+	tenant, err := s.tenantRepo.FindByWhatsAppPhoneNumberID(ctx, phoneNumberID)
+	if err != nil || tenant == nil {
+		return nil, err
+	}
+	legacy := *tenant
+	legacy.WhatsAppPhoneNumberID = &phoneNumberID
+	return legacyChannel(&legacy), nil
+}
+
+func (s *channelService) SelectOutbound(ctx context.Context, tenantID uuid.UUID, channelID, phoneNumberID string) (*Channel, error) {
+	if channelID != "" {
+		return s.GetChannel(ctx, tenantID, channelID)
+	}
+
+	if phoneNumberID != "" {
+		channel, err := s.channelRepo.FindByPhoneNumberID(ctx, phoneNumberID)
+		if err != nil {
+			return nil, err
+		}
+		if channel != nil && channel.TenantID == tenantID {
+			return channel, nil
+		}
+	}
+
+	channel, err := s.channelRepo.FindDefault(ctx, tenantID)
+	if err != nil || channel != nil {
+		return channel, err
+	}
+
+	// Tenants sem canais cadastrados seguem usando o número do cadastro
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID.String())
+	if err != nil {
+		return nil, err
+	}
+	if channel := legacyChannel(tenant); channel != nil {
+		return channel, nil
+	}
+	return nil, ErrChannelNotConfigured
+}
+
+func (s *channelService) WithCredentials(ctx context.Context, channel *Channel) (context.Context, error) {
+	if channel == nil || channel.AccessTokenEncrypted == "" {
+		return ctx, nil
+	}
+	token, err := s.cipher.Decrypt(channel.AccessTokenEncrypted)
+	if err != nil {
+		return ctx, err
+	}
+	return WithAccessToken(ctx, token), nil
+}
+
+// ensureAvailable impede que o mesmo número seja de dois canais ou de outro tenant
+func (s *channelService) ensureAvailable(ctx context.Context, tenantID uuid.UUID, phoneNumberID string) error {
+	channel, err := s.channelRepo.FindByPhoneNumberID(ctx, phoneNumberID)
+	if err != nil {
+		return err
+	}
+	if channel != nil {
+		return ErrPhoneNumberTaken
+	}
+
+	tenant, err := s.tenantRepo.FindByWhatsAppPhoneNumberID(ctx, phoneNumberID)
+	if err != nil {
+		return err
+	}
+	if tenant != nil && tenant.ID != tenantID {
+		return ErrPhoneNumberTaken
+	}
+	return nil
+}
+
+func (s *channelService) setAccessToken(channel *Channel, token string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		channel.AccessTokenEncrypted = ""
+		channel.HasAccessToken = false
+		return nil
+	}
+
+	encrypted, err := s.cipher.Encrypt(token)
+	if err != nil {
+		return err
+	}
+	channel.AccessTokenEncrypted = encrypted
+	channel.HasAccessToken = true
+	return nil
+}
+
+// verifyOwnership confirma na Graph API, com as credenciais do contexto, que o número
+// existe para o token e, quando a WABA é informada, que pertence a ela
+func (s *channelService) verifyOwnership(ctx context.Context, phoneNumberID, businessAccountID string) error {
+	if businessAccountID == "" {
+		_, err := s.provider.GetPhoneNumber(ctx, phoneNumberID)
+		return ownershipError(err)
+	}
+
+	numbers, err := s.provider.ListPhoneNumbers(ctx, businessAccountID)
+	if err != nil {
+		return ownershipError(err)
+	}
+	for _, number := range numbers {
+		if number.ID == phoneNumberID {
+			return nil
+		}
+	}
+	return ErrPhoneNumberNotOwned
+}
+
+// ownershipError trata as recusas da Graph API (número inexistente, sem permissão ou
+// token inválido) como número alheio; falhas do provider seguem como estão
+func ownershipError(err error) error {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) && providerErr.StatusCode < 500 {
+		return fmt.Errorf("%w: %s", ErrPhoneNumberNotOwned, providerErr.Message)
+	}
+	return err
+}
+
+// defaultTeam valida o time padrão informado; vazio remove o padrão
+func (s *channelService) defaultTeam(ctx context.Context, tenantID uuid.UUID, value *string) (*uuid.UUID, error) {
+	id, err := optionalUUID(value, ErrInvalidDefaultTeam)
+	if err != nil || id == nil {
+		return id, err
+	}
+	if s.teams == nil {
+		return nil, ErrInvalidDefaultTeam
+	}
+
+	found, err := s.teams.TeamInTenant(ctx, tenantID, *id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrInvalidDefaultTeam
+	}
+	return id, nil
+}
+
+// defaultFlow valida o fluxo padrão informado; vazio remove o padrão
+func (s *channelService) defaultFlow(ctx context.Context, tenantID uuid.UUID, value *string) (*uuid.UUID, error) {
+	id, err := optionalUUID(value, ErrInvalidDefaultFlow)
+	if err != nil || id == nil {
+		return id, err
+	}
+	if s.flows == nil {
+		return nil, ErrInvalidDefaultFlow
+	}
+
+	found, err := s.flows.FlowInTenant(ctx, tenantID, *id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrInvalidDefaultFlow
+	}
+	return id, nil
+}
+
+func optionalUUID(value *string, invalid error) (*uuid.UUID, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, invalid
+	}
+	return &id, nil
+}
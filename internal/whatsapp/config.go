@@ -0,0 +1,56 @@
+package whatsapp
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	ProviderCloudAPI = "cloud"
+	ProviderFake     = "fake"
+)
+
+type Config struct {
+	// Token configurado no painel da Meta para o handshake do webhook
+	VerifyToken string
+	// App secret usado para validar o header X-Hub-Signature-256
+	AppSecret string
+
+	// Provider de envio: "cloud" (padrão) ou "fake"
+	Provider    string
+	APIBaseURL  string
+	AccessToken string
+
+	// EventWorkers e EventBuffer dimensionam a EventQueue dos listeners do webhook
+	EventWorkers int
+	EventBuffer  int
+}
+
+func LoadConfig() Config {
+	workers, err := strconv.Atoi(os.Getenv("WHATSAPP_EVENT_WORKERS"))
+	if err != nil || workers <= 0 {
+		workers = 4
+	}
+	buffer, err := strconv.Atoi(os.Getenv("WHATSAPP_EVENT_BUFFER"))
+	if err != nil || buffer <= 0 {
+		buffer = 256
+	}
+
+	return Config{
+		VerifyToken:  os.Getenv("WHATSAPP_VERIFY_TOKEN"),
+		AppSecret:    os.Getenv("WHATSAPP_APP_SECRET"),
+		Provider:     os.Getenv("WHATSAPP_PROVIDER"),
+		APIBaseURL:   os.Getenv("WHATSAPP_API_BASE_URL"),
+		AccessToken:  os.Getenv("WHATSAPP_ACCESS_TOKEN"),
+		EventWorkers: workers,
+		EventBuffer:  buffer,
+	}
+}
+
+// NewProvider cria o Provider configurado
+func NewProvider(config Config) Provider {
+	if config.Provider == ProviderFake {
+		return NewFakeProvider()
+	}
+	return NewCloudAPIProvider(config.APIBaseURL, config.AccessToken, nil)
+}
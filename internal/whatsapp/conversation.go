@@ -0,0 +1,123 @@
+package whatsapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const ChannelWhatsApp = "whatsapp"
+
+type ConversationStatus string
+
+const (
+	ConversationOpen     ConversationStatus = "open"
+	ConversationPending  ConversationStatus = "pending"
+	ConversationResolved ConversationStatus = "resolved"
+)
+
+func (s ConversationStatus) Valid() bool {
+	switch s {
+	case ConversationOpen, ConversationPending, ConversationResolved:
+		return true
+	}
+	return false
+}
+
+// InboxView define o recorte da caixa compartilhada
+type InboxView string
+
+const (
+	ViewAll        InboxView = "all"
+	ViewMine       InboxView = "mine"
+	ViewUnassigned InboxView = "unassigned"
+)
+
+func (v InboxView) Valid() bool {
+	switch v {
+	case ViewAll, ViewMine, ViewUnassigned:
+		return true
+	}
+	return false
+}
+
+// Conversation agrupa as mensagens de um cliente em um canal
+type Conversation struct {
+	ID            uuid.UUID          `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID      uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_conversations_customer_channel,priority:1;index:idx_conversations_inbox,priority:1" json:"tenant_id"`
+	CustomerID    uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_conversations_customer_channel,priority:2" json:"customer_id"`
+	Channel       string             `gorm:"type:varchar(32);not null;uniqueIndex:idx_conversations_customer_channel,priority:3" json:"channel"`
+	PhoneNumberID string             `gorm:"type:varchar(64);not null;uniqueIndex:idx_conversations_customer_channel,priority:4" json:"phone_number_id"`
+	ContactPhone  string             `gorm:"type:varchar(32);not null" json:"contact_phone"`
+	ContactName   string             `gorm:"type:varchar(255)" json:"contact_name,omitempty"`
+	Status        ConversationStatus `gorm:"type:varchar(16);not null;index:idx_conversations_inbox,priority:2" json:"status"`
+	AssigneeID    *uuid.UUID         `gorm:"type:uuid;index" json:"assignee_id,omitempty"`
+	TeamID        *uuid.UUID         `gorm:"type:uuid;index" json:"team_id,omitempty"`
+	UnreadCount   int                `gorm:"not null;default:0" json:"unread_count"`
+
+	LastMessagePreview   string     `gorm:"type:varchar(255)" json:"last_message_preview,omitempty"`
+	LastMessageDirection Direction  `gorm:"type:varchar(16)" json:"last_message_direction,omitempty"`
+	LastMessageAt        *time.Time `gorm:"index" json:"last_message_at,omitempty"`
+
+	// LastInboundAt abre a janela de atendimento de 24h da Meta
+	LastInboundAt   *time.Time `json:"last_inbound_at,omitempty"`
+	WindowExpiresAt *time.Time `gorm:"-" json:"window_expires_at,omitempty"`
+	WindowOpen      bool       `gorm:"-" json:"window_open"`
+
+	// ResolvedAt marca o último encerramento; automações usam para saber se o atendimento recomeçou
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+
+	// AwayMessageSentAt evita repetir a mensagem de ausência a cada mensagem fora do expediente
+	AwayMessageSentAt *time.Time `json:"away_message_sent_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *Conversation) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// AfterFind preenche os campos calculados da janela de atendimento
+func (c *Conversation) AfterFind(tx *gorm.DB) error {
+	c.WindowExpiresAt = ServiceWindowExpiry(c.LastInboundAt)
+	c.WindowOpen = c.IsWindowOpen(time.Now())
+	return nil
+}
+
+// IsWindowOpen indica se mensagens livres (não-template) ainda podem ser enviadas
+func (c *Conversation) IsWindowOpen(now time.Time) bool {
+	expiry := ServiceWindowExpiry(c.LastInboundAt)
+	return expiry != nil && now.Before(*expiry)
+}
+
+type ConversationFilter struct {
+	TenantID uuid.UUID
+	View     InboxView
+	UserID   uuid.UUID
+	TeamID   *uuid.UUID
+	Statuses []ConversationStatus
+	Offset   int
+	Limit    int
+}
+
+const previewLength = 100
+
+// Preview gera o texto exibido na lista da caixa de entrada
+func (m *Message) Preview() string {
+	text := m.Body
+	if text == "" {
+		text = fmt.Sprintf("[%s]", m.Type)
+	}
+
+	runes := []rune(text)
+	if len(runes) > previewLength {
+		return string(runes[:previewLength-1]) + "…"
+	}
+	return text
+}
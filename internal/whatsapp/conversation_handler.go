@@ -0,0 +1,159 @@
+package whatsapp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ConversationHandler struct {
+	conversationService ConversationService
+}
+
+func NewConversationHandler(conversationService ConversationService) *ConversationHandler {
+	return &ConversationHandler{
+		conversationService: conversationService,
+	}
+}
+
+// List aceita ?view=mine|unassigned|all, ?status=open,pending e ?team_id=
+func (h *ConversationHandler) List(c *gin.Context) {
+	pagination := web.ParsePagination(c)
+
+	filter := ConversationFilter{
+		TenantID: web.TenantID(c),
+		UserID:   web.UserID(c),
+		View:     InboxView(c.DefaultQuery("view", string(ViewAll))),
+		Offset:   pagination.Offset(),
+		Limit:    pagination.PageSize,
+	}
+	if teamID, err := uuid.Parse(c.Query("team_id")); err == nil {
+		filter.TeamID = &teamID
+	}
+	for _, value := range c.QueryArray("status") {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filter.Statuses = append(filter.Statuses, ConversationStatus(status))
+			}
+		}
+	}
+
+	items, total, err := h.conversationService.ListConversations(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(conversationStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, ListConversationsResponse{
+		Items:    items,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+		Total:    total,
+	})
+}
+
+func (h *ConversationHandler) Get(c *gin.Context) {
+	conversation, err := h.conversationService.GetConversation(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(conversationStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+func (h *ConversationHandler) Messages(c *gin.Context) {
+	pagination := web.ParsePagination(c)
+
+	items, total, err := h.conversationService.ListMessages(c.Request.Context(), web.TenantID(c), c.Param("id"), pagination.Offset(), pagination.PageSize)
+	if err != nil {
+		c.JSON(conversationStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, ListMessagesResponse{
+		Items:    items,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+		Total:    total,
+	})
+}
+
+func (h *ConversationHandler) MarkRead(c *gin.Context) {
+	if err := h.conversationService.MarkRead(c.Request.Context(), web.TenantID(c), c.Param("id")); err != nil {
+		c.JSON(conversationStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ConversationHandler) UpdateStatus(c *gin.Context) {
+	var req UpdateConversationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	conversation, err := h.conversationService.UpdateStatus(c.Request.Context(), web.TenantID(c), c.Param("id"), req.Status)
+	if err != nil {
+		c.JSON(conversationStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+func (h *ConversationHandler) Assign(c *gin.Context) {
+	var req AssignConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	var assigneeID *uuid.UUID
+	if req.AssigneeID != nil {
+		id := uuid.MustParse(*req.AssigneeID)
+		assigneeID = &id
+	}
+
+	conversation, err := h.conversationService.Assign(c.Request.Context(), web.TenantID(c), c.Param("id"), assigneeID)
+	if err != nil {
+		c.JSON(conversationStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+func (h *ConversationHandler) Reply(c *gin.Context) {
+	var req ReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	message, err := h.conversationService.SendMessage(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Param("id"), req.ToSendRequest())
+	if err != nil {
+		c.JSON(conversationStatusFor(err), errorBody(c, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+func conversationStatusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrConversationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidView),
+		errors.Is(err, ErrInvalidConversationStatus),
+		errors.Is(err, ErrInvalidAssignee):
+		return http.StatusBadRequest
+	}
+	return statusFor(err)
+}
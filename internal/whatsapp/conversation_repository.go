@@ -0,0 +1,375 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type conversationRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newConversationRepositoryBase(db *gorm.DB) *conversationRepositoryBase {
+	return &conversationRepositoryBase{db: db}
+}
+
+func (r *conversationRepositoryBase) findOne(ctx context.Context, query string, args ...interface{}) (*Conversation, error) {
+	var conversation Conversation
+	err := r.db.WithContext(ctx).Where(query, args...).First(&conversation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+func (r *conversationRepositoryBase) findOrCreate(ctx context.Context, conversation *Conversation) (*Conversation, error) {
+	query := "tenant_id = ? AND customer_id = ? AND channel = ? AND phone_number_id = ?"
+	args := []interface{}{conversation.TenantID, conversation.CustomerID, conversation.Channel, conversation.PhoneNumberID}
+
+	existing, err := r.findOne(ctx, query, args...)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	if err := r.db.WithContext(ctx).Create(conversation).Error; err != nil {
+		// Criada em paralelo por outro webhook: usa a existente
+		if existing, findErr := r.findOne(ctx, query, args...); findErr == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (r *conversationRepositoryBase) list(ctx context.Context, filter ConversationFilter) ([]Conversation, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Conversation{}).Where("tenant_id = ?", filter.TenantID)
+
+	switch filter.View {
+	case ViewMine:
+		query = query.Where("assignee_id = ?", filter.UserID)
+	case ViewUnassigned:
+		query = query.Where("assignee_id IS NULL")
+	}
+	if filter.TeamID != nil {
+		query = query.Where("team_id = ?", *filter.TeamID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []Conversation
+	err := query.Order("last_message_at DESC, id DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// updateStatus grava só as colunas do status: um Save da linha lida antes
+// desfaria contadores e preview gravados em paralelo pelos webhooks
+func (r *conversationRepositoryBase) updateStatus(ctx context.Context, id uuid.UUID, status ConversationStatus, resolvedAt *time.Time) error {
+	updates := map[string]interface{}{
+		"status":     status,
+		"updated_at": time.Now(),
+	}
+	if resolvedAt != nil {
+		updates["resolved_at"] = resolvedAt
+	}
+	return r.db.WithContext(ctx).Model(&Conversation{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *conversationRepositoryBase) assign(ctx context.Context, id uuid.UUID, assigneeID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&Conversation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"assignee_id": assigneeID,
+		"updated_at":  time.Now(),
+	}).Error
+}
+
+// recordMessage atualiza o preview e o contador de não lidas de forma atômica
+func (r *conversationRepositoryBase) recordMessage(ctx context.Context, id uuid.UUID, message *Message) error {
+	updates := map[string]interface{}{
+		"last_message_preview":   message.Preview(),
+		"last_message_direction": message.Direction,
+		"last_message_at":        message.Timestamp,
+		"updated_at":             time.Now(),
+	}
+	if message.Direction == DirectionInbound {
+		updates["unread_count"] = gorm.Expr("unread_count + 1")
+		// Webhooks fora de ordem não podem encurtar a janela
+		updates["last_inbound_at"] = gorm.Expr(
+			"CASE WHEN last_inbound_at IS NULL OR last_inbound_at < ? THEN ? ELSE last_inbound_at END",
+			message.Timestamp, message.Timestamp,
+		)
+	}
+	return r.db.WithContext(ctx).Model(&Conversation{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// claimAwayMessage reserva o envio da mensagem de ausência; só um listener vence
+// e nada é reenviado antes do fim do intervalo
+func (r *conversationRepositoryBase) claimAwayMessage(ctx context.Context, id uuid.UUID, now, notSince time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Conversation{}).
+		Where("id = ? AND (away_message_sent_at IS NULL OR away_message_sent_at <= ?)", id, notSince).
+		Update("away_message_sent_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *conversationRepositoryBase) markRead(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&Conversation{}).Where("id = ?", id).Update("unread_count", 0).Error
+}
+
+func (r *conversationRepositoryBase) route(ctx context.Context, id uuid.UUID, teamID, assigneeID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&Conversation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"team_id":     teamID,
+		"assignee_id": assigneeID,
+		"updated_at":  time.Now(),
+	}).Error
+}
+
+// claimAssignee evita que duas distribuições simultâneas atribuam a mesma conversa
+func (r *conversationRepositoryBase) claimAssignee(ctx context.Context, id, assigneeID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Conversation{}).
+		Where("id = ? AND assignee_id IS NULL", id).
+		Updates(map[string]interface{}{"assignee_id": assigneeID, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *conversationRepositoryBase) countActiveByAssignee(ctx context.Context, tenantID uuid.UUID, assigneeIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(assigneeIDs))
+	if len(assigneeIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		AssigneeID uuid.UUID
+		Total      int
+	}
+	err := r.db.WithContext(ctx).Model(&Conversation{}).
+		Select("assignee_id, COUNT(*) AS total").
+		Where("tenant_id = ? AND assignee_id IN ? AND status IN ?", tenantID, assigneeIDs, []ConversationStatus{ConversationOpen, ConversationPending}).
+		Group("assignee_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.AssigneeID] = row.Total
+	}
+	return counts, nil
+}
+
+func (r *conversationRepositoryBase) listQueued(ctx context.Context, teamID uuid.UUID, limit int) ([]Conversation, error) {
+	var items []Conversation
+	err := r.db.WithContext(ctx).Where("team_id = ? AND assignee_id IS NULL AND status IN ?", teamID, []ConversationStatus{ConversationOpen, ConversationPending}).
+		Order("last_inbound_at ASC, created_at ASC").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Repository com telemetria (decorator)
+type conversationRepository struct {
+	base      *conversationRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewConversationRepository(db *gorm.DB, telemetry telemetry.TelemetryService) ConversationRepository {
+	return &conversationRepository{
+		base:      newConversationRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *conversationRepository) FindOrCreate(ctx context.Context, conversation *Conversation) (*Conversation, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.find_or_create")
+	defer span.End()
+
+	span.SetTag("tenant_id", conversation.TenantID.String())
+	span.SetTag("customer_id", conversation.CustomerID.String())
+
+	result, err := r.base.findOrCreate(ctx, conversation)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *conversationRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Conversation, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.find_by_id")
+	defer span.End()
+
+	span.SetTag("conversation_id", id)
+
+	conversation, err := r.base.findOne(ctx, "tenant_id = ? AND id = ?", tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (r *conversationRepository) List(ctx context.Context, filter ConversationFilter) ([]Conversation, int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", filter.TenantID.String())
+	span.SetTag("view", string(filter.View))
+
+	items, total, err := r.base.list(ctx, filter)
+	if err != nil {
+		span.SetError(err)
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *conversationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status ConversationStatus, resolvedAt *time.Time) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.update_status")
+	defer span.End()
+
+	span.SetTag("conversation_id", id.String())
+	span.SetTag("status", string(status))
+
+	err := r.base.updateStatus(ctx, id, status, resolvedAt)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *conversationRepository) Assign(ctx context.Context, id uuid.UUID, assigneeID *uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.assign")
+	defer span.End()
+
+	span.SetTag("conversation_id", id.String())
+
+	err := r.base.assign(ctx, id, assigneeID)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *conversationRepository) RecordMessage(ctx context.Context, id uuid.UUID, message *Message) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.record_message")
+	defer span.End()
+
+	span.SetTag("conversation_id", id.String())
+
+	err := r.base.recordMessage(ctx, id, message)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *conversationRepository) ClaimAwayMessage(ctx context.Context, id uuid.UUID, now, notSince time.Time) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.claim_away_message")
+	defer span.End()
+
+	span.SetTag("conversation_id", id.String())
+
+	claimed, err := r.base.claimAwayMessage(ctx, id, now, notSince)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return claimed, nil
+}
+
+func (r *conversationRepository) MarkRead(ctx context.Context, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.mark_read")
+	defer span.End()
+
+	span.SetTag("conversation_id", id.String())
+
+	err := r.base.markRead(ctx, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *conversationRepository) Route(ctx context.Context, id uuid.UUID, teamID, assigneeID *uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.route")
+	defer span.End()
+
+	span.SetTag("conversation_id", id.String())
+
+	err := r.base.route(ctx, id, teamID, assigneeID)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *conversationRepository) ClaimAssignee(ctx context.Context, id, assigneeID uuid.UUID) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.claim_assignee")
+	defer span.End()
+
+	span.SetTag("conversation_id", id.String())
+	span.SetTag("assignee_id", assigneeID.String())
+
+	claimed, err := r.base.claimAssignee(ctx, id, assigneeID)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+	return claimed, nil
+}
+
+func (r *conversationRepository) CountActiveByAssignee(ctx context.Context, tenantID uuid.UUID, assigneeIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.count_active_by_assignee")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	counts, err := r.base.countActiveByAssignee(ctx, tenantID, assigneeIDs)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *conversationRepository) ListQueued(ctx context.Context, teamID uuid.UUID, limit int) ([]Conversation, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.conversation.list_queued")
+	defer span.End()
+
+	span.SetTag("team_id", teamID.String())
+
+	items, err := r.base.listQueued(ctx, teamID, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,267 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrConversationNotFound      = errors.New("conversation not found")
+	ErrInvalidConversationStatus = errors.New("invalid conversation status")
+	ErrInvalidAssignee           = errors.New("assignee not found in tenant")
+	ErrInvalidView               = errors.New("invalid inbox view")
+)
+
+type conversationService struct {
+	conversationRepo ConversationRepository
+	messageRepo      MessageRepository
+	messageService   MessageService
+	customerService  customers.CustomerService
+	userRepo         auth.UserRepository
+	telemetry        telemetry.TelemetryService
+
+	mu        sync.RWMutex
+	listeners []EventListener
+}
+
+func NewConversationService(
+	conversationRepo ConversationRepository,
+	messageRepo MessageRepository,
+	messageService MessageService,
+	customerService customers.CustomerService,
+	userRepo auth.UserRepository,
+	telemetry telemetry.TelemetryService,
+) ConversationService {
+	return &conversationService{
+		conversationRepo: conversationRepo,
+		messageRepo:      messageRepo,
+		messageService:   messageService,
+		customerService:  customerService,
+		userRepo:         userRepo,
+		telemetry:        telemetry,
+	}
+}
+
+func (s *conversationService) Subscribe(listener EventListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *conversationService) publish(ctx context.Context, event Event) {
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(ctx, event)
+	}
+}
+
+func (s *conversationService) HandleEvent(ctx context.Context, event Event) {
+	inbound, ok := event.(InboundMessageEvent)
+	if !ok {
+		return
+	}
+
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.conversation.handle_inbound")
+	defer span.End()
+
+	if err := s.attachInbound(ctx, inbound); err != nil {
+		span.SetError(err)
+	}
+}
+
+// attachInbound vincula a mensagem recebida à conversa do cliente,
+// criando o cliente e a conversa no primeiro contato
+func (s *conversationService) attachInbound(ctx context.Context, event InboundMessageEvent) error {
+	message := event.Message
+
+	customer, err := s.customerService.FindOrCreateByPhone(ctx, event.TenantID, message.From, message.ContactName)
+	if err != nil {
+		return err
+	}
+
+	conversation, err := s.conversationRepo.FindOrCreate(ctx, &Conversation{
+		TenantID:      event.TenantID,
+		CustomerID:    customer.ID,
+		Channel:       ChannelWhatsApp,
+		PhoneNumberID: event.PhoneNumberID,
+		ContactPhone:  customer.Phone,
+		ContactName:   message.ContactName,
+		Status:        ConversationOpen,
+	})
+	if err != nil {
+		return err
+	}
+
+	message.ConversationID = &conversation.ID
+	if err := s.messageRepo.Update(ctx, message); err != nil {
+		return err
+	}
+
+	// Cliente voltou a falar: conversa pendente ou resolvida é reaberta
+	if conversation.Status != ConversationOpen {
+		conversation.Status = ConversationOpen
+		if err := s.conversationRepo.UpdateStatus(ctx, conversation.ID, ConversationOpen, nil); err != nil {
+			return err
+		}
+	}
+
+	return s.conversationRepo.RecordMessage(ctx, conversation.ID, message)
+}
+
+func (s *conversationService) ListConversations(ctx context.Context, filter ConversationFilter) ([]Conversation, int64, error) {
+	if filter.View == "" {
+		filter.View = ViewAll
+	}
+	if !filter.View.Valid() {
+		return nil, 0, ErrInvalidView
+	}
+	for _, status := range filter.Statuses {
+		if !status.Valid() {
+			return nil, 0, ErrInvalidConversationStatus
+		}
+	}
+	return s.conversationRepo.List(ctx, filter)
+}
+
+func (s *conversationService) GetConversation(ctx context.Context, tenantID uuid.UUID, id string) (*Conversation, error) {
+	conversation, err := s.conversationRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if conversation == nil {
+		return nil, ErrConversationNotFound
+	}
+	return conversation, nil
+}
+
+func (s *conversationService) ListMessages(ctx context.Context, tenantID uuid.UUID, id string, offset, limit int) ([]Message, int64, error) {
+	conversation, err := s.GetConversation(ctx, tenantID, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.messageRepo.ListByConversation(ctx, tenantID, conversation.ID, offset, limit)
+}
+
+func (s *conversationService) MarkRead(ctx context.Context, tenantID uuid.UUID, id string) error {
+	conversation, err := s.GetConversation(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	return s.conversationRepo.MarkRead(ctx, conversation.ID)
+}
+
+func (s *conversationService) UpdateStatus(ctx context.Context, tenantID uuid.UUID, id string, status ConversationStatus) (*Conversation, error) {
+	if !status.Valid() {
+		return nil, ErrInvalidConversationStatus
+	}
+
+	conversation, err := s.GetConversation(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolvedAt *time.Time
+	if status == ConversationResolved {
+		now := time.Now()
+		resolvedAt = &now
+	}
+	if err := s.conversationRepo.UpdateStatus(ctx, conversation.ID, status, resolvedAt); err != nil {
+		return nil, err
+	}
+	conversation.Status = status
+	if resolvedAt != nil {
+		conversation.ResolvedAt = resolvedAt
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "whatsapp.conversation.status_changed",
+		Properties: map[string]interface{}{
+			"conversation_id": conversation.ID.String(),
+			"tenant_id":       tenantID.String(),
+			"status":          string(status),
+		},
+		Timestamp: time.Now(),
+	})
+	s.publish(ctx, ConversationUpdatedEvent{TenantID: tenantID, Conversation: conversation, Change: ConversationStatusChanged})
+
+	return conversation, nil
+}
+
+func (s *conversationService) Assign(ctx context.Context, tenantID uuid.UUID, id string, assigneeID *uuid.UUID) (*Conversation, error) {
+	conversation, err := s.GetConversation(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if assigneeID != nil {
+		assignee, err := s.userRepo.FindByID(ctx, assigneeID.String())
+		if err != nil {
+			return nil, err
+		}
+		if assignee == nil || assignee.TenantID != tenantID {
+			return nil, ErrInvalidAssignee
+		}
+	}
+
+	if err := s.conversationRepo.Assign(ctx, conversation.ID, assigneeID); err != nil {
+		return nil, err
+	}
+	conversation.AssigneeID = assigneeID
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "whatsapp.conversation.assigned",
+		Properties: map[string]interface{}{
+			"conversation_id": conversation.ID.String(),
+			"tenant_id":       tenantID.String(),
+			"assigned":        assigneeID != nil,
+		},
+		Timestamp: time.Now(),
+	})
+	s.publish(ctx, ConversationUpdatedEvent{TenantID: tenantID, Conversation: conversation, Change: ConversationAssigned})
+
+	return conversation, nil
+}
+
+// SendMessage responde ao cliente da conversa
+func (s *conversationService) SendMessage(ctx context.Context, tenantID, userID uuid.UUID, id string, req SendMessageRequest) (*Message, error) {
+	conversation, err := s.GetConversation(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type.RequiresWindow() && !conversation.IsWindowOpen(time.Now()) {
+		s.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "whatsapp.message.window_closed",
+			Value: 1,
+			Tags:  map[string]string{"type": string(req.Type)},
+		})
+		return nil, ErrWindowClosed
+	}
+
+	req.To = conversation.ContactPhone
+	req.ConversationID = &conversation.ID
+	// A resposta sai pelo número da conversa; a janela de atendimento é por número
+	req.ChannelID = ""
+	req.PhoneNumberID = conversation.PhoneNumberID
+
+	message, err := s.messageService.SendMessage(ctx, tenantID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.conversationRepo.RecordMessage(ctx, conversation.ID, message); err != nil {
+		return nil, err
+	}
+	s.publish(ctx, MessageSentEvent{TenantID: tenantID, Conversation: conversation, Message: message})
+	return message, nil
+}
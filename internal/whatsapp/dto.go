@@ -0,0 +1,130 @@
+package whatsapp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SendMessageRequest struct {
+	To          string              `json:"to" binding:"required"`
+	Type        MessageType         `json:"type" binding:"required"`
+	Text        *TextContent        `json:"text"`
+	Media       *MediaContent       `json:"media"`
+	Interactive *InteractiveContent `json:"interactive"`
+	Template    *TemplateContent    `json:"template"`
+	// ChannelID escolhe o número de envio; vazio usa o canal padrão do tenant
+	ChannelID string `json:"channel_id" binding:"omitempty,uuid"`
+
+	// PhoneNumberID é preenchido pela conversa para responder pelo mesmo número
+	PhoneNumberID string `json:"-"`
+	// Preenchido internamente quando o envio parte de uma conversa
+	ConversationID *uuid.UUID `json:"-"`
+	// Preview substitui o corpo persistido (ex.: texto renderizado do template)
+	Preview string `json:"-"`
+}
+
+func (r SendMessageRequest) ToOutbound() OutboundMessage {
+	return OutboundMessage{
+		To:          r.To,
+		Type:        r.Type,
+		Text:        r.Text,
+		Media:       r.Media,
+		Interactive: r.Interactive,
+		Template:    r.Template,
+	}
+}
+
+// ReplyRequest é o envio a partir de uma conversa; o destinatário vem da conversa
+type ReplyRequest struct {
+	Type        MessageType         `json:"type" binding:"required"`
+	Text        *TextContent        `json:"text"`
+	Media       *MediaContent       `json:"media"`
+	Interactive *InteractiveContent `json:"interactive"`
+	Template    *TemplateContent    `json:"template"`
+}
+
+func (r ReplyRequest) ToSendRequest() SendMessageRequest {
+	return SendMessageRequest{
+		Type:        r.Type,
+		Text:        r.Text,
+		Media:       r.Media,
+		Interactive: r.Interactive,
+		Template:    r.Template,
+	}
+}
+
+type ListConversationsResponse struct {
+	Items    []Conversation `json:"items"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+	Total    int64          `json:"total"`
+}
+
+type ListMessagesResponse struct {
+	Items    []Message `json:"items"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+	Total    int64     `json:"total"`
+}
+
+type UpdateConversationStatusRequest struct {
+	Status ConversationStatus `json:"status" binding:"required"`
+}
+
+// AssignConversationRequest com assignee_id nulo remove a atribuição
+type AssignConversationRequest struct {
+	AssigneeID *string `json:"assignee_id" binding:"omitempty,uuid"`
+}
+
+type CreateTemplateRequest struct {
+	Name       string                        `json:"name" binding:"required"`
+	Language   string                        `json:"language" binding:"required"`
+	Category   TemplateCategory              `json:"category" binding:"required"`
+	Components []TemplateDefinitionComponent `json:"components" binding:"required"`
+}
+
+// SendTemplateRequest envia para um número avulso (to) ou para uma conversa existente
+type SendTemplateRequest struct {
+	To             string            `json:"to"`
+	ConversationID *string           `json:"conversation_id" binding:"omitempty,uuid"`
+	ChannelID      string            `json:"channel_id" binding:"omitempty,uuid"`
+	Variables      TemplateVariables `json:"variables"`
+}
+
+type ListTemplatesResponse struct {
+	Items []Template `json:"items"`
+}
+
+// MediaResponse inclui a URL assinada para download
+type MediaResponse struct {
+	*Media
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"url_expires_at"`
+}
+
+// CreateChannelRequest registra um número; access_token vazio usa o token global
+type CreateChannelRequest struct {
+	PhoneNumberID     string  `json:"phone_number_id" binding:"required"`
+	DisplayName       string  `json:"display_name" binding:"required"`
+	DisplayPhone      string  `json:"display_phone"`
+	BusinessAccountID string  `json:"business_account_id"`
+	AccessToken       string  `json:"access_token"`
+	IsDefault         bool    `json:"is_default"`
+	DefaultTeamID     *string `json:"default_team_id" binding:"omitempty,uuid"`
+	DefaultFlowID     *string `json:"default_flow_id" binding:"omitempty,uuid"`
+}
+
+// UpdateChannelRequest altera só os campos enviados; access_token "" remove o token próprio
+type UpdateChannelRequest struct {
+	DisplayName       *string `json:"display_name"`
+	DisplayPhone      *string `json:"display_phone"`
+	BusinessAccountID *string `json:"business_account_id"`
+	AccessToken       *string `json:"access_token"`
+	DefaultTeamID     *string `json:"default_team_id" binding:"omitempty,uuid"`
+	DefaultFlowID     *string `json:"default_flow_id" binding:"omitempty,uuid"`
+}
+
+type ListChannelsResponse struct {
+	Items []Channel `json:"items"`
+}
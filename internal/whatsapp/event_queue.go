@@ -0,0 +1,258 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+// EventQueue entrega os eventos do webhook aos listeners fora da requisição: a
+// Meta recebe a resposta assim que a mensagem é gravada e o download de mídia, o
+// chatbot, o SLA e os demais listeners rodam nos workers. Os eventos de um mesmo
+// contato caem sempre no mesmo worker, na ordem em que chegaram.
+//
+// Cada evento do webhook é gravado antes de ir para o shard e apagado quando os
+// listeners terminam; o Start reentrega o que um processo anterior deixou para trás
+// (entrega ao menos uma vez).
+type EventQueue struct {
+	shards    []chan queuedEvent
+	pending   PendingEventRepository
+	messages  MessageRepository
+	channels  ChannelService
+	telemetry telemetry.TelemetryService
+
+	mu        sync.RWMutex
+	listeners []EventListener
+
+	// sending protege o envio aos shards contra o close do Stop
+	sending sync.RWMutex
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+type queuedEvent struct {
+	ctx   context.Context
+	event Event
+	// pendingID é a linha em PendingEvent; uuid.Nil para eventos que não são gravados
+	pendingID uuid.UUID
+}
+
+func NewEventQueue(config Config, pending PendingEventRepository, messages MessageRepository, channels ChannelService, telemetry telemetry.TelemetryService) *EventQueue {
+	if config.EventWorkers <= 0 {
+		config.EventWorkers = 1
+	}
+	shards := make([]chan queuedEvent, config.EventWorkers)
+	for i := range shards {
+		shards[i] = make(chan queuedEvent, config.EventBuffer)
+	}
+	return &EventQueue{
+		shards:    shards,
+		pending:   pending,
+		messages:  messages,
+		channels:  channels,
+		telemetry: telemetry,
+	}
+}
+
+// Subscribe registra um listener; a ordem de registro é a ordem de execução
+func (q *EventQueue) Subscribe(listener EventListener) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.listeners = append(q.listeners, listener)
+}
+
+// Enqueue é registrado como listener do WebhookService. Com a fila cheia o webhook
+// espera: a mensagem já foi gravada e um reenvio da Meta não publicaria o evento de novo.
+func (q *EventQueue) Enqueue(ctx context.Context, event Event) {
+	// O trace e o request ID seguem para o worker; o cancelamento da requisição não
+	ctx = context.WithoutCancel(ctx)
+
+	pendingID, err := q.persist(ctx, event)
+	if err != nil {
+		// Sem a linha o evento não sobreviveria a uma queda: entrega ainda na requisição
+		q.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "whatsapp.events.persist_failed",
+			Value: 1,
+			Tags:  map[string]string{"event": event.EventName()},
+		})
+		q.deliver(ctx, event)
+		return
+	}
+
+	q.push(queuedEvent{ctx: ctx, event: event, pendingID: pendingID})
+	q.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "whatsapp.events.enqueued",
+		Value: 1,
+		Tags:  map[string]string{"event": event.EventName()},
+	})
+}
+
+// Start sobe os workers e reentrega os eventos gravados que não terminaram
+func (q *EventQueue) Start(ctx context.Context) {
+	for _, shard := range q.shards {
+		q.wg.Add(1)
+		go func(shard chan queuedEvent) {
+			defer q.wg.Done()
+			for item := range shard {
+				q.process(item)
+			}
+		}(shard)
+	}
+	q.replay(ctx)
+}
+
+// Stop entrega o que já estava na fila e encerra os workers
+func (q *EventQueue) Stop() {
+	q.sending.Lock()
+	if !q.closed {
+		q.closed = true
+		for _, shard := range q.shards {
+			close(shard)
+		}
+	}
+	q.sending.Unlock()
+	q.wg.Wait()
+}
+
+func (q *EventQueue) push(item queuedEvent) {
+	q.sending.RLock()
+	defer q.sending.RUnlock()
+
+	if q.closed {
+		q.process(item)
+		return
+	}
+	q.shards[q.shard(item.event)] <- item
+}
+
+// process roda os listeners e só então libera a linha gravada
+func (q *EventQueue) process(item queuedEvent) {
+	q.deliver(item.ctx, item.event)
+	if item.pendingID == uuid.Nil {
+		return
+	}
+	if err := q.pending.Delete(item.ctx, item.pendingID); err != nil {
+		// A linha fica e o evento é entregue de novo no próximo Start
+		q.telemetry.TrackMetric(item.ctx, telemetry.Metric{
+			Name:  "whatsapp.events.release_failed",
+			Value: 1,
+			Tags:  map[string]string{"event": item.event.EventName()},
+		})
+	}
+}
+
+func (q *EventQueue) persist(ctx context.Context, event Event) (uuid.UUID, error) {
+	pending, ok, err := encodePendingEvent(event)
+	if err != nil || !ok {
+		return uuid.Nil, err
+	}
+	if err := q.pending.Create(ctx, pending); err != nil {
+		return uuid.Nil, err
+	}
+	return pending.ID, nil
+}
+
+// replay devolve aos shards os eventos que o processo anterior gravou e não concluiu
+func (q *EventQueue) replay(ctx context.Context) {
+	span, ctx := q.telemetry.StartSpan(ctx, "whatsapp.events.replay")
+	defer span.End()
+
+	pending, err := q.pending.List(ctx)
+	if err != nil {
+		span.SetError(err)
+		return
+	}
+
+	for _, row := range pending {
+		event, err := q.decode(ctx, row)
+		if err != nil {
+			// Erro de banco: a linha fica para o próximo Start
+			span.SetError(err)
+			continue
+		}
+		if event == nil {
+			// A mensagem ou o número não existem mais; não há o que entregar
+			if err := q.pending.Delete(ctx, row.ID); err != nil {
+				span.SetError(err)
+			}
+			continue
+		}
+		q.push(queuedEvent{ctx: ctx, event: event, pendingID: row.ID})
+	}
+
+	q.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "whatsapp.events.replayed",
+		Value: float64(len(pending)),
+	})
+}
+
+// decode remonta o evento gravado; nil quando os dados que ele referencia sumiram
+func (q *EventQueue) decode(ctx context.Context, row PendingEvent) (Event, error) {
+	switch row.Name {
+	case EventInboundMessage:
+		var keys pendingInbound
+		if err := json.Unmarshal([]byte(row.Payload), &keys); err != nil {
+			return nil, nil
+		}
+		channel, err := q.channels.ResolveInbound(ctx, keys.PhoneNumberID)
+		if err != nil || channel == nil {
+			return nil, err
+		}
+		message, err := q.messages.FindByWAMessageID(ctx, keys.WAMessageID)
+		if err != nil || message == nil {
+			return nil, err
+		}
+		return InboundMessageEvent{
+			TenantID:      channel.TenantID,
+			PhoneNumberID: keys.PhoneNumberID,
+			Channel:       channel,
+			Message:       message,
+		}, nil
+	case EventStatusUpdate:
+		var event StatusUpdateEvent
+		if err := json.Unmarshal([]byte(row.Payload), &event); err != nil {
+			return nil, nil
+		}
+		return event, nil
+	case EventTemplateStatus:
+		var event TemplateStatusEvent
+		if err := json.Unmarshal([]byte(row.Payload), &event); err != nil {
+			return nil, nil
+		}
+		return event, nil
+	}
+	return nil, nil
+}
+
+func (q *EventQueue) deliver(ctx context.Context, event Event) {
+	q.mu.RLock()
+	listeners := q.listeners
+	q.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(ctx, event)
+	}
+}
+
+// shard agrupa por contato no número (status de envio usam o destinatário) e os
+// eventos de template pela WABA
+func (q *EventQueue) shard(event Event) int {
+	var key string
+	switch e := event.(type) {
+	case InboundMessageEvent:
+		key = e.PhoneNumberID + ":" + e.Message.From
+	case StatusUpdateEvent:
+		key = e.PhoneNumberID + ":" + e.RecipientID
+	case TemplateStatusEvent:
+		key = e.BusinessAccountID
+	default:
+		key = event.EventName()
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(len(q.shards)))
+}
@@ -0,0 +1,85 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventInboundMessage = "whatsapp.message.inbound"
+	EventStatusUpdate   = "whatsapp.message.status"
+	EventTemplateStatus = "whatsapp.template.status"
+
+	EventMessageSent         = "whatsapp.message.sent"
+	EventConversationUpdated = "whatsapp.conversation.updated"
+)
+
+type Event interface {
+	EventName() string
+}
+
+// InboundMessageEvent é publicado uma única vez por mensagem recebida
+type InboundMessageEvent struct {
+	TenantID      uuid.UUID
+	PhoneNumberID string
+	// Channel é o número que recebeu a mensagem (inclui time e fluxo padrão)
+	Channel *Channel
+	Message *Message
+}
+
+func (e InboundMessageEvent) EventName() string { return EventInboundMessage }
+
+type StatusUpdateEvent struct {
+	TenantID      uuid.UUID
+	PhoneNumberID string
+	WAMessageID   string
+	Status        MessageStatus
+	RecipientID   string
+	ErrorCode     string
+	ErrorMessage  string
+	Timestamp     time.Time
+}
+
+func (e StatusUpdateEvent) EventName() string { return EventStatusUpdate }
+
+// TemplateStatusEvent chega pela WABA, sem tenant; quem consome resolve pelo ProviderTemplateID
+type TemplateStatusEvent struct {
+	BusinessAccountID  string
+	ProviderTemplateID string
+	Name               string
+	Language           string
+	Status             TemplateStatus
+	Reason             string
+}
+
+func (e TemplateStatusEvent) EventName() string { return EventTemplateStatus }
+
+// MessageSentEvent é publicado pelo ConversationService a cada mensagem enviada na conversa
+type MessageSentEvent struct {
+	TenantID     uuid.UUID
+	Conversation *Conversation
+	Message      *Message
+}
+
+func (e MessageSentEvent) EventName() string { return EventMessageSent }
+
+type ConversationChange string
+
+const (
+	ConversationStatusChanged ConversationChange = "status"
+	ConversationAssigned      ConversationChange = "assignee"
+)
+
+// ConversationUpdatedEvent é publicado quando o status ou o atendente da conversa muda
+type ConversationUpdatedEvent struct {
+	TenantID     uuid.UUID
+	Conversation *Conversation
+	Change       ConversationChange
+}
+
+func (e ConversationUpdatedEvent) EventName() string { return EventConversationUpdated }
+
+// EventListener recebe os eventos de domínio gerados pelo webhook
+type EventListener func(ctx context.Context, event Event)
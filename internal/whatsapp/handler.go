@@ -0,0 +1,77 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	SignatureHeader = "X-Hub-Signature-256"
+
+	// MaxWebhookBodySize limita o corpo lido antes da checagem da assinatura; os
+	// payloads da Meta têm poucos KB
+	MaxWebhookBodySize = 4 << 20
+)
+
+type WebhookHandler struct {
+	config         Config
+	webhookService WebhookService
+}
+
+func NewWebhookHandler(config Config, webhookService WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		config:         config,
+		webhookService: webhookService,
+	}
+}
+
+// Verify responde ao handshake GET feito pela Meta ao cadastrar o webhook
+func (h *WebhookHandler) Verify(c *gin.Context) {
+	mode := c.Query("hub.mode")
+	token := c.Query("hub.verify_token")
+	challenge := c.Query("hub.challenge")
+
+	if mode != "subscribe" || h.config.VerifyToken == "" || token != h.config.VerifyToken {
+		c.JSON(http.StatusForbidden, web.ErrorBody(c, "invalid verify token"))
+		return
+	}
+
+	c.String(http.StatusOK, challenge)
+}
+
+func (h *WebhookHandler) Receive(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxWebhookBodySize)
+	body, err := c.GetRawData()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, web.ErrorBody(c, "request body too large"))
+			return
+		}
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	if !VerifySignature(h.config.AppSecret, body, c.GetHeader(SignatureHeader)) {
+		c.JSON(http.StatusUnauthorized, web.ErrorBody(c, "invalid signature"))
+		return
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	if err := h.webhookService.HandleWebhook(c.Request.Context(), payload); err != nil {
+		// 5xx faz a Meta reenviar; a gravação idempotente evita duplicidade
+		c.JSON(http.StatusInternalServerError, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
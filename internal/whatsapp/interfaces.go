@@ -0,0 +1,158 @@
+package whatsapp
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageRepository interface {
+	// CreateIfNotExists grava a mensagem e retorna false se o WAMessageID já existia
+	CreateIfNotExists(ctx context.Context, message *Message) (bool, error)
+	FindByWAMessageID(ctx context.Context, waMessageID string) (*Message, error)
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error)
+	Update(ctx context.Context, message *Message) error
+	ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID, offset, limit int) ([]Message, int64, error)
+	// LastInboundAt retorna o horário da última mensagem recebida do contato no número
+	LastInboundAt(ctx context.Context, tenantID uuid.UUID, phoneNumberID, contact string) (*time.Time, error)
+	// FirstAgentReplySince retorna a primeira resposta de um agente na conversa a partir de since
+	FirstAgentReplySince(ctx context.Context, conversationID uuid.UUID, since time.Time) (*Message, error)
+}
+
+type WebhookService interface {
+	HandleWebhook(ctx context.Context, payload WebhookPayload) error
+	Subscribe(listener EventListener)
+}
+
+type MessageService interface {
+	SendMessage(ctx context.Context, tenantID, userID uuid.UUID, req SendMessageRequest) (*Message, error)
+	GetMessage(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error)
+}
+
+type ConversationRepository interface {
+	// FindOrCreate busca a conversa do cliente no canal, criando-a se necessário
+	FindOrCreate(ctx context.Context, conversation *Conversation) (*Conversation, error)
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Conversation, error)
+	List(ctx context.Context, filter ConversationFilter) ([]Conversation, int64, error)
+	// UpdateStatus grava o status (e resolved_at, se informado) sem sobrescrever o restante
+	UpdateStatus(ctx context.Context, id uuid.UUID, status ConversationStatus, resolvedAt *time.Time) error
+	// Assign grava só o atendente
+	Assign(ctx context.Context, id uuid.UUID, assigneeID *uuid.UUID) error
+	RecordMessage(ctx context.Context, id uuid.UUID, message *Message) error
+	// ClaimAwayMessage marca o envio da mensagem de ausência se nenhuma saiu desde notSince
+	ClaimAwayMessage(ctx context.Context, id uuid.UUID, now, notSince time.Time) (bool, error)
+	MarkRead(ctx context.Context, id uuid.UUID) error
+	// Route grava time e atendente sem sobrescrever o restante da conversa
+	Route(ctx context.Context, id uuid.UUID, teamID, assigneeID *uuid.UUID) error
+	// ClaimAssignee atribui a conversa só se ela ainda estiver sem atendente
+	ClaimAssignee(ctx context.Context, id, assigneeID uuid.UUID) (bool, error)
+	// CountActiveByAssignee conta as conversas abertas ou pendentes de cada atendente
+	CountActiveByAssignee(ctx context.Context, tenantID uuid.UUID, assigneeIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	// ListQueued retorna as conversas do time sem atendente, das mais antigas para as mais novas
+	ListQueued(ctx context.Context, teamID uuid.UUID, limit int) ([]Conversation, error)
+}
+
+type ConversationService interface {
+	// HandleEvent é registrado como listener do WebhookService
+	HandleEvent(ctx context.Context, event Event)
+	// Subscribe recebe as mensagens enviadas e as mudanças de status e atendente
+	Subscribe(listener EventListener)
+	ListConversations(ctx context.Context, filter ConversationFilter) ([]Conversation, int64, error)
+	GetConversation(ctx context.Context, tenantID uuid.UUID, id string) (*Conversation, error)
+	ListMessages(ctx context.Context, tenantID uuid.UUID, id string, offset, limit int) ([]Message, int64, error)
+	MarkRead(ctx context.Context, tenantID uuid.UUID, id string) error
+	UpdateStatus(ctx context.Context, tenantID uuid.UUID, id string, status ConversationStatus) (*Conversation, error)
+	Assign(ctx context.Context, tenantID uuid.UUID, id string, assigneeID *uuid.UUID) (*Conversation, error)
+	SendMessage(ctx context.Context, tenantID, userID uuid.UUID, id string, req SendMessageRequest) (*Message, error)
+}
+
+type AwayMessageService interface {
+	// HandleEvent é registrado como listener do WebhookService
+	HandleEvent(ctx context.Context, event Event)
+}
+
+type TemplateRepository interface {
+	Create(ctx context.Context, template *Template) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Template, error)
+	FindByNameLanguage(ctx context.Context, tenantID uuid.UUID, name, language string) (*Template, error)
+	FindByProviderTemplateID(ctx context.Context, providerTemplateID string) ([]Template, error)
+	List(ctx context.Context, tenantID uuid.UUID, status TemplateStatus) ([]Template, error)
+	Update(ctx context.Context, template *Template) error
+	Delete(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error
+}
+
+type TemplateService interface {
+	// HandleEvent é registrado como listener do WebhookService
+	HandleEvent(ctx context.Context, event Event)
+	ListTemplates(ctx context.Context, tenantID uuid.UUID, status TemplateStatus) ([]Template, error)
+	GetTemplate(ctx context.Context, tenantID uuid.UUID, id string) (*Template, error)
+	CreateTemplate(ctx context.Context, tenantID uuid.UUID, req CreateTemplateRequest) (*Template, error)
+	DeleteTemplate(ctx context.Context, tenantID uuid.UUID, id string) error
+	// SyncTemplates importa/atualiza os templates da WABA e retorna quantos foram gravados
+	SyncTemplates(ctx context.Context, tenantID uuid.UUID) (int, error)
+	SendTemplate(ctx context.Context, tenantID, userID uuid.UUID, id string, req SendTemplateRequest) (*Message, error)
+}
+
+type MediaRepository interface {
+	Create(ctx context.Context, media *Media) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Media, error)
+	FindByProviderMediaID(ctx context.Context, tenantID uuid.UUID, providerMediaID string) (*Media, error)
+}
+
+type PendingEventRepository interface {
+	Create(ctx context.Context, event *PendingEvent) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// List retorna os eventos pendentes na ordem em que chegaram
+	List(ctx context.Context) ([]PendingEvent, error)
+}
+
+type MediaService interface {
+	// HandleEvent é registrado como listener do WebhookService
+	HandleEvent(ctx context.Context, event Event)
+	// Upload valida e guarda o arquivo e o envia ao provider, devolvendo o ID para uso em mensagens
+	Upload(ctx context.Context, tenantID, userID uuid.UUID, upload MediaUpload) (*Media, error)
+	GetMedia(ctx context.Context, tenantID uuid.UUID, id string) (*Media, error)
+	GetMessageMedia(ctx context.Context, tenantID uuid.UUID, messageID string) (*Media, error)
+	SignedURL(media *Media) (string, time.Time)
+	// Open valida a URL assinada e abre o conteúdo; o chamador deve fechá-lo
+	Open(ctx context.Context, tenantID, id, expires, signature string) (*Media, io.ReadCloser, error)
+}
+
+type ChannelRepository interface {
+	Create(ctx context.Context, channel *Channel) error
+	FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error)
+	FindByPhoneNumberID(ctx context.Context, phoneNumberID string) (*Channel, error)
+	// FindDefault retorna o canal padrão do tenant ou, sem marcação, o mais antigo
+	FindDefault(ctx context.Context, tenantID uuid.UUID) (*Channel, error)
+	List(ctx context.Context, tenantID uuid.UUID) ([]Channel, error)
+	Update(ctx context.Context, channel *Channel) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+	SetDefault(ctx context.Context, tenantID, id uuid.UUID) error
+}
+
+// TeamDirectory confirma que o time padrão de um canal pertence ao tenant
+type TeamDirectory interface {
+	TeamInTenant(ctx context.Context, tenantID, teamID uuid.UUID) (bool, error)
+}
+
+// FlowDirectory confirma que o fluxo padrão de um canal pertence ao tenant
+type FlowDirectory interface {
+	FlowInTenant(ctx context.Context, tenantID, flowID uuid.UUID) (bool, error)
+}
+
+type ChannelService interface {
+	ListChannels(ctx context.Context, tenantID uuid.UUID) ([]Channel, error)
+	GetChannel(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error)
+	CreateChannel(ctx context.Context, tenantID uuid.UUID, req CreateChannelRequest) (*Channel, error)
+	UpdateChannel(ctx context.Context, tenantID uuid.UUID, id string, req UpdateChannelRequest) (*Channel, error)
+	DeleteChannel(ctx context.Context, tenantID uuid.UUID, id string) error
+	SetDefault(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error)
+	// ResolveInbound identifica o canal (e o tenant) pelo phone_number_id do webhook; nil se desconhecido
+	ResolveInbound(ctx context.Context, phoneNumberID string) (*Channel, error)
+	// SelectOutbound escolhe o canal de envio: canal explícito, número da conversa ou o padrão
+	SelectOutbound(ctx context.Context, tenantID uuid.UUID, channelID, phoneNumberID string) (*Channel, error)
+	// WithCredentials coloca no contexto o token do canal para as chamadas ao provider
+	WithCredentials(ctx context.Context, channel *Channel) (context.Context, error)
+}
@@ -0,0 +1,103 @@
+package whatsapp
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TypeSticker só chega em mensagens recebidas
+const TypeSticker MessageType = "sticker"
+
+var (
+	ErrMediaNotFound        = errors.New("media not found")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrMediaTooLarge        = errors.New("media exceeds the size limit")
+)
+
+// Media é o arquivo de uma mensagem, guardado no BlobStore
+type Media struct {
+	ID              uuid.UUID   `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID        uuid.UUID   `gorm:"type:uuid;not null;index:idx_media_provider,priority:1" json:"tenant_id"`
+	ProviderMediaID string      `gorm:"type:varchar(128);not null;index:idx_media_provider,priority:2" json:"provider_media_id"`
+	Direction       Direction   `gorm:"type:varchar(16);not null" json:"direction"`
+	Type            MessageType `gorm:"type:varchar(16);not null" json:"type"`
+	MimeType        string      `gorm:"type:varchar(128);not null" json:"mime_type"`
+	Size            int64       `gorm:"not null" json:"size"`
+	Filename        string      `gorm:"type:varchar(255)" json:"filename,omitempty"`
+	SHA256          string      `gorm:"column:sha256;type:varchar(64)" json:"sha256"`
+	StorageKey      string      `gorm:"type:varchar(512);not null" json:"-"`
+	UploadedByID    *uuid.UUID  `gorm:"type:uuid" json:"uploaded_by_id,omitempty"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+func (m *Media) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+type mediaRule struct {
+	messageType MessageType
+	maxSize     int64
+}
+
+const megabyte = 1 << 20
+
+// mediaRules segue os formatos e limites aceitos pela Cloud API
+var mediaRules = map[string]mediaRule{
+	"image/jpeg": {TypeImage, 5 * megabyte},
+	"image/png":  {TypeImage, 5 * megabyte},
+	"image/webp": {TypeSticker, 500 * 1024},
+
+	"audio/aac":  {TypeAudio, 16 * megabyte},
+	"audio/amr":  {TypeAudio, 16 * megabyte},
+	"audio/mpeg": {TypeAudio, 16 * megabyte},
+	"audio/mp4":  {TypeAudio, 16 * megabyte},
+	"audio/ogg":  {TypeAudio, 16 * megabyte},
+
+	"video/mp4":  {TypeVideo, 16 * megabyte},
+	"video/3gpp": {TypeVideo, 16 * megabyte},
+
+	"text/plain":                    {TypeDocument, 100 * megabyte},
+	"application/pdf":               {TypeDocument, 100 * megabyte},
+	"application/msword":            {TypeDocument, 100 * megabyte},
+	"application/vnd.ms-excel":      {TypeDocument, 100 * megabyte},
+	"application/vnd.ms-powerpoint": {TypeDocument, 100 * megabyte},
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   {TypeDocument, 100 * megabyte},
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         {TypeDocument, 100 * megabyte},
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": {TypeDocument, 100 * megabyte},
+}
+
+// MaxMediaSize é o maior limite entre os formatos aceitos
+const MaxMediaSize = 100 * megabyte
+
+// ValidateMedia normaliza o MIME (sem parâmetros, ex.: "audio/ogg; codecs=opus")
+// e confere formato e tamanho. size < 0 indica tamanho ainda desconhecido.
+func ValidateMedia(mimeType string, size int64) (string, MessageType, int64, error) {
+	normalized, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mimeType)
+	}
+	normalized = strings.ToLower(normalized)
+
+	rule, ok := mediaRules[normalized]
+	if !ok {
+		return "", "", 0, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, normalized)
+	}
+	if size > rule.maxSize {
+		return "", "", 0, fmt.Errorf("%w: %s accepts up to %d bytes", ErrMediaTooLarge, rule.messageType, rule.maxSize)
+	}
+	return normalized, rule.messageType, rule.maxSize, nil
+}
+
+// mediaStorageKey agrupa os objetos por tenant e mês
+func mediaStorageKey(media *Media) string {
+	return fmt.Sprintf("whatsapp/%s/%s/%s", media.TenantID, media.CreatedAt.Format("2006/01"), media.ID)
+}
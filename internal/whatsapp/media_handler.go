@@ -0,0 +1,124 @@
+package whatsapp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/storage"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	mediaService MediaService
+}
+
+func NewMediaHandler(mediaService MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+// Upload recebe multipart/form-data com o campo "file"
+func (h *MediaHandler) Upload(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	// Rejeita cedo o que certamente excede qualquer limite
+	if header.Size > MaxMediaSize {
+		c.JSON(http.StatusRequestEntityTooLarge, web.ErrorBody(c, ErrMediaTooLarge.Error()))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+	defer file.Close()
+
+	mimeType := header.Header.Get("Content-Type")
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		sniff := make([]byte, 512)
+		n, _ := io.ReadFull(file, sniff)
+		mimeType = http.DetectContentType(sniff[:n])
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			c.JSON(http.StatusInternalServerError, web.ErrorBody(c, err.Error()))
+			return
+		}
+	}
+
+	media, err := h.mediaService.Upload(c.Request.Context(), web.TenantID(c), web.UserID(c), MediaUpload{
+		Filename:  header.Filename,
+		MimeType:  mimeType,
+		Content:   file,
+		ChannelID: c.PostForm("channel_id"),
+	})
+	if err != nil {
+		c.JSON(mediaStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.response(media))
+}
+
+func (h *MediaHandler) Get(c *gin.Context) {
+	media, err := h.mediaService.GetMedia(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(mediaStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.response(media))
+}
+
+func (h *MediaHandler) MessageMedia(c *gin.Context) {
+	media, err := h.mediaService.GetMessageMedia(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(mediaStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.response(media))
+}
+
+// Download é público e autorizado apenas pela assinatura da URL
+func (h *MediaHandler) Download(c *gin.Context) {
+	media, content, err := h.mediaService.Open(c.Request.Context(), c.Param("tenant_id"), c.Param("id"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		c.JSON(mediaStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+	defer content.Close()
+
+	if media.Filename != "" {
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%s", strconv.Quote(media.Filename)))
+	}
+	c.Header("Cache-Control", "private, max-age=300")
+	c.DataFromReader(http.StatusOK, media.Size, media.MimeType, content, nil)
+}
+
+func (h *MediaHandler) response(media *Media) MediaResponse {
+	url, expiresAt := h.mediaService.SignedURL(media)
+	return MediaResponse{Media: media, URL: url, ExpiresAt: expiresAt}
+}
+
+func mediaStatusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrMediaNotFound), errors.Is(err, storage.ErrBlobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrMediaTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, storage.ErrInvalidSignature), errors.Is(err, storage.ErrURLExpired):
+		return http.StatusForbidden
+	}
+	return statusFor(err)
+}
@@ -0,0 +1,99 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type mediaRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newMediaRepositoryBase(db *gorm.DB) *mediaRepositoryBase {
+	return &mediaRepositoryBase{db: db}
+}
+
+func (r *mediaRepositoryBase) create(ctx context.Context, media *Media) error {
+	return r.db.WithContext(ctx).Create(media).Error
+}
+
+func (r *mediaRepositoryBase) findOne(ctx context.Context, query string, args ...interface{}) (*Media, error) {
+	var media Media
+	err := r.db.WithContext(ctx).Where(query, args...).First(&media).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *mediaRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Media, error) {
+	return r.findOne(ctx, "tenant_id = ? AND id = ?", tenantID, id)
+}
+
+func (r *mediaRepositoryBase) findByProviderMediaID(ctx context.Context, tenantID uuid.UUID, providerMediaID string) (*Media, error) {
+	return r.findOne(ctx, "tenant_id = ? AND provider_media_id = ?", tenantID, providerMediaID)
+}
+
+// Repository com telemetria (decorator)
+type mediaRepository struct {
+	base      *mediaRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewMediaRepository(db *gorm.DB, telemetry telemetry.TelemetryService) MediaRepository {
+	return &mediaRepository{
+		base:      newMediaRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *mediaRepository) Create(ctx context.Context, media *Media) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_media.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", media.TenantID.String())
+	span.SetTag("mime_type", media.MimeType)
+
+	err := r.base.create(ctx, media)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mediaRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Media, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_media.find_by_id")
+	defer span.End()
+
+	span.SetTag("media_id", id)
+
+	media, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return media, nil
+}
+
+func (r *mediaRepository) FindByProviderMediaID(ctx context.Context, tenantID uuid.UUID, providerMediaID string) (*Media, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_media.find_by_provider_media_id")
+	defer span.End()
+
+	span.SetTag("provider_media_id", providerMediaID)
+
+	media, err := r.base.findByProviderMediaID(ctx, tenantID, providerMediaID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return media, nil
+}
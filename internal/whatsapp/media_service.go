@@ -0,0 +1,275 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/storage"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+type mediaService struct {
+	mediaRepo      MediaRepository
+	messageRepo    MessageRepository
+	channelService ChannelService
+	provider       Provider
+	blobStore      storage.BlobStore
+	signer         *storage.URLSigner
+	telemetry      telemetry.TelemetryService
+}
+
+func NewMediaService(
+	mediaRepo MediaRepository,
+	messageRepo MessageRepository,
+	channelService ChannelService,
+	provider Provider,
+	blobStore storage.BlobStore,
+	signer *storage.URLSigner,
+	telemetry telemetry.TelemetryService,
+) MediaService {
+	return &mediaService{
+		mediaRepo:      mediaRepo,
+		messageRepo:    messageRepo,
+		channelService: channelService,
+		provider:       provider,
+		blobStore:      blobStore,
+		signer:         signer,
+		telemetry:      telemetry,
+	}
+}
+
+// HandleEvent baixa a mídia das mensagens recebidas enquanto a URL da Meta ainda é válida
+func (s *mediaService) HandleEvent(ctx context.Context, event Event) {
+	inbound, ok := event.(InboundMessageEvent)
+	if !ok || inbound.Message.MediaID == "" {
+		return
+	}
+
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.media.download")
+	defer span.End()
+
+	span.SetTag("tenant_id", inbound.TenantID.String())
+	span.SetTag("provider_media_id", inbound.Message.MediaID)
+
+	if err := s.downloadInbound(ctx, inbound); err != nil {
+		span.SetError(err)
+		s.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "whatsapp.media.download.failure",
+			Value: 1,
+			Tags:  map[string]string{"type": inbound.Message.Type},
+		})
+	}
+}
+
+func (s *mediaService) downloadInbound(ctx context.Context, event InboundMessageEvent) error {
+	existing, err := s.mediaRepo.FindByProviderMediaID(ctx, event.TenantID, event.Message.MediaID)
+	if err != nil || existing != nil {
+		return err
+	}
+
+	// A mídia só pode ser baixada com o token do número que a recebeu
+	downloadCtx, err := s.channelService.WithCredentials(ctx, event.Channel)
+	if err != nil {
+		return err
+	}
+	download, err := s.provider.DownloadMedia(downloadCtx, event.Message.MediaID)
+	if err != nil {
+		return err
+	}
+	defer download.Content.Close()
+
+	mimeType := download.MimeType
+	if mimeType == "" {
+		mimeType = event.Message.MediaMimeType
+	}
+
+	_, err = s.store(ctx, &Media{
+		TenantID:        event.TenantID,
+		ProviderMediaID: event.Message.MediaID,
+		Direction:       DirectionInbound,
+		MimeType:        mimeType,
+		Size:            download.Size,
+	}, download.Content)
+	return err
+}
+
+func (s *mediaService) Upload(ctx context.Context, tenantID, userID uuid.UUID, upload MediaUpload) (*Media, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.media.upload")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+	span.SetTag("mime_type", upload.MimeType)
+
+	channel, err := s.channelService.SelectOutbound(ctx, tenantID, upload.ChannelID, "")
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	uploadCtx, err := s.channelService.WithCredentials(ctx, channel)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	media := &Media{
+		TenantID:     tenantID,
+		Direction:    DirectionOutbound,
+		MimeType:     upload.MimeType,
+		Size:         -1,
+		Filename:     upload.Filename,
+		UploadedByID: &userID,
+	}
+	data, err := s.read(media, upload.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	providerMediaID, err := s.provider.UploadMedia(uploadCtx, channel.PhoneNumberID, MediaUpload{
+		Filename: upload.Filename,
+		MimeType: media.MimeType,
+		Content:  bytes.NewReader(data),
+	})
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	media.ProviderMediaID = providerMediaID
+
+	if err := s.save(ctx, media, data); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return media, nil
+}
+
+// store valida, lê e persiste o conteúdo no BlobStore e no banco
+func (s *mediaService) store(ctx context.Context, media *Media, content io.Reader) (*Media, error) {
+	data, err := s.read(media, content)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.save(ctx, media, data); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// read valida o formato e lê o conteúdo respeitando o limite do tipo.
+// O conteúdo fica em memória: o maior limite da Cloud API é 100MB (documentos).
+func (s *mediaService) read(media *Media, content io.Reader) ([]byte, error) {
+	mimeType, messageType, maxSize, err := ValidateMedia(media.MimeType, media.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w: %s accepts up to %d bytes", ErrMediaTooLarge, messageType, maxSize)
+	}
+
+	sum := sha256.Sum256(data)
+	media.MimeType = mimeType
+	media.Type = messageType
+	media.Size = int64(len(data))
+	media.SHA256 = hex.EncodeToString(sum[:])
+	return data, nil
+}
+
+func (s *mediaService) save(ctx context.Context, media *Media, data []byte) error {
+	media.ID = uuid.New()
+	media.CreatedAt = time.Now()
+	media.StorageKey = mediaStorageKey(media)
+
+	if err := s.blobStore.Put(ctx, media.StorageKey, bytes.NewReader(data), media.Size, media.MimeType); err != nil {
+		return err
+	}
+	if err := s.mediaRepo.Create(ctx, media); err != nil {
+		// Evita objeto órfão quando o registro não é gravado
+		s.blobStore.Delete(ctx, media.StorageKey)
+		return err
+	}
+
+	s.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "whatsapp.media.stored_bytes",
+		Value: float64(media.Size),
+		Tags:  map[string]string{"type": string(media.Type), "direction": string(media.Direction)},
+		Kind:  telemetry.MetricHistogram,
+	})
+	return nil
+}
+
+func (s *mediaService) GetMedia(ctx context.Context, tenantID uuid.UUID, id string) (*Media, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrMediaNotFound
+	}
+
+	media, err := s.mediaRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if media == nil {
+		return nil, ErrMediaNotFound
+	}
+	return media, nil
+}
+
+func (s *mediaService) GetMessageMedia(ctx context.Context, tenantID uuid.UUID, messageID string) (*Media, error) {
+	message, err := s.messageRepo.FindByID(ctx, tenantID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message == nil {
+		return nil, ErrMessageNotFound
+	}
+	if message.MediaID == "" {
+		return nil, ErrMediaNotFound
+	}
+
+	media, err := s.mediaRepo.FindByProviderMediaID(ctx, tenantID, message.MediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media == nil {
+		return nil, ErrMediaNotFound
+	}
+	return media, nil
+}
+
+func (s *mediaService) SignedURL(media *Media) (string, time.Time) {
+	return s.signer.SignedURL(mediaDownloadPath(media.TenantID.String(), media.ID.String()), time.Now())
+}
+
+func (s *mediaService) Open(ctx context.Context, tenantID, id, expires, signature string) (*Media, io.ReadCloser, error) {
+	if err := s.signer.Verify(mediaDownloadPath(tenantID, id), expires, signature, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	parsedTenantID, err := uuid.Parse(tenantID)
+	if err != nil {
+		return nil, nil, ErrMediaNotFound
+	}
+	media, err := s.GetMedia(ctx, parsedTenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobStore.Get(ctx, media.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return media, content, nil
+}
+
+// mediaDownloadPath é o path público (fora de /api) assinado nas URLs de download
+func mediaDownloadPath(tenantID, id string) string {
+	return fmt.Sprintf("/media/%s/%s", tenantID, id)
+}
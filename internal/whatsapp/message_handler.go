@@ -0,0 +1,71 @@
+package whatsapp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+)
+
+type MessageHandler struct {
+	messageService MessageService
+}
+
+func NewMessageHandler(messageService MessageService) *MessageHandler {
+	return &MessageHandler{
+		messageService: messageService,
+	}
+}
+
+func (h *MessageHandler) Send(c *gin.Context) {
+	var req SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	message, err := h.messageService.SendMessage(c.Request.Context(), web.TenantID(c), web.UserID(c), req)
+	if err != nil {
+		c.JSON(statusFor(err), errorBody(c, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+func (h *MessageHandler) Get(c *gin.Context) {
+	message, err := h.messageService.GetMessage(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+func statusFor(err error) int {
+	var providerErr *ProviderError
+	switch {
+	case errors.Is(err, ErrMessageNotFound),
+		errors.Is(err, ErrChannelNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidMessage):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrChannelNotConfigured),
+		errors.Is(err, ErrWindowClosed):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &providerErr):
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// errorBody inclui um código estável para erros que o cliente precisa tratar
+func errorBody(c *gin.Context, err error) gin.H {
+	body := web.ErrorBody(c, err.Error())
+	if errors.Is(err, ErrWindowClosed) {
+		body["code"] = ErrorCodeWindowClosed
+	}
+	return body
+}
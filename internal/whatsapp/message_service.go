@@ -0,0 +1,150 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrChannelNotConfigured = errors.New("whatsapp channel not configured for tenant")
+	ErrMessageNotFound      = errors.New("message not found")
+)
+
+type messageService struct {
+	messageRepo    MessageRepository
+	channelService ChannelService
+	provider       Provider
+	telemetry      telemetry.TelemetryService
+}
+
+func NewMessageService(messageRepo MessageRepository, channelService ChannelService, provider Provider, telemetry telemetry.TelemetryService) MessageService {
+	return &messageService{
+		messageRepo:    messageRepo,
+		channelService: channelService,
+		provider:       provider,
+		telemetry:      telemetry,
+	}
+}
+
+func (s *messageService) SendMessage(ctx context.Context, tenantID, userID uuid.UUID, req SendMessageRequest) (*Message, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.send_message")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+	span.SetTag("type", string(req.Type))
+
+	outbound := req.ToOutbound()
+	if err := outbound.Validate(); err != nil {
+		return nil, err
+	}
+
+	channel, err := s.channelService.SelectOutbound(ctx, tenantID, req.ChannelID, req.PhoneNumberID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	phoneNumberID := channel.PhoneNumberID
+	span.SetTag("phone_number_id", phoneNumberID)
+
+	// Envios por conversa já conferiram a janela no ConversationService
+	if req.ConversationID == nil && outbound.Type.RequiresWindow() {
+		lastInboundAt, err := s.messageRepo.LastInboundAt(ctx, tenantID, phoneNumberID, customers.NormalizePhone(outbound.To))
+		if err != nil {
+			span.SetError(err)
+			return nil, err
+		}
+		if expiry := ServiceWindowExpiry(lastInboundAt); expiry == nil || !time.Now().Before(*expiry) {
+			s.trackWindowClosed(ctx, outbound.Type)
+			return nil, ErrWindowClosed
+		}
+	}
+
+	sendCtx, err := s.channelService.WithCredentials(ctx, channel)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	waMessageID, err := s.provider.SendMessage(sendCtx, phoneNumberID, outbound)
+	if err != nil {
+		span.SetError(err)
+		s.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "whatsapp.message.send.failure",
+			Value: 1,
+			Tags:  map[string]string{"type": string(outbound.Type)},
+		})
+
+		// A janela pode ter fechado entre a checagem local e o envio
+		var providerErr *ProviderError
+		if errors.As(err, &providerErr) && providerErr.Code == cloudAPIReengagementCode {
+			return nil, fmt.Errorf("%w: %v", ErrWindowClosed, err)
+		}
+		return nil, err
+	}
+
+	payload, _ := json.Marshal(outbound)
+	message := &Message{
+		TenantID:       tenantID,
+		ConversationID: req.ConversationID,
+		WAMessageID:    waMessageID,
+		PhoneNumberID:  phoneNumberID,
+		Direction:      DirectionOutbound,
+		From:           phoneNumberID,
+		To:             outbound.To,
+		Type:           string(outbound.Type),
+		Body:           outbound.Summary(),
+		Status:         StatusSent,
+		Payload:        string(payload),
+		SentByID:       &userID,
+		Timestamp:      time.Now(),
+	}
+	if outbound.Media != nil {
+		message.MediaID = outbound.Media.ID
+	}
+	if req.Preview != "" {
+		message.Body = req.Preview
+	}
+
+	if _, err := s.messageRepo.CreateIfNotExists(ctx, message); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: "whatsapp.message.outbound",
+		Properties: map[string]interface{}{
+			"tenant_id":     tenantID.String(),
+			"user_id":       userID.String(),
+			"wa_message_id": waMessageID,
+			"type":          string(outbound.Type),
+		},
+		Timestamp: time.Now(),
+	})
+
+	return message, nil
+}
+
+func (s *messageService) trackWindowClosed(ctx context.Context, messageType MessageType) {
+	s.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "whatsapp.message.window_closed",
+		Value: 1,
+		Tags:  map[string]string{"type": string(messageType)},
+	})
+}
+
+func (s *messageService) GetMessage(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error) {
+	message, err := s.messageRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if message == nil {
+		return nil, ErrMessageNotFound
+	}
+	return message, nil
+}
@@ -0,0 +1,391 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MockMessageRepository para testes
+type MockMessageRepository struct {
+	CreateIfNotExistsFunc    func(ctx context.Context, message *Message) (bool, error)
+	FindByWAMessageIDFunc    func(ctx context.Context, waMessageID string) (*Message, error)
+	FindByIDFunc             func(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error)
+	UpdateFunc               func(ctx context.Context, message *Message) error
+	ListByConversationFunc   func(ctx context.Context, tenantID, conversationID uuid.UUID, offset, limit int) ([]Message, int64, error)
+	LastInboundAtFunc        func(ctx context.Context, tenantID uuid.UUID, phoneNumberID, contact string) (*time.Time, error)
+	FirstAgentReplySinceFunc func(ctx context.Context, conversationID uuid.UUID, since time.Time) (*Message, error)
+}
+
+func (m *MockMessageRepository) CreateIfNotExists(ctx context.Context, message *Message) (bool, error) {
+	if m.CreateIfNotExistsFunc != nil {
+		return m.CreateIfNotExistsFunc(ctx, message)
+	}
+	return true, nil
+}
+
+func (m *MockMessageRepository) FindByWAMessageID(ctx context.Context, waMessageID string) (*Message, error) {
+	if m.FindByWAMessageIDFunc != nil {
+		return m.FindByWAMessageIDFunc(ctx, waMessageID)
+	}
+	return nil, nil
+}
+
+func (m *MockMessageRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockMessageRepository) Update(ctx context.Context, message *Message) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, message)
+	}
+	return nil
+}
+
+func (m *MockMessageRepository) ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID, offset, limit int) ([]Message, int64, error) {
+	if m.ListByConversationFunc != nil {
+		return m.ListByConversationFunc(ctx, tenantID, conversationID, offset, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockMessageRepository) LastInboundAt(ctx context.Context, tenantID uuid.UUID, phoneNumberID, contact string) (*time.Time, error) {
+	if m.LastInboundAtFunc != nil {
+		return m.LastInboundAtFunc(ctx, tenantID, phoneNumberID, contact)
+	}
+	return nil, nil
+}
+
+func (m *MockMessageRepository) FirstAgentReplySince(ctx context.Context, conversationID uuid.UUID, since time.Time) (*Message, error) {
+	if m.FirstAgentReplySinceFunc != nil {
+		return m.FirstAgentReplySinceFunc(ctx, conversationID, since)
+	}
+	return nil, nil
+}
+
+// MockWebhookService para testes
+type MockWebhookService struct {
+	HandleWebhookFunc func(ctx context.Context, payload WebhookPayload) error
+	SubscribeFunc     func(listener EventListener)
+}
+
+func (m *MockWebhookService) HandleWebhook(ctx context.Context, payload WebhookPayload) error {
+	if m.HandleWebhookFunc != nil {
+		return m.HandleWebhookFunc(ctx, payload)
+	}
+	return nil
+}
+
+func (m *MockWebhookService) Subscribe(listener EventListener) {
+	if m.SubscribeFunc != nil {
+		m.SubscribeFunc(listener)
+	}
+}
+
+// MockMessageService para testes
+type MockMessageService struct {
+	SendMessageFunc func(ctx context.Context, tenantID, userID uuid.UUID, req SendMessageRequest) (*Message, error)
+	GetMessageFunc  func(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error)
+}
+
+func (m *MockMessageService) SendMessage(ctx context.Context, tenantID, userID uuid.UUID, req SendMessageRequest) (*Message, error) {
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, tenantID, userID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockMessageService) GetMessage(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error) {
+	if m.GetMessageFunc != nil {
+		return m.GetMessageFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+// MockConversationRepository para testes
+type MockConversationRepository struct {
+	FindOrCreateFunc          func(ctx context.Context, conversation *Conversation) (*Conversation, error)
+	FindByIDFunc              func(ctx context.Context, tenantID uuid.UUID, id string) (*Conversation, error)
+	ListFunc                  func(ctx context.Context, filter ConversationFilter) ([]Conversation, int64, error)
+	UpdateStatusFunc          func(ctx context.Context, id uuid.UUID, status ConversationStatus, resolvedAt *time.Time) error
+	AssignFunc                func(ctx context.Context, id uuid.UUID, assigneeID *uuid.UUID) error
+	RecordMessageFunc         func(ctx context.Context, id uuid.UUID, message *Message) error
+	ClaimAwayMessageFunc      func(ctx context.Context, id uuid.UUID, now, notSince time.Time) (bool, error)
+	MarkReadFunc              func(ctx context.Context, id uuid.UUID) error
+	RouteFunc                 func(ctx context.Context, id uuid.UUID, teamID, assigneeID *uuid.UUID) error
+	ClaimAssigneeFunc         func(ctx context.Context, id, assigneeID uuid.UUID) (bool, error)
+	CountActiveByAssigneeFunc func(ctx context.Context, tenantID uuid.UUID, assigneeIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	ListQueuedFunc            func(ctx context.Context, teamID uuid.UUID, limit int) ([]Conversation, error)
+}
+
+func (m *MockConversationRepository) FindOrCreate(ctx context.Context, conversation *Conversation) (*Conversation, error) {
+	if m.FindOrCreateFunc != nil {
+		return m.FindOrCreateFunc(ctx, conversation)
+	}
+	return conversation, nil
+}
+
+func (m *MockConversationRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Conversation, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockConversationRepository) List(ctx context.Context, filter ConversationFilter) ([]Conversation, int64, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockConversationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status ConversationStatus, resolvedAt *time.Time) error {
+	if m.UpdateStatusFunc != nil {
+		return m.UpdateStatusFunc(ctx, id, status, resolvedAt)
+	}
+	return nil
+}
+
+func (m *MockConversationRepository) Assign(ctx context.Context, id uuid.UUID, assigneeID *uuid.UUID) error {
+	if m.AssignFunc != nil {
+		return m.AssignFunc(ctx, id, assigneeID)
+	}
+	return nil
+}
+
+func (m *MockConversationRepository) RecordMessage(ctx context.Context, id uuid.UUID, message *Message) error {
+	if m.RecordMessageFunc != nil {
+		return m.RecordMessageFunc(ctx, id, message)
+	}
+	return nil
+}
+
+func (m *MockConversationRepository) ClaimAwayMessage(ctx context.Context, id uuid.UUID, now, notSince time.Time) (bool, error) {
+	if m.ClaimAwayMessageFunc != nil {
+		return m.ClaimAwayMessageFunc(ctx, id, now, notSince)
+	}
+	return true, nil
+}
+
+func (m *MockConversationRepository) MarkRead(ctx context.Context, id uuid.UUID) error {
+	if m.MarkReadFunc != nil {
+		return m.MarkReadFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockConversationRepository) Route(ctx context.Context, id uuid.UUID, teamID, assigneeID *uuid.UUID) error {
+	if m.RouteFunc != nil {
+		return m.RouteFunc(ctx, id, teamID, assigneeID)
+	}
+	return nil
+}
+
+func (m *MockConversationRepository) ClaimAssignee(ctx context.Context, id, assigneeID uuid.UUID) (bool, error) {
+	if m.ClaimAssigneeFunc != nil {
+		return m.ClaimAssigneeFunc(ctx, id, assigneeID)
+	}
+	return true, nil
+}
+
+func (m *MockConversationRepository) CountActiveByAssignee(ctx context.Context, tenantID uuid.UUID, assigneeIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	if m.CountActiveByAssigneeFunc != nil {
+		return m.CountActiveByAssigneeFunc(ctx, tenantID, assigneeIDs)
+	}
+	return map[uuid.UUID]int{}, nil
+}
+
+func (m *MockConversationRepository) ListQueued(ctx context.Context, teamID uuid.UUID, limit int) ([]Conversation, error) {
+	if m.ListQueuedFunc != nil {
+		return m.ListQueuedFunc(ctx, teamID, limit)
+	}
+	return nil, nil
+}
+
+// MockTemplateRepository para testes
+type MockTemplateRepository struct {
+	CreateFunc                   func(ctx context.Context, template *Template) error
+	FindByIDFunc                 func(ctx context.Context, tenantID uuid.UUID, id string) (*Template, error)
+	FindByNameLanguageFunc       func(ctx context.Context, tenantID uuid.UUID, name, language string) (*Template, error)
+	FindByProviderTemplateIDFunc func(ctx context.Context, providerTemplateID string) ([]Template, error)
+	ListFunc                     func(ctx context.Context, tenantID uuid.UUID, status TemplateStatus) ([]Template, error)
+	UpdateFunc                   func(ctx context.Context, template *Template) error
+	DeleteFunc                   func(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error
+}
+
+func (m *MockTemplateRepository) Create(ctx context.Context, template *Template) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, template)
+	}
+	return nil
+}
+
+func (m *MockTemplateRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Template, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockTemplateRepository) FindByNameLanguage(ctx context.Context, tenantID uuid.UUID, name, language string) (*Template, error) {
+	if m.FindByNameLanguageFunc != nil {
+		return m.FindByNameLanguageFunc(ctx, tenantID, name, language)
+	}
+	return nil, nil
+}
+
+func (m *MockTemplateRepository) FindByProviderTemplateID(ctx context.Context, providerTemplateID string) ([]Template, error) {
+	if m.FindByProviderTemplateIDFunc != nil {
+		return m.FindByProviderTemplateIDFunc(ctx, providerTemplateID)
+	}
+	return nil, nil
+}
+
+func (m *MockTemplateRepository) List(ctx context.Context, tenantID uuid.UUID, status TemplateStatus) ([]Template, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, tenantID, status)
+	}
+	return nil, nil
+}
+
+func (m *MockTemplateRepository) Update(ctx context.Context, template *Template) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, template)
+	}
+	return nil
+}
+
+func (m *MockTemplateRepository) Delete(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID, id)
+	}
+	return nil
+}
+
+// MockMediaRepository para testes
+type MockMediaRepository struct {
+	CreateFunc                func(ctx context.Context, media *Media) error
+	FindByIDFunc              func(ctx context.Context, tenantID uuid.UUID, id string) (*Media, error)
+	FindByProviderMediaIDFunc func(ctx context.Context, tenantID uuid.UUID, providerMediaID string) (*Media, error)
+}
+
+func (m *MockMediaRepository) Create(ctx context.Context, media *Media) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, media)
+	}
+	return nil
+}
+
+func (m *MockMediaRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Media, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockMediaRepository) FindByProviderMediaID(ctx context.Context, tenantID uuid.UUID, providerMediaID string) (*Media, error) {
+	if m.FindByProviderMediaIDFunc != nil {
+		return m.FindByProviderMediaIDFunc(ctx, tenantID, providerMediaID)
+	}
+	return nil, nil
+}
+
+// MockPendingEventRepository para testes
+type MockPendingEventRepository struct {
+	CreateFunc func(ctx context.Context, event *PendingEvent) error
+	DeleteFunc func(ctx context.Context, id uuid.UUID) error
+	ListFunc   func(ctx context.Context) ([]PendingEvent, error)
+}
+
+func (m *MockPendingEventRepository) Create(ctx context.Context, event *PendingEvent) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, event)
+	}
+	return nil
+}
+
+func (m *MockPendingEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockPendingEventRepository) List(ctx context.Context) ([]PendingEvent, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// MockChannelRepository para testes; sem funções configuradas, o tenant não tem canais cadastrados
+type MockChannelRepository struct {
+	CreateFunc              func(ctx context.Context, channel *Channel) error
+	FindByIDFunc            func(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error)
+	FindByPhoneNumberIDFunc func(ctx context.Context, phoneNumberID string) (*Channel, error)
+	FindDefaultFunc         func(ctx context.Context, tenantID uuid.UUID) (*Channel, error)
+	ListFunc                func(ctx context.Context, tenantID uuid.UUID) ([]Channel, error)
+	UpdateFunc              func(ctx context.Context, channel *Channel) error
+	DeleteFunc              func(ctx context.Context, tenantID, id uuid.UUID) error
+	SetDefaultFunc          func(ctx context.Context, tenantID, id uuid.UUID) error
+}
+
+func (m *MockChannelRepository) Create(ctx context.Context, channel *Channel) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, channel)
+	}
+	return nil
+}
+
+func (m *MockChannelRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Channel, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, tenantID, id)
+	}
+	return nil, nil
+}
+
+func (m *MockChannelRepository) FindByPhoneNumberID(ctx context.Context, phoneNumberID string) (*Channel, error) {
+	if m.FindByPhoneNumberIDFunc != nil {
+		return m.FindByPhoneNumberIDFunc(ctx, phoneNumberID)
+	}
+	return nil, nil
+}
+
+func (m *MockChannelRepository) FindDefault(ctx context.Context, tenantID uuid.UUID) (*Channel, error) {
+	if m.FindDefaultFunc != nil {
+		return m.FindDefaultFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockChannelRepository) List(ctx context.Context, tenantID uuid.UUID) ([]Channel, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockChannelRepository) Update(ctx context.Context, channel *Channel) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, channel)
+	}
+	return nil
+}
+
+func (m *MockChannelRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID, id)
+	}
+	return nil
+}
+
+func (m *MockChannelRepository) SetDefault(ctx context.Context, tenantID, id uuid.UUID) error {
+	if m.SetDefaultFunc != nil {
+		return m.SetDefaultFunc(ctx, tenantID, id)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package whatsapp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Direction string
+
+const (
+	DirectionInbound  Direction = "inbound"
+	DirectionOutbound Direction = "outbound"
+)
+
+type MessageStatus string
+
+const (
+	StatusReceived  MessageStatus = "received"
+	StatusSent      MessageStatus = "sent"
+	StatusDelivered MessageStatus = "delivered"
+	StatusRead      MessageStatus = "read"
+	StatusFailed    MessageStatus = "failed"
+)
+
+// statusRank impede que um webhook atrasado regrida o status (ex.: read -> delivered)
+var statusRank = map[MessageStatus]int{
+	StatusReceived:  0,
+	StatusSent:      1,
+	StatusDelivered: 2,
+	StatusRead:      3,
+}
+
+// CanTransitionTo informa se o status pode avançar para next.
+// Falhas sempre são aceitas.
+func (s MessageStatus) CanTransitionTo(next MessageStatus) bool {
+	if next == StatusFailed {
+		return s != StatusFailed
+	}
+	if s == StatusFailed {
+		return false
+	}
+	return statusRank[next] > statusRank[s]
+}
+
+type Message struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID       uuid.UUID     `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	ConversationID *uuid.UUID    `gorm:"type:uuid;index" json:"conversation_id,omitempty"`
+	WAMessageID    string        `gorm:"column:wa_message_id;type:varchar(128);not null;uniqueIndex" json:"wa_message_id"`
+	PhoneNumberID  string        `gorm:"type:varchar(64);not null" json:"phone_number_id"`
+	Direction      Direction     `gorm:"type:varchar(16);not null" json:"direction"`
+	From           string        `gorm:"column:from_number;type:varchar(32);not null;index" json:"from"`
+	To             string        `gorm:"column:to_number;type:varchar(32)" json:"to"`
+	ContactName    string        `gorm:"type:varchar(255)" json:"contact_name,omitempty"`
+	Type           string        `gorm:"type:varchar(32);not null" json:"type"`
+	Body           string        `gorm:"type:text" json:"body,omitempty"`
+	MediaID        string        `gorm:"type:varchar(128)" json:"media_id,omitempty"`
+	MediaMimeType  string        `gorm:"type:varchar(128)" json:"media_mime_type,omitempty"`
+	Status         MessageStatus `gorm:"type:varchar(16);not null" json:"status"`
+	ErrorCode      string        `gorm:"type:varchar(32)" json:"error_code,omitempty"`
+	ErrorMessage   string        `gorm:"type:text" json:"error_message,omitempty"`
+	Payload        string        `gorm:"type:text" json:"-"` // JSON original trocado com o provider
+	SentByID       *uuid.UUID    `gorm:"type:uuid" json:"sent_by_id,omitempty"`
+	Timestamp      time.Time     `gorm:"not null;index" json:"timestamp"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+func (m *Message) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PendingEvent é um evento do webhook que já foi respondido à Meta mas ainda não
+// passou por todos os listeners. A linha sai da tabela quando o worker termina; o
+// que sobrar de um processo que caiu é entregue de novo no Start.
+type PendingEvent struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name      string    `gorm:"type:varchar(64);not null"`
+	Payload   string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (e *PendingEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// pendingInbound guarda só as chaves da mensagem: o payload bruto e o token do
+// canal não são serializados e voltam do banco na reentrega
+type pendingInbound struct {
+	PhoneNumberID string `json:"phone_number_id"`
+	WAMessageID   string `json:"wa_message_id"`
+}
+
+// encodePendingEvent retorna false para eventos que não vêm do webhook
+func encodePendingEvent(event Event) (*PendingEvent, bool, error) {
+	var payload interface{}
+	switch e := event.(type) {
+	case InboundMessageEvent:
+		payload = pendingInbound{PhoneNumberID: e.PhoneNumberID, WAMessageID: e.Message.WAMessageID}
+	case StatusUpdateEvent, TemplateStatusEvent:
+		payload = e
+	default:
+		return nil, false, nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false, err
+	}
+	return &PendingEvent{Name: event.EventName(), Payload: string(data)}, true, nil
+}
@@ -0,0 +1,85 @@
+package whatsapp
+
+import (
+	"context"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type pendingEventRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newPendingEventRepositoryBase(db *gorm.DB) *pendingEventRepositoryBase {
+	return &pendingEventRepositoryBase{db: db}
+}
+
+func (r *pendingEventRepositoryBase) create(ctx context.Context, event *PendingEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *pendingEventRepositoryBase) delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&PendingEvent{}, "id = ?", id).Error
+}
+
+func (r *pendingEventRepositoryBase) list(ctx context.Context) ([]PendingEvent, error) {
+	var events []PendingEvent
+	err := r.db.WithContext(ctx).Order("created_at ASC").Find(&events).Error
+	return events, err
+}
+
+// Repository com telemetria (decorator)
+type pendingEventRepository struct {
+	base      *pendingEventRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewPendingEventRepository(db *gorm.DB, telemetry telemetry.TelemetryService) PendingEventRepository {
+	return &pendingEventRepository{
+		base:      newPendingEventRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *pendingEventRepository) Create(ctx context.Context, event *PendingEvent) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_pending_event.create")
+	defer span.End()
+
+	span.SetTag("event", event.Name)
+
+	err := r.base.create(ctx, event)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *pendingEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_pending_event.delete")
+	defer span.End()
+
+	span.SetTag("pending_event_id", id.String())
+
+	err := r.base.delete(ctx, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *pendingEventRepository) List(ctx context.Context) ([]PendingEvent, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_pending_event.list")
+	defer span.End()
+
+	events, err := r.base.list(ctx)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return events, nil
+}
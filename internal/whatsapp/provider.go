@@ -0,0 +1,285 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+type MessageType string
+
+const (
+	TypeText        MessageType = "text"
+	TypeImage       MessageType = "image"
+	TypeAudio       MessageType = "audio"
+	TypeVideo       MessageType = "video"
+	TypeDocument    MessageType = "document"
+	TypeInteractive MessageType = "interactive"
+	TypeTemplate    MessageType = "template"
+)
+
+func (t MessageType) IsMedia() bool {
+	switch t {
+	case TypeImage, TypeAudio, TypeVideo, TypeDocument:
+		return true
+	}
+	return false
+}
+
+// OutboundMessage é a mensagem a ser enviada, independente do provider
+type OutboundMessage struct {
+	To          string              `json:"to"`
+	Type        MessageType         `json:"type"`
+	Text        *TextContent        `json:"text,omitempty"`
+	Media       *MediaContent       `json:"media,omitempty"`
+	Interactive *InteractiveContent `json:"interactive,omitempty"`
+	Template    *TemplateContent    `json:"template,omitempty"`
+}
+
+type TextContent struct {
+	Body       string `json:"body"`
+	PreviewURL bool   `json:"preview_url,omitempty"`
+}
+
+// MediaContent referencia uma mídia já enviada ao provider (ID) ou uma URL pública (Link)
+type MediaContent struct {
+	ID       string `json:"id,omitempty"`
+	Link     string `json:"link,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+type InteractiveType string
+
+const (
+	InteractiveButton InteractiveType = "button"
+	InteractiveList   InteractiveType = "list"
+)
+
+type InteractiveContent struct {
+	Type       InteractiveType `json:"type"`
+	Header     string          `json:"header,omitempty"`
+	Body       string          `json:"body"`
+	Footer     string          `json:"footer,omitempty"`
+	Buttons    []ReplyButton   `json:"buttons,omitempty"`
+	ButtonText string          `json:"button_text,omitempty"` // list
+	Sections   []ListSection   `json:"sections,omitempty"`    // list
+}
+
+type ReplyButton struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type ListSection struct {
+	Title string    `json:"title"`
+	Rows  []ListRow `json:"rows"`
+}
+
+type ListRow struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+type TemplateContent struct {
+	Name       string              `json:"name"`
+	Language   string              `json:"language"`
+	Components []TemplateComponent `json:"components,omitempty"`
+}
+
+type TemplateComponent struct {
+	Type       string              `json:"type"` // header, body, button
+	SubType    string              `json:"sub_type,omitempty"`
+	Index      string              `json:"index,omitempty"`
+	Parameters []TemplateParameter `json:"parameters"`
+}
+
+type TemplateParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+var ErrInvalidMessage = errors.New("invalid message")
+
+// Validate aplica os limites documentados pela Cloud API
+func (m OutboundMessage) Validate() error {
+	if m.To == "" {
+		return fmt.Errorf("%w: recipient is required", ErrInvalidMessage)
+	}
+
+	switch {
+	case m.Type == TypeText:
+		if m.Text == nil || m.Text.Body == "" {
+			return fmt.Errorf("%w: text body is required", ErrInvalidMessage)
+		}
+		if len([]rune(m.Text.Body)) > 4096 {
+			return fmt.Errorf("%w: text body exceeds 4096 characters", ErrInvalidMessage)
+		}
+	case m.Type.IsMedia():
+		if m.Media == nil || (m.Media.ID == "" && m.Media.Link == "") {
+			return fmt.Errorf("%w: media id or link is required", ErrInvalidMessage)
+		}
+	case m.Type == TypeInteractive:
+		return m.Interactive.validate()
+	case m.Type == TypeTemplate:
+		if m.Template == nil || m.Template.Name == "" || m.Template.Language == "" {
+			return fmt.Errorf("%w: template name and language are required", ErrInvalidMessage)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported type %q", ErrInvalidMessage, m.Type)
+	}
+
+	return nil
+}
+
+func (i *InteractiveContent) validate() error {
+	if i == nil || i.Body == "" {
+		return fmt.Errorf("%w: interactive body is required", ErrInvalidMessage)
+	}
+
+	switch i.Type {
+	case InteractiveButton:
+		if len(i.Buttons) == 0 || len(i.Buttons) > 3 {
+			return fmt.Errorf("%w: interactive buttons must have 1 to 3 buttons", ErrInvalidMessage)
+		}
+		for _, button := range i.Buttons {
+			if button.ID == "" || button.Title == "" || len([]rune(button.Title)) > 20 {
+				return fmt.Errorf("%w: button id and title (max 20 chars) are required", ErrInvalidMessage)
+			}
+		}
+	case InteractiveList:
+		if i.ButtonText == "" {
+			return fmt.Errorf("%w: list button_text is required", ErrInvalidMessage)
+		}
+		rows := 0
+		for _, section := range i.Sections {
+			rows += len(section.Rows)
+		}
+		if len(i.Sections) == 0 || len(i.Sections) > 10 || rows == 0 || rows > 10 {
+			return fmt.Errorf("%w: list must have 1 to 10 sections and rows", ErrInvalidMessage)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported interactive type %q", ErrInvalidMessage, i.Type)
+	}
+
+	return nil
+}
+
+// Summary retorna o texto usado como corpo/preview da mensagem persistida
+func (m OutboundMessage) Summary() string {
+	switch {
+	case m.Text != nil:
+		return m.Text.Body
+	case m.Media != nil:
+		return m.Media.Caption
+	case m.Interactive != nil:
+		return m.Interactive.Body
+	case m.Template != nil:
+		return m.Template.Name
+	}
+	return ""
+}
+
+// ProviderTemplate é o template como cadastrado no provider
+type ProviderTemplate struct {
+	ID             string                        `json:"id"`
+	Name           string                        `json:"name"`
+	Language       string                        `json:"language"`
+	Category       string                        `json:"category"`
+	Status         string                        `json:"status"`
+	Components     []TemplateDefinitionComponent `json:"components"`
+	RejectedReason string                        `json:"rejected_reason,omitempty"`
+}
+
+// ProviderPhoneNumber é um número da WABA como cadastrado no provider
+type ProviderPhoneNumber struct {
+	ID                 string `json:"id"`
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	VerifiedName       string `json:"verified_name"`
+}
+
+// MediaUpload é o arquivo enviado ao provider antes de referenciá-lo numa mensagem
+type MediaUpload struct {
+	Filename string
+	MimeType string
+	Content  io.Reader
+	// ChannelID escolhe o número dono da mídia no MediaService; o provider o ignora
+	ChannelID string
+}
+
+// MediaDownload é a mídia recebida; o chamador deve fechar Content
+type MediaDownload struct {
+	MimeType string
+	Size     int64
+	Content  io.ReadCloser
+}
+
+// Provider envia mensagens e gerencia templates e mídias por um BSP/API do WhatsApp
+type Provider interface {
+	SendMessage(ctx context.Context, phoneNumberID string, message OutboundMessage) (string, error)
+
+	UploadMedia(ctx context.Context, phoneNumberID string, media MediaUpload) (string, error)
+	DownloadMedia(ctx context.Context, mediaID string) (*MediaDownload, error)
+
+	// GetPhoneNumber só encontra números que o token em uso consegue acessar
+	GetPhoneNumber(ctx context.Context, phoneNumberID string) (*ProviderPhoneNumber, error)
+	ListPhoneNumbers(ctx context.Context, businessAccountID string) ([]ProviderPhoneNumber, error)
+
+	// Templates pertencem à conta do WhatsApp Business (WABA), não ao número
+	ListTemplates(ctx context.Context, businessAccountID string) ([]ProviderTemplate, error)
+	CreateTemplate(ctx context.Context, businessAccountID string, template ProviderTemplate) (ProviderTemplate, error)
+	DeleteTemplate(ctx context.Context, businessAccountID string, name string) error
+}
+
+// ProviderError representa um erro retornado pela API do provider
+type ProviderError struct {
+	StatusCode int
+	Code       int
+	Message    string
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("whatsapp provider error (status %d, code %d): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// cloudAPITransientCodes são os erros da Cloud API que passam sozinhos: limites de
+// vazão do app, da WABA e do número, e indisponibilidades do lado da Meta
+var cloudAPITransientCodes = map[int]bool{
+	1:      true, // API unknown
+	2:      true, // API service
+	4:      true, // limite de chamadas do app
+	80007:  true, // limite de chamadas da WABA
+	130429: true, // vazão do número atingida
+	131000: true, // erro interno
+	131016: true, // serviço sobrecarregado
+	131048: true, // limite por spam
+	131056: true, // muitas mensagens para o mesmo destinatário
+	133004: true, // servidor indisponível
+}
+
+// Retryable indica se o mesmo envio pode dar certo mais tarde; número inválido,
+// template rejeitado e opt-out do destinatário não mudam com uma nova tentativa
+func (e *ProviderError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests ||
+		e.StatusCode >= http.StatusInternalServerError ||
+		cloudAPITransientCodes[e.Code]
+}
+
+// IsRetryable classifica o erro de um envio: falhas de rede e erros transitórios do
+// provider valem uma nova tentativa, o resto é definitivo
+func IsRetryable(err error) bool {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Retryable()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	// Inclui o *url.Error que o http.Client devolve nas falhas de transporte
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,376 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+)
+
+const DefaultCloudAPIBaseURL = "https://graph.facebook.com/v21.0"
+
+type accessTokenKey struct{}
+
+// WithAccessToken faz as chamadas ao provider usarem o token do canal em vez do global
+func WithAccessToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, accessTokenKey{}, token)
+}
+
+// AccessTokenFromContext retorna o token definido por WithAccessToken, se houver
+func AccessTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(accessTokenKey{}).(string)
+	return token
+}
+
+// cloudAPIProvider implementa Provider sobre a WhatsApp Cloud API (Graph API)
+type cloudAPIProvider struct {
+	baseURL     string
+	accessToken string
+	httpClient  *http.Client
+}
+
+func NewCloudAPIProvider(baseURL, accessToken string, httpClient *http.Client) Provider {
+	if baseURL == "" {
+		baseURL = DefaultCloudAPIBaseURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 15 * time.Second, Transport: telemetry.NewTransport(nil)}
+	}
+	return &cloudAPIProvider{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		accessToken: accessToken,
+		httpClient:  httpClient,
+	}
+}
+
+type cloudAPIResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+	Error *struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+func (p *cloudAPIProvider) SendMessage(ctx context.Context, phoneNumberID string, message OutboundMessage) (string, error) {
+	body, err := json.Marshal(cloudAPIPayload(message))
+	if err != nil {
+		return "", err
+	}
+
+	var response cloudAPIResponse
+	if err := p.post(ctx, fmt.Sprintf("%s/%s/messages", p.baseURL, phoneNumberID), body, &response); err != nil {
+		return "", err
+	}
+	if len(response.Messages) == 0 {
+		return "", &ProviderError{StatusCode: http.StatusOK, Message: "response without message id"}
+	}
+	return response.Messages[0].ID, nil
+}
+
+type cloudAPITemplatePage struct {
+	Data   []ProviderTemplate `json:"data"`
+	Paging struct {
+		Next string `json:"next"`
+	} `json:"paging"`
+}
+
+func (p *cloudAPIProvider) ListTemplates(ctx context.Context, businessAccountID string) ([]ProviderTemplate, error) {
+	var templates []ProviderTemplate
+
+	next := fmt.Sprintf("%s/%s/message_templates?limit=100", p.baseURL, businessAccountID)
+	for next != "" {
+		var page cloudAPITemplatePage
+		if err := p.do(ctx, http.MethodGet, next, nil, &page); err != nil {
+			return nil, err
+		}
+		templates = append(templates, page.Data...)
+		next = page.Paging.Next
+	}
+
+	return templates, nil
+}
+
+func (p *cloudAPIProvider) GetPhoneNumber(ctx context.Context, phoneNumberID string) (*ProviderPhoneNumber, error) {
+	var number ProviderPhoneNumber
+	endpoint := fmt.Sprintf("%s/%s?fields=id,display_phone_number,verified_name", p.baseURL, url.PathEscape(phoneNumberID))
+	if err := p.do(ctx, http.MethodGet, endpoint, nil, &number); err != nil {
+		return nil, err
+	}
+	return &number, nil
+}
+
+type cloudAPIPhoneNumberPage struct {
+	Data   []ProviderPhoneNumber `json:"data"`
+	Paging struct {
+		Next string `json:"next"`
+	} `json:"paging"`
+}
+
+func (p *cloudAPIProvider) ListPhoneNumbers(ctx context.Context, businessAccountID string) ([]ProviderPhoneNumber, error) {
+	var numbers []ProviderPhoneNumber
+
+	next := fmt.Sprintf("%s/%s/phone_numbers?fields=id,display_phone_number,verified_name", p.baseURL, url.PathEscape(businessAccountID))
+	for next != "" {
+		var page cloudAPIPhoneNumberPage
+		if err := p.do(ctx, http.MethodGet, next, nil, &page); err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, page.Data...)
+		next = page.Paging.Next
+	}
+
+	return numbers, nil
+}
+
+func (p *cloudAPIProvider) CreateTemplate(ctx context.Context, businessAccountID string, template ProviderTemplate) (ProviderTemplate, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"name":       template.Name,
+		"language":   template.Language,
+		"category":   template.Category,
+		"components": template.Components,
+	})
+	if err != nil {
+		return ProviderTemplate{}, err
+	}
+
+	var created struct {
+		ID       string `json:"id"`
+		Status   string `json:"status"`
+		Category string `json:"category"`
+	}
+	if err := p.post(ctx, fmt.Sprintf("%s/%s/message_templates", p.baseURL, businessAccountID), body, &created); err != nil {
+		return ProviderTemplate{}, err
+	}
+
+	template.ID = created.ID
+	template.Status = created.Status
+	if created.Category != "" {
+		template.Category = created.Category
+	}
+	return template, nil
+}
+
+func (p *cloudAPIProvider) DeleteTemplate(ctx context.Context, businessAccountID string, name string) error {
+	endpoint := fmt.Sprintf("%s/%s/message_templates?name=%s", p.baseURL, businessAccountID, url.QueryEscape(name))
+	return p.do(ctx, http.MethodDelete, endpoint, nil, nil)
+}
+
+func (p *cloudAPIProvider) UploadMedia(ctx context.Context, phoneNumberID string, media MediaUpload) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("messaging_product", "whatsapp")
+	writer.WriteField("type", media.MimeType)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, media.Filename))
+	header.Set("Content-Type", media.MimeType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, media.Content); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/media", p.baseURL, phoneNumberID), &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+p.token(ctx))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var uploaded struct {
+		ID string `json:"id"`
+	}
+	if err := decodeCloudAPIResponse(resp, &uploaded); err != nil {
+		return "", err
+	}
+	return uploaded.ID, nil
+}
+
+// DownloadMedia resolve a URL temporária da mídia e baixa o conteúdo (ambos exigem o token)
+func (p *cloudAPIProvider) DownloadMedia(ctx context.Context, mediaID string) (*MediaDownload, error) {
+	var metadata struct {
+		URL      string `json:"url"`
+		MimeType string `json:"mime_type"`
+		FileSize int64  `json:"file_size"`
+	}
+	if err := p.do(ctx, http.MethodGet, fmt.Sprintf("%s/%s", p.baseURL, mediaID), nil, &metadata); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token(ctx))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, decodeCloudAPIResponse(resp, nil)
+	}
+
+	return &MediaDownload{
+		MimeType: metadata.MimeType,
+		Size:     metadata.FileSize,
+		Content:  resp.Body,
+	}, nil
+}
+
+func (p *cloudAPIProvider) token(ctx context.Context) string {
+	if token := AccessTokenFromContext(ctx); token != "" {
+		return token
+	}
+	return p.accessToken
+}
+
+func (p *cloudAPIProvider) post(ctx context.Context, url string, body []byte, out interface{}) error {
+	return p.do(ctx, http.MethodPost, url, body, out)
+}
+
+func (p *cloudAPIProvider) do(ctx context.Context, method, url string, body []byte, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token(ctx))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return decodeCloudAPIResponse(resp, out)
+}
+
+func decodeCloudAPIResponse(resp *http.Response, out interface{}) error {
+	if resp.StatusCode >= 300 {
+		var failure cloudAPIResponse
+		json.NewDecoder(resp.Body).Decode(&failure)
+
+		providerErr := &ProviderError{StatusCode: resp.StatusCode, Message: resp.Status}
+		if failure.Error != nil {
+			providerErr.Code = failure.Error.Code
+			providerErr.Message = failure.Error.Message
+		}
+		return providerErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// cloudAPIPayload monta o JSON no formato esperado pelo endpoint /messages
+func cloudAPIPayload(message OutboundMessage) map[string]interface{} {
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"recipient_type":    "individual",
+		"to":                message.To,
+		"type":              string(message.Type),
+	}
+
+	switch {
+	case message.Type == TypeText:
+		payload["text"] = map[string]interface{}{
+			"body":        message.Text.Body,
+			"preview_url": message.Text.PreviewURL,
+		}
+	case message.Type.IsMedia():
+		media := map[string]interface{}{}
+		if message.Media.ID != "" {
+			media["id"] = message.Media.ID
+		} else {
+			media["link"] = message.Media.Link
+		}
+		if message.Media.Caption != "" && message.Type != TypeAudio {
+			media["caption"] = message.Media.Caption
+		}
+		if message.Media.Filename != "" && message.Type == TypeDocument {
+			media["filename"] = message.Media.Filename
+		}
+		payload[string(message.Type)] = media
+	case message.Type == TypeInteractive:
+		payload["interactive"] = interactivePayload(message.Interactive)
+	case message.Type == TypeTemplate:
+		template := map[string]interface{}{
+			"name":     message.Template.Name,
+			"language": map[string]string{"code": message.Template.Language},
+		}
+		if len(message.Template.Components) > 0 {
+			template["components"] = message.Template.Components
+		}
+		payload["template"] = template
+	}
+
+	return payload
+}
+
+func interactivePayload(content *InteractiveContent) map[string]interface{} {
+	interactive := map[string]interface{}{
+		"type": string(content.Type),
+		"body": map[string]string{"text": content.Body},
+	}
+	if content.Header != "" {
+		interactive["header"] = map[string]string{"type": "text", "text": content.Header}
+	}
+	if content.Footer != "" {
+		interactive["footer"] = map[string]string{"text": content.Footer}
+	}
+
+	switch content.Type {
+	case InteractiveButton:
+		buttons := make([]map[string]interface{}, 0, len(content.Buttons))
+		for _, button := range content.Buttons {
+			buttons = append(buttons, map[string]interface{}{
+				"type":  "reply",
+				"reply": map[string]string{"id": button.ID, "title": button.Title},
+			})
+		}
+		interactive["action"] = map[string]interface{}{"buttons": buttons}
+	case InteractiveList:
+		interactive["action"] = map[string]interface{}{
+			"button":   content.ButtonText,
+			"sections": content.Sections,
+		}
+	}
+
+	return interactive
+}
@@ -0,0 +1,233 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// SentMessage registra uma mensagem enviada pelo FakeProvider
+type SentMessage struct {
+	WAMessageID   string
+	PhoneNumberID string
+	// AccessToken é o token do canal recebido via contexto (vazio = global)
+	AccessToken string
+	Message     OutboundMessage
+}
+
+// FakeProvider é um Provider em memória para testes e desenvolvimento local
+type FakeProvider struct {
+	// Err, quando definido, é retornado por todos os envios
+	Err error
+
+	mu        sync.Mutex
+	sent      []SentMessage
+	seq       int
+	templates map[string][]ProviderTemplate // por WABA
+	media     map[string]fakeMedia
+	numbers   map[string][]ProviderPhoneNumber // por WABA
+}
+
+type fakeMedia struct {
+	mimeType string
+	data     []byte
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+func (p *FakeProvider) SendMessage(ctx context.Context, phoneNumberID string, message OutboundMessage) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return "", p.Err
+	}
+
+	p.seq++
+	id := fmt.Sprintf("wamid.fake.%d", p.seq)
+	p.sent = append(p.sent, SentMessage{
+		WAMessageID:   id,
+		PhoneNumberID: phoneNumberID,
+		AccessToken:   AccessTokenFromContext(ctx),
+		Message:       message,
+	})
+	return id, nil
+}
+
+func (p *FakeProvider) ListTemplates(ctx context.Context, businessAccountID string) ([]ProviderTemplate, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	templates := make([]ProviderTemplate, len(p.templates[businessAccountID]))
+	copy(templates, p.templates[businessAccountID])
+	return templates, nil
+}
+
+func (p *FakeProvider) CreateTemplate(ctx context.Context, businessAccountID string, template ProviderTemplate) (ProviderTemplate, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return ProviderTemplate{}, p.Err
+	}
+	if p.templates == nil {
+		p.templates = map[string][]ProviderTemplate{}
+	}
+
+	p.seq++
+	template.ID = fmt.Sprintf("%d", p.seq)
+	template.Status = "PENDING"
+	p.templates[businessAccountID] = append(p.templates[businessAccountID], template)
+	return template, nil
+}
+
+func (p *FakeProvider) DeleteTemplate(ctx context.Context, businessAccountID string, name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return p.Err
+	}
+
+	kept := p.templates[businessAccountID][:0]
+	for _, template := range p.templates[businessAccountID] {
+		if template.Name != name {
+			kept = append(kept, template)
+		}
+	}
+	p.templates[businessAccountID] = kept
+	return nil
+}
+
+// SetTemplateStatus simula a revisão do template pela Meta
+func (p *FakeProvider) SetTemplateStatus(businessAccountID, name, language, status, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, template := range p.templates[businessAccountID] {
+		if template.Name == name && template.Language == language {
+			p.templates[businessAccountID][i].Status = status
+			p.templates[businessAccountID][i].RejectedReason = reason
+		}
+	}
+}
+
+func (p *FakeProvider) UploadMedia(ctx context.Context, phoneNumberID string, media MediaUpload) (string, error) {
+	data, err := io.ReadAll(media.Content)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return "", p.Err
+	}
+
+	p.seq++
+	id := fmt.Sprintf("media.fake.%d", p.seq)
+	p.putMedia(id, media.MimeType, data)
+	return id, nil
+}
+
+func (p *FakeProvider) DownloadMedia(ctx context.Context, mediaID string) (*MediaDownload, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	media, ok := p.media[mediaID]
+	if !ok {
+		return nil, &ProviderError{StatusCode: 404, Message: "media not found"}
+	}
+	return &MediaDownload{
+		MimeType: media.mimeType,
+		Size:     int64(len(media.data)),
+		Content:  io.NopCloser(bytes.NewReader(media.data)),
+	}, nil
+}
+
+// AddMedia simula uma mídia recebida de um cliente, disponível para download
+func (p *FakeProvider) AddMedia(mediaID, mimeType string, data []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.putMedia(mediaID, mimeType, data)
+}
+
+// Media retorna o conteúdo de uma mídia enviada ou cadastrada
+func (p *FakeProvider) Media(mediaID string) ([]byte, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	media, ok := p.media[mediaID]
+	return media.data, ok
+}
+
+func (p *FakeProvider) putMedia(mediaID, mimeType string, data []byte) {
+	if p.media == nil {
+		p.media = map[string]fakeMedia{}
+	}
+	p.media[mediaID] = fakeMedia{mimeType: mimeType, data: data}
+}
+
+func (p *FakeProvider) GetPhoneNumber(ctx context.Context, phoneNumberID string) (*ProviderPhoneNumber, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	for _, numbers := range p.numbers {
+		for _, number := range numbers {
+			if number.ID == phoneNumberID {
+				return &number, nil
+			}
+		}
+	}
+	return nil, &ProviderError{StatusCode: 400, Code: 100, Message: "phone number not found"}
+}
+
+func (p *FakeProvider) ListPhoneNumbers(ctx context.Context, businessAccountID string) ([]ProviderPhoneNumber, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	numbers := make([]ProviderPhoneNumber, len(p.numbers[businessAccountID]))
+	copy(numbers, p.numbers[businessAccountID])
+	return numbers, nil
+}
+
+// AddPhoneNumber simula um número cadastrado na WABA, acessível pelo token da conta
+func (p *FakeProvider) AddPhoneNumber(businessAccountID string, number ProviderPhoneNumber) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.numbers == nil {
+		p.numbers = map[string][]ProviderPhoneNumber{}
+	}
+	p.numbers[businessAccountID] = append(p.numbers[businessAccountID], number)
+}
+
+// Sent retorna uma cópia das mensagens enviadas até agora
+func (p *FakeProvider) Sent() []SentMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sent := make([]SentMessage, len(p.sent))
+	copy(sent, p.sent)
+	return sent
+}
@@ -0,0 +1,230 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository base (sem telemetria)
+type messageRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newMessageRepositoryBase(db *gorm.DB) *messageRepositoryBase {
+	return &messageRepositoryBase{db: db}
+}
+
+func (r *messageRepositoryBase) createIfNotExists(ctx context.Context, message *Message) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "wa_message_id"}},
+		DoNothing: true,
+	}).Create(message)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *messageRepositoryBase) findByWAMessageID(ctx context.Context, waMessageID string) (*Message, error) {
+	var message Message
+	err := r.db.WithContext(ctx).Where("wa_message_id = ?", waMessageID).First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *messageRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error) {
+	var message Message
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *messageRepositoryBase) update(ctx context.Context, message *Message) error {
+	return r.db.WithContext(ctx).Save(message).Error
+}
+
+func (r *messageRepositoryBase) listByConversation(ctx context.Context, tenantID, conversationID uuid.UUID, offset, limit int) ([]Message, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Message{}).Where("tenant_id = ? AND conversation_id = ?", tenantID, conversationID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []Message
+	err := query.Order("timestamp DESC, id DESC").Offset(offset).Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *messageRepositoryBase) lastInboundAt(ctx context.Context, tenantID uuid.UUID, phoneNumberID, contact string) (*time.Time, error) {
+	var message Message
+	err := r.db.WithContext(ctx).Select("timestamp").
+		Where("tenant_id = ? AND phone_number_id = ? AND from_number = ? AND direction = ?", tenantID, phoneNumberID, contact, DirectionInbound).
+		Order("timestamp DESC").
+		First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &message.Timestamp, nil
+}
+
+// firstAgentReplySince ignora envios automáticos (chatbot, ausência), feitos com uuid.Nil
+func (r *messageRepositoryBase) firstAgentReplySince(ctx context.Context, conversationID uuid.UUID, since time.Time) (*Message, error) {
+	var message Message
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND direction = ? AND timestamp >= ?", conversationID, DirectionOutbound, since).
+		Where("sent_by_id IS NOT NULL AND sent_by_id <> ?", uuid.Nil).
+		Order("timestamp ASC").
+		First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+// Repository com telemetria (decorator)
+type messageRepository struct {
+	base      *messageRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewMessageRepository(db *gorm.DB, telemetry telemetry.TelemetryService) MessageRepository {
+	return &messageRepository{
+		base:      newMessageRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *messageRepository) CreateIfNotExists(ctx context.Context, message *Message) (bool, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_message.create")
+	defer span.End()
+
+	span.SetTag("wa_message_id", message.WAMessageID)
+	span.SetTag("direction", string(message.Direction))
+
+	created, err := r.base.createIfNotExists(ctx, message)
+	if err != nil {
+		span.SetError(err)
+		return false, err
+	}
+
+	if !created {
+		span.SetTag("duplicate", "true")
+		r.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "repository.whatsapp_message.duplicate",
+			Value: 1,
+			Tags:  map[string]string{"direction": string(message.Direction)},
+		})
+	}
+
+	return created, nil
+}
+
+func (r *messageRepository) FindByWAMessageID(ctx context.Context, waMessageID string) (*Message, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_message.find_by_wa_message_id")
+	defer span.End()
+
+	span.SetTag("wa_message_id", waMessageID)
+
+	message, err := r.base.findByWAMessageID(ctx, waMessageID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return message, nil
+}
+
+func (r *messageRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Message, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_message.find_by_id")
+	defer span.End()
+
+	span.SetTag("message_id", id)
+
+	message, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return message, nil
+}
+
+func (r *messageRepository) Update(ctx context.Context, message *Message) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_message.update")
+	defer span.End()
+
+	span.SetTag("wa_message_id", message.WAMessageID)
+
+	err := r.base.update(ctx, message)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *messageRepository) ListByConversation(ctx context.Context, tenantID, conversationID uuid.UUID, offset, limit int) ([]Message, int64, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_message.list_by_conversation")
+	defer span.End()
+
+	span.SetTag("conversation_id", conversationID.String())
+
+	items, total, err := r.base.listByConversation(ctx, tenantID, conversationID, offset, limit)
+	if err != nil {
+		span.SetError(err)
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *messageRepository) LastInboundAt(ctx context.Context, tenantID uuid.UUID, phoneNumberID, contact string) (*time.Time, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_message.last_inbound_at")
+	defer span.End()
+
+	span.SetTag("phone_number_id", phoneNumberID)
+
+	lastInboundAt, err := r.base.lastInboundAt(ctx, tenantID, phoneNumberID, contact)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return lastInboundAt, nil
+}
+
+func (r *messageRepository) FirstAgentReplySince(ctx context.Context, conversationID uuid.UUID, since time.Time) (*Message, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_message.first_agent_reply_since")
+	defer span.End()
+
+	span.SetTag("conversation_id", conversationID.String())
+
+	message, err := r.base.firstAgentReplySince(ctx, conversationID, since)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return message, nil
+}
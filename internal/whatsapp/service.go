@@ -0,0 +1,144 @@
+package whatsapp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+)
+
+type webhookService struct {
+	messageRepo    MessageRepository
+	channelService ChannelService
+	telemetry      telemetry.TelemetryService
+
+	mu        sync.RWMutex
+	listeners []EventListener
+}
+
+func NewWebhookService(messageRepo MessageRepository, channelService ChannelService, telemetry telemetry.TelemetryService) WebhookService {
+	return &webhookService{
+		messageRepo:    messageRepo,
+		channelService: channelService,
+		telemetry:      telemetry,
+	}
+}
+
+func (s *webhookService) Subscribe(listener EventListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *webhookService) publish(ctx context.Context, event Event) {
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(ctx, event)
+	}
+}
+
+func (s *webhookService) HandleWebhook(ctx context.Context, payload WebhookPayload) error {
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.handle_webhook")
+	defer span.End()
+
+	messages, statuses := ParseWebhook(payload)
+
+	for _, event := range messages {
+		if err := s.handleInbound(ctx, event); err != nil {
+			span.SetError(err)
+			return err
+		}
+	}
+
+	for _, event := range statuses {
+		if err := s.handleStatus(ctx, event); err != nil {
+			span.SetError(err)
+			return err
+		}
+	}
+
+	for _, event := range ParseTemplateStatusUpdates(payload) {
+		s.publish(ctx, event)
+	}
+
+	return nil
+}
+
+func (s *webhookService) handleInbound(ctx context.Context, event InboundMessageEvent) error {
+	channel, err := s.channelService.ResolveInbound(ctx, event.PhoneNumberID)
+	if err != nil {
+		return err
+	}
+	if channel == nil {
+		// Número não cadastrado: descarta para a Meta não reenviar indefinidamente
+		s.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "whatsapp.webhook.unknown_phone_number",
+			Value: 1,
+			Tags:  map[string]string{"phone_number_id": event.PhoneNumberID},
+		})
+		return nil
+	}
+
+	event.TenantID = channel.TenantID
+	event.Channel = channel
+	event.Message.TenantID = channel.TenantID
+
+	created, err := s.messageRepo.CreateIfNotExists(ctx, event.Message)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+
+	s.telemetry.TrackEvent(ctx, telemetry.Event{
+		Name: EventInboundMessage,
+		Properties: map[string]interface{}{
+			"tenant_id":     channel.TenantID.String(),
+			"wa_message_id": event.Message.WAMessageID,
+			"type":          event.Message.Type,
+		},
+		Timestamp: time.Now(),
+	})
+	s.publish(ctx, event)
+
+	return nil
+}
+
+func (s *webhookService) handleStatus(ctx context.Context, event StatusUpdateEvent) error {
+	message, err := s.messageRepo.FindByWAMessageID(ctx, event.WAMessageID)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		s.telemetry.TrackMetric(ctx, telemetry.Metric{
+			Name:  "whatsapp.webhook.unknown_message_status",
+			Value: 1,
+			Tags:  map[string]string{"status": string(event.Status)},
+		})
+		return nil
+	}
+
+	// Reentregas e webhooks fora de ordem não alteram o status
+	if !message.Status.CanTransitionTo(event.Status) {
+		return nil
+	}
+
+	message.Status = event.Status
+	if event.Status == StatusFailed {
+		message.ErrorCode = event.ErrorCode
+		message.ErrorMessage = event.ErrorMessage
+	}
+	if err := s.messageRepo.Update(ctx, message); err != nil {
+		return err
+	}
+
+	event.TenantID = message.TenantID
+	s.publish(ctx, event)
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package whatsapp
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TemplateCategory string
+
+const (
+	CategoryMarketing      TemplateCategory = "MARKETING"
+	CategoryUtility        TemplateCategory = "UTILITY"
+	CategoryAuthentication TemplateCategory = "AUTHENTICATION"
+)
+
+func (c TemplateCategory) Valid() bool {
+	switch c {
+	case CategoryMarketing, CategoryUtility, CategoryAuthentication:
+		return true
+	}
+	return false
+}
+
+type TemplateStatus string
+
+const (
+	TemplatePending  TemplateStatus = "pending"
+	TemplateApproved TemplateStatus = "approved"
+	TemplateRejected TemplateStatus = "rejected"
+	TemplatePaused   TemplateStatus = "paused"
+	TemplateDisabled TemplateStatus = "disabled"
+)
+
+// ParseTemplateStatus converte o status da Meta (APPROVED, REJECTED...) para o local
+func ParseTemplateStatus(value string) TemplateStatus {
+	switch strings.ToUpper(value) {
+	case "APPROVED":
+		return TemplateApproved
+	case "REJECTED":
+		return TemplateRejected
+	case "PAUSED":
+		return TemplatePaused
+	case "DISABLED":
+		return TemplateDisabled
+	}
+	return TemplatePending
+}
+
+const (
+	ComponentHeader  = "HEADER"
+	ComponentBody    = "BODY"
+	ComponentFooter  = "FOOTER"
+	ComponentButtons = "BUTTONS"
+)
+
+// TemplateDefinitionComponent descreve um componente do template como cadastrado na Meta
+type TemplateDefinitionComponent struct {
+	Type    string           `json:"type"`
+	Format  string           `json:"format,omitempty"`
+	Text    string           `json:"text,omitempty"`
+	Buttons []TemplateButton `json:"buttons,omitempty"`
+}
+
+type TemplateButton struct {
+	Type        string `json:"type"` // QUICK_REPLY, URL, PHONE_NUMBER
+	Text        string `json:"text"`
+	URL         string `json:"url,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+}
+
+type Template struct {
+	ID                 uuid.UUID                     `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID           uuid.UUID                     `gorm:"type:uuid;not null;uniqueIndex:idx_templates_name_language,priority:1" json:"tenant_id"`
+	Name               string                        `gorm:"type:varchar(512);not null;uniqueIndex:idx_templates_name_language,priority:2" json:"name"`
+	Language           string                        `gorm:"type:varchar(16);not null;uniqueIndex:idx_templates_name_language,priority:3" json:"language"`
+	Category           TemplateCategory              `gorm:"type:varchar(32);not null" json:"category"`
+	Components         []TemplateDefinitionComponent `gorm:"type:text;serializer:json" json:"components"`
+	Status             TemplateStatus                `gorm:"type:varchar(16);not null;index" json:"status"`
+	ProviderTemplateID string                        `gorm:"type:varchar(64);index" json:"provider_template_id,omitempty"`
+	RejectionReason    string                        `gorm:"type:varchar(255)" json:"rejection_reason,omitempty"`
+	LastSyncedAt       *time.Time                    `json:"last_synced_at,omitempty"`
+	CreatedAt          time.Time                     `json:"created_at"`
+	UpdatedAt          time.Time                     `json:"updated_at"`
+}
+
+func (t *Template) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+var (
+	ErrInvalidTemplate       = errors.New("invalid template")
+	ErrInvalidTemplateValues = errors.New("invalid template variables")
+
+	templateNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,512}$`)
+	placeholderPattern  = regexp.MustCompile(`\{\{\s*(\d+)\s*\}\}`)
+)
+
+// Validate confere nome, categoria e a numeração sequencial dos placeholders ({{1}}, {{2}}...)
+func (t *Template) Validate() error {
+	if !templateNamePattern.MatchString(t.Name) {
+		return fmt.Errorf("%w: name must contain only lowercase letters, numbers and underscores", ErrInvalidTemplate)
+	}
+	if t.Language == "" {
+		return fmt.Errorf("%w: language is required", ErrInvalidTemplate)
+	}
+	if !t.Category.Valid() {
+		return fmt.Errorf("%w: unsupported category %q", ErrInvalidTemplate, t.Category)
+	}
+
+	hasBody := false
+	for _, component := range t.Components {
+		switch component.Type {
+		case ComponentBody:
+			hasBody = true
+			if len([]rune(component.Text)) > 1024 {
+				return fmt.Errorf("%w: body exceeds 1024 characters", ErrInvalidTemplate)
+			}
+		case ComponentHeader, ComponentFooter, ComponentButtons:
+		default:
+			return fmt.Errorf("%w: unsupported component %q", ErrInvalidTemplate, component.Type)
+		}
+
+		if _, err := countPlaceholders(component.Text); err != nil {
+			return err
+		}
+		if component.Type == ComponentFooter && placeholderPattern.MatchString(component.Text) {
+			return fmt.Errorf("%w: footer does not accept variables", ErrInvalidTemplate)
+		}
+	}
+	if !hasBody {
+		return fmt.Errorf("%w: body component is required", ErrInvalidTemplate)
+	}
+
+	return nil
+}
+
+// countPlaceholders retorna quantas variáveis o texto espera, exigindo numeração 1..n
+func countPlaceholders(text string) (int, error) {
+	seen := map[int]bool{}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		n, _ := strconv.Atoi(match[1])
+		seen[n] = true
+	}
+	for i := 1; i <= len(seen); i++ {
+		if !seen[i] {
+			return 0, fmt.Errorf("%w: placeholders must be numbered sequentially from {{1}}", ErrInvalidTemplate)
+		}
+	}
+	return len(seen), nil
+}
+
+func (t *Template) component(componentType string) *TemplateDefinitionComponent {
+	for i := range t.Components {
+		if t.Components[i].Type == componentType {
+			return &t.Components[i]
+		}
+	}
+	return nil
+}
+
+// TemplateVariables são os valores dos placeholders por componente
+type TemplateVariables struct {
+	Header []string `json:"header"`
+	Body   []string `json:"body"`
+}
+
+// Render valida as variáveis e monta o conteúdo de envio e o texto renderizado do corpo
+func (t *Template) Render(variables TemplateVariables) (*TemplateContent, string, error) {
+	content := &TemplateContent{Name: t.Name, Language: t.Language}
+	var rendered string
+
+	for _, part := range []struct {
+		componentType string
+		values        []string
+	}{
+		{ComponentHeader, variables.Header},
+		{ComponentBody, variables.Body},
+	} {
+		component := t.component(part.componentType)
+		expected := 0
+		if component != nil && (component.Format == "" || component.Format == "TEXT") {
+			expected, _ = countPlaceholders(component.Text)
+		}
+		if len(part.values) != expected {
+			return nil, "", fmt.Errorf("%w: %s expects %d variables, got %d",
+				ErrInvalidTemplateValues, strings.ToLower(part.componentType), expected, len(part.values))
+		}
+		if expected == 0 {
+			continue
+		}
+
+		parameters := make([]TemplateParameter, 0, expected)
+		for _, value := range part.values {
+			if strings.TrimSpace(value) == "" {
+				return nil, "", fmt.Errorf("%w: %s variables cannot be empty", ErrInvalidTemplateValues, strings.ToLower(part.componentType))
+			}
+			parameters = append(parameters, TemplateParameter{Type: "text", Text: value})
+		}
+		content.Components = append(content.Components, TemplateComponent{
+			Type:       strings.ToLower(part.componentType),
+			Parameters: parameters,
+		})
+
+		if part.componentType == ComponentBody {
+			rendered = fillPlaceholders(component.Text, part.values)
+		}
+	}
+
+	if rendered == "" {
+		if body := t.component(ComponentBody); body != nil {
+			rendered = body.Text
+		}
+	}
+
+	return content, rendered, nil
+}
+
+func fillPlaceholders(text string, values []string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		n, _ := strconv.Atoi(placeholderPattern.FindStringSubmatch(match)[1])
+		return values[n-1]
+	})
+}
@@ -0,0 +1,107 @@
+package whatsapp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateHandler struct {
+	templateService TemplateService
+}
+
+func NewTemplateHandler(templateService TemplateService) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// List aceita ?status=approved para filtrar os templates utilizáveis
+func (h *TemplateHandler) List(c *gin.Context) {
+	items, err := h.templateService.ListTemplates(c.Request.Context(), web.TenantID(c), TemplateStatus(c.Query("status")))
+	if err != nil {
+		c.JSON(templateStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, ListTemplatesResponse{Items: items})
+}
+
+func (h *TemplateHandler) Get(c *gin.Context) {
+	template, err := h.templateService.GetTemplate(c.Request.Context(), web.TenantID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(templateStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *TemplateHandler) Create(c *gin.Context) {
+	var req CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(c.Request.Context(), web.TenantID(c), req)
+	if err != nil {
+		c.JSON(templateStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+func (h *TemplateHandler) Delete(c *gin.Context) {
+	if err := h.templateService.DeleteTemplate(c.Request.Context(), web.TenantID(c), c.Param("id")); err != nil {
+		c.JSON(templateStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TemplateHandler) Sync(c *gin.Context) {
+	synced, err := h.templateService.SyncTemplates(c.Request.Context(), web.TenantID(c))
+	if err != nil {
+		c.JSON(templateStatusFor(err), web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"synced": synced})
+}
+
+func (h *TemplateHandler) Send(c *gin.Context) {
+	var req SendTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, web.ErrorBody(c, err.Error()))
+		return
+	}
+
+	message, err := h.templateService.SendTemplate(c.Request.Context(), web.TenantID(c), web.UserID(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(templateStatusFor(err), errorBody(c, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+func templateStatusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTemplateAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrTemplateNotApproved):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrInvalidTemplate),
+		errors.Is(err, ErrInvalidTemplateValues),
+		errors.Is(err, ErrTemplateRecipient):
+		return http.StatusBadRequest
+	}
+	return conversationStatusFor(err)
+}
@@ -0,0 +1,187 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository base (sem telemetria)
+type templateRepositoryBase struct {
+	db *gorm.DB
+}
+
+func newTemplateRepositoryBase(db *gorm.DB) *templateRepositoryBase {
+	return &templateRepositoryBase{db: db}
+}
+
+func (r *templateRepositoryBase) create(ctx context.Context, template *Template) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+func (r *templateRepositoryBase) first(ctx context.Context, query *gorm.DB) (*Template, error) {
+	var template Template
+	err := query.First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *templateRepositoryBase) findByID(ctx context.Context, tenantID uuid.UUID, id string) (*Template, error) {
+	return r.first(ctx, r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id))
+}
+
+func (r *templateRepositoryBase) findByNameLanguage(ctx context.Context, tenantID uuid.UUID, name, language string) (*Template, error) {
+	return r.first(ctx, r.db.WithContext(ctx).Where("tenant_id = ? AND name = ? AND language = ?", tenantID, name, language))
+}
+
+func (r *templateRepositoryBase) findByProviderTemplateID(ctx context.Context, providerTemplateID string) ([]Template, error) {
+	var items []Template
+	err := r.db.WithContext(ctx).Where("provider_template_id = ?", providerTemplateID).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *templateRepositoryBase) list(ctx context.Context, tenantID uuid.UUID, status TemplateStatus) ([]Template, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var items []Template
+	err := query.Order("name ASC, language ASC").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *templateRepositoryBase) update(ctx context.Context, template *Template) error {
+	return r.db.WithContext(ctx).Save(template).Error
+}
+
+func (r *templateRepositoryBase) delete(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&Template{}).Error
+}
+
+// Repository com telemetria (decorator)
+type templateRepository struct {
+	base      *templateRepositoryBase
+	telemetry telemetry.TelemetryService
+}
+
+func NewTemplateRepository(db *gorm.DB, telemetry telemetry.TelemetryService) TemplateRepository {
+	return &templateRepository{
+		base:      newTemplateRepositoryBase(db),
+		telemetry: telemetry,
+	}
+}
+
+func (r *templateRepository) Create(ctx context.Context, template *Template) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_template.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", template.TenantID.String())
+	span.SetTag("name", template.Name)
+
+	err := r.base.create(ctx, template)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *templateRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id string) (*Template, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_template.find_by_id")
+	defer span.End()
+
+	span.SetTag("template_id", id)
+
+	template, err := r.base.findByID(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return template, nil
+}
+
+func (r *templateRepository) FindByNameLanguage(ctx context.Context, tenantID uuid.UUID, name, language string) (*Template, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_template.find_by_name_language")
+	defer span.End()
+
+	span.SetTag("name", name)
+	span.SetTag("language", language)
+
+	template, err := r.base.findByNameLanguage(ctx, tenantID, name, language)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return template, nil
+}
+
+func (r *templateRepository) FindByProviderTemplateID(ctx context.Context, providerTemplateID string) ([]Template, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_template.find_by_provider_template_id")
+	defer span.End()
+
+	span.SetTag("provider_template_id", providerTemplateID)
+
+	items, err := r.base.findByProviderTemplateID(ctx, providerTemplateID)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *templateRepository) List(ctx context.Context, tenantID uuid.UUID, status TemplateStatus) ([]Template, error) {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_template.list")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	items, err := r.base.list(ctx, tenantID, status)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *templateRepository) Update(ctx context.Context, template *Template) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_template.update")
+	defer span.End()
+
+	span.SetTag("template_id", template.ID.String())
+
+	err := r.base.update(ctx, template)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
+
+func (r *templateRepository) Delete(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	span, ctx := r.telemetry.StartSpan(ctx, "repository.whatsapp_template.delete")
+	defer span.End()
+
+	span.SetTag("template_id", id.String())
+
+	err := r.base.delete(ctx, tenantID, id)
+	if err != nil {
+		span.SetError(err)
+		return err
+	}
+	return nil
+}
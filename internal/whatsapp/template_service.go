@@ -0,0 +1,285 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTemplateNotFound      = errors.New("template not found")
+	ErrTemplateAlreadyExists = errors.New("template with this name and language already exists")
+	ErrTemplateNotApproved   = errors.New("template is not approved")
+	ErrTemplateRecipient     = errors.New("either to or conversation_id is required")
+)
+
+type templateService struct {
+	templateRepo        TemplateRepository
+	channelService      ChannelService
+	provider            Provider
+	messageService      MessageService
+	conversationService ConversationService
+	telemetry           telemetry.TelemetryService
+}
+
+func NewTemplateService(
+	templateRepo TemplateRepository,
+	channelService ChannelService,
+	provider Provider,
+	messageService MessageService,
+	conversationService ConversationService,
+	telemetry telemetry.TelemetryService,
+) TemplateService {
+	return &templateService{
+		templateRepo:        templateRepo,
+		channelService:      channelService,
+		provider:            provider,
+		messageService:      messageService,
+		conversationService: conversationService,
+		telemetry:           telemetry,
+	}
+}
+
+// HandleEvent aplica as decisões de aprovação/rejeição enviadas pelo webhook
+func (s *templateService) HandleEvent(ctx context.Context, event Event) {
+	statusEvent, ok := event.(TemplateStatusEvent)
+	if !ok {
+		return
+	}
+
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.template.status_update")
+	defer span.End()
+
+	span.SetTag("provider_template_id", statusEvent.ProviderTemplateID)
+	span.SetTag("status", string(statusEvent.Status))
+
+	templates, err := s.templateRepo.FindByProviderTemplateID(ctx, statusEvent.ProviderTemplateID)
+	if err != nil {
+		span.SetError(err)
+		return
+	}
+
+	for i := range templates {
+		template := &templates[i]
+		template.Status = statusEvent.Status
+		template.RejectionReason = statusEvent.Reason
+		if err := s.templateRepo.Update(ctx, template); err != nil {
+			span.SetError(err)
+			return
+		}
+
+		s.telemetry.TrackEvent(ctx, telemetry.Event{
+			Name: EventTemplateStatus,
+			Properties: map[string]interface{}{
+				"tenant_id":   template.TenantID.String(),
+				"template_id": template.ID.String(),
+				"status":      string(template.Status),
+			},
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+func (s *templateService) ListTemplates(ctx context.Context, tenantID uuid.UUID, status TemplateStatus) ([]Template, error) {
+	return s.templateRepo.List(ctx, tenantID, status)
+}
+
+func (s *templateService) GetTemplate(ctx context.Context, tenantID uuid.UUID, id string) (*Template, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	template, err := s.templateRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (s *templateService) CreateTemplate(ctx context.Context, tenantID uuid.UUID, req CreateTemplateRequest) (*Template, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.template.create")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+	span.SetTag("name", req.Name)
+
+	template := &Template{
+		TenantID:   tenantID,
+		Name:       req.Name,
+		Language:   req.Language,
+		Category:   req.Category,
+		Components: req.Components,
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.templateRepo.FindByNameLanguage(ctx, tenantID, template.Name, template.Language)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrTemplateAlreadyExists
+	}
+
+	ctx, businessAccountID, err := s.businessAccount(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.provider.CreateTemplate(ctx, businessAccountID, ProviderTemplate{
+		Name:       template.Name,
+		Language:   template.Language,
+		Category:   string(template.Category),
+		Components: template.Components,
+	})
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	template.ProviderTemplateID = created.ID
+	template.Status = ParseTemplateStatus(created.Status)
+	template.LastSyncedAt = &now
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *templateService) DeleteTemplate(ctx context.Context, tenantID uuid.UUID, id string) error {
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.template.delete")
+	defer span.End()
+
+	template, err := s.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	// Templates criados só localmente não existem no provider
+	if template.ProviderTemplateID != "" {
+		ctx, businessAccountID, err := s.businessAccount(ctx, tenantID)
+		if err != nil {
+			return err
+		}
+		if err := s.provider.DeleteTemplate(ctx, businessAccountID, template.Name); err != nil {
+			span.SetError(err)
+			return err
+		}
+	}
+
+	return s.templateRepo.Delete(ctx, tenantID, template.ID)
+}
+
+func (s *templateService) SyncTemplates(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	span, ctx := s.telemetry.StartSpan(ctx, "whatsapp.template.sync")
+	defer span.End()
+
+	span.SetTag("tenant_id", tenantID.String())
+
+	ctx, businessAccountID, err := s.businessAccount(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	remote, err := s.provider.ListTemplates(ctx, businessAccountID)
+	if err != nil {
+		span.SetError(err)
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, item := range remote {
+		template, err := s.templateRepo.FindByNameLanguage(ctx, tenantID, item.Name, item.Language)
+		if err != nil {
+			span.SetError(err)
+			return 0, err
+		}
+
+		isNew := template == nil
+		if isNew {
+			template = &Template{TenantID: tenantID, Name: item.Name, Language: item.Language}
+		}
+		template.Category = TemplateCategory(item.Category)
+		template.Components = item.Components
+		template.Status = ParseTemplateStatus(item.Status)
+		template.ProviderTemplateID = item.ID
+		template.RejectionReason = item.RejectedReason
+		template.LastSyncedAt = &now
+
+		if isNew {
+			err = s.templateRepo.Create(ctx, template)
+		} else {
+			err = s.templateRepo.Update(ctx, template)
+		}
+		if err != nil {
+			span.SetError(err)
+			return 0, err
+		}
+	}
+
+	s.telemetry.TrackMetric(ctx, telemetry.Metric{
+		Name:  "whatsapp.template.synced",
+		Value: float64(len(remote)),
+		Tags:  map[string]string{"tenant_id": tenantID.String()},
+	})
+
+	return len(remote), nil
+}
+
+func (s *templateService) SendTemplate(ctx context.Context, tenantID, userID uuid.UUID, id string, req SendTemplateRequest) (*Message, error) {
+	template, err := s.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if template.Status != TemplateApproved {
+		return nil, ErrTemplateNotApproved
+	}
+
+	content, rendered, err := template.Render(req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	send := SendMessageRequest{
+		To:        req.To,
+		Type:      TypeTemplate,
+		Template:  content,
+		Preview:   rendered,
+		ChannelID: req.ChannelID,
+	}
+
+	switch {
+	case req.ConversationID != nil:
+		return s.conversationService.SendMessage(ctx, tenantID, userID, *req.ConversationID, send)
+	case req.To != "":
+		return s.messageService.SendMessage(ctx, tenantID, userID, send)
+	}
+	return nil, ErrTemplateRecipient
+}
+
+// businessAccount usa a WABA e as credenciais do canal padrão do tenant
+func (s *templateService) businessAccount(ctx context.Context, tenantID uuid.UUID) (context.Context, string, error) {
+	channel, err := s.channelService.SelectOutbound(ctx, tenantID, "", "")
+	if err != nil {
+		return ctx, "", err
+	}
+	if channel.BusinessAccountID == "" {
+		return ctx, "", ErrChannelNotConfigured
+	}
+	ctx, err = s.channelService.WithCredentials(ctx, channel)
+	if err != nil {
+		return ctx, "", err
+	}
+	return ctx, channel.BusinessAccountID, nil
+}
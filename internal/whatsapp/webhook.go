@@ -0,0 +1,245 @@
+package whatsapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formato do payload enviado pela WhatsApp Cloud API
+type WebhookPayload struct {
+	Object string         `json:"object"`
+	Entry  []WebhookEntry `json:"entry"`
+}
+
+type WebhookEntry struct {
+	ID      string          `json:"id"`
+	Changes []WebhookChange `json:"changes"`
+}
+
+type WebhookChange struct {
+	Field string       `json:"field"`
+	Value WebhookValue `json:"value"`
+}
+
+type WebhookValue struct {
+	MessagingProduct string            `json:"messaging_product"`
+	Metadata         WebhookMetadata   `json:"metadata"`
+	Contacts         []WebhookContact  `json:"contacts"`
+	Messages         []json.RawMessage `json:"messages"`
+	Statuses         []WebhookStatus   `json:"statuses"`
+
+	// Campos do field "message_template_status_update"
+	Event                   string `json:"event"`
+	MessageTemplateID       int64  `json:"message_template_id"`
+	MessageTemplateName     string `json:"message_template_name"`
+	MessageTemplateLanguage string `json:"message_template_language"`
+	Reason                  string `json:"reason"`
+}
+
+type WebhookMetadata struct {
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	PhoneNumberID      string `json:"phone_number_id"`
+}
+
+type WebhookContact struct {
+	WaID    string `json:"wa_id"`
+	Profile struct {
+		Name string `json:"name"`
+	} `json:"profile"`
+}
+
+type webhookMedia struct {
+	ID       string `json:"id"`
+	MimeType string `json:"mime_type"`
+	Caption  string `json:"caption"`
+	Filename string `json:"filename"`
+}
+
+type webhookMessage struct {
+	From      string `json:"from"`
+	ID        string `json:"id"`
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"`
+	Text      *struct {
+		Body string `json:"body"`
+	} `json:"text"`
+	Image    *webhookMedia `json:"image"`
+	Audio    *webhookMedia `json:"audio"`
+	Video    *webhookMedia `json:"video"`
+	Document *webhookMedia `json:"document"`
+	Sticker  *webhookMedia `json:"sticker"`
+	Button   *struct {
+		Text    string `json:"text"`
+		Payload string `json:"payload"`
+	} `json:"button"`
+	Interactive *struct {
+		Type        string `json:"type"`
+		ButtonReply *struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"button_reply"`
+		ListReply *struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"list_reply"`
+	} `json:"interactive"`
+}
+
+type WebhookStatus struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	Timestamp   string `json:"timestamp"`
+	RecipientID string `json:"recipient_id"`
+	Errors      []struct {
+		Code  int    `json:"code"`
+		Title string `json:"title"`
+	} `json:"errors"`
+}
+
+// VerifySignature valida o header X-Hub-Signature-256 ("sha256=<hex>")
+func VerifySignature(appSecret string, body []byte, header string) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok || appSecret == "" {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// Sign gera o valor do header X-Hub-Signature-256 para o body
+func Sign(appSecret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseWebhook converte o payload em eventos de domínio ainda sem tenant resolvido
+func ParseWebhook(payload WebhookPayload) ([]InboundMessageEvent, []StatusUpdateEvent) {
+	var messages []InboundMessageEvent
+	var statuses []StatusUpdateEvent
+
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			if change.Field != "" && change.Field != "messages" {
+				continue
+			}
+			value := change.Value
+
+			names := make(map[string]string, len(value.Contacts))
+			for _, contact := range value.Contacts {
+				names[contact.WaID] = contact.Profile.Name
+			}
+
+			for _, raw := range value.Messages {
+				var msg webhookMessage
+				if err := json.Unmarshal(raw, &msg); err != nil || msg.ID == "" {
+					continue
+				}
+				messages = append(messages, InboundMessageEvent{
+					PhoneNumberID: value.Metadata.PhoneNumberID,
+					Message:       toMessage(msg, value.Metadata, names[msg.From], raw),
+				})
+			}
+
+			for _, status := range value.Statuses {
+				event := StatusUpdateEvent{
+					PhoneNumberID: value.Metadata.PhoneNumberID,
+					WAMessageID:   status.ID,
+					Status:        MessageStatus(status.Status),
+					RecipientID:   status.RecipientID,
+					Timestamp:     parseTimestamp(status.Timestamp),
+				}
+				if len(status.Errors) > 0 {
+					event.ErrorCode = strconv.Itoa(status.Errors[0].Code)
+					event.ErrorMessage = status.Errors[0].Title
+				}
+				statuses = append(statuses, event)
+			}
+		}
+	}
+
+	return messages, statuses
+}
+
+// ParseTemplateStatusUpdates extrai as mudanças de status de templates revisados pela Meta
+func ParseTemplateStatusUpdates(payload WebhookPayload) []TemplateStatusEvent {
+	var events []TemplateStatusEvent
+
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			if change.Field != "message_template_status_update" || change.Value.MessageTemplateID == 0 {
+				continue
+			}
+			event := TemplateStatusEvent{
+				BusinessAccountID:  entry.ID,
+				ProviderTemplateID: strconv.FormatInt(change.Value.MessageTemplateID, 10),
+				Name:               change.Value.MessageTemplateName,
+				Language:           change.Value.MessageTemplateLanguage,
+				Status:             ParseTemplateStatus(change.Value.Event),
+			}
+			if change.Value.Reason != "" && change.Value.Reason != "NONE" {
+				event.Reason = change.Value.Reason
+			}
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+func toMessage(msg webhookMessage, metadata WebhookMetadata, contactName string, raw json.RawMessage) *Message {
+	message := &Message{
+		WAMessageID:   msg.ID,
+		PhoneNumberID: metadata.PhoneNumberID,
+		Direction:     DirectionInbound,
+		From:          msg.From,
+		To:            metadata.DisplayPhoneNumber,
+		ContactName:   contactName,
+		Type:          msg.Type,
+		Status:        StatusReceived,
+		Payload:       string(raw),
+		Timestamp:     parseTimestamp(msg.Timestamp),
+	}
+
+	switch {
+	case msg.Text != nil:
+		message.Body = msg.Text.Body
+	case msg.Button != nil:
+		message.Body = msg.Button.Text
+	case msg.Interactive != nil && msg.Interactive.ButtonReply != nil:
+		message.Body = msg.Interactive.ButtonReply.Title
+	case msg.Interactive != nil && msg.Interactive.ListReply != nil:
+		message.Body = msg.Interactive.ListReply.Title
+	}
+
+	for _, media := range []*webhookMedia{msg.Image, msg.Audio, msg.Video, msg.Document, msg.Sticker} {
+		if media != nil {
+			message.MediaID = media.ID
+			message.MediaMimeType = media.MimeType
+			message.Body = media.Caption
+			break
+		}
+	}
+
+	return message
+}
+
+// parseTimestamp converte o unix timestamp (em string) enviado pela Meta
+func parseTimestamp(value string) time.Time {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+	return time.Unix(seconds, 0).UTC()
+}
@@ -0,0 +1,32 @@
+package whatsapp
+
+import (
+	"errors"
+	"time"
+)
+
+// CustomerServiceWindow é o prazo, a partir da última mensagem do cliente,
+// em que a Meta aceita mensagens livres. Fora dele só templates aprovados.
+const CustomerServiceWindow = 24 * time.Hour
+
+// ErrorCodeWindowClosed é devolvido no campo "code" das respostas de erro
+const ErrorCodeWindowClosed = "whatsapp_window_closed"
+
+// cloudAPIReengagementCode é o erro da Cloud API para envio livre fora da janela
+const cloudAPIReengagementCode = 131047
+
+var ErrWindowClosed = errors.New("customer service window is closed: only approved templates can be sent")
+
+// ServiceWindowExpiry retorna quando a janela fecha, ou nil se o cliente nunca escreveu
+func ServiceWindowExpiry(lastInboundAt *time.Time) *time.Time {
+	if lastInboundAt == nil {
+		return nil
+	}
+	expiry := lastInboundAt.Add(CustomerServiceWindow)
+	return &expiry
+}
+
+// RequiresWindow indica se o tipo de mensagem depende da janela aberta
+func (t MessageType) RequiresWindow() bool {
+	return t != TypeTemplate
+}
@@ -0,0 +1,105 @@
+package activities_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/activities"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupRepository(t *testing.T) activities.ActivityRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	err = db.AutoMigrate(&activities.Activity{})
+	assert.NoError(t, err)
+
+	telemetryService := telemetry.NewTelemetryService(false) // disabled for tests
+	return activities.NewActivityRepository(db, telemetryService)
+}
+
+func TestActivityRepository_ListBySubject(t *testing.T) {
+	// Setup
+	repo := setupRepository(t)
+	tenantID := uuid.New()
+	userID := uuid.New()
+	customerID := uuid.New()
+	now := time.Now()
+
+	records := []*activities.Activity{
+		{Type: activities.TypeNote, SubjectType: activities.SubjectCustomer, SubjectID: customerID, OccurredAt: now.Add(-2 * time.Hour)},
+		{Type: activities.TypeCall, SubjectType: activities.SubjectCustomer, SubjectID: customerID, OccurredAt: now},
+		{Type: activities.TypeMeeting, SubjectType: activities.SubjectCustomer, SubjectID: customerID, OccurredAt: now.Add(-time.Hour)},
+		{Type: activities.TypeNote, SubjectType: activities.SubjectDeal, SubjectID: uuid.New(), OccurredAt: now},
+	}
+	for _, a := range records {
+		a.TenantID = tenantID
+		a.UserID = userID
+		assert.NoError(t, repo.Create(context.Background(), a))
+	}
+
+	// Another tenant must never leak into the timeline
+	assert.NoError(t, repo.Create(context.Background(), &activities.Activity{
+		TenantID:    uuid.New(),
+		UserID:      userID,
+		Type:        activities.TypeNote,
+		SubjectType: activities.SubjectCustomer,
+		SubjectID:   customerID,
+		OccurredAt:  now,
+	}))
+
+	// Execute
+	items, total, err := repo.List(context.Background(), activities.TimelineFilter{
+		TenantID:    tenantID,
+		SubjectType: activities.SubjectCustomer,
+		SubjectID:   customerID,
+		Limit:       2,
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, items, 2)
+	assert.Equal(t, activities.TypeCall, items[0].Type)
+	assert.Equal(t, activities.TypeMeeting, items[1].Type)
+}
+
+func TestActivityRepository_ListByUserFilteredByType(t *testing.T) {
+	// Setup
+	repo := setupRepository(t)
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	for _, activityType := range []activities.ActivityType{activities.TypeNote, activities.TypeCall, activities.TypeTask} {
+		err := repo.Create(context.Background(), &activities.Activity{
+			TenantID:    tenantID,
+			UserID:      userID,
+			Type:        activityType,
+			SubjectType: activities.SubjectLead,
+			SubjectID:   uuid.New(),
+			OccurredAt:  time.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	// Execute
+	items, total, err := repo.List(context.Background(), activities.TimelineFilter{
+		TenantID: tenantID,
+		UserID:   userID,
+		Types:    []activities.ActivityType{activities.TypeNote, activities.TypeTask},
+		Limit:    10,
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	for _, item := range items {
+		assert.NotEqual(t, activities.TypeCall, item.Type)
+	}
+}
@@ -0,0 +1,31 @@
+package activities_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/activities"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivityService_GetActivity_InvalidID(t *testing.T) {
+	// Setup
+	called := false
+	repo := &activities.MockActivityRepository{
+		FindByIDFunc: func(_ context.Context, tenantID uuid.UUID, id string) (*activities.Activity, error) {
+			called = true
+			return nil, nil
+		},
+	}
+	service := activities.NewActivityService(repo, telemetry.NewTelemetryService(false))
+
+	// Execute
+	activity, err := service.GetActivity(context.Background(), uuid.New(), "not-a-uuid")
+
+	// Assertions: o ID inválido não chega ao banco
+	assert.Nil(t, activity)
+	assert.ErrorIs(t, err, activities.ErrActivityNotFound)
+	assert.False(t, called)
+}
@@ -1,25 +1,36 @@
 package auth_test
 
 import (
+	"context"
 	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
 	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+func newSessions(t *testing.T) *web.Sessions {
+	sessions, err := web.NewSessions(web.SessionConfig{Secret: "test-secret", TTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sessions
+}
+
 func TestAuthHandler_Register(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
 	
 	mockAuthService := &auth.MockAuthService{}
-	handler := auth.NewAuthHandler(mockAuthService)
+	handler := auth.NewAuthHandler(mockAuthService, newSessions(t))
 
 	// Test data
 	userID := uuid.New()
@@ -43,7 +54,7 @@ func TestAuthHandler_Register(t *testing.T) {
 	}
 
 	// Mock behavior
-	mockAuthService.RegisterUserFunc = func(req auth.RegisterRequest) (*auth.User, *tenants.Tenant, error) {
+	mockAuthService.RegisterUserFunc = func(_ context.Context, req auth.RegisterRequest) (*auth.User, *tenants.Tenant, error) {
 		return expectedUser, expectedTenant, nil
 	}
 
@@ -74,7 +85,7 @@ func TestAuthHandler_Login(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	
 	mockAuthService := &auth.MockAuthService{}
-	handler := auth.NewAuthHandler(mockAuthService)
+	handler := auth.NewAuthHandler(mockAuthService, newSessions(t))
 
 	// Test data
 	userID := uuid.New()
@@ -84,13 +95,15 @@ func TestAuthHandler_Login(t *testing.T) {
 		Password: "password123",
 	}
 
+	tenantID := uuid.New()
 	expectedUser := &auth.User{
-		ID:    userID,
-		Email: "test@example.com",
+		ID:       userID,
+		TenantID: tenantID,
+		Email:    "test@example.com",
 	}
 
 	// Mock behavior
-	mockAuthService.LoginUserFunc = func(req auth.LoginRequest) (*auth.User, error) {
+	mockAuthService.LoginUserFunc = func(_ context.Context, req auth.LoginRequest) (*auth.User, error) {
 		return expectedUser, nil
 	}
 
@@ -113,5 +126,9 @@ func TestAuthHandler_Login(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, userID.String(), response.UserID)
-	assert.Equal(t, "jwt-token-here", response.Token)
+	gotTenant, gotUser, err := newSessions(t).Verify(response.Token, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, tenantID, gotTenant)
+	assert.Equal(t, userID, gotUser)
+	assert.Equal(t, tenantID.String(), response.TenantID)
 } 
\ No newline at end of file
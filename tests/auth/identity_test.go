@@ -0,0 +1,92 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdentityRouter(t *testing.T, sessions *web.Sessions) (*gin.Engine, *repositoryFixture) {
+	gin.SetMode(gin.TestMode)
+	fixture := newRepositoryFixture(t)
+
+	router := gin.New()
+	router.GET("/me", web.IdentityMiddleware(sessions, auth.NewUserDirectory(fixture.users)), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"tenant_id": web.TenantID(c), "user_id": web.UserID(c)})
+	})
+	return router, fixture
+}
+
+func requestMe(router *gin.Engine, header func(*http.Request)) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	header(req)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewSessions_RequiresSecret(t *testing.T) {
+	_, err := web.NewSessions(web.SessionConfig{TTL: time.Hour})
+	assert.ErrorIs(t, err, web.ErrMissingSessionSecret)
+}
+
+func TestIdentityMiddleware_AcceptsIssuedSession(t *testing.T) {
+	// Setup
+	sessions := newSessions(t)
+	router, fixture := newIdentityRouter(t, sessions)
+	user := fixture.createUser(t, "ana@example.com")
+	token, _ := sessions.Issue(user.TenantID, user.ID, time.Now())
+
+	// Execute
+	w := requestMe(router, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	})
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), user.ID.String())
+	assert.Contains(t, w.Body.String(), user.TenantID.String())
+}
+
+func TestIdentityMiddleware_RejectsUnverifiedIdentity(t *testing.T) {
+	// Setup
+	sessions := newSessions(t)
+	router, fixture := newIdentityRouter(t, sessions)
+	user := fixture.createUser(t, "ana@example.com")
+
+	forger, err := web.NewSessions(web.SessionConfig{Secret: "other-secret", TTL: time.Hour})
+	require.NoError(t, err)
+	forged, _ := forger.Issue(user.TenantID, user.ID, time.Now())
+	expired, _ := sessions.Issue(user.TenantID, user.ID, time.Now().Add(-2*time.Hour))
+	otherTenant, _ := sessions.Issue(uuid.New(), user.ID, time.Now())
+	unknownUser, _ := sessions.Issue(user.TenantID, uuid.New(), time.Now())
+
+	cases := map[string]func(*http.Request){
+		"sem sessão": func(*http.Request) {},
+		// os headers de identidade de antes não valem mais nada sozinhos
+		"headers": func(req *http.Request) {
+			req.Header.Set("X-Tenant-ID", user.TenantID.String())
+			req.Header.Set("X-User-ID", user.ID.String())
+		},
+		"forjada":             func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+forged) },
+		"expirada":            func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+expired) },
+		"outro tenant":        func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+otherTenant) },
+		"usuário inexistente": func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+unknownUser) },
+	}
+
+	for name, header := range cases {
+		// Execute
+		w := requestMe(router, header)
+
+		// Assertions
+		assert.Equal(t, http.StatusUnauthorized, w.Code, name)
+	}
+}
@@ -0,0 +1,124 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/tests/testdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type repositoryFixture struct {
+	db      *gorm.DB
+	users   auth.UserRepository
+	tenants tenants.TenantRepository
+	tenant  *tenants.Tenant
+}
+
+func newRepositoryFixture(t *testing.T) *repositoryFixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, testdb.Migrate(db, &tenants.Tenant{}, &auth.User{}))
+
+	// os dois repositórios dividem o cache, como no container
+	store := cache.NewMemoryCache(cache.LoadConfig(), telemetry.NewTelemetryService(false))
+	telemetryService := telemetry.NewTelemetryService(false)
+	fixture := &repositoryFixture{
+		db:      db,
+		users:   auth.NewUserRepository(db, store, telemetryService),
+		tenants: tenants.NewTenantRepository(db, store, telemetryService),
+		tenant:  &tenants.Tenant{Name: "Test Company"},
+	}
+	require.NoError(t, fixture.tenants.Create(context.Background(), fixture.tenant))
+	return fixture
+}
+
+func (f *repositoryFixture) createUser(t *testing.T, email string) *auth.User {
+	user := &auth.User{TenantID: f.tenant.ID, Email: email, PasswordHash: "hash"}
+	require.NoError(t, f.users.Create(context.Background(), user))
+	return user
+}
+
+func TestUserRepository_CreateInvalidatesNegativeEntry(t *testing.T) {
+	// Setup
+	f := newRepositoryFixture(t)
+	missing, err := f.users.FindByEmail(context.Background(), "ana@example.com")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	// Execute
+	user := f.createUser(t, "ana@example.com")
+	found, err := f.users.FindByEmail(context.Background(), "ana@example.com")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, user.ID, found.ID)
+}
+
+func TestUserRepository_UpdateInvalidatesAllKeys(t *testing.T) {
+	// Setup
+	f := newRepositoryFixture(t)
+	user := f.createUser(t, "old@example.com")
+	f.users.FindByEmail(context.Background(), "old@example.com")
+	f.users.FindByID(context.Background(), user.ID.String())
+	f.users.FindByEmail(context.Background(), "new@example.com") // entrada negativa
+
+	// Execute
+	user.Email = "new@example.com"
+	user.Name = "Ana"
+	err := f.users.Update(context.Background(), user)
+
+	// Assertions
+	assert.NoError(t, err)
+	old, _ := f.users.FindByEmail(context.Background(), "old@example.com")
+	assert.Nil(t, old)
+	renamed, _ := f.users.FindByEmail(context.Background(), "new@example.com")
+	assert.NotNil(t, renamed)
+	byID, _ := f.users.FindByID(context.Background(), user.ID.String())
+	assert.Equal(t, "Ana", byID.Name)
+}
+
+func TestUserRepository_Delete(t *testing.T) {
+	// Setup
+	f := newRepositoryFixture(t)
+	user := f.createUser(t, "ana@example.com")
+	f.users.FindByEmail(context.Background(), "ana@example.com")
+	f.users.FindByID(context.Background(), user.ID.String())
+
+	// Execute
+	err := f.users.Delete(context.Background(), user.ID.String())
+
+	// Assertions
+	assert.NoError(t, err)
+	byEmail, _ := f.users.FindByEmail(context.Background(), "ana@example.com")
+	assert.Nil(t, byEmail)
+	byID, _ := f.users.FindByID(context.Background(), user.ID.String())
+	assert.Nil(t, byID)
+}
+
+func TestTenantRepository_DeleteDropsTenantEntries(t *testing.T) {
+	// Setup
+	f := newRepositoryFixture(t)
+	user := f.createUser(t, "ana@example.com")
+	f.users.FindByID(context.Background(), user.ID.String())
+	// apagado direto no banco: só a invalidação do tenant tira o usuário do cache
+	f.db.Delete(&auth.User{}, "id = ?", user.ID)
+
+	// Execute
+	err := f.tenants.Delete(context.Background(), f.tenant.ID.String())
+
+	// Assertions
+	assert.NoError(t, err)
+	byID, _ := f.users.FindByID(context.Background(), user.ID.String())
+	assert.Nil(t, byID)
+	tenant, _ := f.tenants.FindByID(context.Background(), f.tenant.ID.String())
+	assert.Nil(t, tenant)
+}
@@ -0,0 +1,266 @@
+package businesshours_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func commercialCalendar() *businesshours.Calendar {
+	weekday := []businesshours.Interval{{Start: "08:00", End: "12:00"}, {Start: "13:00", End: "18:00"}}
+	return &businesshours.Calendar{
+		Timezone: "America/Sao_Paulo",
+		Weekly: businesshours.WeeklySchedule{
+			"monday":    weekday,
+			"tuesday":   weekday,
+			"wednesday": weekday,
+			"thursday":  weekday,
+			"friday":    weekday,
+			"saturday":  {{Start: "09:00", End: "13:00"}},
+		},
+		ObserveNationalHolidays: true,
+	}
+}
+
+func saoPaulo(t *testing.T, value string) time.Time {
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	assert.NoError(t, err)
+	at, err := time.ParseInLocation("2006-01-02 15:04", value, location)
+	assert.NoError(t, err)
+	return at
+}
+
+func TestNationalHolidays_MovableDates(t *testing.T) {
+	// Execute
+	holidays := businesshours.NationalHolidays(2025)
+
+	// Assertions: Páscoa de 2025 foi em 20/04
+	byDate := map[string]businesshours.Holiday{}
+	for _, holiday := range holidays {
+		byDate[holiday.Date] = holiday
+	}
+	assert.Equal(t, "Sexta-feira Santa", byDate["2025-04-18"].Name)
+	assert.True(t, byDate["2025-03-03"].Optional)
+	assert.True(t, byDate["2025-03-04"].Optional)
+	assert.Equal(t, "Corpus Christi", byDate["2025-06-19"].Name)
+	assert.Contains(t, byDate, "2025-11-20")
+	assert.Len(t, holidays, 13)
+
+	assert.NotContains(t, holidaysByDate(businesshours.NationalHolidays(2023)), "2023-11-20")
+	assert.Contains(t, holidaysByDate(businesshours.NationalHolidays(2024)), "2024-03-29")
+}
+
+func holidaysByDate(holidays []businesshours.Holiday) map[string]bool {
+	dates := map[string]bool{}
+	for _, holiday := range holidays {
+		dates[holiday.Date] = true
+	}
+	return dates
+}
+
+func TestCalendar_IsOpen(t *testing.T) {
+	// Setup
+	calendar := commercialCalendar()
+	calendar.SpecialDates = []businesshours.SpecialDate{
+		{Date: "2025-12-24", Name: "Véspera de Natal", Intervals: []businesshours.Interval{{Start: "08:00", End: "12:00"}}},
+		{Date: "2025-06-02", Name: "Inventário", Closed: true},
+	}
+
+	cases := []struct {
+		at   string
+		open bool
+	}{
+		{"2025-06-03 10:00", true},  // terça
+		{"2025-06-03 12:30", false}, // almoço
+		{"2025-06-03 18:00", false}, // fim do expediente é exclusivo
+		{"2025-06-07 10:00", true},  // sábado
+		{"2025-06-08 10:00", false}, // domingo
+		{"2025-04-18 10:00", false}, // Sexta-feira Santa
+		{"2025-03-04 10:00", true},  // carnaval é facultativo
+		{"2025-06-02 10:00", false}, // data especial fechada
+		{"2025-12-24 11:00", true},  // horário reduzido
+		{"2025-12-24 14:00", false},
+	}
+
+	// Execute / Assertions
+	for _, c := range cases {
+		assert.Equal(t, c.open, calendar.IsOpen(saoPaulo(t, c.at)), c.at)
+	}
+
+	calendar.ObserveOptionalHolidays = true
+	assert.False(t, calendar.IsOpen(saoPaulo(t, "2025-03-04 10:00")))
+
+	// Instante em UTC é avaliado no fuso do calendário (13:00 UTC = 10:00 em São Paulo)
+	assert.True(t, calendar.IsOpen(time.Date(2025, 6, 3, 13, 0, 0, 0, time.UTC)))
+
+	var missing *businesshours.Calendar
+	assert.True(t, missing.IsOpen(time.Now()))
+}
+
+func TestCalendar_NextOpeningAndClosing(t *testing.T) {
+	// Setup
+	calendar := commercialCalendar()
+
+	// Execute: sábado à tarde, domingo fechado; segunda é dia útil
+	opening, ok := calendar.NextOpening(saoPaulo(t, "2025-06-07 15:00"))
+
+	// Assertions
+	assert.True(t, ok)
+	assert.True(t, opening.Equal(saoPaulo(t, "2025-06-09 08:00")))
+	assert.Equal(t, "segunda-feira, 09/06 às 08:00", calendar.FormatOpening(opening))
+
+	// Véspera da Sexta-feira Santa: próxima abertura é sábado
+	opening, ok = calendar.NextOpening(saoPaulo(t, "2025-04-17 19:00"))
+	assert.True(t, ok)
+	assert.True(t, opening.Equal(saoPaulo(t, "2025-04-19 09:00")))
+
+	closing, ok := calendar.NextClosing(saoPaulo(t, "2025-06-03 09:00"))
+	assert.True(t, ok)
+	assert.True(t, closing.Equal(saoPaulo(t, "2025-06-03 12:00")))
+
+	status := calendar.Evaluate(saoPaulo(t, "2025-04-18 10:00"))
+	assert.False(t, status.Open)
+	assert.Equal(t, "Sexta-feira Santa", status.Reason)
+	assert.NotNil(t, status.NextOpening)
+
+	// Atendimento 24h: faixas contíguas entre dias formam um único expediente
+	allDay := []businesshours.Interval{{Start: "00:00", End: "24:00"}}
+	nonstop := &businesshours.Calendar{Timezone: "UTC", Weekly: businesshours.WeeklySchedule{"monday": allDay, "tuesday": allDay}}
+	closing, ok = nonstop.NextClosing(time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.True(t, closing.Equal(time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC)))
+
+	closed := &businesshours.Calendar{Timezone: "UTC"}
+	_, ok = closed.NextOpening(time.Now())
+	assert.False(t, ok)
+}
+
+func TestCalendar_Validate(t *testing.T) {
+	cases := map[string]func(c *businesshours.Calendar){
+		"timezone": func(c *businesshours.Calendar) { c.Timezone = "Mars/Olympus" },
+		"weekday":  func(c *businesshours.Calendar) { c.Weekly["segunda"] = nil },
+		"inverted": func(c *businesshours.Calendar) {
+			c.Weekly["sunday"] = []businesshours.Interval{{Start: "18:00", End: "08:00"}}
+		},
+		"overlap": func(c *businesshours.Calendar) {
+			c.Weekly["sunday"] = []businesshours.Interval{{Start: "08:00", End: "12:00"}, {Start: "11:00", End: "14:00"}}
+		},
+		"clock": func(c *businesshours.Calendar) {
+			c.Weekly["sunday"] = []businesshours.Interval{{Start: "8h", End: "12:00"}}
+		},
+		"date": func(c *businesshours.Calendar) {
+			c.SpecialDates = []businesshours.SpecialDate{{Date: "24/12/2025", Closed: true}}
+		},
+		"empty_special":  func(c *businesshours.Calendar) { c.SpecialDates = []businesshours.SpecialDate{{Date: "2025-12-24"}} },
+		"away_no_text":   func(c *businesshours.Calendar) { c.AwayMessageEnabled = true },
+		"negative_delay": func(c *businesshours.Calendar) { c.AwayCooldownMinutes = -1 },
+	}
+
+	assert.NoError(t, commercialCalendar().Validate())
+	for name, mutate := range cases {
+		calendar := commercialCalendar()
+		mutate(calendar)
+		assert.Error(t, calendar.Validate(), name)
+	}
+}
+
+func TestBusinessHoursService_SaveAndStatus(t *testing.T) {
+	// Setup
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&businesshours.Calendar{}))
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	service := businesshours.NewBusinessHoursService(businesshours.NewCalendarRepository(db, telemetryService), telemetryService)
+	tenantID := uuid.New()
+
+	// Sem calendário: sempre aberto
+	status, err := service.Status(context.Background(), tenantID, saoPaulo(t, "2025-06-08 03:00"))
+	assert.NoError(t, err)
+	assert.True(t, status.Open)
+	_, err = service.GetCalendar(context.Background(), tenantID)
+	assert.ErrorIs(t, err, businesshours.ErrCalendarNotFound)
+
+	// Execute
+	calendar := commercialCalendar()
+	_, err = service.SaveCalendar(context.Background(), tenantID, businesshours.SaveCalendarRequest{
+		Weekly:             calendar.Weekly,
+		SpecialDates:       []businesshours.SpecialDate{{Date: "2025-06-20", Name: "Emenda", Closed: true}},
+		AwayMessageEnabled: true,
+		AwayMessage:        "Voltamos {{next_opening}}",
+	})
+	assert.NoError(t, err)
+
+	// Desmarcar feriados nacionais grava o false
+	disabled := false
+	other, err := service.SaveCalendar(context.Background(), uuid.New(), businesshours.SaveCalendarRequest{Weekly: calendar.Weekly, ObserveNationalHolidays: &disabled})
+	assert.NoError(t, err)
+	assert.False(t, other.ObserveNationalHolidays)
+	reloaded, err := service.GetCalendar(context.Background(), other.TenantID)
+	assert.NoError(t, err)
+	assert.False(t, reloaded.ObserveNationalHolidays)
+
+	saved, err := service.SaveCalendar(context.Background(), tenantID, businesshours.SaveCalendarRequest{
+		Weekly:                  calendar.Weekly,
+		SpecialDates:            []businesshours.SpecialDate{{Date: "2025-06-20", Name: "Emenda", Closed: true}},
+		ObserveNationalHolidays: &disabled,
+	})
+	assert.NoError(t, err)
+
+	// Assertions
+	stored, err := service.GetCalendar(context.Background(), tenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, saved.ID, stored.ID)
+	assert.Equal(t, businesshours.DefaultTimezone, stored.Timezone)
+	assert.False(t, stored.ObserveNationalHolidays)
+	assert.False(t, stored.AwayMessageEnabled)
+	assert.Equal(t, businesshours.DefaultAwayCooldownMinutes, stored.AwayCooldownMinutes)
+
+	status, err = service.Status(context.Background(), tenantID, saoPaulo(t, "2025-04-18 10:00"))
+	assert.NoError(t, err)
+	assert.True(t, status.Open)
+	assert.NotNil(t, status.NextClosing)
+
+	holidays, err := service.Holidays(context.Background(), tenantID, 2025)
+	assert.NoError(t, err)
+	assert.Equal(t, []businesshours.Holiday{{Date: "2025-06-20", Name: "Emenda", Custom: true}}, holidays)
+
+	_, err = service.SaveCalendar(context.Background(), tenantID, businesshours.SaveCalendarRequest{Timezone: "Nowhere", Weekly: calendar.Weekly})
+	assert.ErrorIs(t, err, businesshours.ErrInvalidTimezone)
+
+	assert.NoError(t, service.DeleteCalendar(context.Background(), tenantID))
+	assert.ErrorIs(t, service.DeleteCalendar(context.Background(), tenantID), businesshours.ErrCalendarNotFound)
+}
+
+func TestCalendar_BusinessTime(t *testing.T) {
+	// Setup
+	calendar := commercialCalendar()
+
+	// Execute / Assertions: sexta 17:30 + 1h = 30min na sexta e 30min no sábado
+	due := calendar.AddBusinessTime(saoPaulo(t, "2025-06-06 17:30"), time.Hour)
+	assert.True(t, due.Equal(saoPaulo(t, "2025-06-07 09:30")), due.String())
+
+	// Almoço não conta
+	due = calendar.AddBusinessTime(saoPaulo(t, "2025-06-03 11:30"), time.Hour)
+	assert.True(t, due.Equal(saoPaulo(t, "2025-06-03 13:30")), due.String())
+
+	// Fora do expediente, conta a partir da abertura
+	due = calendar.AddBusinessTime(saoPaulo(t, "2025-06-03 06:00"), 15*time.Minute)
+	assert.True(t, due.Equal(saoPaulo(t, "2025-06-03 08:15")), due.String())
+
+	assert.Equal(t, time.Hour, calendar.BusinessDuration(saoPaulo(t, "2025-06-06 17:30"), saoPaulo(t, "2025-06-07 09:30")))
+	assert.Equal(t, 9*time.Hour, calendar.BusinessDuration(saoPaulo(t, "2025-06-03 00:00"), saoPaulo(t, "2025-06-04 00:00")))
+	assert.Equal(t, time.Duration(0), calendar.BusinessDuration(saoPaulo(t, "2025-06-08 10:00"), saoPaulo(t, "2025-06-08 16:00")))
+
+	var missing *businesshours.Calendar
+	from := time.Now()
+	assert.True(t, missing.AddBusinessTime(from, time.Hour).Equal(from.Add(time.Hour)))
+	assert.Equal(t, time.Hour, missing.BusinessDuration(from, from.Add(time.Hour)))
+}
@@ -0,0 +1,223 @@
+package cache_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis é um servidor RESP em memória com os comandos que o cache usa
+type fakeRedis struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	values      map[string]string
+	sets        map[string]map[string]bool
+	expires     map[string]time.Time
+	subscribers map[string][]*fakeConn
+}
+
+// fakeConn serializa as escritas: um PUBLISH de outra conexão escreve nos assinantes
+type fakeConn struct {
+	net.Conn
+	mu sync.Mutex
+}
+
+func (c *fakeConn) write(reply string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := io.WriteString(c.Conn, reply)
+	return err
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := &fakeRedis{
+		listener:    listener,
+		values:      map[string]string{},
+		sets:        map[string]map[string]bool{},
+		expires:     map[string]time.Time{},
+		subscribers: map[string][]*fakeConn{},
+	}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeRedis) URL() string {
+	return "redis://" + s.listener.Addr().String() + "/0"
+}
+
+func (s *fakeRedis) TTL(key string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if at, ok := s.expires[key]; ok {
+		return time.Until(at)
+	}
+	return 0
+}
+
+func (s *fakeRedis) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeRedis) Subscribers(channel string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers[channel])
+}
+
+// DropSubscribers derruba as conexões em SUBSCRIBE, como numa queda do servidor
+func (s *fakeRedis) DropSubscribers() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for channel, conns := range s.subscribers {
+		for _, conn := range conns {
+			conn.Close()
+		}
+		delete(s.subscribers, channel)
+	}
+}
+
+func (s *fakeRedis) handle(netConn net.Conn) {
+	conn := &fakeConn{Conn: netConn}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if err := conn.write(s.exec(conn, args)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil || line[0] != '*' {
+		return nil, fmt.Errorf("bad command %q", line)
+	}
+	args := make([]string, count)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+func (s *fakeRedis) exec(conn *fakeConn, args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.ToUpper(args[0])
+
+	switch name {
+	case "PING":
+		return "+PONG\r\n"
+	case "AUTH", "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		s.expire(args[1])
+		value, ok := s.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return bulk(value)
+	case "SET":
+		s.values[args[1]] = args[2]
+		delete(s.expires, args[1])
+		if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+			ms, _ := strconv.Atoi(args[4])
+			s.expires[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "DEL", "EXISTS":
+		count := 0
+		for _, key := range args[1:] {
+			s.expire(key)
+			_, isValue := s.values[key]
+			_, isSet := s.sets[key]
+			if isValue || isSet {
+				count++
+				if name == "DEL" {
+					delete(s.values, key)
+					delete(s.sets, key)
+					delete(s.expires, key)
+				}
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", count)
+	case "SADD":
+		set, ok := s.sets[args[1]]
+		if !ok {
+			set = map[string]bool{}
+			s.sets[args[1]] = set
+		}
+		added := 0
+		for _, member := range args[2:] {
+			if !set[member] {
+				set[member] = true
+				added++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", added)
+	case "SUBSCRIBE":
+		s.subscribers[args[1]] = append(s.subscribers[args[1]], conn)
+		return "*3\r\n" + bulk("subscribe") + bulk(args[1]) + ":1\r\n"
+	case "PUBLISH":
+		message := "*3\r\n" + bulk("message") + bulk(args[1]) + bulk(args[2])
+		for _, subscriber := range s.subscribers[args[1]] {
+			subscriber.write(message)
+		}
+		return fmt.Sprintf(":%d\r\n", len(s.subscribers[args[1]]))
+	case "SMEMBERS":
+		var b strings.Builder
+		fmt.Fprintf(&b, "*%d\r\n", len(s.sets[args[1]]))
+		for member := range s.sets[args[1]] {
+			b.WriteString(bulk(member))
+		}
+		return b.String()
+	}
+	return "-ERR unknown command '" + args[0] + "'\r\n"
+}
+
+// expire exige s.mu travado
+func (s *fakeRedis) expire(key string) {
+	if at, ok := s.expires[key]; ok && !time.Now().Before(at) {
+		delete(s.values, key)
+		delete(s.expires, key)
+	}
+}
+
+func bulk(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
@@ -0,0 +1,151 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLoader(store cache.CacheService, options cache.LoaderOptions) *cache.Loader[*auth.User] {
+	return cache.NewLoader[*auth.User](store, options, telemetry.NewTelemetryService(false))
+}
+
+func TestLoader_CachesValue(t *testing.T) {
+	// Setup
+	loader := newLoader(newMemoryCache(10), cache.LoaderOptions{TTL: time.Minute})
+	ctx := context.Background()
+	var calls int32
+	load := func() (*auth.User, error) {
+		atomic.AddInt32(&calls, 1)
+		return newUser(), nil
+	}
+
+	// Execute
+	first, firstHit, err := loader.Load(ctx, "user:1", load)
+	second, secondHit, _ := loader.Load(ctx, "user:1", load)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, firstHit)
+	assert.True(t, secondHit)
+	assert.Same(t, first, second)
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestLoader_NegativeEntry(t *testing.T) {
+	for _, backend := range []string{cache.BackendMemory, cache.BackendRedis} {
+		t.Run(backend, func(t *testing.T) {
+			// Setup
+			var store cache.CacheService = newMemoryCache(10)
+			if backend == cache.BackendRedis {
+				store = newRedisStore(t, newFakeRedis(t), "")
+			}
+			loader := newLoader(store, cache.LoaderOptions{TTL: time.Minute, NegativeTTL: time.Minute})
+			ctx := context.Background()
+			var calls int32
+			load := func() (*auth.User, error) {
+				atomic.AddInt32(&calls, 1)
+				return nil, nil
+			}
+
+			// Execute
+			loader.Load(ctx, "user:none", load)
+			user, hit, err := loader.Load(ctx, "user:none", load)
+
+			// Assertions
+			assert.NoError(t, err)
+			assert.True(t, hit)
+			assert.Nil(t, user)
+			assert.Equal(t, int32(1), calls)
+
+			_, err = cache.NewTyped[*auth.User](store).Get(ctx, "user:none")
+			assert.ErrorIs(t, err, cache.ErrNotFound)
+		})
+	}
+}
+
+func TestLoader_WithoutNegativeTTL(t *testing.T) {
+	// Setup
+	loader := newLoader(newMemoryCache(10), cache.LoaderOptions{TTL: time.Minute})
+	ctx := context.Background()
+	var calls int32
+	load := func() (*auth.User, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	}
+
+	// Execute
+	loader.Load(ctx, "user:none", load)
+	_, hit, _ := loader.Load(ctx, "user:none", load)
+
+	// Assertions
+	assert.False(t, hit)
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestLoader_ErrorIsNotCached(t *testing.T) {
+	// Setup
+	loader := newLoader(newMemoryCache(10), cache.LoaderOptions{TTL: time.Minute, NegativeTTL: time.Minute})
+	ctx := context.Background()
+	failure := errors.New("db down")
+
+	// Execute
+	_, _, err := loader.Load(ctx, "user:1", func() (*auth.User, error) { return nil, failure })
+	user, hit, _ := loader.Load(ctx, "user:1", func() (*auth.User, error) { return newUser(), nil })
+
+	// Assertions
+	assert.ErrorIs(t, err, failure)
+	assert.False(t, hit)
+	assert.NotNil(t, user)
+}
+
+func TestLoader_CoalescesConcurrentMisses(t *testing.T) {
+	// Setup
+	loader := newLoader(newMemoryCache(10), cache.LoaderOptions{TTL: time.Minute})
+	ctx := context.Background()
+	var calls int32
+	release := make(chan struct{})
+	load := func() (*auth.User, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return newUser(), nil
+	}
+
+	// Execute
+	var wg sync.WaitGroup
+	results := make([]*auth.User, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, _ = loader.Load(ctx, "user:1", load)
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assertions
+	assert.Equal(t, int32(1), calls)
+	for _, user := range results {
+		assert.Same(t, results[0], user)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	ttl := 10 * time.Minute
+	for i := 0; i < 100; i++ {
+		jittered := cache.Jitter(ttl, 0.1)
+		assert.GreaterOrEqual(t, jittered, 9*time.Minute)
+		assert.LessOrEqual(t, jittered, 11*time.Minute)
+	}
+	assert.Equal(t, ttl, cache.Jitter(ttl, 0))
+}
@@ -0,0 +1,144 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMemoryCache(maxEntries int) *cache.MemoryCache {
+	return cache.NewMemoryCache(cache.Config{MaxEntries: maxEntries, JanitorInterval: 10 * time.Millisecond}, telemetry.NewTelemetryService(false))
+}
+
+func TestMemoryCache_GetSetDelete(t *testing.T) {
+	// Setup
+	c := newMemoryCache(10)
+	ctx := context.Background()
+
+	// Execute
+	assert.NoError(t, c.Set(ctx, "user:1", "Ana", time.Minute))
+	assert.NoError(t, c.Set(ctx, "user:missing", nil, time.Minute))
+	value, err := c.Get(ctx, "user:1")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana", value)
+
+	// nil gravado é acerto, diferente de chave ausente
+	value, err = c.Get(ctx, "user:missing")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	_, err = c.Get(ctx, "user:2")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+
+	exists, _ := c.Exists(ctx, "user:1")
+	assert.True(t, exists)
+	assert.NoError(t, c.Delete(ctx, "user:1"))
+	exists, _ = c.Exists(ctx, "user:1")
+	assert.False(t, exists)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Size)
+}
+
+func TestMemoryCache_TTL(t *testing.T) {
+	// Setup
+	c := newMemoryCache(10)
+	ctx := context.Background()
+	c.Set(ctx, "short", 1, 20*time.Millisecond)
+	c.Set(ctx, "forever", 2, 0)
+
+	// Execute
+	time.Sleep(30 * time.Millisecond)
+
+	// Assertions
+	_, err := c.Get(ctx, "short")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+	value, err := c.Get(ctx, "forever")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, int64(1), c.Stats().Evictions)
+}
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	// Setup
+	c := newMemoryCache(2)
+	ctx := context.Background()
+	c.Set(ctx, "a", 1, time.Minute)
+	c.Set(ctx, "b", 2, time.Minute)
+
+	// Execute: ler "a" a torna a mais recente; "b" sai ao entrar "c"
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", 3, time.Minute)
+
+	// Assertions
+	_, err := c.Get(ctx, "b")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+	_, err = c.Get(ctx, "a")
+	assert.NoError(t, err)
+	_, err = c.Get(ctx, "c")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.Stats().Size)
+	assert.Equal(t, int64(1), c.Stats().Evictions)
+}
+
+func TestMemoryCache_Janitor(t *testing.T) {
+	// Setup
+	c := newMemoryCache(10)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		c.Set(ctx, fmt.Sprintf("k%d", i), i, 5*time.Millisecond)
+	}
+	c.Set(ctx, "kept", true, time.Minute)
+
+	// Execute
+	c.Start(ctx)
+	defer c.Stop()
+	deadline := time.Now().Add(time.Second)
+	for c.Stats().Size > 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Assertions: a varredura remove sem precisar de leitura
+	stats := c.Stats()
+	assert.Equal(t, 1, stats.Size)
+	assert.Equal(t, int64(5), stats.Evictions)
+	assert.Equal(t, int64(0), stats.Misses)
+}
+
+func TestMemoryCache_Concurrent(t *testing.T) {
+	// Setup
+	c := newMemoryCache(50)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+
+	// Execute
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := fmt.Sprintf("k%d", (w*i)%100)
+				c.Set(ctx, key, i, time.Minute)
+				c.Get(ctx, key)
+				if i%10 == 0 {
+					c.Delete(ctx, key)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// Assertions
+	stats := c.Stats()
+	assert.LessOrEqual(t, stats.Size, 50)
+	assert.Equal(t, int64(8*200), stats.Hits+stats.Misses)
+}
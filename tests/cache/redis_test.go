@@ -0,0 +1,184 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedisStore(t *testing.T, server *fakeRedis, codec string) cache.Store {
+	config := cache.LoadConfig()
+	config.Backend = cache.BackendRedis
+	config.RedisURL = server.URL()
+	config.Codec = codec
+
+	store, err := cache.NewStore(config, telemetry.NewTelemetryService(false))
+	require.NoError(t, err)
+	t.Cleanup(store.Stop)
+	return store
+}
+
+func newUser() *auth.User {
+	return &auth.User{
+		ID:           uuid.New(),
+		TenantID:     uuid.New(),
+		Name:         "Ana",
+		Email:        "ana@example.com",
+		PasswordHash: "$2a$10$hash",
+		CreatedAt:    time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestRedisCache_GetSetDelete(t *testing.T) {
+	// Setup
+	server := newFakeRedis(t)
+	store := newRedisStore(t, server, "")
+	ctx := context.Background()
+
+	// Execute
+	require.NoError(t, store.Set(ctx, "greeting", map[string]string{"text": "olá"}, time.Minute))
+	raw, err := store.Get(ctx, "greeting")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"text":"olá"}`, string(raw.([]byte)))
+	assert.InDelta(t, time.Minute.Seconds(), server.TTL("greeting").Seconds(), 1)
+
+	exists, err := store.Exists(ctx, "greeting")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.NoError(t, store.Delete(ctx, "greeting"))
+	_, err = store.Get(ctx, "greeting")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+	exists, _ = store.Exists(ctx, "greeting")
+	assert.False(t, exists)
+}
+
+func TestRedisCache_Expiry(t *testing.T) {
+	// Setup
+	server := newFakeRedis(t)
+	store := newRedisStore(t, server, "")
+	ctx := context.Background()
+	store.Set(ctx, "short", "x", 20*time.Millisecond)
+	store.Set(ctx, "forever", "y", 0)
+
+	// Execute
+	time.Sleep(30 * time.Millisecond)
+
+	// Assertions
+	_, err := store.Get(ctx, "short")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+	_, err = store.Get(ctx, "forever")
+	assert.NoError(t, err)
+	assert.Zero(t, server.TTL("forever"))
+}
+
+func TestTyped_RedisCodecs(t *testing.T) {
+	for _, codec := range []string{cache.CodecJSON, cache.CodecMsgpack} {
+		t.Run(codec, func(t *testing.T) {
+			// Setup
+			server := newFakeRedis(t)
+			users := cache.NewTyped[*auth.User](newRedisStore(t, server, codec))
+			ctx := context.Background()
+			user := newUser()
+
+			// Execute
+			require.NoError(t, users.Set(ctx, "user:1", user, time.Minute))
+			require.NoError(t, users.Set(ctx, "user:none", nil, time.Minute))
+			got, err := users.Get(ctx, "user:1")
+
+			// Assertions
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, got.ID)
+			assert.Equal(t, user.Email, got.Email)
+			assert.True(t, user.CreatedAt.Equal(got.CreatedAt))
+			// a tag cache preserva o hash que o json:"-" esconde da API
+			assert.Equal(t, user.PasswordHash, got.PasswordHash)
+
+			none, err := users.Get(ctx, "user:none")
+			assert.NoError(t, err)
+			assert.Nil(t, none)
+
+			_, err = users.Get(ctx, "user:2")
+			assert.ErrorIs(t, err, cache.ErrCacheMiss)
+		})
+	}
+}
+
+func TestTyped_Memory(t *testing.T) {
+	// Setup
+	users := cache.NewTyped[*auth.User](newMemoryCache(10))
+	ctx := context.Background()
+	user := newUser()
+
+	// Execute
+	users.Set(ctx, "user:1", user, time.Minute)
+	got, err := users.Get(ctx, "user:1")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Same(t, user, got)
+}
+
+func TestTyped_TypeMismatch(t *testing.T) {
+	// Setup
+	memory := newMemoryCache(10)
+	ctx := context.Background()
+	memory.Set(ctx, "user:1", "not a user", time.Minute)
+
+	// Execute
+	_, err := cache.NewTyped[*auth.User](memory).Get(ctx, "user:1")
+
+	// Assertions
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, cache.ErrCacheMiss)
+}
+
+func TestRedisClient_ServerErrorKeepsConnection(t *testing.T) {
+	// Setup
+	server := newFakeRedis(t)
+	options, err := cache.ParseRedisURL(server.URL())
+	require.NoError(t, err)
+	client := cache.NewRedisClient(options, 1, time.Second)
+	defer client.Close()
+	ctx := context.Background()
+
+	// Execute
+	_, err = client.Do(ctx, "NOPE")
+	reply, pingErr := client.Do(ctx, "PING")
+
+	// Assertions
+	var redisErr cache.RedisError
+	assert.ErrorAs(t, err, &redisErr)
+	assert.NoError(t, pingErr)
+	assert.Equal(t, "PONG", reply)
+}
+
+func TestParseRedisURL(t *testing.T) {
+	options, err := cache.ParseRedisURL("redis://:secret@cache.internal/2")
+	assert.NoError(t, err)
+	assert.Equal(t, cache.RedisOptions{Addr: "cache.internal:6379", Password: "secret", DB: 2}, options)
+
+	_, err = cache.ParseRedisURL("http://localhost:6379")
+	assert.Error(t, err)
+	_, err = cache.ParseRedisURL("redis://localhost:6379/x")
+	assert.Error(t, err)
+}
+
+func TestNewStore_UnknownCodec(t *testing.T) {
+	config := cache.LoadConfig()
+	config.Backend = cache.BackendRedis
+	config.Codec = "xml"
+
+	_, err := cache.NewStore(config, telemetry.NewTelemetryService(false))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyBuilder(t *testing.T) {
+	keys := cache.NewKeyBuilder("user")
+
+	assert.Equal(t, "t:42:user:id:7", keys.Tenant("42", "id", "7"))
+	assert.Equal(t, "g:user:email:ana@example.com", keys.Global("email", "ana@example.com"))
+	assert.Equal(t, "user:7", keys.RecordTag("7"))
+	assert.Equal(t, "t:42", cache.TenantTag("42"))
+}
+
+func TestStore_InvalidateTags(t *testing.T) {
+	for _, backend := range []string{cache.BackendMemory, cache.BackendRedis} {
+		t.Run(backend, func(t *testing.T) {
+			// Setup
+			var store cache.Store = newMemoryCache(10)
+			if backend == cache.BackendRedis {
+				store = newRedisStore(t, newFakeRedis(t), "")
+			}
+			ctx := context.Background()
+			store.Set(ctx, "t:1:user:id:a", "a", time.Minute)
+			store.Set(ctx, "g:user:email:a", "a", time.Minute)
+			store.Set(ctx, "t:2:user:id:b", "b", time.Minute)
+			store.Tag(ctx, "t:1:user:id:a", "user:a", "t:1")
+			store.Tag(ctx, "g:user:email:a", "user:a", "t:1")
+			store.Tag(ctx, "t:2:user:id:b", "user:b", "t:2")
+
+			// Execute
+			err := store.InvalidateTags(ctx, cache.TenantTag("1"))
+
+			// Assertions
+			assert.NoError(t, err)
+			for _, key := range []string{"t:1:user:id:a", "g:user:email:a"} {
+				exists, _ := store.Exists(ctx, key)
+				assert.False(t, exists, key)
+			}
+			exists, _ := store.Exists(ctx, "t:2:user:id:b")
+			assert.True(t, exists)
+		})
+	}
+}
+
+func TestMemoryCache_EvictionDropsTagMembership(t *testing.T) {
+	// Setup
+	c := newMemoryCache(1)
+	ctx := context.Background()
+	c.Set(ctx, "a", 1, time.Minute)
+	c.Tag(ctx, "a", "t:1")
+
+	// Execute: "a" sai pelo LRU e volta sem tag
+	c.Set(ctx, "b", 2, time.Minute)
+	c.Set(ctx, "a", 1, time.Minute)
+	c.InvalidateTags(ctx, "t:1")
+
+	// Assertions
+	exists, _ := c.Exists(ctx, "a")
+	assert.True(t, exists)
+}
@@ -0,0 +1,194 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/tests/testdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newInstances simula réplicas da API: cada uma com o seu L1, todas com o mesmo L2 e bus
+func newInstances(n int) []*cache.TieredCache {
+	shared := newMemoryCache(100)
+	bus := cache.NewLocalBus()
+	instances := make([]*cache.TieredCache, n)
+	for i := range instances {
+		instances[i] = cache.NewTieredCache(newMemoryCache(100), shared, bus, time.Minute, telemetry.NewTelemetryService(false))
+	}
+	return instances
+}
+
+func TestTieredCache_SetInvalidatesOtherInstances(t *testing.T) {
+	// Setup
+	instances := newInstances(3)
+	ctx := context.Background()
+	instances[0].Set(ctx, "greeting", "olá", time.Minute)
+	for _, instance := range instances {
+		value, err := instance.Get(ctx, "greeting")
+		require.NoError(t, err)
+		require.Equal(t, "olá", value)
+	}
+
+	// Execute
+	instances[1].Set(ctx, "greeting", "oi", time.Minute)
+
+	// Assertions
+	for _, instance := range instances {
+		value, _ := instance.Get(ctx, "greeting")
+		assert.Equal(t, "oi", value)
+	}
+}
+
+func TestTieredCache_DeleteInvalidatesOtherInstances(t *testing.T) {
+	// Setup
+	instances := newInstances(2)
+	ctx := context.Background()
+	instances[0].Set(ctx, "greeting", "olá", time.Minute)
+	instances[1].Get(ctx, "greeting")
+
+	// Execute
+	instances[0].Delete(ctx, "greeting")
+
+	// Assertions
+	_, err := instances[1].Get(ctx, "greeting")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+}
+
+func TestTieredCache_InvalidateTagsReachesUntaggedL1(t *testing.T) {
+	// Setup: a segunda instância preenche o L1 pelo L2, sem conhecer as tags
+	instances := newInstances(2)
+	ctx := context.Background()
+	instances[0].Set(ctx, "t:1:user:id:a", "a", time.Minute)
+	instances[0].Tag(ctx, "t:1:user:id:a", cache.TenantTag("1"))
+	instances[1].Get(ctx, "t:1:user:id:a")
+
+	// Execute
+	instances[0].InvalidateTags(ctx, cache.TenantTag("1"))
+
+	// Assertions
+	for _, instance := range instances {
+		_, err := instance.Get(ctx, "t:1:user:id:a")
+		assert.ErrorIs(t, err, cache.ErrCacheMiss)
+	}
+}
+
+func TestTieredCache_LocalTTL(t *testing.T) {
+	// Setup: sem bus que entregue, o L1 só segura a chave por localTTL
+	shared := newMemoryCache(10)
+	writer := cache.NewTieredCache(newMemoryCache(10), shared, cache.NewLocalBus(), time.Minute, telemetry.NewTelemetryService(false))
+	reader := cache.NewTieredCache(newMemoryCache(10), shared, cache.NewLocalBus(), 20*time.Millisecond, telemetry.NewTelemetryService(false))
+	ctx := context.Background()
+	writer.Set(ctx, "greeting", "olá", time.Minute)
+	reader.Get(ctx, "greeting")
+	writer.Set(ctx, "greeting", "oi", time.Minute)
+
+	// Execute
+	stale, _ := reader.Get(ctx, "greeting")
+	time.Sleep(30 * time.Millisecond)
+	fresh, _ := reader.Get(ctx, "greeting")
+
+	// Assertions
+	assert.Equal(t, "olá", stale)
+	assert.Equal(t, "oi", fresh)
+}
+
+func TestTieredCache_Repositories(t *testing.T) {
+	// Setup: duas réplicas com o mesmo banco
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, testdb.Migrate(db, &tenants.Tenant{}, &auth.User{}))
+	instances := newInstances(2)
+	telemetryService := telemetry.NewTelemetryService(false)
+	first := auth.NewUserRepository(db, instances[0], telemetryService)
+	second := auth.NewUserRepository(db, instances[1], telemetryService)
+
+	tenant := &tenants.Tenant{Name: "Test Company"}
+	require.NoError(t, db.Create(tenant).Error)
+	user := &auth.User{TenantID: tenant.ID, Email: "ana@example.com", PasswordHash: "hash"}
+	require.NoError(t, first.Create(context.Background(), user))
+	cached, _ := second.FindByID(context.Background(), user.ID.String())
+	require.Equal(t, "", cached.Name)
+
+	// Execute
+	user.Name = "Ana"
+	err = first.Update(context.Background(), user)
+
+	// Assertions
+	assert.NoError(t, err)
+	updated, _ := second.FindByID(context.Background(), user.ID.String())
+	assert.Equal(t, "Ana", updated.Name)
+}
+
+func TestTieredCache_RedisBus(t *testing.T) {
+	// Setup
+	server := newFakeRedis(t)
+	first := newTieredStore(t, server)
+	second := newTieredStore(t, server)
+	ctx := context.Background()
+	waitSubscribers(t, server, 2)
+
+	users := cache.NewTyped[*auth.User](first)
+	user := newUser()
+	users.Set(ctx, "user:1", user, time.Minute)
+	_, err := cache.NewTyped[*auth.User](second).Get(ctx, "user:1")
+	require.NoError(t, err)
+
+	// Execute
+	user.Name = "Ana Maria"
+	users.Set(ctx, "user:1", user, time.Minute)
+
+	// Assertions
+	assert.Eventually(t, func() bool {
+		got, err := cache.NewTyped[*auth.User](second).Get(ctx, "user:1")
+		return err == nil && got.Name == "Ana Maria"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTieredCache_RedisBusReconnects(t *testing.T) {
+	// Setup
+	server := newFakeRedis(t)
+	first := newTieredStore(t, server)
+	second := newTieredStore(t, server)
+	ctx := context.Background()
+	waitSubscribers(t, server, 2)
+
+	// Execute
+	server.DropSubscribers()
+	waitSubscribers(t, server, 2)
+	first.Set(ctx, "greeting", "olá", time.Minute)
+	second.Get(ctx, "greeting")
+	first.Set(ctx, "greeting", "oi", time.Minute)
+
+	// Assertions
+	assert.Eventually(t, func() bool {
+		value, err := second.Get(ctx, "greeting")
+		return err == nil && string(value.([]byte)) == `"oi"`
+	}, time.Second, 10*time.Millisecond)
+}
+
+func newTieredStore(t *testing.T, server *fakeRedis) cache.Store {
+	config := cache.LoadConfig()
+	config.Backend = cache.BackendTiered
+	config.RedisURL = server.URL()
+
+	store, err := cache.NewStore(config, telemetry.NewTelemetryService(false))
+	require.NoError(t, err)
+	store.Start(context.Background())
+	t.Cleanup(store.Stop)
+	return store
+}
+
+func waitSubscribers(t *testing.T, server *fakeRedis, n int) {
+	require.Eventually(t, func() bool {
+		return server.Subscribers(cache.LoadConfig().InvalidationChannel) == n
+	}, 3*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,438 @@
+package campaigns_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/campaigns"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type fixture struct {
+	webhooks     whatsapp.WebhookService
+	campaigns    campaigns.CampaignService
+	campaignRepo campaigns.CampaignRepository
+	customerRepo customers.CustomerRepository
+	provider     *whatsapp.FakeProvider
+	newWorker    func(config campaigns.Config) *campaigns.Dispatcher
+	tenantID     uuid.UUID
+	userID       uuid.UUID
+	templateID   string
+	segmentID    string
+}
+
+func setup(t *testing.T) fixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(
+		&whatsapp.Message{}, &whatsapp.Conversation{}, &whatsapp.Template{},
+		&customers.Customer{}, &customers.Segment{},
+		&campaigns.Campaign{}, &campaigns.CampaignRecipient{},
+	))
+
+	tenantID := uuid.New()
+	phoneNumberID := "PHONE_ID"
+	businessAccountID := "WABA_ID"
+	tenant := &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID, WhatsAppBusinessAccountID: &businessAccountID}
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc:                    func(_ context.Context, id string) (*tenants.Tenant, error) { return tenant, nil },
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) { return tenant, nil },
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	customerRepo := customers.NewCustomerRepository(db, telemetryService)
+	campaignRepo := campaigns.NewCampaignRepository(db, telemetryService)
+	provider := whatsapp.NewFakeProvider()
+
+	customerService := customers.NewCustomerService(customerRepo, telemetryService)
+	segmentService := customers.NewSegmentService(customers.NewSegmentRepository(db, telemetryService), customerRepo, telemetryService)
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	webhooks := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	messages := whatsapp.NewMessageService(messageRepo, channels, provider, telemetryService)
+	conversations := whatsapp.NewConversationService(
+		whatsapp.NewConversationRepository(db, telemetryService), messageRepo, messages, customerService, &auth.MockUserRepository{}, telemetryService,
+	)
+	templates := whatsapp.NewTemplateService(whatsapp.NewTemplateRepository(db, telemetryService), channels, provider, messages, conversations, telemetryService)
+	service := campaigns.NewCampaignService(campaignRepo, templates, segmentService, customerService, telemetryService)
+	webhooks.Subscribe(service.HandleEvent)
+
+	// Template aprovado pela Meta
+	template, err := templates.CreateTemplate(context.Background(), tenantID, whatsapp.CreateTemplateRequest{
+		Name:       "promo_week",
+		Language:   "pt_BR",
+		Category:   whatsapp.CategoryMarketing,
+		Components: []whatsapp.TemplateDefinitionComponent{{Type: whatsapp.ComponentBody, Text: "Olá {{1}}, a semana de ofertas começou!"}},
+	})
+	assert.NoError(t, err)
+	provider.SetTemplateStatus(businessAccountID, "promo_week", "pt_BR", "APPROVED", "")
+	_, err = templates.SyncTemplates(context.Background(), tenantID)
+	assert.NoError(t, err)
+
+	segment, err := segmentService.CreateSegment(context.Background(), tenantID, customers.CreateSegmentRequest{
+		Name:     "São Paulo",
+		Criteria: customers.SegmentCriteria{PhonePrefix: "5511"},
+	})
+	assert.NoError(t, err)
+
+	return fixture{
+		webhooks:     webhooks,
+		campaigns:    service,
+		campaignRepo: campaignRepo,
+		customerRepo: customerRepo,
+		provider:     provider,
+		newWorker: func(config campaigns.Config) *campaigns.Dispatcher {
+			return campaigns.NewDispatcher(campaignRepo, customerRepo, templates, channels, telemetryService, config, time.Second)
+		},
+		tenantID:   tenantID,
+		userID:     uuid.New(),
+		templateID: template.ID.String(),
+		segmentID:  segment.ID.String(),
+	}
+}
+
+func (f fixture) addCustomer(t *testing.T, name, phone string, optedOut bool) *customers.Customer {
+	customer := &customers.Customer{TenantID: f.tenantID, Name: name, Phone: phone}
+	if optedOut {
+		now := time.Now()
+		customer.WhatsAppOptOutAt = &now
+	}
+	assert.NoError(t, f.customerRepo.Create(context.Background(), customer))
+	return customer
+}
+
+func (f fixture) startCampaign(t *testing.T) *campaigns.Campaign {
+	campaign, err := f.campaigns.CreateCampaign(context.Background(), f.tenantID, f.userID, campaigns.CreateCampaignRequest{
+		Name:       "Semana de ofertas",
+		TemplateID: f.templateID,
+		SegmentID:  f.segmentID,
+		Variables:  whatsapp.TemplateVariables{Body: []string{"{{customer.first_name|cliente}}"}},
+	})
+	assert.NoError(t, err)
+
+	campaign, err = f.campaigns.StartCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.NoError(t, err)
+	return campaign
+}
+
+func (f fixture) status(t *testing.T, waMessageID, status string) {
+	err := f.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: "PHONE_ID"},
+					Statuses: []whatsapp.WebhookStatus{{ID: waMessageID, Status: status, Timestamp: fmt.Sprint(time.Now().Unix())}},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+}
+
+func (f fixture) receive(t *testing.T, from, waMessageID, body string) {
+	raw := fmt.Sprintf(`{"from":%q,"id":%q,"timestamp":"%d","type":"text","text":{"body":%q}}`, from, waMessageID, time.Now().Unix(), body)
+	err := f.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: "PHONE_ID"},
+					Messages: []json.RawMessage{json.RawMessage(raw)},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestRenderVariables(t *testing.T) {
+	customer := &customers.Customer{Name: "Ana Souza", Phone: "5511999990001"}
+
+	rendered := campaigns.RenderVariables(whatsapp.TemplateVariables{
+		Body: []string{"{{customer.first_name}}", "{{ customer.email | sem e-mail }}", "fixo"},
+	}, customer)
+
+	assert.Equal(t, []string{"Ana", "sem e-mail", "fixo"}, rendered.Body)
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := campaigns.NewRateLimiter(2, 2)
+	now := time.Now()
+
+	assert.True(t, limiter.Allow(now))
+	assert.True(t, limiter.Allow(now))
+	assert.False(t, limiter.Allow(now))
+	assert.True(t, limiter.Allow(now.Add(500*time.Millisecond)))
+	assert.False(t, limiter.Allow(now.Add(500*time.Millisecond)))
+}
+
+func TestCampaign_DispatchAndStats(t *testing.T) {
+	// Setup
+	f := setup(t)
+	ana := f.addCustomer(t, "Ana Souza", "5511999990001", false)
+	bruno := f.addCustomer(t, "Bruno Lima", "5511999990002", false)
+	f.addCustomer(t, "Carla Dias", "5511999990003", true)
+	f.addCustomer(t, "Diego Rio", "5521999990004", false) // fora do segmento
+
+	campaign := f.startCampaign(t)
+	assert.Equal(t, campaigns.CampaignRunning, campaign.Status)
+	assert.Equal(t, int64(3), campaign.Stats.Total)
+	assert.Equal(t, int64(1), campaign.Stats.Skipped)
+	assert.Equal(t, int64(2), campaign.Stats.Pending)
+
+	dispatcher := f.newWorker(campaigns.Config{MessagesPerSecond: 1, DailyLimit: 1000})
+	now := time.Now()
+
+	// Execute: 1 mensagem por segundo
+	sent, err := dispatcher.RunOnce(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	sent, _ = dispatcher.RunOnce(context.Background(), now)
+	assert.Equal(t, 0, sent)
+
+	sent, _ = dispatcher.RunOnce(context.Background(), now.Add(time.Second))
+	assert.Equal(t, 1, sent)
+
+	sent, _ = dispatcher.RunOnce(context.Background(), now.Add(2*time.Second))
+	assert.Equal(t, 0, sent)
+
+	// Assertions
+	byPhone := map[string]whatsapp.SentMessage{}
+	for _, message := range f.provider.Sent() {
+		byPhone[message.Message.To] = message
+	}
+	assert.Len(t, byPhone, 2)
+	assert.Equal(t, "Ana", byPhone[ana.Phone].Message.Template.Components[0].Parameters[0].Text)
+	assert.Equal(t, "Bruno", byPhone[bruno.Phone].Message.Template.Components[0].Parameters[0].Text)
+
+	f.status(t, byPhone[ana.Phone].WAMessageID, "delivered")
+	f.status(t, byPhone[bruno.Phone].WAMessageID, "read")
+	f.status(t, byPhone[bruno.Phone].WAMessageID, "delivered") // fora de ordem
+	f.receive(t, ana.Phone, "wamid.reply", "Quero saber mais")
+
+	stored, err := f.campaigns.GetCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, campaigns.CampaignCompleted, stored.Status)
+	assert.Equal(t, &campaigns.CampaignStats{
+		Total: 3, Sent: 2, Delivered: 2, Read: 2, Replied: 1, Skipped: 1,
+	}, stored.Stats)
+
+	replied, total, err := f.campaigns.ListRecipients(context.Background(), f.tenantID, campaign.ID.String(), campaigns.RecipientReplied, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, ana.ID, replied[0].CustomerID)
+}
+
+func TestCampaign_RespectsTierLimit(t *testing.T) {
+	// Setup
+	f := setup(t)
+	f.addCustomer(t, "Ana Souza", "5511999990001", false)
+	f.addCustomer(t, "Bruno Lima", "5511999990002", false)
+	campaign := f.startCampaign(t)
+
+	dispatcher := f.newWorker(campaigns.Config{MessagesPerSecond: 100, DailyLimit: 1})
+
+	// Execute
+	sent, err := dispatcher.RunOnce(context.Background(), time.Now())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	stored, _ := f.campaigns.GetCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.Equal(t, campaigns.CampaignRunning, stored.Status)
+	assert.Equal(t, int64(1), stored.Stats.Pending)
+}
+
+func TestCampaign_TierLimitIsPerPhoneNumber(t *testing.T) {
+	// Setup: outro número do tenant já esgotou o próprio limite na janela
+	f := setup(t)
+	now := time.Now()
+	assert.NoError(t, f.campaignRepo.CreateRecipients(context.Background(), []campaigns.CampaignRecipient{{
+		TenantID:      f.tenantID,
+		CampaignID:    uuid.New(),
+		CustomerID:    uuid.New(),
+		Phone:         "5511999990009",
+		Status:        campaigns.RecipientSent,
+		PhoneNumberID: "OTHER_PHONE_ID",
+		SentAt:        &now,
+	}}))
+	f.addCustomer(t, "Ana Souza", "5511999990001", false)
+	campaign := f.startCampaign(t)
+
+	dispatcher := f.newWorker(campaigns.Config{MessagesPerSecond: 100, DailyLimit: 1})
+
+	// Execute
+	sent, err := dispatcher.RunOnce(context.Background(), now)
+
+	// Assertions: o envio conta para o número da campanha
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	recipients, _, err := f.campaigns.ListRecipients(context.Background(), f.tenantID, campaign.ID.String(), campaigns.RecipientSent, 0, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, recipients, 1) {
+		assert.Equal(t, "PHONE_ID", recipients[0].PhoneNumberID)
+	}
+
+	count, err := f.campaignRepo.CountSentSince(context.Background(), "PHONE_ID", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestCampaign_RetriesTransientProviderErrors(t *testing.T) {
+	// Setup: o número atingiu a vazão da Cloud API
+	f := setup(t)
+	f.addCustomer(t, "Ana Souza", "5511999990001", false)
+	campaign := f.startCampaign(t)
+	f.provider.Err = &whatsapp.ProviderError{StatusCode: http.StatusBadRequest, Code: 130429, Message: "Rate limit hit"}
+
+	dispatcher := f.newWorker(campaigns.Config{MessagesPerSecond: 100, DailyLimit: 1000, RetryBackoff: time.Minute})
+	now := time.Now()
+
+	// Execute
+	sent, err := dispatcher.RunOnce(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	f.provider.Err = nil
+	early, err := dispatcher.RunOnce(context.Background(), now.Add(30*time.Second))
+	assert.NoError(t, err)
+	retried, err := dispatcher.RunOnce(context.Background(), now.Add(time.Minute))
+	assert.NoError(t, err)
+
+	// Assertions: o destinatário espera o backoff e é enviado, sem falha
+	assert.Equal(t, 0, early)
+	assert.Equal(t, 1, retried)
+
+	stored, _ := f.campaigns.GetCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.Equal(t, campaigns.CampaignCompleted, stored.Status)
+	assert.Equal(t, int64(1), stored.Stats.Sent)
+	assert.Equal(t, int64(0), stored.Stats.Failed)
+}
+
+func TestCampaign_FailsTerminalProviderErrors(t *testing.T) {
+	// Setup: número inválido não melhora com uma nova tentativa
+	f := setup(t)
+	f.addCustomer(t, "Ana Souza", "5511999990001", false)
+	campaign := f.startCampaign(t)
+	f.provider.Err = &whatsapp.ProviderError{StatusCode: http.StatusBadRequest, Code: 131026, Message: "Message undeliverable"}
+
+	dispatcher := f.newWorker(campaigns.Config{MessagesPerSecond: 100, DailyLimit: 1000})
+
+	// Execute
+	sent, err := dispatcher.RunOnce(context.Background(), time.Now())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	stored, _ := f.campaigns.GetCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.Equal(t, campaigns.CampaignCompleted, stored.Status)
+	assert.Equal(t, int64(1), stored.Stats.Failed)
+}
+
+func TestCampaign_OptOutKeyword(t *testing.T) {
+	// Setup
+	f := setup(t)
+	ana := f.addCustomer(t, "Ana Souza", "5511999990001", false)
+	f.addCustomer(t, "Bruno Lima", "5511999990002", false)
+	campaign := f.startCampaign(t)
+
+	// Execute: Ana pede para sair antes do envio
+	f.receive(t, ana.Phone, "wamid.sair", " sair ")
+	sent, err := f.newWorker(campaigns.Config{MessagesPerSecond: 100, DailyLimit: 1000}).RunOnce(context.Background(), time.Now())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	customer, _ := f.customerRepo.FindByID(context.Background(), f.tenantID, ana.ID.String())
+	assert.True(t, customer.OptedOut())
+
+	stored, _ := f.campaigns.GetCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.Equal(t, int64(1), stored.Stats.Skipped)
+	assert.Equal(t, int64(1), stored.Stats.Sent)
+}
+
+func TestCampaign_CancelWinsOverDispatcher(t *testing.T) {
+	// Setup: o dispatcher leu a campanha em execução antes do cancelamento
+	f := setup(t)
+	f.addCustomer(t, "Ana Souza", "5511999990001", false)
+	campaign := f.startCampaign(t)
+
+	_, err := f.campaigns.CancelCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.NoError(t, err)
+
+	// Execute
+	recipient, err := f.campaignRepo.ClaimNextRecipient(context.Background(), campaign.ID, time.Now())
+	assert.NoError(t, err)
+	now := time.Now()
+	campaign.Status = campaigns.CampaignCompleted
+	campaign.CompletedAt = &now
+	completed, err := f.campaignRepo.UpdateStatus(context.Background(), campaign, campaigns.CampaignRunning)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Nil(t, recipient)
+	assert.False(t, completed)
+	assert.Empty(t, f.provider.Sent())
+
+	stored, _ := f.campaigns.GetCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.Equal(t, campaigns.CampaignCanceled, stored.Status)
+
+	_, err = f.campaigns.CancelCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.ErrorIs(t, err, campaigns.ErrCampaignFinished)
+}
+
+func TestCampaign_FailsStaleClaims(t *testing.T) {
+	// Setup: uma instância reservou o envio e caiu
+	f := setup(t)
+	f.addCustomer(t, "Ana Souza", "5511999990001", false)
+	campaign := f.startCampaign(t)
+
+	now := time.Now()
+	claimed, err := f.campaignRepo.ClaimNextRecipient(context.Background(), campaign.ID, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.NotNil(t, claimed)
+
+	dispatcher := f.newWorker(campaigns.Config{MessagesPerSecond: 100, DailyLimit: 1000, ClaimTimeout: 5 * time.Minute})
+
+	// Execute
+	sent, err := dispatcher.RunOnce(context.Background(), now)
+
+	// Assertions: a reserva órfã não é reenviada e a campanha conclui
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, f.provider.Sent())
+
+	stored, _ := f.campaigns.GetCampaign(context.Background(), f.tenantID, campaign.ID.String())
+	assert.Equal(t, campaigns.CampaignCompleted, stored.Status)
+	assert.Equal(t, int64(1), stored.Stats.Failed)
+}
+
+func TestCampaign_RequiresApprovedTemplate(t *testing.T) {
+	f := setup(t)
+
+	_, err := f.campaigns.CreateCampaign(context.Background(), f.tenantID, f.userID, campaigns.CreateCampaignRequest{
+		Name:       "Sem template",
+		TemplateID: uuid.New().String(),
+		SegmentID:  f.segmentID,
+	})
+
+	assert.ErrorIs(t, err, whatsapp.ErrTemplateNotFound)
+}
@@ -0,0 +1,144 @@
+package cannedresponses_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/cannedresponses"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type fixture struct {
+	service      cannedresponses.CannedResponseService
+	customerRepo customers.CustomerRepository
+	tenantID     uuid.UUID
+	ana          uuid.UUID
+	bruno        uuid.UUID
+}
+
+func setup(t *testing.T) fixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&cannedresponses.CannedResponse{}, &customers.Customer{}))
+
+	tenantID := uuid.New()
+	agents := map[string]*auth.User{}
+	ana := &auth.User{ID: uuid.New(), TenantID: tenantID, Name: "Ana Paula", Email: "ana@loja.com"}
+	bruno := &auth.User{ID: uuid.New(), TenantID: tenantID, Email: "bruno@loja.com"}
+	agents[ana.ID.String()] = ana
+	agents[bruno.ID.String()] = bruno
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	customerRepo := customers.NewCustomerRepository(db, telemetryService)
+	service := cannedresponses.NewCannedResponseService(
+		cannedresponses.NewCannedResponseRepository(db, telemetryService),
+		customerRepo,
+		&auth.MockUserRepository{FindByIDFunc: func(_ context.Context, id string) (*auth.User, error) { return agents[id], nil }},
+		&tenants.MockTenantRepository{FindByIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, Name: "Loja Exemplo"}, nil
+		}},
+		nil,
+		telemetryService,
+	)
+
+	return fixture{service: service, customerRepo: customerRepo, tenantID: tenantID, ana: ana.ID, bruno: bruno.ID}
+}
+
+func (f fixture) create(t *testing.T, userID uuid.UUID, req cannedresponses.SaveCannedResponseRequest) *cannedresponses.CannedResponse {
+	response, err := f.service.CreateResponse(context.Background(), f.tenantID, userID, req)
+	assert.NoError(t, err)
+	return response
+}
+
+func TestCannedResponses_ScopesAndSearch(t *testing.T) {
+	// Setup
+	f := setup(t)
+	shared := f.create(t, f.ana, cannedresponses.SaveCannedResponseRequest{
+		Shortcut: "saudacao",
+		Title:    "Saudação",
+		Content:  "Olá {{customer.first_name}}, aqui é {{agent.first_name}} da {{tenant.name}}!",
+		Category: "Atendimento",
+	})
+	personal := f.create(t, f.ana, cannedresponses.SaveCannedResponseRequest{
+		Scope:    cannedresponses.ScopePersonal,
+		Shortcut: "/Saudacao",
+		Title:    "Minha saudação",
+		Content:  "Oi {{customer.name}}! Seu e-mail é {{customer.email}}?",
+	})
+	f.create(t, f.bruno, cannedresponses.SaveCannedResponseRequest{
+		Shortcut: "prazo",
+		Title:    "Prazo de entrega",
+		Content:  "O prazo de entrega é de 5 dias úteis.",
+		Category: "Vendas",
+	})
+
+	customer := &customers.Customer{TenantID: f.tenantID, Name: "Maria Souza", Phone: "5511988887777"}
+	assert.NoError(t, f.customerRepo.Create(context.Background(), customer))
+
+	// Execute
+	items, err := f.service.Search(context.Background(), f.tenantID, f.ana, cannedresponses.SearchRequest{
+		RenderRequest: cannedresponses.RenderRequest{CustomerID: customer.ID.String()},
+		Query:         "/sau",
+	})
+
+	// Assertions: a pessoal vem antes da compartilhada com o mesmo atalho
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, personal.ID, items[0].ID)
+	assert.Equal(t, "saudacao", items[0].Shortcut)
+	assert.Equal(t, "Oi Maria Souza! Seu e-mail é {{customer.email}}?", items[0].Rendered)
+	assert.Equal(t, shared.ID, items[1].ID)
+	assert.Equal(t, "Olá Maria, aqui é Ana da Loja Exemplo!", items[1].Rendered)
+
+	// Outro agente não enxerga a resposta pessoal
+	items, err = f.service.Search(context.Background(), f.tenantID, f.bruno, cannedresponses.SearchRequest{Query: "/sau"})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	_, err = f.service.GetResponse(context.Background(), f.tenantID, f.bruno, personal.ID.String())
+	assert.ErrorIs(t, err, cannedresponses.ErrCannedResponseNotFound)
+
+	// Busca por texto livre
+	items, err = f.service.Search(context.Background(), f.tenantID, f.bruno, cannedresponses.SearchRequest{Query: "ENTREGA"})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "prazo", items[0].Shortcut)
+
+	// Sem nome, o agente aparece pelo placeholder original
+	rendered, err := f.service.Render(context.Background(), f.tenantID, f.bruno, shared.ID.String(), cannedresponses.RenderRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Olá {{customer.first_name}}, aqui é {{agent.first_name}} da Loja Exemplo!", rendered.Rendered)
+
+	categories, err := f.service.ListCategories(context.Background(), f.tenantID, f.ana)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Atendimento", "Vendas"}, categories)
+}
+
+func TestCannedResponses_Validation(t *testing.T) {
+	f := setup(t)
+	f.create(t, f.ana, cannedresponses.SaveCannedResponseRequest{Shortcut: "pix", Title: "Pix", Content: "Nossa chave Pix é o CNPJ."})
+
+	tests := []struct {
+		name string
+		req  cannedresponses.SaveCannedResponseRequest
+		err  error
+	}{
+		{"duplicate shortcut", cannedresponses.SaveCannedResponseRequest{Shortcut: "/PIX", Title: "Pix", Content: "Outra"}, cannedresponses.ErrShortcutTaken},
+		{"invalid shortcut", cannedresponses.SaveCannedResponseRequest{Shortcut: "com espaço", Title: "X", Content: "Y"}, cannedresponses.ErrInvalidShortcut},
+		{"unknown placeholder", cannedresponses.SaveCannedResponseRequest{Shortcut: "cpf", Title: "CPF", Content: "Seu CPF {{customer.cpf}}"}, cannedresponses.ErrUnknownPlaceholder},
+		{"invalid scope", cannedresponses.SaveCannedResponseRequest{Scope: "team", Shortcut: "oi", Title: "Oi", Content: "Oi"}, cannedresponses.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.CreateResponse(context.Background(), f.tenantID, f.ana, tt.req)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
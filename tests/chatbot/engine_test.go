@@ -0,0 +1,445 @@
+package chatbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/chatbot"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const welcomeFlow = `{
+  "start": "greet",
+  "timeout_minutes": 15,
+  "timeout_message": "Encerramos o atendimento automático. É só escrever para recomeçar.",
+  "nodes": [
+    {"id": "greet", "type": "message", "text": "Olá! Sou o assistente virtual da Loja.", "next": "department"},
+    {"id": "department", "type": "buttons", "text": "Com qual setor você quer falar?", "save_as": "department",
+     "buttons": [{"id": "sales", "title": "Vendas"}, {"id": "support", "title": "Suporte"}], "next": "name"},
+    {"id": "name", "type": "question", "text": "Qual é o seu nome?", "save_as": "name",
+     "validation": {"type": "text", "min_length": 2}, "error_message": "Por favor, informe seu nome.", "next": "save_name"},
+    {"id": "save_name", "type": "set_field", "field": "customer.name", "value": "{{vars.name}}", "next": "route"},
+    {"id": "route", "type": "condition", "rules": [{"variable": "vars.department", "operator": "equals", "value": "sales", "next": "crm"}],
+     "default": "support_handoff"},
+    {"id": "crm", "type": "http", "save_as": "crm", "next": "sales_handoff", "on_error": "support_handoff",
+     "request": {"method": "POST", "url": "%s/leads", "body": "{\"name\": \"{{vars.name}}\"}"}},
+    {"id": "sales_handoff", "type": "handoff", "text": "Obrigado, {{customer.first_name}}! Protocolo {{vars.crm_protocol}}. Um vendedor já vai te atender."},
+    {"id": "support_handoff", "type": "handoff", "text": "Um atendente do suporte já vai te responder."}
+  ]
+}`
+
+type bot struct {
+	webhooks      whatsapp.WebhookService
+	conversations whatsapp.ConversationService
+	flows         chatbot.FlowService
+	timeouts      *chatbot.TimeoutWorker
+	sessions      chatbot.SessionRepository
+	customerRepo  customers.CustomerRepository
+	provider      *whatsapp.FakeProvider
+	telemetry     *recordingTelemetry
+	tenantID      uuid.UUID
+	agentID       uuid.UUID
+}
+
+// recordingTelemetry guarda os eventos para conferir o que sai do processo
+type recordingTelemetry struct {
+	telemetry.TelemetryService
+	mu     sync.Mutex
+	events []telemetry.Event
+}
+
+func (r *recordingTelemetry) TrackEvent(ctx context.Context, event telemetry.Event) error {
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+	return r.TelemetryService.TrackEvent(ctx, event)
+}
+
+func (r *recordingTelemetry) Events(name string) []telemetry.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []telemetry.Event
+	for _, event := range r.events {
+		if event.Name == name {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// setup injeta um cliente sem o filtro de endereços: o httptest escuta em loopback,
+// que o cliente padrão dos nós HTTP recusa
+func setup(t *testing.T) bot {
+	return setupWithClient(t, &http.Client{Timeout: 10 * time.Second}, chatbot.Config{})
+}
+
+func setupWithClient(t *testing.T, client *http.Client, config chatbot.Config) bot {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}, &whatsapp.Conversation{}, &customers.Customer{}, &chatbot.Flow{}, &chatbot.Session{}))
+
+	tenantID := uuid.New()
+	agentID := uuid.New()
+	phoneNumberID := "PHONE_ID"
+	tenant := &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc:                    func(_ context.Context, id string) (*tenants.Tenant, error) { return tenant, nil },
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) { return tenant, nil },
+	}
+	userRepo := &auth.MockUserRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*auth.User, error) {
+			return &auth.User{ID: uuid.MustParse(id), TenantID: tenantID}, nil
+		},
+	}
+
+	telemetryService := &recordingTelemetry{TelemetryService: telemetry.NewTelemetryService(false)}
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	customerRepo := customers.NewCustomerRepository(db, telemetryService)
+	flowRepo := chatbot.NewFlowRepository(db, telemetryService)
+	sessionRepo := chatbot.NewSessionRepository(db, telemetryService)
+	provider := whatsapp.NewFakeProvider()
+
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	webhooks := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	messages := whatsapp.NewMessageService(messageRepo, channels, provider, telemetryService)
+	conversations := whatsapp.NewConversationService(
+		whatsapp.NewConversationRepository(db, telemetryService),
+		messageRepo,
+		messages,
+		customers.NewCustomerService(customerRepo, telemetryService),
+		userRepo,
+		telemetryService,
+	)
+	engine := chatbot.NewEngine(config, flowRepo, sessionRepo, conversations, customerRepo, client, telemetryService)
+	webhooks.Subscribe(conversations.HandleEvent)
+	webhooks.Subscribe(engine.HandleEvent)
+
+	return bot{
+		webhooks:      webhooks,
+		conversations: conversations,
+		flows:         chatbot.NewFlowService(flowRepo, telemetryService),
+		timeouts:      chatbot.NewTimeoutWorker(sessionRepo, flowRepo, conversations, telemetryService, time.Minute),
+		sessions:      sessionRepo,
+		customerRepo:  customerRepo,
+		provider:      provider,
+		telemetry:     telemetryService,
+		tenantID:      tenantID,
+		agentID:       agentID,
+	}
+}
+
+func (b bot) activate(t *testing.T, crmURL string) *chatbot.Flow {
+	var definition chatbot.FlowDefinition
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(welcomeFlow, crmURL)), &definition))
+
+	flow, err := b.flows.CreateFlow(context.Background(), b.tenantID, chatbot.SaveFlowRequest{Name: "Boas-vindas", Definition: definition})
+	assert.NoError(t, err)
+	flow, err = b.flows.ActivateFlow(context.Background(), b.tenantID, flow.ID.String())
+	assert.NoError(t, err)
+	return flow
+}
+
+func (b bot) receive(t *testing.T, waMessageID, body string) {
+	raw := fmt.Sprintf(`{"from":"5511988887777","id":%q,"timestamp":"%d","type":"text","text":{"body":%q}}`, waMessageID, time.Now().Unix(), body)
+	err := b.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: "PHONE_ID"},
+					Contacts: []whatsapp.WebhookContact{{WaID: "5511988887777"}},
+					Messages: []json.RawMessage{json.RawMessage(raw)},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+}
+
+// replies devolve o texto das mensagens enviadas pelo bot a partir do índice from
+func (b bot) replies(from int) []string {
+	var texts []string
+	for _, sent := range b.provider.Sent()[from:] {
+		switch {
+		case sent.Message.Text != nil:
+			texts = append(texts, sent.Message.Text.Body)
+		case sent.Message.Interactive != nil:
+			texts = append(texts, sent.Message.Interactive.Body)
+		}
+	}
+	return texts
+}
+
+func (b bot) conversation(t *testing.T) whatsapp.Conversation {
+	items, _, err := b.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: b.tenantID, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	return items[0]
+}
+
+func TestEngine_WelcomeFlow(t *testing.T) {
+	// Setup
+	var lead map[string]string
+	crm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&lead)
+		w.Write([]byte(`{"protocol":"A-123"}`))
+	}))
+	defer crm.Close()
+
+	b := setup(t)
+	b.activate(t, crm.URL)
+
+	// Execute: saudação e pergunta do setor
+	b.receive(t, "wamid.1", "Oi")
+	assert.Equal(t, []string{"Olá! Sou o assistente virtual da Loja.", "Com qual setor você quer falar?"}, b.replies(0))
+	assert.Len(t, b.provider.Sent()[1].Message.Interactive.Buttons, 2)
+
+	b.receive(t, "wamid.2", "vendas")
+	assert.Equal(t, []string{"Qual é o seu nome?"}, b.replies(2))
+
+	// Resposta inválida repete o pedido
+	b.receive(t, "wamid.3", "A")
+	assert.Equal(t, []string{"Por favor, informe seu nome."}, b.replies(3))
+
+	b.receive(t, "wamid.4", "Maria Silva")
+
+	// Assertions
+	assert.Equal(t, []string{"Obrigado, Maria! Protocolo A-123. Um vendedor já vai te atender."}, b.replies(4))
+	assert.Equal(t, "Maria Silva", lead["name"])
+
+	conversation := b.conversation(t)
+	customer, err := b.customerRepo.FindByID(context.Background(), b.tenantID, conversation.CustomerID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "Maria Silva", customer.Name)
+
+	session, err := b.sessions.FindLatest(context.Background(), conversation.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, chatbot.SessionHandedOff, session.Status)
+	assert.Equal(t, "sales", session.Variables["department"])
+
+	// O que o cliente digitou não vai para a telemetria
+	handoffs := b.telemetry.Events("chatbot.handoff")
+	assert.Len(t, handoffs, 1)
+	for key, value := range handoffs[0].Properties {
+		assert.NotContains(t, key, "vars.")
+		assert.NotEqual(t, "Maria Silva", value)
+	}
+
+	// Após o handoff o bot fica em silêncio até a conversa ser resolvida
+	b.receive(t, "wamid.5", "Alguém aí?")
+	assert.Empty(t, b.replies(5))
+
+	_, err = b.conversations.UpdateStatus(context.Background(), b.tenantID, conversation.ID.String(), whatsapp.ConversationResolved)
+	assert.NoError(t, err)
+	b.receive(t, "wamid.6", "Oi de novo")
+	assert.Equal(t, "Olá! Sou o assistente virtual da Loja.", b.replies(5)[0])
+}
+
+func TestEngine_HTTPFailureFallsBack(t *testing.T) {
+	// Setup
+	crm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer crm.Close()
+
+	b := setup(t)
+	b.activate(t, crm.URL)
+
+	// Execute
+	b.receive(t, "wamid.1", "Oi")
+	b.receive(t, "wamid.2", "1")
+	b.receive(t, "wamid.3", "Maria")
+
+	// Assertions
+	assert.Equal(t, []string{"Um atendente do suporte já vai te responder."}, b.replies(3))
+}
+
+func TestEngine_HTTPNodeEscapesPlaceholders(t *testing.T) {
+	// Setup: o nome digitado tenta sair do segmento da URL e do valor do JSON
+	var lead map[string]interface{}
+	var path, query string
+	crm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.EscapedPath(), r.URL.RawQuery
+		json.NewDecoder(r.Body).Decode(&lead)
+		w.Write([]byte(`{"protocol":"A-123"}`))
+	}))
+	defer crm.Close()
+
+	b := setup(t)
+	b.activate(t, crm.URL+"/{{vars.name}}")
+	name := `Ana/../admin?x=1&y", "admin": "true`
+
+	// Execute
+	b.receive(t, "wamid.1", "Oi")
+	b.receive(t, "wamid.2", "vendas")
+	b.receive(t, "wamid.3", name)
+
+	// Assertions
+	assert.Equal(t, "/"+strings.ReplaceAll(url.QueryEscape(name), "+", "%20")+"/leads", path)
+	assert.Empty(t, query)
+	assert.Equal(t, map[string]interface{}{"name": name}, lead)
+}
+
+func TestEngine_HTTPNodeRefusesInternalAddresses(t *testing.T) {
+	// Setup: com o cliente padrão, loopback (onde escuta o httptest) é recusado
+	called := false
+	crm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer crm.Close()
+
+	b := setupWithClient(t, nil, chatbot.Config{})
+	b.activate(t, crm.URL)
+
+	// Execute
+	b.receive(t, "wamid.1", "Oi")
+	b.receive(t, "wamid.2", "vendas")
+	b.receive(t, "wamid.3", "Maria")
+
+	// Assertions: o nó cai no on_error
+	assert.False(t, called)
+	assert.Equal(t, []string{"Um atendente do suporte já vai te responder."}, b.replies(3))
+}
+
+func TestEngine_HTTPNodesShareTheMessageBudget(t *testing.T) {
+	// Setup: uma integração lenta não pode prender o worker da fila do webhook
+	crm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer crm.Close()
+
+	b := setupWithClient(t, &http.Client{Timeout: 10 * time.Second}, chatbot.Config{HTTPBudget: 100 * time.Millisecond})
+	b.activate(t, crm.URL)
+	b.receive(t, "wamid.1", "Oi")
+	b.receive(t, "wamid.2", "vendas")
+
+	// Execute
+	started := time.Now()
+	b.receive(t, "wamid.3", "Maria")
+
+	// Assertions: o nó desiste no prazo da mensagem e cai no on_error
+	assert.Less(t, time.Since(started), 2*time.Second)
+	assert.Equal(t, []string{"Um atendente do suporte já vai te responder."}, b.replies(3))
+}
+
+func TestEngine_AgentTakesOver(t *testing.T) {
+	// Setup
+	b := setup(t)
+	b.activate(t, "http://crm.invalid")
+	b.receive(t, "wamid.1", "Oi")
+	conversation := b.conversation(t)
+
+	// Execute
+	_, err := b.conversations.Assign(context.Background(), b.tenantID, conversation.ID.String(), &b.agentID)
+	assert.NoError(t, err)
+	b.receive(t, "wamid.2", "Suporte")
+
+	// Assertions
+	assert.Empty(t, b.replies(2))
+	session, _ := b.sessions.FindLatest(context.Background(), conversation.ID)
+	assert.Equal(t, chatbot.SessionHandedOff, session.Status)
+}
+
+func TestTimeoutWorker(t *testing.T) {
+	// Setup
+	b := setup(t)
+	b.activate(t, "http://crm.invalid")
+	b.receive(t, "wamid.1", "Oi")
+
+	// Execute
+	count, err := b.timeouts.RunOnce(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = b.timeouts.RunOnce(context.Background(), time.Now().Add(16*time.Minute))
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"Encerramos o atendimento automático. É só escrever para recomeçar."}, b.replies(2))
+
+	session, _ := b.sessions.FindLatest(context.Background(), b.conversation(t).ID)
+	assert.Equal(t, chatbot.SessionTimedOut, session.Status)
+}
+
+func TestFlowDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		flow    chatbot.FlowDefinition
+		problem string
+	}{
+		{
+			name: "unknown start",
+			flow: chatbot.FlowDefinition{Start: "missing", Nodes: []chatbot.Node{
+				{ID: "a", Type: chatbot.NodeHandoff},
+			}},
+			problem: `start node "missing" not found`,
+		},
+		{
+			name: "dangling next",
+			flow: chatbot.FlowDefinition{Start: "a", Nodes: []chatbot.Node{
+				{ID: "a", Type: chatbot.NodeMessage, Text: "Oi", Next: "b"},
+			}},
+			problem: `references unknown node "b"`,
+		},
+		{
+			name: "button title too long",
+			flow: chatbot.FlowDefinition{Start: "a", Nodes: []chatbot.Node{
+				{ID: "a", Type: chatbot.NodeButtons, Text: "Escolha", SaveAs: "choice",
+					Buttons: []chatbot.Button{{ID: "x", Title: "Um título grande demais para botão"}}},
+			}},
+			problem: `node "a"`,
+		},
+		{
+			name: "automatic loop",
+			flow: chatbot.FlowDefinition{Start: "a", Nodes: []chatbot.Node{
+				{ID: "a", Type: chatbot.NodeMessage, Text: "Oi", Next: "b"},
+				{ID: "b", Type: chatbot.NodeSetField, Field: "vars.x", Value: "1", Next: "a"},
+			}},
+			problem: "loop without waiting",
+		},
+		{
+			name: "invalid field",
+			flow: chatbot.FlowDefinition{Start: "a", Nodes: []chatbot.Node{
+				{ID: "a", Type: chatbot.NodeSetField, Field: "customer.id", Value: "1"},
+			}},
+			problem: `invalid field "customer.id"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.flow.Validate()
+			assert.True(t, errors.Is(err, chatbot.ErrInvalidFlow))
+			assert.True(t, strings.Contains(err.Error(), tt.problem), err.Error())
+		})
+	}
+
+	// O fluxo de exemplo é válido
+	var definition chatbot.FlowDefinition
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(welcomeFlow, "https://crm.example.com")), &definition))
+	assert.NoError(t, definition.Validate())
+}
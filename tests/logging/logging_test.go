@@ -0,0 +1,165 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/logging"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/middleware"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestLogger_RedactsSensitiveFields(t *testing.T) {
+	// Setup
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.Config{Level: slog.LevelInfo})
+
+	// Execute
+	logger.Info("user.registered",
+		"email", "ana@example.com",
+		"password", "s3cret",
+		"tenant_name", "Acme",
+		slog.Group("whatsapp", "access_token", "EAAB", "phone_number_id", "123"),
+		"payload", map[string]any{"Authorization": "Bearer x", "nested": map[string]any{"api_key": "k", "ok": true}},
+		"tags", map[string]string{"user_email": "bia@example.com", "type": "text"},
+	)
+
+	// Assertions
+	entry := decodeLines(t, &buf)[0]
+	assert.Equal(t, "user.registered", entry["msg"])
+	assert.Equal(t, logging.Redacted, entry["email"])
+	assert.Equal(t, logging.Redacted, entry["password"])
+	assert.Equal(t, "Acme", entry["tenant_name"])
+
+	group := entry["whatsapp"].(map[string]any)
+	assert.Equal(t, logging.Redacted, group["access_token"])
+	assert.Equal(t, "123", group["phone_number_id"])
+
+	payload := entry["payload"].(map[string]any)
+	assert.Equal(t, logging.Redacted, payload["Authorization"])
+	assert.Equal(t, logging.Redacted, payload["nested"].(map[string]any)["api_key"])
+	assert.Equal(t, true, payload["nested"].(map[string]any)["ok"])
+
+	tags := entry["tags"].(map[string]any)
+	assert.Equal(t, logging.Redacted, tags["user_email"])
+	assert.Equal(t, "text", tags["type"])
+	assert.NotContains(t, buf.String(), "ana@example.com")
+}
+
+func TestLoadConfig_Level(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "debug")
+	assert.Equal(t, slog.LevelDebug, logging.LoadConfig().Level)
+
+	t.Setenv("LOG_LEVEL", "WARN")
+	assert.Equal(t, slog.LevelWarn, logging.LoadConfig().Level)
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	assert.Equal(t, slog.LevelInfo, logging.LoadConfig().Level)
+}
+
+func TestLogger_Level(t *testing.T) {
+	// Setup
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.Config{Level: slog.LevelWarn})
+
+	// Execute
+	logger.Info("ignored")
+	logger.Warn("kept")
+
+	// Assertions
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "kept", lines[0]["msg"])
+}
+
+func TestFromContext(t *testing.T) {
+	// Setup
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.Config{})
+
+	// Execute
+	ctx := logging.WithLogger(context.Background(), logger)
+	ctx = logging.With(ctx, "tenant_id", "t1")
+	logging.FromContext(ctx).Info("scoped")
+
+	// Assertions
+	assert.Equal(t, "t1", decodeLines(t, &buf)[0]["tenant_id"])
+	assert.Same(t, slog.Default(), logging.FromContext(context.Background()))
+}
+
+func TestLoggingMiddleware_CorrelatesRequest(t *testing.T) {
+	// Setup
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.Config{})
+	service := telemetry.NewOpenTelemetryService(sdktrace.NewTracerProvider(), sdkmetric.NewMeterProvider())
+	tenantID, userID := uuid.New(), uuid.New()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.TelemetryMiddleware(service))
+	router.Use(middleware.LoggingMiddleware(logger))
+	sessions, err := web.NewSessions(web.SessionConfig{Secret: "test-secret", TTL: time.Hour})
+	require.NoError(t, err)
+	users := web.UserDirectoryFunc(func(context.Context, uuid.UUID, uuid.UUID) (bool, error) { return true, nil })
+	router.GET("/api/tasks/:id", web.IdentityMiddleware(sessions, users), func(c *gin.Context) {
+		logging.FromContext(c.Request.Context()).Info("task.lookup", "email", "ana@example.com")
+		c.JSON(http.StatusNotFound, web.ErrorBody(c, "task not found"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tasks/123", nil)
+	req.Header.Set(telemetry.RequestIDHeader, "req-abc")
+	token, _ := sessions.Issue(tenantID, userID, time.Now())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 2)
+	handler, access := lines[0], lines[1]
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	for _, entry := range lines {
+		assert.Equal(t, "req-abc", entry["request_id"])
+		assert.Equal(t, body["trace_id"], entry["trace_id"])
+		assert.Equal(t, tenantID.String(), entry["tenant_id"])
+		assert.Equal(t, userID.String(), entry["user_id"])
+	}
+	assert.NotEmpty(t, body["trace_id"])
+	assert.Equal(t, logging.Redacted, handler["email"])
+
+	assert.Equal(t, "http.request", access["msg"])
+	assert.Equal(t, "WARN", access["level"])
+	assert.Equal(t, "/api/tasks/:id", access["route"])
+	assert.Equal(t, float64(http.StatusNotFound), access["status"])
+}
@@ -0,0 +1,53 @@
+package pubsub_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/pubsub"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlers_DeliverInSubscriptionOrder(t *testing.T) {
+	// Setup
+	var handlers pubsub.Handlers
+	var received []string
+	handlers.Subscribe(func(payload []byte) { received = append(received, "a:"+string(payload)) })
+	handlers.Subscribe(func(payload []byte) { received = append(received, "b:"+string(payload)) })
+
+	// Execute
+	handlers.Deliver([]byte("x"))
+
+	// Assertions
+	assert.Equal(t, []string{"a:x", "b:x"}, received)
+}
+
+func TestListener_ReconnectsUntilStopped(t *testing.T) {
+	// Setup: a primeira conexão falha, a segunda assina e fica até o Stop
+	var calls atomic.Int32
+	subscribed := make(chan struct{})
+	listener := pubsub.NewListener("test.reconnect", func(ctx context.Context) (bool, error) {
+		if calls.Add(1) == 1 {
+			return false, errors.New("connection refused")
+		}
+		close(subscribed)
+		<-ctx.Done()
+		return true, ctx.Err()
+	}, telemetry.NewTelemetryService(false))
+
+	// Execute
+	listener.Start(context.Background())
+
+	// Assertions
+	select {
+	case <-subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not reconnect")
+	}
+	listener.Stop()
+	assert.Equal(t, int32(2), calls.Load())
+}
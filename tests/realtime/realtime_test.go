@@ -0,0 +1,449 @@
+package realtime_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newHub(bufferSize int) *realtime.Hub {
+	return realtime.NewHub(realtime.NewLocalBroker(), telemetry.NewTelemetryService(false), bufferSize)
+}
+
+// received drena os frames já entregues ao cliente
+func received(client *realtime.Client) []string {
+	var types []string
+	for {
+		select {
+		case frame := <-client.Frames():
+			types = append(types, frame.Type)
+		default:
+			return types
+		}
+	}
+}
+
+func TestHub_TenantScopeAndSubscriptions(t *testing.T) {
+	// Setup
+	hub := newHub(16)
+	tenantID, otherTenantID := uuid.New(), uuid.New()
+	ana, bruno := uuid.New(), uuid.New()
+
+	anaClient := hub.Subscribe(realtime.Subscription{TenantID: tenantID, UserID: ana})
+	brunoClient := hub.Subscribe(realtime.Subscription{TenantID: tenantID, UserID: bruno, Types: []string{realtime.EventMessageCreated}})
+	otherClient := hub.Subscribe(realtime.Subscription{TenantID: otherTenantID, UserID: uuid.New()})
+
+	// Execute
+	hub.Publish(context.Background(), realtime.NewEvent(tenantID, realtime.EventMessageCreated, nil))
+	hub.Publish(context.Background(), realtime.NewEvent(tenantID, realtime.EventConversationAssigned, nil))
+	onlyAna := realtime.NewEvent(tenantID, realtime.EventMessageCreated, nil)
+	onlyAna.UserID = &ana
+	hub.Publish(context.Background(), onlyAna)
+
+	// Assertions
+	assert.Equal(t, []string{realtime.EventMessageCreated, realtime.EventConversationAssigned, realtime.EventMessageCreated}, received(anaClient))
+	assert.Equal(t, []string{realtime.EventMessageCreated}, received(brunoClient))
+	assert.Empty(t, received(otherClient))
+	assert.Equal(t, 2, hub.Connections(tenantID))
+}
+
+func TestHub_DropsSlowConsumer(t *testing.T) {
+	// Setup
+	hub := newHub(2)
+	tenantID := uuid.New()
+	slow := hub.Subscribe(realtime.Subscription{TenantID: tenantID, UserID: uuid.New()})
+	fast := hub.Subscribe(realtime.Subscription{TenantID: tenantID, UserID: uuid.New()})
+
+	// Execute: o cliente lento não lê nada; o outro acompanha
+	for i := 0; i < 3; i++ {
+		hub.Publish(context.Background(), realtime.NewEvent(tenantID, realtime.EventMessageCreated, nil))
+		<-fast.Frames()
+	}
+
+	// Assertions
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("slow client should have been dropped")
+	}
+	assert.Equal(t, 1, hub.Connections(tenantID))
+}
+
+func TestHub_CloseDropsEveryClient(t *testing.T) {
+	// Setup
+	hub := newHub(2)
+	tenantID := uuid.New()
+	clients := []*realtime.Client{
+		hub.Subscribe(realtime.Subscription{TenantID: tenantID, UserID: uuid.New()}),
+		hub.Subscribe(realtime.Subscription{TenantID: uuid.New(), UserID: uuid.New()}),
+	}
+
+	// Execute
+	hub.Close()
+
+	// Assertions
+	for _, client := range clients {
+		select {
+		case <-client.Done():
+		default:
+			t.Fatal("client should have been closed")
+		}
+	}
+	assert.Equal(t, 0, hub.Connections(tenantID))
+}
+
+func TestTicketer(t *testing.T) {
+	// Setup
+	tickets := realtime.NewTicketer("secret", time.Minute)
+	tenantID, userID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	// Execute
+	ticket, expiresAt := tickets.Issue(tenantID, userID, now)
+	gotTenant, gotUser, err := tickets.Verify(ticket, now)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, tenantID, gotTenant)
+	assert.Equal(t, userID, gotUser)
+	assert.Equal(t, now.Add(time.Minute).Truncate(time.Second), expiresAt)
+
+	_, _, err = tickets.Verify(ticket, now.Add(2*time.Minute))
+	assert.ErrorIs(t, err, realtime.ErrTicketExpired)
+
+	forged := uuid.New().String() + ticket[36:]
+	_, _, err = tickets.Verify(forged, now)
+	assert.ErrorIs(t, err, realtime.ErrInvalidTicket)
+
+	_, _, err = realtime.NewTicketer("other", time.Minute).Verify(ticket, now)
+	assert.ErrorIs(t, err, realtime.ErrInvalidTicket)
+}
+
+type server struct {
+	*httptest.Server
+	hub      *realtime.Hub
+	sessions *web.Sessions
+	tenantID uuid.UUID
+	userID   uuid.UUID
+}
+
+func setupServer(t *testing.T) server {
+	return setupServerWithOrigins(t, nil)
+}
+
+func setupServerWithOrigins(t *testing.T, allowedOrigins []string) server {
+	gin.SetMode(gin.TestMode)
+	hub := newHub(16)
+	sessions, err := web.NewSessions(web.SessionConfig{Secret: "session-secret", TTL: time.Hour})
+	assert.NoError(t, err)
+	s := server{hub: hub, sessions: sessions, tenantID: uuid.New(), userID: uuid.New()}
+	users := web.UserDirectoryFunc(func(_ context.Context, tenantID, userID uuid.UUID) (bool, error) {
+		return tenantID == s.tenantID && userID == s.userID, nil
+	})
+	identity := web.IdentityMiddleware(sessions, users)
+	handler := realtime.NewRealtimeHandler(hub, realtime.NewTicketer("secret", time.Minute), identity, time.Hour, allowedOrigins)
+
+	r := gin.New()
+	r.POST("/realtime/tickets", identity, handler.IssueTicket)
+	r.GET("/realtime/ws", handler.Authenticate(), handler.WebSocket)
+	r.GET("/realtime/events", handler.Authenticate(), handler.Events)
+
+	s.Server = httptest.NewServer(r)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s server) ticket(t *testing.T) string {
+	req, _ := http.NewRequest(http.MethodPost, s.URL+"/realtime/tickets", nil)
+	token, _ := s.sessions.Issue(s.tenantID, s.userID, time.Now())
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body realtime.TicketResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body.Ticket
+}
+
+// waitConnected espera o handler registrar a conexão no Hub antes de publicar
+func (s server) waitConnected(t *testing.T) {
+	deadline := time.Now().Add(2 * time.Second)
+	for s.hub.Connections(s.tenantID) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("connection not registered")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestHandler_WebSocket(t *testing.T) {
+	// Setup
+	s := setupServer(t)
+	url := "ws" + strings.TrimPrefix(s.URL, "http") + "/realtime/ws?ticket=" + s.ticket(t)
+	conn, err := websocket.Dial(url, "", s.URL)
+	assert.NoError(t, err)
+	defer conn.Close()
+	s.waitConnected(t)
+
+	// Execute
+	s.hub.Publish(context.Background(), realtime.NewEvent(s.tenantID, realtime.EventConversationAssigned, map[string]string{"conversation_id": "c1"}))
+
+	// Assertions
+	var event struct {
+		Type string            `json:"type"`
+		Data map[string]string `json:"data"`
+	}
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	assert.NoError(t, websocket.JSON.Receive(conn, &event))
+	assert.Equal(t, realtime.EventConversationAssigned, event.Type)
+	assert.Equal(t, "c1", event.Data["conversation_id"])
+
+	// Ao desconectar, a conexão sai do Hub
+	conn.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for s.hub.Connections(s.tenantID) > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(t, 0, s.hub.Connections(s.tenantID))
+}
+
+func TestHandler_WebSocketChecksOrigin(t *testing.T) {
+	// Setup
+	sameOrigin := setupServer(t)
+	configured := setupServerWithOrigins(t, []string{"https://app.example.com"})
+	wsURL := func(s server) string {
+		return "ws" + strings.TrimPrefix(s.URL, "http") + "/realtime/ws?ticket=" + s.ticket(t)
+	}
+
+	// Execute
+	_, crossSite := websocket.Dial(wsURL(sameOrigin), "", "https://evil.example.com")
+	_, notListed := websocket.Dial(wsURL(configured), "", configured.URL)
+	conn, listed := websocket.Dial(wsURL(configured), "", "https://app.example.com")
+
+	// Assertions: sem lista só a própria origem passa; com lista, só as cadastradas
+	assert.Error(t, crossSite)
+	assert.Error(t, notListed)
+	if assert.NoError(t, listed) {
+		conn.Close()
+	}
+}
+
+func TestHandler_ServerSentEvents(t *testing.T) {
+	// Setup
+	s := setupServer(t)
+	resp, err := http.Get(s.URL + "/realtime/events?types=message.created&ticket=" + s.ticket(t))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	s.waitConnected(t)
+
+	// Execute
+	s.hub.Publish(context.Background(), realtime.NewEvent(s.tenantID, realtime.EventConversationAssigned, nil))
+	event := realtime.NewEvent(s.tenantID, realtime.EventMessageCreated, nil)
+	s.hub.Publish(context.Background(), event)
+
+	// Assertions: o filtro de tipos descarta a atribuição
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 3 {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "retry:") {
+			lines = append(lines, line)
+		}
+	}
+	assert.Equal(t, "id: "+event.ID.String(), lines[0])
+	assert.Equal(t, "event: "+realtime.EventMessageCreated, lines[1])
+	assert.Contains(t, lines[2], fmt.Sprintf(`"tenant_id":%q`, s.tenantID))
+}
+
+func TestHandler_RejectsInvalidTicket(t *testing.T) {
+	// Setup
+	s := setupServer(t)
+
+	// Execute
+	resp, err := http.Get(s.URL + "/realtime/events?ticket=invalid")
+
+	// Assertions
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestHandler_RequiresSignedSession(t *testing.T) {
+	// Setup
+	s := setupServer(t)
+	stranger := uuid.New()
+	token, _ := s.sessions.Issue(s.tenantID, stranger, time.Now())
+
+	requests := map[string]func(*http.Request){
+		// headers de identidade soltos não emitem ticket
+		"headers": func(req *http.Request) {
+			req.Header.Set("X-Tenant-ID", s.tenantID.String())
+			req.Header.Set("X-User-ID", s.userID.String())
+		},
+		"usuário fora do tenant": func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) },
+	}
+
+	for name, header := range requests {
+		for _, path := range []string{"/realtime/tickets", "/realtime/events"} {
+			method := http.MethodGet
+			if path == "/realtime/tickets" {
+				method = http.MethodPost
+			}
+			req, _ := http.NewRequest(method, s.URL+path, nil)
+			header(req)
+
+			// Execute
+			resp, err := http.DefaultClient.Do(req)
+
+			// Assertions
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, name+" "+path)
+		}
+	}
+}
+
+func TestSplitNotify_ReassemblesLargeEvents(t *testing.T) {
+	// Setup: texto com acentos para cair cortes no meio de caracteres
+	event := realtime.NewEvent(uuid.New(), realtime.EventMessageCreated, map[string]string{
+		"body": strings.Repeat("ação ", 5000),
+	})
+	payload, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	// Execute
+	messages, err := realtime.SplitNotify(payload, 7999)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Greater(t, len(messages), 1)
+	assembler := realtime.NewNotifyAssembler()
+	var got []byte
+	for i, message := range messages {
+		assert.LessOrEqual(t, len(message), 7999)
+		assert.True(t, utf8.ValidString(message))
+		out, ok := assembler.Add(message)
+		assert.Equal(t, i == len(messages)-1, ok)
+		got = out
+	}
+	assert.JSONEq(t, string(payload), string(got))
+}
+
+func TestSplitNotify_SmallAndOversizedEvents(t *testing.T) {
+	// Setup
+	small := []byte(`{"type":"ping"}`)
+
+	// Execute
+	messages, err := realtime.SplitNotify(small, 7999)
+	_, tooLarge := realtime.SplitNotify(make([]byte, 2<<20), 7999)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, []string{string(small)}, messages)
+	out, ok := realtime.NewNotifyAssembler().Add(messages[0])
+	assert.True(t, ok)
+	assert.Equal(t, small, out)
+	assert.ErrorIs(t, tooLarge, realtime.ErrPayloadTooLarge)
+}
+
+func TestWhatsAppBridge(t *testing.T) {
+	// Setup
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}, &whatsapp.Conversation{}, &customers.Customer{}))
+
+	tenantID, agentID := uuid.New(), uuid.New()
+	phoneNumberID := "PHONE_ID"
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+	}
+	userRepo := &auth.MockUserRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*auth.User, error) {
+			return &auth.User{ID: uuid.MustParse(id), TenantID: tenantID}, nil
+		},
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	webhooks := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	messages := whatsapp.NewMessageService(messageRepo, channels, whatsapp.NewFakeProvider(), telemetryService)
+	conversations := whatsapp.NewConversationService(whatsapp.NewConversationRepository(db, telemetryService), messageRepo, messages,
+		customers.NewCustomerService(customers.NewCustomerRepository(db, telemetryService), telemetryService), userRepo, telemetryService)
+
+	publisher := &realtime.MockPublisher{}
+	bridge := realtime.NewWhatsAppBridge(publisher)
+	webhooks.Subscribe(conversations.HandleEvent)
+	webhooks.Subscribe(bridge.HandleEvent)
+	conversations.Subscribe(bridge.HandleEvent)
+
+	// Execute
+	raw := fmt.Sprintf(`{"from":"5511988887777","id":"wamid.1","timestamp":"%d","type":"text","text":{"body":"Oi"}}`, time.Now().Unix())
+	err = webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: phoneNumberID},
+					Contacts: []whatsapp.WebhookContact{{WaID: "5511988887777"}},
+					Messages: []json.RawMessage{json.RawMessage(raw)},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+
+	items, _, err := conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: tenantID, Limit: 1})
+	assert.NoError(t, err)
+	conversation := items[0]
+	_, err = conversations.Assign(context.Background(), tenantID, conversation.ID.String(), &agentID)
+	assert.NoError(t, err)
+	_, err = conversations.SendMessage(context.Background(), tenantID, agentID, conversation.ID.String(), whatsapp.SendMessageRequest{
+		Type: whatsapp.TypeText,
+		Text: &whatsapp.TextContent{Body: "Olá!"},
+	})
+	assert.NoError(t, err)
+
+	// Assertions
+	created := publisher.Events(realtime.EventMessageCreated)
+	assert.Len(t, created, 2)
+	for _, event := range created {
+		assert.Equal(t, tenantID, event.TenantID)
+		assert.Equal(t, conversation.ID, *event.Data.(realtime.MessageData).ConversationID)
+	}
+	assert.Equal(t, "Oi", created[0].Data.(realtime.MessageData).Message.Body)
+
+	assigned := publisher.Events(realtime.EventConversationAssigned)
+	assert.Len(t, assigned, 1)
+	assert.Equal(t, agentID, *assigned[0].Data.(*whatsapp.Conversation).AssigneeID)
+}
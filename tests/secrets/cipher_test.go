@@ -0,0 +1,65 @@
+package secrets_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/secrets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAESCipher_RoundTrip(t *testing.T) {
+	// Setup
+	key := bytes.Repeat([]byte{7}, 32)
+	cipher, err := secrets.NewAESCipher(key)
+	assert.NoError(t, err)
+
+	// Execute
+	first, err := cipher.Encrypt("EAAG-token")
+	assert.NoError(t, err)
+	second, err := cipher.Encrypt("EAAG-token")
+	assert.NoError(t, err)
+
+	// Assertions
+	assert.NotContains(t, first, "EAAG")
+	assert.NotEqual(t, first, second, "nonce aleatório por valor")
+
+	plaintext, err := cipher.Decrypt(first)
+	assert.NoError(t, err)
+	assert.Equal(t, "EAAG-token", plaintext)
+
+	// Outra chave não decifra; adulteração é detectada
+	other, err := secrets.NewAESCipher(bytes.Repeat([]byte{8}, 32))
+	assert.NoError(t, err)
+	_, err = other.Decrypt(first)
+	assert.ErrorIs(t, err, secrets.ErrInvalidCiphertext)
+
+	tampered := []byte(first)
+	tampered[len(tampered)-2] ^= 1
+	_, err = cipher.Decrypt(string(tampered))
+	assert.ErrorIs(t, err, secrets.ErrInvalidCiphertext)
+	_, err = cipher.Decrypt("plain-token")
+	assert.ErrorIs(t, err, secrets.ErrInvalidCiphertext)
+}
+
+func TestNewCipher_Config(t *testing.T) {
+	// Sem chave: falha explícita em vez de gravar texto puro
+	disabled, err := secrets.NewCipher(secrets.Config{})
+	assert.NoError(t, err)
+	_, err = disabled.Encrypt("token")
+	assert.ErrorIs(t, err, secrets.ErrNoKey)
+
+	_, err = secrets.NewCipher(secrets.Config{Key: base64.StdEncoding.EncodeToString([]byte("short"))})
+	assert.ErrorIs(t, err, secrets.ErrInvalidKey)
+	_, err = secrets.NewCipher(secrets.Config{Key: "%%%"})
+	assert.ErrorIs(t, err, secrets.ErrInvalidKey)
+
+	configured, err := secrets.NewCipher(secrets.Config{Key: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))})
+	assert.NoError(t, err)
+	ciphertext, err := configured.Encrypt("token")
+	assert.NoError(t, err)
+	plaintext, err := configured.Decrypt(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "token", plaintext)
+}
@@ -0,0 +1,341 @@
+package sla_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/sla"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type slaFixture struct {
+	webhooks      whatsapp.WebhookService
+	conversations whatsapp.ConversationService
+	service       sla.SLAService
+	monitor       *sla.BreachMonitor
+	breaches      *[]sla.Breach
+	tenantID      uuid.UUID
+	agentID       uuid.UUID
+}
+
+func setupSLA(t *testing.T, calendar *businesshours.Calendar) slaFixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}, &whatsapp.Conversation{}, &customers.Customer{}, &sla.Policy{}, &sla.Timer{}))
+
+	tenantID := uuid.New()
+	phoneNumberID := "PHONE_ID"
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+	}
+	userRepo := &auth.MockUserRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*auth.User, error) {
+			return &auth.User{ID: uuid.MustParse(id), TenantID: tenantID}, nil
+		},
+	}
+	hoursService := &businesshours.MockBusinessHoursService{
+		GetCalendarFunc: func(_ context.Context, id uuid.UUID) (*businesshours.Calendar, error) {
+			if calendar == nil {
+				return nil, businesshours.ErrCalendarNotFound
+			}
+			return calendar, nil
+		},
+	}
+	breaches := []sla.Breach{}
+	notifier := &sla.MockNotifier{
+		NotifyBreachFunc: func(_ context.Context, breach sla.Breach) error {
+			breaches = append(breaches, breach)
+			return nil
+		},
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	customerRepo := customers.NewCustomerRepository(db, telemetryService)
+	conversationRepo := whatsapp.NewConversationRepository(db, telemetryService)
+	timerRepo := sla.NewTimerRepository(db, telemetryService)
+
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	webhooks := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	messages := whatsapp.NewMessageService(messageRepo, channels, whatsapp.NewFakeProvider(), telemetryService)
+	conversations := whatsapp.NewConversationService(conversationRepo, messageRepo, messages, customers.NewCustomerService(customerRepo, telemetryService), userRepo, telemetryService)
+	service := sla.NewSLAService(sla.NewPolicyRepository(db, telemetryService), timerRepo, conversationRepo, messageRepo, hoursService, notifier, telemetryService)
+	monitor := sla.NewBreachMonitor(timerRepo, conversationRepo, messageRepo, hoursService, notifier, telemetryService, time.Minute)
+	webhooks.Subscribe(conversations.HandleEvent)
+	webhooks.Subscribe(service.HandleEvent)
+
+	return slaFixture{
+		webhooks:      webhooks,
+		conversations: conversations,
+		service:       service,
+		monitor:       monitor,
+		breaches:      &breaches,
+		tenantID:      tenantID,
+		agentID:       uuid.New(),
+	}
+}
+
+func (f slaFixture) receive(t *testing.T, waMessageID, body string) *whatsapp.Conversation {
+	raw := fmt.Sprintf(`{"from":"5511988887777","id":%q,"timestamp":"%d","type":"text","text":{"body":%q}}`, waMessageID, time.Now().Unix(), body)
+	err := f.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: "PHONE_ID"},
+					Contacts: []whatsapp.WebhookContact{{WaID: "5511988887777"}},
+					Messages: []json.RawMessage{json.RawMessage(raw)},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+
+	items, _, err := f.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: f.tenantID, Limit: 1})
+	assert.NoError(t, err)
+	return &items[0]
+}
+
+func (f slaFixture) reply(t *testing.T, conversation *whatsapp.Conversation, userID uuid.UUID) {
+	_, err := f.conversations.SendMessage(context.Background(), f.tenantID, userID, conversation.ID.String(), whatsapp.SendMessageRequest{
+		Type: whatsapp.TypeText,
+		Text: &whatsapp.TextContent{Body: "Olá!"},
+	})
+	assert.NoError(t, err)
+}
+
+func timersByKind(timers []sla.Timer) map[sla.TimerKind][]sla.Timer {
+	byKind := map[sla.TimerKind][]sla.Timer{}
+	for _, timer := range timers {
+		byKind[timer.Kind] = append(byKind[timer.Kind], timer)
+	}
+	return byKind
+}
+
+func savePolicy(t *testing.T, f slaFixture, businessHours bool) {
+	_, err := f.service.SavePolicy(context.Background(), f.tenantID, sla.SavePolicyRequest{
+		FirstResponseMinutes: 10,
+		NextResponseMinutes:  5,
+		ResolutionMinutes:    60,
+		BusinessHoursOnly:    &businessHours,
+	})
+	assert.NoError(t, err)
+}
+
+func TestSLA_ResponseTimersAndBreach(t *testing.T) {
+	// Setup
+	f := setupSLA(t, nil)
+	savePolicy(t, f, false)
+
+	// Execute: cliente escreve, agente responde dentro do prazo
+	conversation := f.receive(t, "wamid.1", "Oi")
+	f.reply(t, conversation, f.agentID)
+
+	// Assertions: vencimento da primeira resposta não gera violação
+	breaches, err := f.monitor.RunOnce(context.Background(), time.Now().Add(11*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, breaches)
+
+	timers, err := f.service.ConversationTimers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	byKind := timersByKind(timers)
+	assert.Equal(t, sla.TimerMet, byKind[sla.KindFirstResponse][0].Status)
+	assert.NotNil(t, byKind[sla.KindFirstResponse][0].StoppedAt)
+	assert.Equal(t, sla.TimerRunning, byKind[sla.KindResolution][0].Status)
+
+	// Execute: nova pergunta sem resposta do agente
+	f.receive(t, "wamid.2", "E o pedido?")
+	f.receive(t, "wamid.3", "Alô?")
+
+	breaches, err = f.monitor.RunOnce(context.Background(), time.Now().Add(6*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, breaches)
+
+	// Assertions
+	timers, err = f.service.ConversationTimers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	byKind = timersByKind(timers)
+	assert.Len(t, byKind[sla.KindNextResponse], 1)
+	assert.Len(t, byKind[sla.KindResolution], 1)
+	next := byKind[sla.KindNextResponse][0]
+	assert.Equal(t, sla.TimerBreached, next.Status)
+	assert.True(t, next.Open())
+	assert.Equal(t, next.DueAt.Unix(), next.BreachedAt.Unix())
+
+	if assert.Len(t, *f.breaches, 1) {
+		assert.Equal(t, sla.KindNextResponse, (*f.breaches)[0].Timer.Kind)
+		assert.Equal(t, conversation.ID, (*f.breaches)[0].Conversation.ID)
+	}
+
+	// Não notifica de novo; a resposta atrasada encerra o timer como violado
+	breaches, err = f.monitor.RunOnce(context.Background(), time.Now().Add(7*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, breaches)
+	f.reply(t, conversation, f.agentID)
+
+	timers, err = f.service.ConversationTimers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	next = timersByKind(timers)[sla.KindNextResponse][0]
+	assert.Equal(t, sla.TimerBreached, next.Status)
+	assert.False(t, next.Open())
+
+	items, total, err := f.service.ListBreaches(context.Background(), f.tenantID, "", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, next.ID, items[0].ID)
+	assert.Len(t, *f.breaches, 1)
+}
+
+func TestSLA_BotRepliesDoNotCountAndResolutionStopsTimers(t *testing.T) {
+	// Setup
+	f := setupSLA(t, nil)
+	savePolicy(t, f, false)
+	conversation := f.receive(t, "wamid.1", "Oi")
+
+	// Execute: só o bot (uuid.Nil) respondeu; atendente resolve sem responder
+	f.reply(t, conversation, uuid.Nil)
+	_, err := f.conversations.UpdateStatus(context.Background(), f.tenantID, conversation.ID.String(), whatsapp.ConversationResolved)
+	assert.NoError(t, err)
+
+	// Assertions
+	timers, err := f.service.ConversationTimers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	byKind := timersByKind(timers)
+	assert.Equal(t, sla.TimerCancelled, byKind[sla.KindFirstResponse][0].Status)
+	assert.Equal(t, sla.TimerMet, byKind[sla.KindResolution][0].Status)
+
+	// Cliente volta a falar: novo ciclo começa com primeira resposta
+	time.Sleep(time.Second)
+	f.receive(t, "wamid.2", "Voltei")
+	timers, err = f.service.ConversationTimers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	byKind = timersByKind(timers)
+	assert.Len(t, byKind[sla.KindFirstResponse], 2)
+	assert.Len(t, byKind[sla.KindNextResponse], 0)
+	assert.Len(t, byKind[sla.KindResolution], 2)
+
+	breaches, err := f.monitor.RunOnce(context.Background(), time.Now().Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, breaches)
+}
+
+func TestSLA_DueDateFollowsBusinessHours(t *testing.T) {
+	// Setup: fechado hoje e amanhã; expediente nos demais dias das 09h às 18h
+	weekday := []businesshours.Interval{{Start: "09:00", End: "18:00"}}
+	calendar := &businesshours.Calendar{
+		Timezone: "America/Sao_Paulo",
+		Weekly: businesshours.WeeklySchedule{
+			"sunday": weekday, "monday": weekday, "tuesday": weekday, "wednesday": weekday,
+			"thursday": weekday, "friday": weekday, "saturday": weekday,
+		},
+	}
+	today := time.Now().In(calendar.Location())
+	for offset := -1; offset <= 1; offset++ {
+		calendar.SpecialDates = append(calendar.SpecialDates, businesshours.SpecialDate{
+			Date: today.AddDate(0, 0, offset).Format("2006-01-02"), Closed: true,
+		})
+	}
+	f := setupSLA(t, calendar)
+	savePolicy(t, f, true)
+
+	// Execute
+	conversation := f.receive(t, "wamid.1", "Oi")
+
+	// Assertions: o prazo só começa a contar no próximo expediente
+	timers, err := f.service.ConversationTimers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	first := timersByKind(timers)[sla.KindFirstResponse][0]
+	assert.True(t, first.BusinessHours)
+	assert.True(t, first.DueAt.Equal(calendar.AddBusinessTime(first.StartedAt, 10*time.Minute)))
+
+	opening, ok := calendar.NextOpening(first.StartedAt)
+	assert.True(t, ok)
+	assert.True(t, first.DueAt.Equal(opening.Add(10*time.Minute)))
+
+	breaches, err := f.monitor.RunOnce(context.Background(), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, breaches)
+}
+
+func TestSLA_PolicyManagement(t *testing.T) {
+	// Setup
+	f := setupSLA(t, nil)
+
+	// Sem política nenhum timer é criado
+	conversation := f.receive(t, "wamid.1", "Oi")
+	timers, err := f.service.ConversationTimers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	assert.Empty(t, timers)
+
+	_, err = f.service.GetPolicy(context.Background(), f.tenantID)
+	assert.ErrorIs(t, err, sla.ErrPolicyNotFound)
+
+	// Execute
+	disabled := false
+	policy, err := f.service.SavePolicy(context.Background(), f.tenantID, sla.SavePolicyRequest{FirstResponseMinutes: 15, Enabled: &disabled})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, policy.Enabled)
+	assert.True(t, policy.BusinessHoursOnly)
+	stored, err := f.service.GetPolicy(context.Background(), f.tenantID)
+	assert.NoError(t, err)
+	assert.False(t, stored.Enabled)
+
+	f.receive(t, "wamid.2", "Oi de novo")
+	timers, err = f.service.ConversationTimers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	assert.Empty(t, timers)
+
+	_, err = f.service.SavePolicy(context.Background(), f.tenantID, sla.SavePolicyRequest{FirstResponseMinutes: -1})
+	assert.ErrorIs(t, err, sla.ErrInvalidPolicy)
+	_, _, err = f.service.ListBreaches(context.Background(), f.tenantID, "lunch", 0, 10)
+	assert.ErrorIs(t, err, sla.ErrInvalidKind)
+	_, err = f.service.ConversationTimers(context.Background(), f.tenantID, "not-a-uuid")
+	assert.ErrorIs(t, err, whatsapp.ErrConversationNotFound)
+
+	assert.NoError(t, f.service.DeletePolicy(context.Background(), f.tenantID))
+	assert.ErrorIs(t, f.service.DeletePolicy(context.Background(), f.tenantID), sla.ErrPolicyNotFound)
+}
+
+func TestRealtimeNotifier_PublishesBreachToTenant(t *testing.T) {
+	// Setup
+	publisher := &realtime.MockPublisher{}
+	notifier := sla.NewRealtimeNotifier(publisher, telemetry.NewTelemetryService(false))
+	tenantID, agentID := uuid.New(), uuid.New()
+	timer := &sla.Timer{ID: uuid.New(), TenantID: tenantID, ConversationID: uuid.New(), Kind: sla.KindFirstResponse}
+	conversation := &whatsapp.Conversation{ID: timer.ConversationID, TenantID: tenantID, ContactPhone: "5511988887777", AssigneeID: &agentID}
+
+	// Execute
+	err := notifier.NotifyBreach(context.Background(), sla.Breach{Timer: timer, Conversation: conversation})
+
+	// Assertions: o tenant inteiro recebe, com o responsável e sem o telefone do contato
+	assert.NoError(t, err)
+	events := publisher.Events(realtime.EventSLABreached)
+	assert.Len(t, events, 1)
+	assert.Equal(t, tenantID, events[0].TenantID)
+	assert.Nil(t, events[0].UserID)
+
+	payload, err := json.Marshal(events[0].Data)
+	assert.NoError(t, err)
+	assert.Contains(t, string(payload), agentID.String())
+	assert.NotContains(t, string(payload), conversation.ContactPhone)
+}
@@ -0,0 +1,209 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 é um stand-in mínimo do S3 (path-style) em memória
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	s3 := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || r.Header.Get("X-Amz-Date") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		s3.mu.Lock()
+		defer s3.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			s3.objects[r.URL.Path] = body
+			s3.types[r.URL.Path] = r.Header.Get("Content-Type")
+		case http.MethodGet:
+			body, ok := s3.objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(body)
+		case http.MethodDelete:
+			delete(s3.objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return s3, server
+}
+
+func exerciseBlobStore(t *testing.T, store storage.BlobStore) {
+	ctx := context.Background()
+	content := []byte("%PDF-1.4 fake")
+
+	assert.NoError(t, store.Put(ctx, "tenant/2026/10/file.pdf", bytes.NewReader(content), int64(len(content)), "application/pdf"))
+
+	reader, err := store.Get(ctx, "tenant/2026/10/file.pdf")
+	assert.NoError(t, err)
+	stored, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, content, stored)
+
+	assert.NoError(t, store.Delete(ctx, "tenant/2026/10/file.pdf"))
+	_, err = store.Get(ctx, "tenant/2026/10/file.pdf")
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+
+	// Deleting a missing object is not an error
+	assert.NoError(t, store.Delete(ctx, "tenant/2026/10/file.pdf"))
+
+	for _, key := range []string{"", "/etc/passwd", "../escape", "a/../../b", "a//b"} {
+		assert.ErrorIs(t, store.Put(ctx, key, bytes.NewReader(nil), 0, ""), storage.ErrInvalidKey, key)
+	}
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	exerciseBlobStore(t, storage.NewLocalBlobStore(t.TempDir()))
+}
+
+func TestS3BlobStore(t *testing.T) {
+	// Setup
+	s3, server := newFakeS3(t)
+	store := storage.NewS3BlobStore(storage.S3Options{
+		Endpoint:  server.URL,
+		Bucket:    "media",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+	}, server.Client())
+
+	// Execute + Assertions
+	exerciseBlobStore(t, store)
+
+	assert.NoError(t, store.Put(context.Background(), "a/b.png", strings.NewReader("png"), 3, "image/png"))
+	assert.Equal(t, []byte("png"), s3.objects["/media/a/b.png"])
+	assert.Equal(t, "image/png", s3.types["/media/a/b.png"])
+}
+
+func TestS3BlobStore_CanonicalURI(t *testing.T) {
+	// Vetores da suíte do SigV4 (get-utf8, get-space, caracteres não reservados) e os
+	// caracteres que url.PathEscape deixaria sem codificar
+	cases := map[string]string{
+		"ሴ":                 "/media/%E1%88%B4",
+		"example space.txt": "/media/example%20space.txt",
+		"-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz": "/media/-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+		"tenant/a+b=c:d@e!f$g&h,i;j'k(l)m*n.pdf":                             "/media/tenant/a%2Bb%3Dc%3Ad%40e%21f%24g%26h%2Ci%3Bj%27k%28l%29m%2An.pdf",
+	}
+
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+	}))
+	defer server.Close()
+	store := storage.NewS3BlobStore(storage.S3Options{
+		Endpoint:  server.URL,
+		Bucket:    "media",
+		AccessKey: "AKIDEXAMPLE",
+		SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, server.Client())
+
+	for key, canonicalURI := range cases {
+		// Execute
+		err := store.Delete(context.Background(), key)
+
+		// Assertions: o caminho enviado é o canônico e a assinatura confere com ele
+		assert.NoError(t, err, key)
+		assert.Equal(t, canonicalURI, received.RequestURI, key)
+		assert.Equal(t, expectedSignature(received, canonicalURI, "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1"),
+			signatureOf(received.Header.Get("Authorization")), key)
+	}
+}
+
+// expectedSignature refaz o SigV4 do lado do servidor a partir do URI canônico esperado
+func expectedSignature(r *http.Request, canonicalURI, secret, region string) string {
+	amzDate := r.Header.Get("X-Amz-Date")
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		canonicalURI,
+		"",
+		"host:" + r.Host + "\n" +
+			"x-amz-content-sha256:" + r.Header.Get("X-Amz-Content-Sha256") + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		"host;x-amz-content-sha256;x-amz-date",
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	scope := amzDate[:8] + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + secret)
+	for _, part := range []string{amzDate[:8], region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	return hex.EncodeToString(key)
+}
+
+func signatureOf(authorization string) string {
+	return authorization[strings.Index(authorization, "Signature=")+len("Signature="):]
+}
+
+func TestS3BlobStore_ErrorResponse(t *testing.T) {
+	_, server := newFakeS3(t)
+	store := storage.NewS3BlobStore(storage.S3Options{Endpoint: server.URL, Bucket: "media", AccessKey: "WRONG"}, server.Client())
+
+	err := store.Put(context.Background(), "a/b.png", strings.NewReader("png"), 3, "image/png")
+
+	var s3Err *storage.S3Error
+	assert.ErrorAs(t, err, &s3Err)
+	assert.Equal(t, http.StatusForbidden, s3Err.StatusCode)
+}
+
+func TestURLSigner(t *testing.T) {
+	signer, err := storage.NewURLSigner("secret", "https://crm.example.com", time.Minute)
+	assert.NoError(t, err)
+	now := time.Now()
+
+	url, expiresAt := signer.SignedURL("/media/t/1", now)
+	assert.True(t, strings.HasPrefix(url, "https://crm.example.com/media/t/1?expires="))
+
+	query := url[strings.Index(url, "?")+1:]
+	params := map[string]string{}
+	for _, pair := range strings.Split(query, "&") {
+		parts := strings.SplitN(pair, "=", 2)
+		params[parts[0]] = parts[1]
+	}
+
+	assert.NoError(t, signer.Verify("/media/t/1", params["expires"], params["signature"], now))
+	assert.ErrorIs(t, signer.Verify("/media/t/2", params["expires"], params["signature"], now), storage.ErrInvalidSignature)
+	assert.ErrorIs(t, signer.Verify("/media/t/1", params["expires"], params["signature"], expiresAt.Add(time.Second)), storage.ErrURLExpired)
+	other, err := storage.NewURLSigner("other", "", time.Minute)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, other.Verify("/media/t/1", params["expires"], params["signature"], now), storage.ErrInvalidSignature)
+}
+
+func TestNewURLSigner_RequiresSecret(t *testing.T) {
+	_, err := storage.NewURLSigner("", "https://crm.example.com", time.Minute)
+	assert.ErrorIs(t, err, storage.ErrMissingURLSecret)
+}
@@ -0,0 +1,77 @@
+package tasks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurrenceRule_Next(t *testing.T) {
+	// Monday, 2025-03-03 09:00 UTC
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		rule     string
+		current  time.Time
+		expected time.Time
+	}{
+		{"daily", "FREQ=DAILY", start, start.AddDate(0, 0, 1)},
+		{"every 3 days", "FREQ=DAILY;INTERVAL=3", start, start.AddDate(0, 0, 3)},
+		{"weekly", "RRULE:FREQ=WEEKLY", start, start.AddDate(0, 0, 7)},
+		{"weekly by day same week", "FREQ=WEEKLY;BYDAY=MO,TH", start, start.AddDate(0, 0, 3)},
+		{"weekly by day wraps", "FREQ=WEEKLY;BYDAY=MO,TH", start.AddDate(0, 0, 3), start.AddDate(0, 0, 7)},
+		{"biweekly by day wraps", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", start, start.AddDate(0, 0, 14)},
+		{"monthly clamps end of month", "FREQ=MONTHLY", time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC)},
+		{"yearly", "FREQ=YEARLY", start, start.AddDate(1, 0, 0)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := tasks.ParseRecurrenceRule(tc.rule)
+			assert.NoError(t, err)
+
+			next, ok := rule.Next(tc.current, 1)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, next)
+		})
+	}
+}
+
+func TestRecurrenceRule_Limits(t *testing.T) {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	// COUNT=2 ends after the second occurrence
+	rule, err := tasks.ParseRecurrenceRule("FREQ=DAILY;COUNT=2")
+	assert.NoError(t, err)
+	_, ok := rule.Next(start, 1)
+	assert.True(t, ok)
+	_, ok = rule.Next(start, 2)
+	assert.False(t, ok)
+
+	// UNTIL is inclusive
+	rule, err = tasks.ParseRecurrenceRule("FREQ=DAILY;UNTIL=20250304T090000Z")
+	assert.NoError(t, err)
+	_, ok = rule.Next(start, 1)
+	assert.True(t, ok)
+	_, ok = rule.Next(start.AddDate(0, 0, 1), 2)
+	assert.False(t, ok)
+}
+
+func TestParseRecurrenceRule_Invalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250101",
+		"FREQ=DAILY;BYMONTH=1",
+	} {
+		_, err := tasks.ParseRecurrenceRule(value)
+		assert.ErrorIs(t, err, tasks.ErrInvalidRecurrence, value)
+	}
+}
@@ -0,0 +1,366 @@
+package tasks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tasks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupRepository(t *testing.T) tasks.TaskRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	err = db.AutoMigrate(&tasks.Task{})
+	assert.NoError(t, err)
+
+	return tasks.NewTaskRepository(db, telemetry.NewTelemetryService(false))
+}
+
+func TestTaskService_CompleteRecurringTask(t *testing.T) {
+	// Setup
+	repo := setupRepository(t)
+	service := tasks.NewTaskService(repo, &auth.MockUserRepository{}, telemetry.NewTelemetryService(false))
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	dueAt := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	remindAt := dueAt.Add(-30 * time.Minute)
+
+	task, err := service.CreateTask(context.Background(), tenantID, userID, tasks.CreateTaskRequest{
+		Title:          "Weekly follow-up",
+		DueAt:          dueAt,
+		RemindAt:       &remindAt,
+		RecurrenceRule: "FREQ=WEEKLY;COUNT=2",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tasks.PriorityMedium, task.Priority)
+	assert.Equal(t, userID, task.AssigneeID)
+
+	// Execute
+	completed, next, err := service.CompleteTask(context.Background(), tenantID, task.ID.String())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, tasks.StatusCompleted, completed.Status)
+	assert.NotNil(t, next)
+	assert.Equal(t, dueAt.AddDate(0, 0, 7), next.DueAt)
+	assert.Equal(t, remindAt.AddDate(0, 0, 7), *next.RemindAt)
+	assert.Equal(t, task.SeriesID, next.SeriesID)
+
+	// COUNT=2 reached, series ends
+	_, last, err := service.CompleteTask(context.Background(), tenantID, next.ID.String())
+	assert.NoError(t, err)
+	assert.Nil(t, last)
+}
+
+func TestTaskService_RejectsAssigneeFromAnotherTenant(t *testing.T) {
+	userRepo := &auth.MockUserRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*auth.User, error) {
+			return &auth.User{ID: uuid.MustParse(id), TenantID: uuid.New()}, nil
+		},
+	}
+	service := tasks.NewTaskService(setupRepository(t), userRepo, telemetry.NewTelemetryService(false))
+
+	_, err := service.CreateTask(context.Background(), uuid.New(), uuid.New(), tasks.CreateTaskRequest{
+		Title:      "Call back",
+		AssigneeID: uuid.New().String(),
+		DueAt:      time.Now().Add(time.Hour),
+	})
+
+	assert.ErrorIs(t, err, tasks.ErrInvalidAssignee)
+}
+
+func TestTaskService_StopsWhenRequestIsCanceled(t *testing.T) {
+	// Setup
+	service := tasks.NewTaskService(setupRepository(t), &auth.MockUserRepository{}, telemetry.NewTelemetryService(false))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute: o cliente desistiu da requisição antes da consulta
+	_, err := service.CreateTask(ctx, uuid.New(), uuid.New(), tasks.CreateTaskRequest{
+		Title: "Call back",
+		DueAt: time.Now().Add(time.Hour),
+	})
+
+	// Assertions
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTaskRepository_Overdue(t *testing.T) {
+	repo := setupRepository(t)
+	tenantID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	for _, dueAt := range []time.Time{now.Add(-time.Hour), now.Add(time.Hour)} {
+		assert.NoError(t, repo.Create(context.Background(), &tasks.Task{
+			TenantID:    tenantID,
+			AssigneeID:  userID,
+			CreatedByID: userID,
+			Title:       "Task",
+			Priority:    tasks.PriorityLow,
+			Status:      tasks.StatusOpen,
+			DueAt:       dueAt,
+		}))
+	}
+
+	mine, total, err := repo.ListByAssignee(context.Background(), tenantID, userID, false, now, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, mine, 2)
+
+	overdue, total, err := repo.ListByAssignee(context.Background(), tenantID, userID, true, now, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.True(t, overdue[0].IsOverdue(now))
+}
+
+func TestReminderScheduler_RunOnce(t *testing.T) {
+	// Setup
+	repo := setupRepository(t)
+	now := time.Now()
+	remindAt := now.Add(-time.Minute)
+
+	task := &tasks.Task{
+		TenantID:    uuid.New(),
+		AssigneeID:  uuid.New(),
+		CreatedByID: uuid.New(),
+		Title:       "Send proposal",
+		Priority:    tasks.PriorityHigh,
+		Status:      tasks.StatusOpen,
+		DueAt:       now.Add(time.Hour),
+		RemindAt:    &remindAt,
+	}
+	assert.NoError(t, repo.Create(context.Background(), task))
+
+	var notified []uuid.UUID
+	notifier := &tasks.MockNotifier{
+		NotifyReminderFunc: func(_ context.Context, task *tasks.Task) error {
+			notified = append(notified, task.ID)
+			return nil
+		},
+	}
+	scheduler := tasks.NewReminderScheduler(repo, notifier, telemetry.NewTelemetryService(false), time.Minute)
+
+	// Execute
+	sent, err := scheduler.RunOnce(context.Background(), now)
+	assert.NoError(t, err)
+
+	// Second run must not notify again
+	sentAgain, err := scheduler.RunOnce(context.Background(), now)
+	assert.NoError(t, err)
+
+	// Assertions
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 0, sentAgain)
+	assert.Equal(t, []uuid.UUID{task.ID}, notified)
+}
+
+func TestReminderScheduler_RetriesFailedNotification(t *testing.T) {
+	// Setup
+	repo := setupRepository(t)
+	now := time.Now()
+	remindAt := now.Add(-time.Minute)
+
+	task := &tasks.Task{
+		TenantID:    uuid.New(),
+		AssigneeID:  uuid.New(),
+		CreatedByID: uuid.New(),
+		Title:       "Send proposal",
+		Priority:    tasks.PriorityHigh,
+		Status:      tasks.StatusOpen,
+		DueAt:       now.Add(time.Hour),
+		RemindAt:    &remindAt,
+	}
+	assert.NoError(t, repo.Create(context.Background(), task))
+
+	attempts := 0
+	notifier := &tasks.MockNotifier{
+		NotifyReminderFunc: func(_ context.Context, task *tasks.Task) error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	}
+	scheduler := tasks.NewReminderScheduler(repo, notifier, telemetry.NewTelemetryService(false), time.Minute)
+
+	// Execute: a primeira entrega falha e a rodada seguinte tenta de novo
+	failed, err := scheduler.RunOnce(context.Background(), now)
+	assert.NoError(t, err)
+	sent, err := scheduler.RunOnce(context.Background(), now.Add(time.Minute))
+	assert.NoError(t, err)
+
+	// Assertions
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 2, attempts)
+	stored, err := repo.FindByID(context.Background(), task.TenantID, task.ID.String())
+	assert.NoError(t, err)
+	assert.NotNil(t, stored.ReminderNotifiedAt)
+	assert.Nil(t, stored.ReminderSentAt, "só a confirmação do responsável conta como entrega")
+}
+
+func TestRealtimeNotifier_DeliversToAssignee(t *testing.T) {
+	// Setup
+	publisher := &realtime.MockPublisher{}
+	notifier := tasks.NewRealtimeNotifier(publisher, telemetry.NewTelemetryService(false))
+	task := &tasks.Task{ID: uuid.New(), TenantID: uuid.New(), AssigneeID: uuid.New(), Title: "Send proposal"}
+
+	// Execute
+	err := notifier.NotifyReminder(context.Background(), task)
+
+	// Assertions
+	assert.NoError(t, err)
+	events := publisher.Events(realtime.EventTaskReminder)
+	assert.Len(t, events, 1)
+	assert.Equal(t, task.TenantID, events[0].TenantID)
+	assert.Equal(t, task.AssigneeID, *events[0].UserID)
+}
+
+func TestReminderScheduler_KeepsReminderPendingWhileAssigneeIsOffline(t *testing.T) {
+	// Setup: hub sem nenhuma conexão do responsável
+	repo := setupRepository(t)
+	telemetryService := telemetry.NewTelemetryService(false)
+	hub := realtime.NewHub(realtime.NewLocalBroker(), telemetryService, 8)
+	scheduler := tasks.NewReminderScheduler(repo, tasks.NewRealtimeNotifier(hub, telemetryService), telemetryService, time.Minute)
+	service := tasks.NewTaskService(repo, &auth.MockUserRepository{}, telemetryService)
+
+	tenantID := uuid.New()
+	assigneeID := uuid.New()
+	remindAt := time.Now().Add(-time.Minute)
+	task := &tasks.Task{
+		TenantID:    tenantID,
+		AssigneeID:  assigneeID,
+		CreatedByID: assigneeID,
+		Title:       "Send proposal",
+		Priority:    tasks.PriorityHigh,
+		Status:      tasks.StatusOpen,
+		DueAt:       time.Now().Add(time.Hour),
+		RemindAt:    &remindAt,
+	}
+	assert.NoError(t, repo.Create(context.Background(), task))
+
+	// Execute: o aviso em tempo real não chega a ninguém
+	notified, err := scheduler.RunOnce(context.Background(), time.Now())
+	assert.NoError(t, err)
+	again, err := scheduler.RunOnce(context.Background(), time.Now())
+	assert.NoError(t, err)
+
+	// Assertions: o lembrete fica pendente até o responsável buscar e confirmar
+	assert.Equal(t, 1, notified)
+	assert.Equal(t, 0, again)
+	stored, err := repo.FindByID(context.Background(), tenantID, task.ID.String())
+	assert.NoError(t, err)
+	assert.Nil(t, stored.ReminderSentAt)
+
+	pending, err := service.PendingReminders(context.Background(), tenantID, assigneeID)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	err = service.AcknowledgeReminder(context.Background(), tenantID, uuid.New(), task.ID.String())
+	assert.ErrorIs(t, err, tasks.ErrTaskNotFound, "só o responsável confirma")
+	assert.NoError(t, service.AcknowledgeReminder(context.Background(), tenantID, assigneeID, task.ID.String()))
+
+	pending, err = service.PendingReminders(context.Background(), tenantID, assigneeID)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	stored, err = repo.FindByID(context.Background(), tenantID, task.ID.String())
+	assert.NoError(t, err)
+	assert.NotNil(t, stored.ReminderSentAt)
+}
+
+func TestTaskRepository_CompleteOnlyOnce(t *testing.T) {
+	// Setup: dois pedidos leram a mesma task ainda aberta
+	repo := setupRepository(t)
+	seriesID := uuid.New()
+	task := &tasks.Task{
+		ID:             seriesID,
+		TenantID:       uuid.New(),
+		AssigneeID:     uuid.New(),
+		CreatedByID:    uuid.New(),
+		Title:          "Weekly follow-up",
+		Priority:       tasks.PriorityLow,
+		Status:         tasks.StatusOpen,
+		DueAt:          time.Now(),
+		RecurrenceRule: "FREQ=WEEKLY",
+		SeriesID:       &seriesID,
+		Occurrence:     1,
+	}
+	assert.NoError(t, repo.Create(context.Background(), task))
+	nextOf := func() *tasks.Task {
+		return &tasks.Task{
+			TenantID:    task.TenantID,
+			AssigneeID:  task.AssigneeID,
+			CreatedByID: task.CreatedByID,
+			Title:       task.Title,
+			Priority:    task.Priority,
+			Status:      tasks.StatusOpen,
+			DueAt:       task.DueAt.AddDate(0, 0, 7),
+			SeriesID:    &seriesID,
+			Occurrence:  2,
+		}
+	}
+
+	// Execute
+	first, err := repo.Complete(context.Background(), task, time.Now(), nextOf())
+	assert.NoError(t, err)
+	second, err := repo.Complete(context.Background(), task, time.Now(), nextOf())
+	assert.NoError(t, err)
+
+	// Assertions: só a primeira conclusão cria a próxima ocorrência
+	assert.True(t, first)
+	assert.False(t, second)
+	open, total, err := repo.ListByAssignee(context.Background(), task.TenantID, task.AssigneeID, false, time.Now(), 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 2, open[0].Occurrence)
+
+	// A mesma ocorrência não pode ser criada duas vezes
+	assert.Error(t, repo.Create(context.Background(), nextOf()))
+}
+
+func TestTaskService_CompleteRollsBackWhenNextOccurrenceFails(t *testing.T) {
+	// Setup: a próxima ocorrência já existe, então criá-la falha
+	repo := setupRepository(t)
+	service := tasks.NewTaskService(repo, &auth.MockUserRepository{}, telemetry.NewTelemetryService(false))
+	tenantID := uuid.New()
+	dueAt := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	task, err := service.CreateTask(context.Background(), tenantID, uuid.New(), tasks.CreateTaskRequest{
+		Title:          "Weekly follow-up",
+		DueAt:          dueAt,
+		RecurrenceRule: "FREQ=WEEKLY",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Create(context.Background(), &tasks.Task{
+		TenantID:    tenantID,
+		AssigneeID:  task.AssigneeID,
+		CreatedByID: task.CreatedByID,
+		Title:       task.Title,
+		Priority:    task.Priority,
+		Status:      tasks.StatusOpen,
+		DueAt:       dueAt.AddDate(0, 0, 7),
+		SeriesID:    task.SeriesID,
+		Occurrence:  2,
+	}))
+
+	// Execute
+	_, _, err = service.CompleteTask(context.Background(), tenantID, task.ID.String())
+
+	// Assertions: a task continua aberta em vez de encerrar a série em silêncio
+	assert.Error(t, err)
+	stored, err := repo.FindByID(context.Background(), tenantID, task.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, tasks.StatusOpen, stored.Status)
+}
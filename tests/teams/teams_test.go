@@ -0,0 +1,289 @@
+package teams_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/realtime"
+	"github.com/claudineijrdev/sib-crm-backend/internal/teams"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type teamsFixture struct {
+	webhooks      whatsapp.WebhookService
+	conversations whatsapp.ConversationService
+	messageRepo   whatsapp.MessageRepository
+	service       teams.TeamService
+	distributor   *teams.Distributor
+	publisher     *realtime.MockPublisher
+	tenantID      uuid.UUID
+	// defaultTeamID é o time padrão do canal que recebe as mensagens
+	defaultTeamID *uuid.UUID
+}
+
+func setupTeams(t *testing.T) *teamsFixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}, &whatsapp.Conversation{}, &customers.Customer{},
+		&teams.Team{}, &teams.TeamMember{}, &teams.Presence{}, &teams.Transfer{}))
+
+	f := &teamsFixture{tenantID: uuid.New(), publisher: &realtime.MockPublisher{}}
+	channelRepo := &whatsapp.MockChannelRepository{
+		FindByPhoneNumberIDFunc: func(_ context.Context, phoneNumberID string) (*whatsapp.Channel, error) {
+			return &whatsapp.Channel{ID: uuid.New(), TenantID: f.tenantID, PhoneNumberID: phoneNumberID, DefaultTeamID: f.defaultTeamID}, nil
+		},
+	}
+	userRepo := &auth.MockUserRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*auth.User, error) {
+			return &auth.User{ID: uuid.MustParse(id), TenantID: f.tenantID}, nil
+		},
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	customerRepo := customers.NewCustomerRepository(db, telemetryService)
+	conversationRepo := whatsapp.NewConversationRepository(db, telemetryService)
+	teamRepo := teams.NewTeamRepository(db, telemetryService)
+	presenceRepo := teams.NewPresenceRepository(db, telemetryService)
+	transferRepo := teams.NewTransferRepository(db, telemetryService)
+
+	channels := whatsapp.NewChannelService(channelRepo, &tenants.MockTenantRepository{}, nil, nil, nil, nil, telemetryService)
+	f.webhooks = whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	messages := whatsapp.NewMessageService(messageRepo, channels, whatsapp.NewFakeProvider(), telemetryService)
+	f.conversations = whatsapp.NewConversationService(conversationRepo, messageRepo, messages, customers.NewCustomerService(customerRepo, telemetryService), userRepo, telemetryService)
+	f.messageRepo = messageRepo
+	f.service = teams.NewTeamService(teamRepo, presenceRepo, transferRepo, conversationRepo, nil, f.publisher, userRepo, telemetryService)
+	f.distributor = teams.NewDistributor(teamRepo, presenceRepo, transferRepo, conversationRepo, nil, f.publisher, telemetryService, time.Minute)
+	router := teams.NewRouter(teamRepo, presenceRepo, transferRepo, conversationRepo, nil, f.publisher, telemetryService)
+	f.webhooks.Subscribe(f.conversations.HandleEvent)
+	f.webhooks.Subscribe(router.HandleEvent)
+
+	return f
+}
+
+// team cria o time com os membros na ordem informada e o torna o padrão do canal
+func (f *teamsFixture) team(t *testing.T, distribution teams.Distribution, maxActive int, members ...uuid.UUID) *teams.Team {
+	team, err := f.service.CreateTeam(context.Background(), f.tenantID, teams.SaveTeamRequest{Name: "Suporte", Distribution: distribution, MaxActivePerAgent: maxActive})
+	assert.NoError(t, err)
+	for _, userID := range members {
+		_, err := f.service.AddMember(context.Background(), f.tenantID, team.ID.String(), userID)
+		assert.NoError(t, err)
+	}
+	f.defaultTeamID = &team.ID
+	return team
+}
+
+func (f *teamsFixture) online(t *testing.T, userIDs ...uuid.UUID) {
+	for _, userID := range userIDs {
+		_, err := f.service.SetPresence(context.Background(), f.tenantID, userID, teams.PresenceOnline)
+		assert.NoError(t, err)
+	}
+}
+
+// receive simula uma mensagem de um contato novo e devolve a conversa resultante
+func (f *teamsFixture) receive(t *testing.T, n int) *whatsapp.Conversation {
+	waMessageID := fmt.Sprintf("wamid.%d", n)
+	from := fmt.Sprintf("55119888800%02d", n)
+	raw := fmt.Sprintf(`{"from":%q,"id":%q,"timestamp":"%d","type":"text","text":{"body":"Oi"}}`, from, waMessageID, time.Now().Unix())
+	err := f.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: "PHONE_ID"},
+					Contacts: []whatsapp.WebhookContact{{WaID: from}},
+					Messages: []json.RawMessage{json.RawMessage(raw)},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+
+	return f.reload(t, f.conversationOf(t, waMessageID))
+}
+
+func (f *teamsFixture) conversationOf(t *testing.T, waMessageID string) uuid.UUID {
+	message, err := f.messageRepo.FindByWAMessageID(context.Background(), waMessageID)
+	assert.NoError(t, err)
+	return *message.ConversationID
+}
+
+func (f *teamsFixture) reload(t *testing.T, id uuid.UUID) *whatsapp.Conversation {
+	conversation, err := f.conversations.GetConversation(context.Background(), f.tenantID, id.String())
+	assert.NoError(t, err)
+	return conversation
+}
+
+func TestTeams_RoundRobinSkipsOfflineAgents(t *testing.T) {
+	// Setup
+	f := setupTeams(t)
+	ana, bruno, carla := uuid.New(), uuid.New(), uuid.New()
+	team := f.team(t, teams.DistributionRoundRobin, 0, ana, bruno, carla)
+	f.online(t, ana, carla)
+
+	// Execute
+	var assignees []uuid.UUID
+	for i := 1; i <= 3; i++ {
+		conversation := f.receive(t, i)
+		assert.Equal(t, team.ID, *conversation.TeamID)
+		assignees = append(assignees, *conversation.AssigneeID)
+	}
+
+	// Assertions: Bruno está offline e fica fora do rodízio
+	assert.Equal(t, []uuid.UUID{ana, carla, ana}, assignees)
+
+	transfers, err := f.service.ListTransfers(context.Background(), f.tenantID, f.conversationOf(t, "wamid.1").String())
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 1)
+	assert.True(t, transfers[0].Automatic)
+	assert.Equal(t, ana, *transfers[0].ToUserID)
+
+	// Cada atribuição chega aos navegadores do tenant
+	assert.Len(t, f.publisher.Events(realtime.EventConversationAssigned), 3)
+	assert.Len(t, f.publisher.Events(realtime.EventPresenceChanged), 2)
+}
+
+func TestTeams_LeastBusy(t *testing.T) {
+	// Setup
+	f := setupTeams(t)
+	ana, bruno := uuid.New(), uuid.New()
+	f.team(t, teams.DistributionLeastBusy, 0, ana, bruno)
+	f.online(t, ana)
+	f.receive(t, 1)
+	f.receive(t, 2)
+	f.online(t, bruno)
+
+	// Execute
+	third := f.receive(t, 3)
+	fourth := f.receive(t, 4)
+	fifth := f.receive(t, 5)
+
+	// Assertions: Bruno chega sem conversas e recebe até empatar
+	assert.Equal(t, bruno, *third.AssigneeID)
+	assert.Equal(t, bruno, *fourth.AssigneeID)
+	assert.Equal(t, ana, *fifth.AssigneeID)
+}
+
+func TestTeams_QueueWaitsForAgent(t *testing.T) {
+	// Setup
+	f := setupTeams(t)
+	ana := uuid.New()
+	team := f.team(t, teams.DistributionRoundRobin, 1, ana)
+
+	// Execute: ninguém online, as conversas aguardam na fila
+	first := f.receive(t, 1)
+	second := f.receive(t, 2)
+	assert.Nil(t, first.AssigneeID)
+	assert.Nil(t, second.AssigneeID)
+
+	queued, err := f.service.Queue(context.Background(), f.tenantID, team.ID.String(), 50)
+	assert.NoError(t, err)
+	assert.Len(t, queued, 2)
+
+	// Ao ficar online Ana recebe a primeira da fila; o limite de 1 segura a segunda
+	f.online(t, ana)
+	assert.Equal(t, ana, *f.reload(t, first.ID).AssigneeID)
+	assert.Nil(t, f.reload(t, second.ID).AssigneeID)
+
+	// Resolvida a primeira, o distribuidor entrega a segunda
+	_, err = f.conversations.UpdateStatus(context.Background(), f.tenantID, first.ID.String(), whatsapp.ConversationResolved)
+	assert.NoError(t, err)
+	assigned, err := f.distributor.RunOnce(context.Background(), time.Now())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, assigned)
+	assert.Equal(t, ana, *f.reload(t, second.ID).AssigneeID)
+
+	queued, err = f.service.Queue(context.Background(), f.tenantID, team.ID.String(), 50)
+	assert.NoError(t, err)
+	assert.Empty(t, queued)
+}
+
+func TestTeams_PresenceExpiresWithoutHeartbeat(t *testing.T) {
+	// Setup
+	presence := &teams.Presence{Status: teams.PresenceOnline, LastSeenAt: time.Now().Add(-teams.PresenceTimeout - time.Second)}
+
+	// Assertions
+	assert.Equal(t, teams.PresenceOffline, presence.Effective(time.Now()))
+	assert.Equal(t, teams.PresenceOffline, (*teams.Presence)(nil).Effective(time.Now()))
+
+	presence.LastSeenAt = time.Now()
+	assert.Equal(t, teams.PresenceOnline, presence.Effective(time.Now()))
+}
+
+func TestTeams_Transfer(t *testing.T) {
+	// Setup
+	f := setupTeams(t)
+	ana, bruno, carla := uuid.New(), uuid.New(), uuid.New()
+	sales := f.team(t, teams.DistributionRoundRobin, 0, carla)
+	support := f.team(t, teams.DistributionRoundRobin, 0, ana, bruno)
+	f.online(t, ana, carla)
+	conversation := f.receive(t, 1)
+	assert.Equal(t, ana, *conversation.AssigneeID)
+	supervisor := uuid.New()
+	brunoID, salesID, supportID := bruno.String(), sales.ID.String(), support.ID.String()
+
+	// Execute: para um colega do time
+	transferred, err := f.service.Transfer(context.Background(), f.tenantID, supervisor, conversation.ID.String(), teams.TransferRequest{ToUserID: &brunoID, Reason: "Cliente pediu o Bruno"})
+	assert.NoError(t, err)
+	assert.Equal(t, bruno, *transferred.AssigneeID)
+
+	// Para outro time: volta à fila e é distribuída na hora
+	transferred, err = f.service.Transfer(context.Background(), f.tenantID, supervisor, conversation.ID.String(), teams.TransferRequest{ToTeamID: &salesID})
+	assert.NoError(t, err)
+
+	// Assertions
+	assert.Equal(t, sales.ID, *transferred.TeamID)
+	assert.Equal(t, carla, *f.reload(t, conversation.ID).AssigneeID)
+
+	transfers, err := f.service.ListTransfers(context.Background(), f.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 4)
+	assert.False(t, transfers[1].Automatic)
+	assert.Equal(t, ana, *transfers[1].FromUserID)
+	assert.Equal(t, "Cliente pediu o Bruno", transfers[1].Reason)
+	assert.Equal(t, supervisor, *transfers[1].TransferredByID)
+	assert.Equal(t, support.ID, *transfers[2].FromTeamID)
+	assert.Nil(t, transfers[2].ToUserID)
+	assert.True(t, transfers[3].Automatic)
+
+	_, err = f.service.Transfer(context.Background(), f.tenantID, supervisor, conversation.ID.String(), teams.TransferRequest{})
+	assert.ErrorIs(t, err, teams.ErrInvalidTransfer)
+	_, err = f.service.Transfer(context.Background(), f.tenantID, supervisor, conversation.ID.String(), teams.TransferRequest{ToTeamID: &supportID, ToUserID: &brunoID})
+	assert.NoError(t, err)
+	_, err = f.service.Transfer(context.Background(), f.tenantID, supervisor, conversation.ID.String(), teams.TransferRequest{ToUserID: &brunoID})
+	assert.ErrorIs(t, err, teams.ErrTransferNoChange)
+	_, err = f.service.Transfer(context.Background(), f.tenantID, supervisor, conversation.ID.String(), teams.TransferRequest{ToTeamID: &salesID, ToUserID: &brunoID})
+	assert.ErrorIs(t, err, teams.ErrNotTeamMember)
+}
+
+func TestTeams_Validation(t *testing.T) {
+	// Setup
+	f := setupTeams(t)
+
+	// Execute
+	_, err := f.service.CreateTeam(context.Background(), f.tenantID, teams.SaveTeamRequest{Name: "Vendas", Distribution: "random"})
+	assert.ErrorIs(t, err, teams.ErrInvalidTeam)
+
+	team, err := f.service.CreateTeam(context.Background(), f.tenantID, teams.SaveTeamRequest{Name: "Vendas"})
+	assert.NoError(t, err)
+	_, err = f.service.SetPresence(context.Background(), f.tenantID, uuid.New(), "busy")
+
+	// Assertions
+	assert.Equal(t, teams.DistributionRoundRobin, team.Distribution)
+	assert.ErrorIs(t, err, teams.ErrInvalidPresence)
+	_, err = f.service.GetTeam(context.Background(), uuid.New(), team.ID.String())
+	assert.ErrorIs(t, err, teams.ErrTeamNotFound)
+}
@@ -0,0 +1,247 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/middleware"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type fixture struct {
+	service telemetry.TelemetryService
+	spans   *tracetest.SpanRecorder
+	metrics *sdkmetric.ManualReader
+}
+
+func newFixture() *fixture {
+	spans := tracetest.NewSpanRecorder()
+	metrics := sdkmetric.NewManualReader()
+	return &fixture{
+		service: telemetry.NewOpenTelemetryService(
+			sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+			sdkmetric.NewMeterProvider(sdkmetric.WithReader(metrics)),
+		),
+		spans:   spans,
+		metrics: metrics,
+	}
+}
+
+func (f *fixture) metric(t *testing.T, name string) metricdata.Aggregation {
+	var data metricdata.ResourceMetrics
+	require.NoError(t, f.metrics.Collect(context.Background(), &data))
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+	t.Fatalf("metric %s not collected", name)
+	return nil
+}
+
+func TestOpenTelemetry_Spans(t *testing.T) {
+	// Setup
+	f := newFixture()
+
+	// Execute
+	parent, ctx := f.service.StartSpan(context.Background(), "service.user.register")
+	child, _ := f.service.StartSpan(ctx, "repository.user.create")
+	child.SetTag("email", "ana@example.com")
+	child.SetError(errors.New("duplicate email"))
+	child.SetError(nil)
+	child.End()
+	parent.End()
+
+	// Assertions
+	ended := f.spans.Ended()
+	require.Len(t, ended, 2)
+	recorded, root := ended[0], ended[1]
+	assert.Equal(t, "repository.user.create", recorded.Name())
+	assert.Equal(t, root.SpanContext().SpanID(), recorded.Parent().SpanID())
+	assert.Equal(t, root.SpanContext().TraceID(), recorded.SpanContext().TraceID())
+	assert.Contains(t, recorded.Attributes(), attribute.String("email", "ana@example.com"))
+	assert.Equal(t, codes.Error, recorded.Status().Code)
+	assert.Equal(t, "duplicate email", recorded.Status().Description)
+	require.Len(t, recorded.Events(), 1)
+	assert.Equal(t, "exception", recorded.Events()[0].Name)
+}
+
+func TestOpenTelemetry_Events(t *testing.T) {
+	// Setup
+	f := newFixture()
+	span, ctx := f.service.StartSpan(context.Background(), "http.request")
+
+	// Execute
+	f.service.TrackEvent(ctx, telemetry.Event{
+		Name:       "auth.login.success",
+		Properties: map[string]interface{}{"user_id": "42", "attempts": 2},
+	})
+	span.End()
+	// fora de um span o evento ganha um span próprio
+	f.service.TrackEvent(context.Background(), telemetry.Event{Name: "tasks.reminder"})
+
+	// Assertions
+	ended := f.spans.Ended()
+	require.Len(t, ended, 2)
+	require.Len(t, ended[0].Events(), 1)
+	event := ended[0].Events()[0]
+	assert.Equal(t, "auth.login.success", event.Name)
+	assert.Contains(t, event.Attributes, attribute.String("user_id", "42"))
+	assert.Contains(t, event.Attributes, attribute.Int("attempts", 2))
+	assert.Equal(t, "tasks.reminder", ended[1].Name())
+	assert.Equal(t, "tasks.reminder", ended[1].Events()[0].Name)
+}
+
+func TestOpenTelemetry_Metrics(t *testing.T) {
+	// Setup
+	f := newFixture()
+	ctx := context.Background()
+	tags := map[string]string{"backend": "memory"}
+
+	// Execute
+	f.service.TrackMetric(ctx, telemetry.Metric{Name: "cache.hit", Value: 1, Tags: tags})
+	f.service.TrackMetric(ctx, telemetry.Metric{Name: "cache.hit", Value: 2, Tags: tags})
+	f.service.TrackMetric(ctx, telemetry.Metric{Name: "http.request.duration", Value: 12, Kind: telemetry.MetricHistogram})
+	f.service.TrackMetric(ctx, telemetry.Metric{Name: "http.request.duration", Value: 30, Kind: telemetry.MetricHistogram})
+	f.service.TrackMetric(ctx, telemetry.Metric{Name: "cache.size", Value: 10, Kind: telemetry.MetricGauge})
+	f.service.TrackMetric(ctx, telemetry.Metric{Name: "cache.size", Value: 7, Kind: telemetry.MetricGauge})
+
+	// Assertions
+	counter := f.metric(t, "cache.hit").(metricdata.Sum[float64])
+	require.Len(t, counter.DataPoints, 1)
+	assert.True(t, counter.IsMonotonic)
+	assert.Equal(t, 3.0, counter.DataPoints[0].Value)
+	backend, _ := counter.DataPoints[0].Attributes.Value("backend")
+	assert.Equal(t, "memory", backend.AsString())
+
+	histogram := f.metric(t, "http.request.duration").(metricdata.Histogram[float64])
+	assert.Equal(t, uint64(2), histogram.DataPoints[0].Count)
+	assert.Equal(t, 42.0, histogram.DataPoints[0].Sum)
+
+	gauge := f.metric(t, "cache.size").(metricdata.Gauge[float64])
+	assert.Equal(t, 7.0, gauge.DataPoints[0].Value)
+}
+
+func TestOpenTelemetry_MetricLabelPolicy(t *testing.T) {
+	// Setup
+	f := newFixture()
+	ctx := context.Background()
+
+	// Execute: identificadores somem e os valores distintos são limitados
+	for i := 0; i < 150; i++ {
+		f.service.TrackMetric(ctx, telemetry.Metric{
+			Name:  "auth.login.attempt",
+			Value: 1,
+			Tags: map[string]string{
+				"email":     fmt.Sprintf("user%d@example.com", i),
+				"tenant_id": fmt.Sprintf("tenant-%d", i),
+				"reason":    fmt.Sprintf("reason-%d", i),
+			},
+		})
+	}
+
+	// Assertions
+	counter := f.metric(t, "auth.login.attempt").(metricdata.Sum[float64])
+	assert.Len(t, counter.DataPoints, 101)
+	for _, point := range counter.DataPoints {
+		assert.Equal(t, 1, point.Attributes.Len())
+		_, hasEmail := point.Attributes.Value("email")
+		assert.False(t, hasEmail)
+		if reason, _ := point.Attributes.Value("reason"); reason.AsString() == "other" {
+			assert.Equal(t, 50.0, point.Value)
+		}
+	}
+}
+
+func TestTelemetryMiddleware_RecordsRequestSpan(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	f := newFixture()
+	router := gin.New()
+	router.Use(middleware.TelemetryMiddleware(f.service))
+	router.GET("/api/tasks/:id", func(c *gin.Context) {
+		span, _ := f.service.StartSpan(c.Request.Context(), "service.task.get")
+		span.End()
+		c.Status(http.StatusNoContent)
+	})
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/123", nil))
+
+	// Assertions
+	ended := f.spans.Ended()
+	require.Len(t, ended, 2)
+	request := ended[1]
+	assert.Equal(t, "http.request", request.Name())
+	assert.Equal(t, request.SpanContext().SpanID(), ended[0].Parent().SpanID())
+	assert.Contains(t, request.Attributes(), attribute.String("http.status_code", "204"))
+
+	histogram := f.metric(t, "http.request.duration").(metricdata.Histogram[float64])
+	path, _ := histogram.DataPoints[0].Attributes.Value("path")
+	assert.Equal(t, "/api/tasks/:id", path.AsString())
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{Exporter: telemetry.ExporterNone})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = telemetry.Setup(context.Background(), telemetry.Config{Exporter: "zipkin"})
+	assert.Error(t, err)
+
+	// desabilitado continua no-op, como nos testes dos outros pacotes
+	span, ctx := telemetry.NewTelemetryService(false).StartSpan(context.Background(), "noop")
+	span.SetError(errors.New("ignored"))
+	span.End()
+	assert.NotNil(t, ctx)
+}
+
+func TestSetup_OTLPExport(t *testing.T) {
+	// Setup: um coletor OTLP/HTTP falso
+	var mu sync.Mutex
+	received := map[string]int{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+
+	config := telemetry.LoadConfig()
+	config.Exporter = telemetry.ExporterOTLP
+	shutdown, err := telemetry.Setup(context.Background(), config)
+	require.NoError(t, err)
+
+	// Execute
+	service := telemetry.NewTelemetryService(true)
+	span, ctx := service.StartSpan(context.Background(), "repository.user.create")
+	service.TrackMetric(ctx, telemetry.Metric{Name: "repository.user.create.success", Value: 1})
+	span.End()
+	err = shutdown(context.Background())
+
+	// Assertions: o shutdown descarrega o que estava pendente
+	assert.NoError(t, err)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, received["/v1/traces"])
+	assert.Equal(t, 1, received["/v1/metrics"])
+}
@@ -0,0 +1,156 @@
+package telemetry_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheus_CountersAndHistograms(t *testing.T) {
+	// Setup
+	f := newFixture()
+	registry := prometheus.NewRegistry()
+	service := telemetry.NewPrometheusService(f.service, registry)
+	ctx := context.Background()
+
+	// Execute
+	service.TrackMetric(ctx, telemetry.Metric{Name: "cache.hit", Value: 1, Tags: map[string]string{"backend": "memory"}})
+	service.TrackMetric(ctx, telemetry.Metric{Name: "cache.hit", Value: 1, Tags: map[string]string{"backend": "memory"}})
+	service.TrackMetric(ctx, telemetry.Metric{Name: "cache.hit", Value: 1, Tags: map[string]string{"backend": "redis"}})
+	service.TrackMetric(ctx, telemetry.Metric{
+		Name:  "http.request.duration",
+		Value: 42,
+		Tags:  map[string]string{"method": "GET", "path": "/api/tasks/:id", "status": "200"},
+		Kind:  telemetry.MetricHistogram,
+	})
+	service.TrackMetric(ctx, telemetry.Metric{Name: "realtime.connections", Value: 3, Kind: telemetry.MetricGauge})
+
+	// Assertions
+	expected := `
+# HELP cache_hit_total cache.hit
+# TYPE cache_hit_total counter
+cache_hit_total{backend="memory"} 2
+cache_hit_total{backend="redis"} 1
+# HELP realtime_connections realtime.connections
+# TYPE realtime_connections gauge
+realtime_connections 3
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "cache_hit_total", "realtime_connections"))
+
+	count, err := testutil.GatherAndCount(registry, "http_request_duration")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// o serviço de baixo continua recebendo as métricas
+	counter := f.metric(t, "cache.hit")
+	assert.NotNil(t, counter)
+}
+
+func TestPrometheus_DropsHighCardinalityLabels(t *testing.T) {
+	// Setup
+	registry := prometheus.NewRegistry()
+	service := telemetry.NewPrometheusService(telemetry.NewTelemetryService(false), registry)
+	ctx := context.Background()
+
+	// Execute
+	service.TrackMetric(ctx, telemetry.Metric{Name: "auth.login.failed", Value: 1, Tags: map[string]string{"email": "ana@example.com"}})
+	service.TrackMetric(ctx, telemetry.Metric{Name: "auth.login.failed", Value: 1, Tags: map[string]string{"email": "bia@example.com"}})
+	service.TrackMetric(ctx, telemetry.Metric{Name: "teams.assigned", Value: 1, Tags: map[string]string{"team_id": "t1", "reason": "round_robin"}})
+	// uma tag que não existia na primeira chamada não vira label
+	service.TrackMetric(ctx, telemetry.Metric{Name: "teams.assigned", Value: 1, Tags: map[string]string{"reason": "round_robin", "extra": "x"}})
+
+	// Assertions
+	expected := `
+# HELP auth_login_failed_total auth.login.failed
+# TYPE auth_login_failed_total counter
+auth_login_failed_total 2
+# HELP teams_assigned_total teams.assigned
+# TYPE teams_assigned_total counter
+teams_assigned_total{reason="round_robin"} 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "auth_login_failed_total", "teams_assigned_total"))
+}
+
+func TestPrometheus_BoundsLabelValues(t *testing.T) {
+	// Setup
+	registry := prometheus.NewRegistry()
+	service := telemetry.NewPrometheusService(telemetry.NewTelemetryService(false), registry)
+	ctx := context.Background()
+
+	// Execute
+	for i := 0; i < 150; i++ {
+		service.TrackMetric(ctx, telemetry.Metric{Name: "whatsapp.message.sent", Value: 1, Tags: map[string]string{"type": fmt.Sprintf("type-%d", i)}})
+	}
+
+	// Assertions: 100 valores distintos mais o "other"
+	count, err := testutil.GatherAndCount(registry, "whatsapp_message_sent_total")
+	require.NoError(t, err)
+	assert.Equal(t, 101, count)
+}
+
+func TestPrometheus_KindMismatch(t *testing.T) {
+	// Setup
+	service := telemetry.NewPrometheusService(telemetry.NewTelemetryService(false), prometheus.NewRegistry())
+	ctx := context.Background()
+
+	// Execute
+	first := service.TrackMetric(ctx, telemetry.Metric{Name: "cache.size", Value: 1, Kind: telemetry.MetricGauge})
+	second := service.TrackMetric(ctx, telemetry.Metric{Name: "cache.size", Value: 1})
+
+	// Assertions
+	assert.NoError(t, first)
+	assert.Error(t, second)
+}
+
+func TestAdminServer_ServesMetrics(t *testing.T) {
+	// Setup
+	registry := telemetry.NewRegistry()
+	service := telemetry.NewPrometheusService(telemetry.NewTelemetryService(false), registry)
+	service.TrackMetric(context.Background(), telemetry.Metric{Name: "cache.miss", Value: 1, Tags: map[string]string{"backend": "memory"}})
+	server := telemetry.NewAdminServer("127.0.0.1:0", registry)
+	require.NoError(t, server.Start(context.Background()))
+	defer server.Stop()
+
+	// Execute
+	resp, err := http.Get("http://" + server.Addr() + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `cache_miss_total{backend="memory"} 1`)
+	assert.Contains(t, string(body), "go_goroutines")
+}
+
+func TestAdminServer_Disabled(t *testing.T) {
+	server := telemetry.NewAdminServer("", telemetry.NewRegistry())
+	assert.NoError(t, server.Start(context.Background()))
+	assert.Empty(t, server.Addr())
+	server.Stop()
+}
+
+func TestLoadConfig_MetricsOnLoopbackByDefault(t *testing.T) {
+	// Setup: t.Setenv restaura a variável ao fim do teste
+	t.Setenv("METRICS_ADDR", "")
+	os.Unsetenv("METRICS_ADDR")
+
+	// Execute / Assertions
+	assert.Equal(t, "127.0.0.1:9090", telemetry.LoadConfig().MetricsAddr)
+
+	t.Setenv("METRICS_ADDR", ":9090")
+	assert.Equal(t, ":9090", telemetry.LoadConfig().MetricsAddr)
+
+	t.Setenv("METRICS_ADDR", "")
+	assert.Empty(t, telemetry.LoadConfig().MetricsAddr, "vazio desliga o /metrics")
+}
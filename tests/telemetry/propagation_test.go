@@ -0,0 +1,194 @@
+package telemetry_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/middleware"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/web"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingParent  = "00f067aa0ba902b7"
+	traceparent     = "00-" + incomingTraceID + "-" + incomingParent + "-01"
+)
+
+func newTracedRouter(f *fixture) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.TelemetryMiddleware(f.service))
+	router.GET("/api/tasks/:id", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, web.ErrorBody(c, "task not found"))
+	})
+	return router
+}
+
+func TestTelemetryMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	// Setup
+	f := newFixture()
+	router := newTracedRouter(f)
+	req := httptest.NewRequest(http.MethodGet, "/api/tasks/123", nil)
+	req.Header.Set("traceparent", traceparent)
+	req.Header.Set(telemetry.RequestIDHeader, "req-abc")
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	ended := f.spans.Ended()
+	require.Len(t, ended, 1)
+	assert.Equal(t, incomingTraceID, ended[0].SpanContext().TraceID().String())
+	assert.Equal(t, incomingParent, ended[0].Parent().SpanID().String())
+	assert.True(t, ended[0].Parent().IsRemote())
+
+	assert.Equal(t, "req-abc", w.Header().Get(telemetry.RequestIDHeader))
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "task not found", body["error"])
+	assert.Equal(t, "req-abc", body["request_id"])
+	assert.Equal(t, incomingTraceID, body["trace_id"])
+}
+
+func TestTelemetryMiddleware_GeneratesRequestID(t *testing.T) {
+	// Setup
+	f := newFixture()
+	router := newTracedRouter(f)
+
+	for _, incoming := range []string{"", "com espaço", strings.Repeat("x", 200)} {
+		req := httptest.NewRequest(http.MethodGet, "/api/tasks/123", nil)
+		if incoming != "" {
+			req.Header.Set(telemetry.RequestIDHeader, incoming)
+		}
+
+		// Execute
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions: um novo ID e um trace novo, sem pai
+		id := w.Header().Get(telemetry.RequestIDHeader)
+		assert.Len(t, id, 36, incoming)
+		assert.NotEqual(t, incoming, id)
+	}
+	for _, span := range f.spans.Ended() {
+		assert.False(t, span.Parent().IsValid())
+	}
+}
+
+func TestTransport_InjectsTraceContext(t *testing.T) {
+	// Setup
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	f := newFixture()
+	client := &http.Client{Transport: telemetry.NewTransport(nil)}
+	ctx := telemetry.WithRequestID(context.Background(), "req-abc")
+	span, ctx := f.service.StartSpan(ctx, "whatsapp.send")
+
+	// Execute
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, bytes.NewReader(nil))
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	span.End()
+
+	// Assertions
+	recorded := f.spans.Ended()[0]
+	expected := "00-" + recorded.SpanContext().TraceID().String() + "-" + recorded.SpanContext().SpanID().String() + "-01"
+	assert.Equal(t, expected, received.Get("traceparent"))
+	assert.Equal(t, "req-abc", received.Get(telemetry.RequestIDHeader))
+	// o request original não é alterado
+	assert.Empty(t, req.Header.Get("traceparent"))
+}
+
+func TestMessageHandler_OutboundContinuesIncomingTrace(t *testing.T) {
+	// Setup
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Write([]byte(`{"messaging_product":"whatsapp","messages":[{"id":"wamid.OUT"}]}`))
+	}))
+	defer server.Close()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&whatsapp.Message{}))
+
+	f := newFixture()
+	tenantID, phoneNumberID := uuid.New(), "PHONE_ID"
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+	}
+	messageRepo := whatsapp.NewMessageRepository(db, f.service)
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, f.service)
+	// sem http.Client próprio o provider usa o transport que propaga o trace
+	provider := whatsapp.NewCloudAPIProvider(server.URL, "token", nil)
+	handler := whatsapp.NewMessageHandler(whatsapp.NewMessageService(messageRepo, channels, provider, f.service))
+
+	_, err = messageRepo.CreateIfNotExists(context.Background(), &whatsapp.Message{
+		TenantID:      tenantID,
+		WAMessageID:   "wamid.inbound",
+		PhoneNumberID: phoneNumberID,
+		Direction:     whatsapp.DirectionInbound,
+		From:          "5511988887777",
+		Type:          "text",
+		Status:        whatsapp.StatusReceived,
+		Timestamp:     time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.TelemetryMiddleware(f.service))
+	router.POST("/api/whatsapp/messages", func(c *gin.Context) {
+		web.SetIdentity(c, tenantID, uuid.New())
+	}, handler.Send)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/whatsapp/messages",
+		strings.NewReader(`{"to":"5511988887777","type":"text","text":{"body":"Oi"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", traceparent)
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions: a chamada à Cloud API segue no trace da requisição
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.NotEmpty(t, received.Get("traceparent"))
+	assert.Equal(t, incomingTraceID, strings.Split(received.Get("traceparent"), "-")[1])
+}
+
+func TestErrorBody_WithoutTelemetry(t *testing.T) {
+	// Setup: handlers testados sem o middleware mantêm o corpo de antes
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Execute
+	body := web.ErrorBody(c, "invalid id")
+
+	// Assertions
+	assert.Equal(t, gin.H{"error": "invalid id"}, body)
+}
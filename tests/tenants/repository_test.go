@@ -1,11 +1,13 @@
 package tenants_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/cache"
 	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
 	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/tests/testdb"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
@@ -16,24 +18,24 @@ func TestTenantRepository_Create(t *testing.T) {
 	// Setup
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err)
-	
+
 	// Auto migrate
-	err = db.AutoMigrate(&tenants.Tenant{})
+	err = testdb.Migrate(db, &tenants.Tenant{})
 	assert.NoError(t, err)
-	
+
 	// Create repository
-	cacheService := cache.NewCacheService(nil)
+	cacheService := cache.NewMemoryCache(cache.LoadConfig(), telemetry.NewTelemetryService(false))
 	telemetryService := telemetry.NewTelemetryService(false) // disabled for tests
 	repo := tenants.NewTenantRepository(db, cacheService, telemetryService)
-	
+
 	// Test data
 	tenant := &tenants.Tenant{
 		Name: "Test Company",
 	}
-	
+
 	// Execute
-	err = repo.Create(tenant)
-	
+	err = repo.Create(context.Background(), tenant)
+
 	// Assertions
 	assert.NoError(t, err)
 	assert.NotEqual(t, uuid.Nil, tenant.ID)
@@ -44,29 +46,103 @@ func TestTenantRepository_FindByID(t *testing.T) {
 	// Setup
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err)
-	
+
 	// Auto migrate
-	err = db.AutoMigrate(&tenants.Tenant{})
+	err = testdb.Migrate(db, &tenants.Tenant{})
 	assert.NoError(t, err)
-	
+
 	// Create repository
-	cacheService := cache.NewCacheService(nil)
+	cacheService := cache.NewMemoryCache(cache.LoadConfig(), telemetry.NewTelemetryService(false))
 	telemetryService := telemetry.NewTelemetryService(false) // disabled for tests
 	repo := tenants.NewTenantRepository(db, cacheService, telemetryService)
-	
+
 	// Create test tenant
 	tenant := &tenants.Tenant{
 		Name: "Test Company",
 	}
-	err = repo.Create(tenant)
+	err = repo.Create(context.Background(), tenant)
 	assert.NoError(t, err)
-	
+
 	// Test find by ID
-	found, err := repo.FindByID(tenant.ID.String())
-	
+	found, err := repo.FindByID(context.Background(), tenant.ID.String())
+
 	// Assertions
 	assert.NoError(t, err)
 	assert.NotNil(t, found)
 	assert.Equal(t, tenant.ID, found.ID)
 	assert.Equal(t, tenant.Name, found.Name)
-} 
\ No newline at end of file
+}
+func TestTenantRepository_FindByID_NegativeCache(t *testing.T) {
+	// Setup
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	err = testdb.Migrate(db, &tenants.Tenant{})
+	assert.NoError(t, err)
+
+	cacheService := cache.NewMemoryCache(cache.LoadConfig(), telemetry.NewTelemetryService(false))
+	repo := tenants.NewTenantRepository(db, cacheService, telemetry.NewTelemetryService(false))
+	id := uuid.New()
+
+	// Execute: a primeira consulta grava a ausência; o insert direto no banco
+	// não passa pelo repositório e por isso não invalida
+	missing, err := repo.FindByID(context.Background(), id.String())
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+	db.Create(&tenants.Tenant{ID: id, Name: "Late Company"})
+	cached, err := repo.FindByID(context.Background(), id.String())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Nil(t, cached)
+}
+
+func TestTenantRepository_FindByWhatsAppPhoneNumberID_SkipsNegativeCache(t *testing.T) {
+	// Setup
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	err = testdb.Migrate(db, &tenants.Tenant{})
+	assert.NoError(t, err)
+
+	cacheService := cache.NewMemoryCache(cache.LoadConfig(), telemetry.NewTelemetryService(false))
+	repo := tenants.NewTenantRepository(db, cacheService, telemetry.NewTelemetryService(false))
+	phoneNumberID := "1234567890"
+
+	// Execute: um número recém-configurado precisa ser achado na hora
+	missing, err := repo.FindByWhatsAppPhoneNumberID(context.Background(), phoneNumberID)
+	assert.NoError(t, err)
+	db.Create(&tenants.Tenant{Name: "New Company", WhatsAppPhoneNumberID: &phoneNumberID})
+	found, err := repo.FindByWhatsAppPhoneNumberID(context.Background(), phoneNumberID)
+
+	// Assertions
+	assert.Nil(t, missing)
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+}
+
+func TestTenantRepository_UpdateInvalidatesLookups(t *testing.T) {
+	// Setup
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	err = testdb.Migrate(db, &tenants.Tenant{})
+	assert.NoError(t, err)
+
+	cacheService := cache.NewMemoryCache(cache.LoadConfig(), telemetry.NewTelemetryService(false))
+	repo := tenants.NewTenantRepository(db, cacheService, telemetry.NewTelemetryService(false))
+	oldNumber, newNumber := "1111", "2222"
+	tenant := &tenants.Tenant{Name: "Test Company", WhatsAppPhoneNumberID: &oldNumber}
+	assert.NoError(t, repo.Create(context.Background(), tenant))
+	repo.FindByID(context.Background(), tenant.ID.String())
+	repo.FindByWhatsAppPhoneNumberID(context.Background(), oldNumber)
+
+	// Execute
+	tenant.Name = "Renamed Company"
+	tenant.WhatsAppPhoneNumberID = &newNumber
+	err = repo.Update(context.Background(), tenant)
+
+	// Assertions
+	assert.NoError(t, err)
+	byOldNumber, _ := repo.FindByWhatsAppPhoneNumberID(context.Background(), oldNumber)
+	assert.Nil(t, byOldNumber)
+	byID, _ := repo.FindByID(context.Background(), tenant.ID.String())
+	assert.Equal(t, "Renamed Company", byID.Name)
+}
@@ -0,0 +1,32 @@
+// Package testdb prepara os bancos SQLite em memória usados pelos testes de repositório
+package testdb
+
+import (
+	"gorm.io/gorm"
+)
+
+// postgresRandomUUID é o default dos modelos que deixam o Postgres gerar o ID
+const postgresRandomUUID = "gen_random_uuid()"
+
+// sqliteRandomUUID gera um UUID v4 no mesmo formato do Postgres; o SQLite não tem
+// gen_random_uuid() e exige o default entre parênteses
+const sqliteRandomUUID = "(lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || " +
+	"substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', abs(random()) % 4 + 1, 1) || " +
+	"substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))"
+
+// Migrate faz o AutoMigrate trocando, só no schema em cache desta conexão, o
+// default gen_random_uuid() pelo equivalente do SQLite
+func Migrate(db *gorm.DB, models ...interface{}) error {
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DefaultValue == postgresRandomUUID {
+				field.DefaultValue = sqliteRandomUUID
+			}
+		}
+	}
+	return db.AutoMigrate(models...)
+}
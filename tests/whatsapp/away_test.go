@@ -0,0 +1,141 @@
+package whatsapp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/businesshours"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAwayInbox(t *testing.T, calendar *businesshours.Calendar) inbox {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}, &whatsapp.Conversation{}, &customers.Customer{}))
+
+	tenantID := uuid.New()
+	phoneNumberID := "PHONE_ID"
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+	}
+	hoursService := &businesshours.MockBusinessHoursService{
+		GetCalendarFunc: func(_ context.Context, id uuid.UUID) (*businesshours.Calendar, error) {
+			if calendar == nil {
+				return nil, businesshours.ErrCalendarNotFound
+			}
+			return calendar, nil
+		},
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	customerRepo := customers.NewCustomerRepository(db, telemetryService)
+	conversationRepo := whatsapp.NewConversationRepository(db, telemetryService)
+	provider := whatsapp.NewFakeProvider()
+
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	webhooks := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	messages := whatsapp.NewMessageService(messageRepo, channels, provider, telemetryService)
+	conversations := whatsapp.NewConversationService(
+		conversationRepo,
+		messageRepo,
+		messages,
+		customers.NewCustomerService(customerRepo, telemetryService),
+		&auth.MockUserRepository{},
+		telemetryService,
+	)
+	away := whatsapp.NewAwayMessageService(conversationRepo, conversations, hoursService, telemetryService)
+	webhooks.Subscribe(conversations.HandleEvent)
+	webhooks.Subscribe(away.HandleEvent)
+
+	return inbox{
+		webhooks:      webhooks,
+		conversations: conversations,
+		customerRepo:  customerRepo,
+		provider:      provider,
+		tenantID:      tenantID,
+	}
+}
+
+func TestAwayMessage_SentOnceOutsideHours(t *testing.T) {
+	// Setup: expediente só às segundas, 08h às 18h
+	calendar := &businesshours.Calendar{
+		Timezone:            "America/Sao_Paulo",
+		Weekly:              businesshours.WeeklySchedule{"monday": {{Start: "08:00", End: "18:00"}}},
+		AwayMessageEnabled:  true,
+		AwayMessage:         "Estamos fora do horário. Voltamos " + whatsapp.NextOpeningPlaceholder + ".",
+		AwayCooldownMinutes: 60,
+	}
+	// Garante que "agora" esteja fora do expediente, em qualquer dia que o teste rode
+	calendar.SpecialDates = closedAround(calendar)
+	i := setupAwayInbox(t, calendar)
+
+	// Execute
+	i.receive(t, "5511988887777", "wamid.1", "Oi")
+	i.receive(t, "5511988887777", "wamid.2", "Tem alguém?")
+
+	// Assertions
+	sent := i.provider.Sent()
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, "5511988887777", sent[0].Message.To)
+		assert.Contains(t, sent[0].Message.Text.Body, "Estamos fora do horário. Voltamos segunda-feira")
+		assert.NotContains(t, sent[0].Message.Text.Body, "{{")
+	}
+
+	items, _, err := i.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: i.tenantID, Limit: 10})
+	assert.NoError(t, err)
+	assert.NotNil(t, items[0].AwayMessageSentAt)
+}
+
+func TestAwayMessage_SkippedWhenOpenOrDisabled(t *testing.T) {
+	allDay := []businesshours.Interval{{Start: "00:00", End: "24:00"}}
+	open := &businesshours.Calendar{
+		Timezone: "UTC",
+		Weekly: businesshours.WeeklySchedule{
+			"sunday": allDay, "monday": allDay, "tuesday": allDay, "wednesday": allDay,
+			"thursday": allDay, "friday": allDay, "saturday": allDay,
+		},
+		AwayMessageEnabled: true,
+		AwayMessage:        "Fora do horário",
+	}
+	disabled := &businesshours.Calendar{Timezone: "UTC", AwayMessage: "Fora do horário"}
+
+	for name, calendar := range map[string]*businesshours.Calendar{"open": open, "disabled": disabled, "missing": nil} {
+		// Setup
+		i := setupAwayInbox(t, calendar)
+
+		// Execute
+		i.receive(t, "5511988887777", "wamid.1", "Oi")
+
+		// Assertions
+		assert.Empty(t, i.provider.Sent(), name)
+	}
+}
+
+// closedAround fecha ontem, hoje e amanhã, evitando depender do dia da semana da execução
+func closedAround(calendar *businesshours.Calendar) []businesshours.SpecialDate {
+	now := timeNowIn(calendar)
+	dates := []businesshours.SpecialDate{}
+	for offset := -1; offset <= 1; offset++ {
+		dates = append(dates, businesshours.SpecialDate{Date: now.AddDate(0, 0, offset).Format("2006-01-02"), Closed: true})
+	}
+	return dates
+}
+
+func timeNowIn(calendar *businesshours.Calendar) time.Time {
+	return time.Now().In(calendar.Location())
+}
@@ -0,0 +1,423 @@
+package whatsapp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/chatbot"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/secrets"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/teams"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type channelFixture struct {
+	db            *gorm.DB
+	channels      whatsapp.ChannelService
+	webhooks      whatsapp.WebhookService
+	messages      whatsapp.MessageService
+	conversations whatsapp.ConversationService
+	provider      *whatsapp.FakeProvider
+	tenantID      uuid.UUID
+	otherTenantID uuid.UUID
+	agentID       uuid.UUID
+	teamID        uuid.UUID
+	flowID        uuid.UUID
+}
+
+func setupChannels(t *testing.T, cipher secrets.Cipher) channelFixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Channel{}, &whatsapp.Message{}, &whatsapp.Conversation{}, &customers.Customer{}))
+
+	tenantID := uuid.New()
+	otherTenantID := uuid.New()
+	legacyPhone := "LEGACY_PHONE"
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: uuid.MustParse(id)}, nil
+		},
+		// Outro tenant ainda usa o número do cadastro, sem canais
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			if id != legacyPhone {
+				return nil, nil
+			}
+			return &tenants.Tenant{ID: otherTenantID, WhatsAppPhoneNumberID: &legacyPhone}, nil
+		},
+	}
+	userRepo := &auth.MockUserRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*auth.User, error) {
+			return &auth.User{ID: uuid.MustParse(id), TenantID: tenantID}, nil
+		},
+	}
+
+	// Time e fluxo do tenant; qualquer outro ID é de outro tenant ou não existe
+	teamID := uuid.New()
+	flowID := uuid.New()
+	teamRepo := &teams.MockTeamRepository{
+		FindByIDFunc: func(_ context.Context, tenant uuid.UUID, id string) (*teams.Team, error) {
+			if tenant != tenantID || id != teamID.String() {
+				return nil, nil
+			}
+			return &teams.Team{ID: teamID, TenantID: tenantID}, nil
+		},
+	}
+	flowRepo := &chatbot.MockFlowRepository{
+		FindByIDFunc: func(_ context.Context, tenant uuid.UUID, id string) (*chatbot.Flow, error) {
+			if tenant != tenantID || id != flowID.String() {
+				return nil, nil
+			}
+			return &chatbot.Flow{ID: flowID, TenantID: tenantID}, nil
+		},
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	provider := whatsapp.NewFakeProvider()
+	provider.AddPhoneNumber("WABA_SALES", whatsapp.ProviderPhoneNumber{ID: "SALES_PHONE"})
+	provider.AddPhoneNumber("WABA_SUPPORT", whatsapp.ProviderPhoneNumber{ID: "SUPPORT_PHONE"})
+
+	channels := whatsapp.NewChannelService(
+		whatsapp.NewChannelRepository(db, telemetryService),
+		tenantRepo,
+		provider,
+		teams.NewTeamDirectory(teamRepo),
+		chatbot.NewFlowDirectory(flowRepo),
+		cipher,
+		telemetryService,
+	)
+	webhooks := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	messages := whatsapp.NewMessageService(messageRepo, channels, provider, telemetryService)
+	conversations := whatsapp.NewConversationService(
+		whatsapp.NewConversationRepository(db, telemetryService),
+		messageRepo,
+		messages,
+		customers.NewCustomerService(customers.NewCustomerRepository(db, telemetryService), telemetryService),
+		userRepo,
+		telemetryService,
+	)
+	webhooks.Subscribe(conversations.HandleEvent)
+
+	return channelFixture{
+		db:            db,
+		channels:      channels,
+		webhooks:      webhooks,
+		messages:      messages,
+		conversations: conversations,
+		provider:      provider,
+		tenantID:      tenantID,
+		otherTenantID: otherTenantID,
+		agentID:       uuid.New(),
+		teamID:        teamID,
+		flowID:        flowID,
+	}
+}
+
+func testCipher(t *testing.T) secrets.Cipher {
+	cipher, err := secrets.NewAESCipher(bytes.Repeat([]byte{3}, 32))
+	assert.NoError(t, err)
+	return cipher
+}
+
+func (f channelFixture) receive(t *testing.T, phoneNumberID, from, waMessageID string) {
+	raw := fmt.Sprintf(`{"from":%q,"id":%q,"timestamp":"%d","type":"text","text":{"body":"Oi"}}`, from, waMessageID, time.Now().Unix())
+	err := f.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: phoneNumberID},
+					Contacts: []whatsapp.WebhookContact{{WaID: from}},
+					Messages: []json.RawMessage{json.RawMessage(raw)},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestChannelService_RoutesInboundAndRepliesFromSameNumber(t *testing.T) {
+	// Setup
+	f := setupChannels(t, testCipher(t))
+	teamID := f.teamID.String()
+	sales, err := f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{
+		PhoneNumberID: "SALES_PHONE",
+		DisplayName:   "Vendas",
+		AccessToken:   "sales-token",
+	})
+	assert.NoError(t, err)
+	support, err := f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{
+		PhoneNumberID: "SUPPORT_PHONE",
+		DisplayName:   "Suporte",
+		DefaultTeamID: &teamID,
+	})
+	assert.NoError(t, err)
+	assert.True(t, sales.IsDefault, "primeiro canal vira o padrão")
+	assert.False(t, support.IsDefault)
+
+	var routed []*whatsapp.Channel
+	f.webhooks.Subscribe(func(_ context.Context, event whatsapp.Event) {
+		if inbound, ok := event.(whatsapp.InboundMessageEvent); ok {
+			routed = append(routed, inbound.Channel)
+		}
+	})
+
+	// Execute - the same customer writes to both numbers
+	f.receive(t, "SALES_PHONE", "5511988887777", "wamid.sales")
+	f.receive(t, "SUPPORT_PHONE", "5511988887777", "wamid.support")
+	f.receive(t, "UNKNOWN_PHONE", "5511988887777", "wamid.unknown")
+
+	// Assertions
+	assert.Len(t, routed, 2)
+	assert.Equal(t, sales.ID, routed[0].ID)
+	assert.Equal(t, support.ID, routed[1].ID)
+	assert.Equal(t, teamID, routed[1].DefaultTeamID.String())
+
+	items, total, err := f.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: f.tenantID, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total, "uma conversa por número")
+
+	for _, conversation := range items {
+		_, err := f.conversations.SendMessage(context.Background(), f.tenantID, f.agentID, conversation.ID.String(), whatsapp.SendMessageRequest{
+			Type: whatsapp.TypeText,
+			Text: &whatsapp.TextContent{Body: "Olá!"},
+		})
+		assert.NoError(t, err)
+	}
+
+	tokens := map[string]string{}
+	for _, sent := range f.provider.Sent() {
+		tokens[sent.PhoneNumberID] = sent.AccessToken
+	}
+	assert.Equal(t, map[string]string{"SALES_PHONE": "sales-token", "SUPPORT_PHONE": ""}, tokens)
+}
+
+func TestChannelService_SelectsOutboundChannel(t *testing.T) {
+	// Setup
+	f := setupChannels(t, testCipher(t))
+	_, err := f.messages.SendMessage(context.Background(), f.tenantID, f.agentID, whatsapp.SendMessageRequest{
+		To:       "5511988887777",
+		Type:     whatsapp.TypeTemplate,
+		Template: &whatsapp.TemplateContent{Name: "hello", Language: "pt_BR"},
+	})
+	assert.ErrorIs(t, err, whatsapp.ErrChannelNotConfigured)
+
+	sales, err := f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{PhoneNumberID: "SALES_PHONE", DisplayName: "Vendas"})
+	assert.NoError(t, err)
+	support, err := f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{PhoneNumberID: "SUPPORT_PHONE", DisplayName: "Suporte"})
+	assert.NoError(t, err)
+
+	send := func(channelID string) (*whatsapp.Message, error) {
+		return f.messages.SendMessage(context.Background(), f.tenantID, f.agentID, whatsapp.SendMessageRequest{
+			To:        "5511988887777",
+			Type:      whatsapp.TypeTemplate,
+			Template:  &whatsapp.TemplateContent{Name: "hello", Language: "pt_BR"},
+			ChannelID: channelID,
+		})
+	}
+
+	// Execute / Assertions - default channel, explicit channel, new default
+	message, err := send("")
+	assert.NoError(t, err)
+	assert.Equal(t, "SALES_PHONE", message.PhoneNumberID)
+
+	message, err = send(support.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "SUPPORT_PHONE", message.PhoneNumberID)
+
+	_, err = f.channels.SetDefault(context.Background(), f.tenantID, support.ID.String())
+	assert.NoError(t, err)
+	message, err = send("")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUPPORT_PHONE", message.PhoneNumberID)
+
+	stored, err := f.channels.GetChannel(context.Background(), f.tenantID, sales.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, stored.IsDefault)
+
+	// Canal de outro tenant não pode ser usado
+	_, err = f.messages.SendMessage(context.Background(), f.otherTenantID, f.agentID, whatsapp.SendMessageRequest{
+		To:        "5511988887777",
+		Type:      whatsapp.TypeTemplate,
+		Template:  &whatsapp.TemplateContent{Name: "hello", Language: "pt_BR"},
+		ChannelID: sales.ID.String(),
+	})
+	assert.ErrorIs(t, err, whatsapp.ErrChannelNotFound)
+}
+
+func TestChannelService_EncryptsAccessTokenAtRest(t *testing.T) {
+	// Setup
+	f := setupChannels(t, testCipher(t))
+
+	// Execute
+	channel, err := f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{
+		PhoneNumberID: "SALES_PHONE",
+		DisplayName:   "Vendas",
+		AccessToken:   "EAAG-secret",
+	})
+	assert.NoError(t, err)
+
+	// Assertions
+	var stored whatsapp.Channel
+	assert.NoError(t, f.db.First(&stored, "id = ?", channel.ID).Error)
+	assert.NotEmpty(t, stored.AccessTokenEncrypted)
+	assert.NotContains(t, stored.AccessTokenEncrypted, "EAAG-secret")
+	assert.True(t, stored.HasAccessToken)
+
+	body, err := json.Marshal(stored)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "EAAG")
+	assert.NotContains(t, string(body), stored.AccessTokenEncrypted)
+
+	// Token vazio volta a usar o token global
+	empty := ""
+	updated, err := f.channels.UpdateChannel(context.Background(), f.tenantID, channel.ID.String(), whatsapp.UpdateChannelRequest{AccessToken: &empty})
+	assert.NoError(t, err)
+	assert.False(t, updated.HasAccessToken)
+}
+
+func TestChannelService_RejectsTakenNumbersAndTokensWithoutKey(t *testing.T) {
+	// Setup
+	f := setupChannels(t, nil)
+	_, err := f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{PhoneNumberID: "SALES_PHONE", DisplayName: "Vendas"})
+	assert.NoError(t, err)
+
+	// Execute / Assertions
+	_, err = f.channels.CreateChannel(context.Background(), uuid.New(), whatsapp.CreateChannelRequest{PhoneNumberID: "SALES_PHONE", DisplayName: "Outro"})
+	assert.ErrorIs(t, err, whatsapp.ErrPhoneNumberTaken)
+
+	_, err = f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{PhoneNumberID: "LEGACY_PHONE", DisplayName: "Legado"})
+	assert.ErrorIs(t, err, whatsapp.ErrPhoneNumberTaken, "número em uso no cadastro de outro tenant")
+
+	_, err = f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{PhoneNumberID: "SUPPORT_PHONE", DisplayName: "Suporte", AccessToken: "EAAG"})
+	assert.ErrorIs(t, err, secrets.ErrNoKey)
+}
+
+func TestChannelService_VerifiesPhoneNumberOwnership(t *testing.T) {
+	// Setup
+	f := setupChannels(t, testCipher(t))
+
+	// Execute / Assertions - número que o token não acessa e número de outra WABA
+	_, err := f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{PhoneNumberID: "STRANGER_PHONE", DisplayName: "Alheio"})
+	assert.ErrorIs(t, err, whatsapp.ErrPhoneNumberNotOwned)
+
+	_, err = f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{
+		PhoneNumberID:     "SALES_PHONE",
+		DisplayName:       "Vendas",
+		BusinessAccountID: "WABA_SUPPORT",
+	})
+	assert.ErrorIs(t, err, whatsapp.ErrPhoneNumberNotOwned)
+
+	channel, err := f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{
+		PhoneNumberID:     "SALES_PHONE",
+		DisplayName:       "Vendas",
+		BusinessAccountID: "WABA_SALES",
+	})
+	assert.NoError(t, err)
+
+	other := "WABA_SUPPORT"
+	_, err = f.channels.UpdateChannel(context.Background(), f.tenantID, channel.ID.String(), whatsapp.UpdateChannelRequest{BusinessAccountID: &other})
+	assert.ErrorIs(t, err, whatsapp.ErrPhoneNumberNotOwned)
+
+	stored, err := f.channels.GetChannel(context.Background(), f.tenantID, channel.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "WABA_SALES", stored.BusinessAccountID)
+}
+
+func TestChannelService_VerifiesOwnershipWithChannelToken(t *testing.T) {
+	// Setup - a Graph API só conhece o número pelo token do próprio canal
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.URL.Path != "/SALES_PHONE" || r.Header.Get("Authorization") != "Bearer sales-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"Unsupported get request","code":100}}`))
+			return
+		}
+		w.Write([]byte(`{"id":"SALES_PHONE","display_phone_number":"+55 11 99999-0000"}`))
+	}))
+	defer server.Close()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Channel{}))
+	telemetryService := telemetry.NewTelemetryService(false)
+	channels := whatsapp.NewChannelService(
+		whatsapp.NewChannelRepository(db, telemetryService),
+		&tenants.MockTenantRepository{},
+		whatsapp.NewCloudAPIProvider(server.URL, "global-token", nil),
+		nil,
+		nil,
+		testCipher(t),
+		telemetryService,
+	)
+	tenantID := uuid.New()
+
+	// Execute
+	_, withoutToken := channels.CreateChannel(context.Background(), tenantID, whatsapp.CreateChannelRequest{PhoneNumberID: "SALES_PHONE", DisplayName: "Vendas"})
+	_, withToken := channels.CreateChannel(context.Background(), tenantID, whatsapp.CreateChannelRequest{
+		PhoneNumberID: "SALES_PHONE",
+		DisplayName:   "Vendas",
+		AccessToken:   "sales-token",
+	})
+
+	// Assertions - sem token próprio a verificação cai no token global
+	assert.ErrorIs(t, withoutToken, whatsapp.ErrPhoneNumberNotOwned)
+	assert.NoError(t, withToken)
+	assert.Equal(t, []string{"Bearer global-token", "Bearer sales-token"}, authorizations)
+}
+
+func TestChannelService_ValidatesDefaultTeamAndFlow(t *testing.T) {
+	// Setup
+	f := setupChannels(t, testCipher(t))
+	invalid := "not-a-uuid"
+	unknown := uuid.New().String()
+	teamID := f.teamID.String()
+	flowID := f.flowID.String()
+
+	create := func(teamID, flowID *string) (*whatsapp.Channel, error) {
+		return f.channels.CreateChannel(context.Background(), f.tenantID, whatsapp.CreateChannelRequest{
+			PhoneNumberID: "SALES_PHONE",
+			DisplayName:   "Vendas",
+			DefaultTeamID: teamID,
+			DefaultFlowID: flowID,
+		})
+	}
+
+	// Execute / Assertions - IDs inválidos ou de outro tenant não são descartados em silêncio
+	_, err := create(&invalid, nil)
+	assert.ErrorIs(t, err, whatsapp.ErrInvalidDefaultTeam)
+	_, err = create(&unknown, nil)
+	assert.ErrorIs(t, err, whatsapp.ErrInvalidDefaultTeam)
+	_, err = create(nil, &invalid)
+	assert.ErrorIs(t, err, whatsapp.ErrInvalidDefaultFlow)
+	_, err = create(nil, &unknown)
+	assert.ErrorIs(t, err, whatsapp.ErrInvalidDefaultFlow)
+
+	channel, err := create(&teamID, &flowID)
+	assert.NoError(t, err)
+	assert.Equal(t, f.teamID, *channel.DefaultTeamID)
+	assert.Equal(t, f.flowID, *channel.DefaultFlowID)
+
+	_, err = f.channels.UpdateChannel(context.Background(), f.tenantID, channel.ID.String(), whatsapp.UpdateChannelRequest{DefaultTeamID: &unknown})
+	assert.ErrorIs(t, err, whatsapp.ErrInvalidDefaultTeam)
+
+	empty := ""
+	updated, err := f.channels.UpdateChannel(context.Background(), f.tenantID, channel.ID.String(), whatsapp.UpdateChannelRequest{DefaultTeamID: &empty})
+	assert.NoError(t, err)
+	assert.Nil(t, updated.DefaultTeamID)
+	assert.Equal(t, f.flowID, *updated.DefaultFlowID)
+}
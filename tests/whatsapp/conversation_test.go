@@ -0,0 +1,271 @@
+package whatsapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/auth"
+	"github.com/claudineijrdev/sib-crm-backend/internal/customers"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type inbox struct {
+	webhooks      whatsapp.WebhookService
+	conversations whatsapp.ConversationService
+	templates     whatsapp.TemplateService
+	customerRepo  customers.CustomerRepository
+	provider      *whatsapp.FakeProvider
+	tenantID      uuid.UUID
+	agentID       uuid.UUID
+}
+
+func setupInbox(t *testing.T) inbox {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}, &whatsapp.Conversation{}, &whatsapp.Template{}, &customers.Customer{}))
+
+	tenantID := uuid.New()
+	agentID := uuid.New()
+	phoneNumberID := "PHONE_ID"
+	businessAccountID := "WABA_ID"
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID, WhatsAppBusinessAccountID: &businessAccountID}, nil
+		},
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+	}
+	userRepo := &auth.MockUserRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*auth.User, error) {
+			return &auth.User{ID: uuid.MustParse(id), TenantID: tenantID}, nil
+		},
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	customerRepo := customers.NewCustomerRepository(db, telemetryService)
+	provider := whatsapp.NewFakeProvider()
+
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	webhooks := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	messages := whatsapp.NewMessageService(messageRepo, channels, provider, telemetryService)
+	conversations := whatsapp.NewConversationService(
+		whatsapp.NewConversationRepository(db, telemetryService),
+		messageRepo,
+		messages,
+		customers.NewCustomerService(customerRepo, telemetryService),
+		userRepo,
+		telemetryService,
+	)
+	templates := whatsapp.NewTemplateService(whatsapp.NewTemplateRepository(db, telemetryService), channels, provider, messages, conversations, telemetryService)
+	webhooks.Subscribe(conversations.HandleEvent)
+	webhooks.Subscribe(templates.HandleEvent)
+
+	return inbox{
+		webhooks:      webhooks,
+		conversations: conversations,
+		templates:     templates,
+		customerRepo:  customerRepo,
+		provider:      provider,
+		tenantID:      tenantID,
+		agentID:       agentID,
+	}
+}
+
+func (i inbox) receive(t *testing.T, from, waMessageID, body string) {
+	i.receiveAt(t, from, waMessageID, body, time.Now())
+}
+
+func (i inbox) receiveAt(t *testing.T, from, waMessageID, body string, at time.Time) {
+	raw := fmt.Sprintf(`{"from":%q,"id":%q,"timestamp":"%d","type":"text","text":{"body":%q}}`, from, waMessageID, at.Unix(), body)
+	err := i.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: "PHONE_ID"},
+					Contacts: []whatsapp.WebhookContact{{WaID: from}},
+					Messages: []json.RawMessage{json.RawMessage(raw)},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestConversationService_InboundCreatesCustomerAndConversation(t *testing.T) {
+	// Setup
+	i := setupInbox(t)
+
+	// Execute
+	i.receive(t, "5511988887777", "wamid.1", "Oi")
+	i.receive(t, "5511988887777", "wamid.2", "Alguém aí?")
+
+	// Assertions
+	customer, err := i.customerRepo.FindByPhone(context.Background(), i.tenantID, "5511988887777")
+	assert.NoError(t, err)
+	assert.NotNil(t, customer)
+
+	items, total, err := i.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: i.tenantID, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, customer.ID, items[0].CustomerID)
+	assert.Equal(t, 2, items[0].UnreadCount)
+	assert.Equal(t, "Alguém aí?", items[0].LastMessagePreview)
+	assert.Equal(t, whatsapp.ConversationOpen, items[0].Status)
+
+	messages, total, err := i.conversations.ListMessages(context.Background(), i.tenantID, items[0].ID.String(), 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, messages, 2)
+}
+
+func TestConversationService_ViewsAndAssignment(t *testing.T) {
+	// Setup
+	i := setupInbox(t)
+	i.receive(t, "5511900000001", "wamid.a", "Primeiro")
+	i.receive(t, "5511900000002", "wamid.b", "Segundo")
+
+	all, _, err := i.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: i.tenantID, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	// Execute
+	_, err = i.conversations.Assign(context.Background(), i.tenantID, all[0].ID.String(), &i.agentID)
+	assert.NoError(t, err)
+
+	// Assertions
+	mine, _, err := i.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: i.tenantID, View: whatsapp.ViewMine, UserID: i.agentID, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, mine, 1)
+	assert.Equal(t, all[0].ID, mine[0].ID)
+
+	unassigned, _, err := i.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: i.tenantID, View: whatsapp.ViewUnassigned, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, unassigned, 1)
+	assert.Equal(t, all[1].ID, unassigned[0].ID)
+}
+
+func TestConversationService_ReplyResolveAndReopen(t *testing.T) {
+	// Setup
+	i := setupInbox(t)
+	i.receive(t, "5511988887777", "wamid.1", "Preciso de ajuda")
+	items, _, _ := i.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: i.tenantID, Limit: 10})
+	conversationID := items[0].ID.String()
+
+	// Reply goes to the conversation's contact
+	message, err := i.conversations.SendMessage(context.Background(), i.tenantID, i.agentID, conversationID, whatsapp.SendMessageRequest{
+		Type: whatsapp.TypeText,
+		Text: &whatsapp.TextContent{Body: "Claro, como posso ajudar?"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "5511988887777", i.provider.Sent()[0].Message.To)
+	assert.Equal(t, items[0].ID, *message.ConversationID)
+
+	assert.NoError(t, i.conversations.MarkRead(context.Background(), i.tenantID, conversationID))
+	resolved, err := i.conversations.UpdateStatus(context.Background(), i.tenantID, conversationID, whatsapp.ConversationResolved)
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.ConversationResolved, resolved.Status)
+
+	// Execute - customer writes again
+	i.receive(t, "5511988887777", "wamid.2", "Mais uma dúvida")
+
+	// Assertions
+	conversation, err := i.conversations.GetConversation(context.Background(), i.tenantID, conversationID)
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.ConversationOpen, conversation.Status)
+	assert.Equal(t, 1, conversation.UnreadCount)
+}
+
+func TestConversationService_ServiceWindow(t *testing.T) {
+	// Setup
+	i := setupInbox(t)
+	lastInbound := time.Now().Add(-25 * time.Hour).UTC().Truncate(time.Second)
+	i.receiveAt(t, "5511988887777", "wamid.recent", "Mensagem nova", lastInbound)
+	// Out-of-order delivery of an older message must not move the window back
+	i.receiveAt(t, "5511988887777", "wamid.old", "Mensagem antiga", lastInbound.Add(-time.Hour))
+
+	items, _, _ := i.conversations.ListConversations(context.Background(), whatsapp.ConversationFilter{TenantID: i.tenantID, Limit: 10})
+	conversation := items[0]
+
+	// Assertions - window closed after 24h
+	assert.False(t, conversation.WindowOpen)
+	assert.True(t, lastInbound.Equal(*conversation.LastInboundAt))
+	assert.True(t, lastInbound.Add(whatsapp.CustomerServiceWindow).Equal(*conversation.WindowExpiresAt))
+
+	_, err := i.conversations.SendMessage(context.Background(), i.tenantID, i.agentID, conversation.ID.String(), whatsapp.SendMessageRequest{
+		Type: whatsapp.TypeText,
+		Text: &whatsapp.TextContent{Body: "Oi, ainda precisa de ajuda?"},
+	})
+	assert.ErrorIs(t, err, whatsapp.ErrWindowClosed)
+	assert.Empty(t, i.provider.Sent())
+
+	// Templates are still allowed
+	_, err = i.conversations.SendMessage(context.Background(), i.tenantID, i.agentID, conversation.ID.String(), whatsapp.SendMessageRequest{
+		Type:     whatsapp.TypeTemplate,
+		Template: &whatsapp.TemplateContent{Name: "follow_up", Language: "pt_BR"},
+	})
+	assert.NoError(t, err)
+
+	// Execute - customer writes again and the window reopens
+	i.receive(t, "5511988887777", "wamid.new", "Sim!")
+	reopened, err := i.conversations.GetConversation(context.Background(), i.tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, reopened.WindowOpen)
+}
+
+func TestConversationRepository_UpdatesKeepConcurrentWrites(t *testing.T) {
+	// Setup: a conversa é lida antes de webhooks e roteamento gravarem
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Conversation{}))
+	repo := whatsapp.NewConversationRepository(db, telemetry.NewTelemetryService(false))
+
+	tenantID, teamID, agentID := uuid.New(), uuid.New(), uuid.New()
+	conversation, err := repo.FindOrCreate(context.Background(), &whatsapp.Conversation{
+		TenantID:      tenantID,
+		CustomerID:    uuid.New(),
+		Channel:       whatsapp.ChannelWhatsApp,
+		PhoneNumberID: "PHONE_ID",
+		Status:        whatsapp.ConversationPending,
+	})
+	assert.NoError(t, err)
+
+	now := time.Now()
+	assert.NoError(t, repo.RecordMessage(context.Background(), conversation.ID, &whatsapp.Message{
+		Direction: whatsapp.DirectionInbound,
+		Type:      "text",
+		Body:      "Oi",
+		Timestamp: now,
+	}))
+	assert.NoError(t, repo.Route(context.Background(), conversation.ID, &teamID, nil))
+	claimed, err := repo.ClaimAwayMessage(context.Background(), conversation.ID, now, now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	// Execute
+	assert.NoError(t, repo.UpdateStatus(context.Background(), conversation.ID, whatsapp.ConversationResolved, &now))
+	assert.NoError(t, repo.Assign(context.Background(), conversation.ID, &agentID))
+
+	// Assertions
+	stored, err := repo.FindByID(context.Background(), tenantID, conversation.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.ConversationResolved, stored.Status)
+	assert.NotNil(t, stored.ResolvedAt)
+	assert.Equal(t, agentID, *stored.AssigneeID)
+	assert.Equal(t, teamID, *stored.TeamID)
+	assert.Equal(t, 1, stored.UnreadCount)
+	assert.Equal(t, "Oi", stored.LastMessagePreview)
+	assert.NotNil(t, stored.LastInboundAt)
+	assert.NotNil(t, stored.AwayMessageSentAt)
+}
@@ -0,0 +1,178 @@
+package whatsapp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/storage"
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestValidateMedia(t *testing.T) {
+	tests := []struct {
+		mimeType string
+		size     int64
+		want     whatsapp.MessageType
+		err      error
+	}{
+		{"image/jpeg", 1024, whatsapp.TypeImage, nil},
+		{"audio/ogg; codecs=opus", 1024, whatsapp.TypeAudio, nil},
+		{"application/pdf", 50 << 20, whatsapp.TypeDocument, nil},
+		{"image/png", 6 << 20, "", whatsapp.ErrMediaTooLarge},
+		{"application/x-msdownload", 10, "", whatsapp.ErrUnsupportedMediaType},
+		{"", 10, "", whatsapp.ErrUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mimeType, func(t *testing.T) {
+			_, messageType, _, err := whatsapp.ValidateMedia(tt.mimeType, tt.size)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, messageType)
+			}
+		})
+	}
+}
+
+type mediaFixture struct {
+	webhooks whatsapp.WebhookService
+	media    whatsapp.MediaService
+	messages whatsapp.MessageRepository
+	provider *whatsapp.FakeProvider
+	tenantID uuid.UUID
+}
+
+func setupMedia(t *testing.T) mediaFixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}, &whatsapp.Media{}))
+
+	tenantID := uuid.New()
+	phoneNumberID := "PHONE_ID"
+	tenant := &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc:                    func(_ context.Context, id string) (*tenants.Tenant, error) { return tenant, nil },
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) { return tenant, nil },
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	provider := whatsapp.NewFakeProvider()
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	signer, err := storage.NewURLSigner("secret", "https://crm.example.com", time.Minute)
+	assert.NoError(t, err)
+	media := whatsapp.NewMediaService(
+		whatsapp.NewMediaRepository(db, telemetryService),
+		messageRepo,
+		channels,
+		provider,
+		storage.NewLocalBlobStore(t.TempDir()),
+		signer,
+		telemetryService,
+	)
+	webhooks := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	webhooks.Subscribe(media.HandleEvent)
+
+	return mediaFixture{webhooks: webhooks, media: media, messages: messageRepo, provider: provider, tenantID: tenantID}
+}
+
+func signedParams(t *testing.T, signedURL string) (string, string, string, string) {
+	parsed, err := url.Parse(signedURL)
+	assert.NoError(t, err)
+	parts := strings.Split(strings.TrimPrefix(parsed.Path, "/media/"), "/")
+	return parts[0], parts[1], parsed.Query().Get("expires"), parsed.Query().Get("signature")
+}
+
+func TestMediaService_InboundDownloadAndSignedURL(t *testing.T) {
+	// Setup
+	f := setupMedia(t)
+	voiceNote := []byte("OggS fake voice note")
+	f.provider.AddMedia("MEDIA_1", "audio/ogg; codecs=opus", voiceNote)
+
+	raw := fmt.Sprintf(`{"from":"5511988887777","id":"wamid.audio","timestamp":"%d","type":"audio","audio":{"id":"MEDIA_1","mime_type":"audio/ogg; codecs=opus"}}`, time.Now().Unix())
+
+	// Execute
+	assert.NoError(t, f.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: "PHONE_ID"},
+					Messages: []json.RawMessage{json.RawMessage(raw)},
+				},
+			}},
+		}},
+	}))
+
+	// Assertions
+	message, err := f.messages.FindByWAMessageID(context.Background(), "wamid.audio")
+	assert.NoError(t, err)
+
+	media, err := f.media.GetMessageMedia(context.Background(), f.tenantID, message.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.TypeAudio, media.Type)
+	assert.Equal(t, "audio/ogg", media.MimeType)
+	assert.Equal(t, int64(len(voiceNote)), media.Size)
+
+	signedURL, _ := f.media.SignedURL(media)
+	tenantID, id, expires, signature := signedParams(t, signedURL)
+
+	_, content, err := f.media.Open(context.Background(), tenantID, id, expires, signature)
+	assert.NoError(t, err)
+	stored, _ := io.ReadAll(content)
+	content.Close()
+	assert.Equal(t, voiceNote, stored)
+
+	_, _, err = f.media.Open(context.Background(), tenantID, id, expires, strings.Repeat("0", len(signature)))
+	assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+}
+
+func TestMediaService_Upload(t *testing.T) {
+	// Setup
+	f := setupMedia(t)
+	pdf := []byte("%PDF-1.4 contract")
+
+	// Execute
+	media, err := f.media.Upload(context.Background(), f.tenantID, uuid.New(), whatsapp.MediaUpload{
+		Filename: "contrato.pdf",
+		MimeType: "application/pdf",
+		Content:  bytes.NewReader(pdf),
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.TypeDocument, media.Type)
+	assert.Equal(t, whatsapp.DirectionOutbound, media.Direction)
+	uploaded, ok := f.provider.Media(media.ProviderMediaID)
+	assert.True(t, ok)
+	assert.Equal(t, pdf, uploaded)
+
+	_, err = f.media.Upload(context.Background(), f.tenantID, uuid.New(), whatsapp.MediaUpload{
+		Filename: "virus.exe",
+		MimeType: "application/x-msdownload",
+		Content:  bytes.NewReader([]byte("MZ")),
+	})
+	assert.ErrorIs(t, err, whatsapp.ErrUnsupportedMediaType)
+
+	_, err = f.media.Upload(context.Background(), f.tenantID, uuid.New(), whatsapp.MediaUpload{
+		Filename: "huge.png",
+		MimeType: "image/png",
+		Content:  bytes.NewReader(make([]byte, 5<<20+1)),
+	})
+	assert.ErrorIs(t, err, whatsapp.ErrMediaTooLarge)
+}
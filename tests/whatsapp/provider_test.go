@@ -0,0 +1,185 @@
+package whatsapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCloudAPIProvider_SendInteractiveButtons(t *testing.T) {
+	// Setup
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/PHONE_ID/messages", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"messaging_product":"whatsapp","messages":[{"id":"wamid.OUT"}]}`))
+	}))
+	defer server.Close()
+
+	provider := whatsapp.NewCloudAPIProvider(server.URL, "token", server.Client())
+
+	// Execute
+	id, err := provider.SendMessage(context.Background(), "PHONE_ID", whatsapp.OutboundMessage{
+		To:   "5511988887777",
+		Type: whatsapp.TypeInteractive,
+		Interactive: &whatsapp.InteractiveContent{
+			Type:    whatsapp.InteractiveButton,
+			Body:    "Escolha um departamento",
+			Buttons: []whatsapp.ReplyButton{{ID: "sales", Title: "Vendas"}},
+		},
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "wamid.OUT", id)
+	assert.Equal(t, "interactive", received["type"])
+	interactive := received["interactive"].(map[string]interface{})
+	assert.Equal(t, "button", interactive["type"])
+	buttons := interactive["action"].(map[string]interface{})["buttons"].([]interface{})
+	assert.Len(t, buttons, 1)
+}
+
+func TestCloudAPIProvider_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Re-engagement message","code":131047}}`))
+	}))
+	defer server.Close()
+
+	provider := whatsapp.NewCloudAPIProvider(server.URL, "token", server.Client())
+	_, err := provider.SendMessage(context.Background(), "PHONE_ID", whatsapp.OutboundMessage{
+		To:   "5511988887777",
+		Type: whatsapp.TypeText,
+		Text: &whatsapp.TextContent{Body: "Oi"},
+	})
+
+	var providerErr *whatsapp.ProviderError
+	assert.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, 131047, providerErr.Code)
+}
+
+func TestIsRetryable(t *testing.T) {
+	// Falhas de transporte chegam como *url.Error do http.Client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+	provider := whatsapp.NewCloudAPIProvider(server.URL, "token", server.Client())
+	_, networkErr := provider.SendMessage(context.Background(), "PHONE_ID", whatsapp.OutboundMessage{
+		To:   "5511988887777",
+		Type: whatsapp.TypeText,
+		Text: &whatsapp.TextContent{Body: "Oi"},
+	})
+
+	assert.True(t, whatsapp.IsRetryable(networkErr))
+	assert.True(t, whatsapp.IsRetryable(context.DeadlineExceeded))
+	assert.True(t, whatsapp.IsRetryable(&whatsapp.ProviderError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, whatsapp.IsRetryable(&whatsapp.ProviderError{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, whatsapp.IsRetryable(&whatsapp.ProviderError{StatusCode: http.StatusBadRequest, Code: 130429}))
+	assert.True(t, whatsapp.IsRetryable(&whatsapp.ProviderError{StatusCode: http.StatusBadRequest, Code: 80007}))
+
+	assert.False(t, whatsapp.IsRetryable(&whatsapp.ProviderError{StatusCode: http.StatusBadRequest, Code: 131026})) // número inválido
+	assert.False(t, whatsapp.IsRetryable(&whatsapp.ProviderError{StatusCode: http.StatusBadRequest, Code: 132001})) // template inexistente
+	assert.False(t, whatsapp.IsRetryable(&whatsapp.ProviderError{StatusCode: http.StatusBadRequest, Code: 131050})) // opt-out de marketing
+	assert.False(t, whatsapp.IsRetryable(whatsapp.ErrTemplateNotApproved))
+}
+
+func TestMessageService_SendAndTrackStatus(t *testing.T) {
+	// Setup
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}))
+
+	tenantID := uuid.New()
+	phoneNumberID := "PHONE_ID"
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByIDFunc: func(_ context.Context, id string) (*tenants.Tenant, error) {
+			return &tenants.Tenant{ID: tenantID, WhatsAppPhoneNumberID: &phoneNumberID}, nil
+		},
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	provider := whatsapp.NewFakeProvider()
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	service := whatsapp.NewMessageService(messageRepo, channels, provider, telemetryService)
+	webhookService := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+
+	// Customer wrote recently, so the service window is open
+	_, err = messageRepo.CreateIfNotExists(context.Background(), &whatsapp.Message{
+		TenantID:      tenantID,
+		WAMessageID:   "wamid.inbound",
+		PhoneNumberID: phoneNumberID,
+		Direction:     whatsapp.DirectionInbound,
+		From:          "5511988887777",
+		Type:          "text",
+		Status:        whatsapp.StatusReceived,
+		Timestamp:     time.Now().Add(-time.Hour),
+	})
+	assert.NoError(t, err)
+
+	// Execute
+	message, err := service.SendMessage(context.Background(), tenantID, uuid.New(), whatsapp.SendMessageRequest{
+		To:   "5511988887777",
+		Type: whatsapp.TypeText,
+		Text: &whatsapp.TextContent{Body: "Seu pedido foi enviado"},
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.StatusSent, message.Status)
+	assert.Equal(t, whatsapp.DirectionOutbound, message.Direction)
+	assert.Len(t, provider.Sent(), 1)
+	assert.Equal(t, phoneNumberID, provider.Sent()[0].PhoneNumberID)
+
+	// Delivery status arrives through the webhook
+	err = webhookService.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Metadata: whatsapp.WebhookMetadata{PhoneNumberID: phoneNumberID},
+					Statuses: []whatsapp.WebhookStatus{{ID: message.WAMessageID, Status: "delivered", Timestamp: "1735689700"}},
+				},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+
+	stored, err := service.GetMessage(context.Background(), tenantID, message.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.StatusDelivered, stored.Status)
+}
+
+func TestMessageService_ValidatesBeforeSending(t *testing.T) {
+	provider := whatsapp.NewFakeProvider()
+	telemetryService := telemetry.NewTelemetryService(false)
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, &tenants.MockTenantRepository{}, nil, nil, nil, nil, telemetryService)
+	service := whatsapp.NewMessageService(&whatsapp.MockMessageRepository{}, channels, provider, telemetryService)
+
+	_, err := service.SendMessage(context.Background(), uuid.New(), uuid.New(), whatsapp.SendMessageRequest{
+		To:   "5511988887777",
+		Type: whatsapp.TypeInteractive,
+		Interactive: &whatsapp.InteractiveContent{
+			Type: whatsapp.InteractiveButton,
+			Body: "Too many buttons",
+			Buttons: []whatsapp.ReplyButton{
+				{ID: "1", Title: "A"}, {ID: "2", Title: "B"}, {ID: "3", Title: "C"}, {ID: "4", Title: "D"},
+			},
+		},
+	})
+
+	assert.ErrorIs(t, err, whatsapp.ErrInvalidMessage)
+	assert.Empty(t, provider.Sent())
+}
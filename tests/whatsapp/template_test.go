@@ -0,0 +1,180 @@
+package whatsapp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/stretchr/testify/assert"
+)
+
+func orderTemplate() whatsapp.CreateTemplateRequest {
+	return whatsapp.CreateTemplateRequest{
+		Name:     "order_shipped",
+		Language: "pt_BR",
+		Category: whatsapp.CategoryUtility,
+		Components: []whatsapp.TemplateDefinitionComponent{
+			{Type: whatsapp.ComponentHeader, Format: "TEXT", Text: "Pedido {{1}}"},
+			{Type: whatsapp.ComponentBody, Text: "Olá {{1}}, seu pedido saiu para entrega em {{2}}."},
+			{Type: whatsapp.ComponentFooter, Text: "Loja Exemplo"},
+		},
+	}
+}
+
+func TestTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		template   whatsapp.Template
+		shouldFail bool
+	}{
+		{
+			name: "valid",
+			template: whatsapp.Template{Name: "welcome", Language: "pt_BR", Category: whatsapp.CategoryMarketing,
+				Components: []whatsapp.TemplateDefinitionComponent{{Type: whatsapp.ComponentBody, Text: "Oi {{1}}"}}},
+		},
+		{
+			name: "invalid name",
+			template: whatsapp.Template{Name: "Welcome!", Language: "pt_BR", Category: whatsapp.CategoryMarketing,
+				Components: []whatsapp.TemplateDefinitionComponent{{Type: whatsapp.ComponentBody, Text: "Oi"}}},
+			shouldFail: true,
+		},
+		{
+			name: "placeholder gap",
+			template: whatsapp.Template{Name: "welcome", Language: "pt_BR", Category: whatsapp.CategoryMarketing,
+				Components: []whatsapp.TemplateDefinitionComponent{{Type: whatsapp.ComponentBody, Text: "Oi {{1}} {{3}}"}}},
+			shouldFail: true,
+		},
+		{
+			name: "missing body",
+			template: whatsapp.Template{Name: "welcome", Language: "pt_BR", Category: whatsapp.CategoryMarketing,
+				Components: []whatsapp.TemplateDefinitionComponent{{Type: whatsapp.ComponentHeader, Text: "Oi"}}},
+			shouldFail: true,
+		},
+		{
+			name: "footer variable",
+			template: whatsapp.Template{Name: "welcome", Language: "pt_BR", Category: whatsapp.CategoryMarketing,
+				Components: []whatsapp.TemplateDefinitionComponent{
+					{Type: whatsapp.ComponentBody, Text: "Oi"},
+					{Type: whatsapp.ComponentFooter, Text: "{{1}}"},
+				}},
+			shouldFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.template.Validate()
+			if tt.shouldFail {
+				assert.True(t, errors.Is(err, whatsapp.ErrInvalidTemplate))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	// Setup
+	req := orderTemplate()
+	template := whatsapp.Template{Name: req.Name, Language: req.Language, Category: req.Category, Components: req.Components}
+
+	// Execute
+	content, rendered, err := template.Render(whatsapp.TemplateVariables{
+		Header: []string{"#123"},
+		Body:   []string{"Maria", "São Paulo"},
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Olá Maria, seu pedido saiu para entrega em São Paulo.", rendered)
+	assert.Len(t, content.Components, 2)
+	assert.Equal(t, "header", content.Components[0].Type)
+	assert.Equal(t, "São Paulo", content.Components[1].Parameters[1].Text)
+
+	_, _, err = template.Render(whatsapp.TemplateVariables{Header: []string{"#123"}, Body: []string{"Maria"}})
+	assert.True(t, errors.Is(err, whatsapp.ErrInvalidTemplateValues))
+}
+
+func TestTemplateService_CreateApproveAndSend(t *testing.T) {
+	// Setup
+	i := setupInbox(t)
+
+	template, err := i.templates.CreateTemplate(context.Background(), i.tenantID, orderTemplate())
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.TemplatePending, template.Status)
+
+	_, err = i.templates.CreateTemplate(context.Background(), i.tenantID, orderTemplate())
+	assert.True(t, errors.Is(err, whatsapp.ErrTemplateAlreadyExists))
+
+	send := whatsapp.SendTemplateRequest{
+		To:        "5511988887777",
+		Variables: whatsapp.TemplateVariables{Header: []string{"#123"}, Body: []string{"Maria", "São Paulo"}},
+	}
+	_, err = i.templates.SendTemplate(context.Background(), i.tenantID, i.agentID, template.ID.String(), send)
+	assert.True(t, errors.Is(err, whatsapp.ErrTemplateNotApproved))
+
+	// Execute - Meta approves through the webhook
+	assert.NoError(t, i.webhooks.HandleWebhook(context.Background(), whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			ID: "WABA_ID",
+			Changes: []whatsapp.WebhookChange{{
+				Field: "message_template_status_update",
+				Value: whatsapp.WebhookValue{
+					Event:                   "APPROVED",
+					MessageTemplateID:       1,
+					MessageTemplateName:     "order_shipped",
+					MessageTemplateLanguage: "pt_BR",
+					Reason:                  "NONE",
+				},
+			}},
+		}},
+	}))
+
+	message, err := i.templates.SendTemplate(context.Background(), i.tenantID, i.agentID, template.ID.String(), send)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Olá Maria, seu pedido saiu para entrega em São Paulo.", message.Body)
+	sent := i.provider.Sent()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, whatsapp.TypeTemplate, sent[0].Message.Type)
+	assert.Equal(t, "order_shipped", sent[0].Message.Template.Name)
+}
+
+func TestTemplateService_Sync(t *testing.T) {
+	// Setup
+	i := setupInbox(t)
+	created, err := i.templates.CreateTemplate(context.Background(), i.tenantID, orderTemplate())
+	assert.NoError(t, err)
+
+	i.provider.SetTemplateStatus("WABA_ID", "order_shipped", "pt_BR", "REJECTED", "INVALID_FORMAT")
+	_, err = i.provider.CreateTemplate(context.Background(), "WABA_ID", whatsapp.ProviderTemplate{
+		Name:       "welcome",
+		Language:   "en_US",
+		Category:   "MARKETING",
+		Components: []whatsapp.TemplateDefinitionComponent{{Type: whatsapp.ComponentBody, Text: "Hi {{1}}"}},
+	})
+	assert.NoError(t, err)
+
+	// Execute
+	synced, err := i.templates.SyncTemplates(context.Background(), i.tenantID)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 2, synced)
+
+	rejected, err := i.templates.GetTemplate(context.Background(), i.tenantID, created.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.TemplateRejected, rejected.Status)
+	assert.Equal(t, "INVALID_FORMAT", rejected.RejectionReason)
+
+	pending, err := i.templates.ListTemplates(context.Background(), i.tenantID, whatsapp.TemplatePending)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "welcome", pending[0].Name)
+
+	assert.NoError(t, i.templates.DeleteTemplate(context.Background(), i.tenantID, created.ID.String()))
+	remaining, _ := i.templates.ListTemplates(context.Background(), i.tenantID, "")
+	assert.Len(t, remaining, 1)
+}
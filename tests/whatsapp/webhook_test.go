@@ -0,0 +1,261 @@
+package whatsapp_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/claudineijrdev/sib-crm-backend/internal/platform/telemetry"
+	"github.com/claudineijrdev/sib-crm-backend/internal/tenants"
+	"github.com/claudineijrdev/sib-crm-backend/internal/whatsapp"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const appSecret = "test-secret"
+
+const inboundPayload = `{
+  "object": "whatsapp_business_account",
+  "entry": [{
+    "id": "WABA_ID",
+    "changes": [{
+      "field": "messages",
+      "value": {
+        "messaging_product": "whatsapp",
+        "metadata": {"display_phone_number": "5511999990000", "phone_number_id": "PHONE_ID"},
+        "contacts": [{"profile": {"name": "Maria"}, "wa_id": "5511988887777"}],
+        "messages": [{"from": "5511988887777", "id": "wamid.ABC", "timestamp": "1735689600", "type": "text", "text": {"body": "Olá!"}}]
+      }
+    }]
+  }]
+}`
+
+const statusPayload = `{
+  "object": "whatsapp_business_account",
+  "entry": [{
+    "id": "WABA_ID",
+    "changes": [{
+      "field": "messages",
+      "value": {
+        "messaging_product": "whatsapp",
+        "metadata": {"display_phone_number": "5511999990000", "phone_number_id": "PHONE_ID"},
+        "statuses": [{"id": "wamid.ABC", "status": "read", "timestamp": "1735689700", "recipient_id": "5511988887777"}]
+      }
+    }]
+  }]
+}`
+
+type fixture struct {
+	handler     *whatsapp.WebhookHandler
+	service     whatsapp.WebhookService
+	messageRepo whatsapp.MessageRepository
+	pendingRepo whatsapp.PendingEventRepository
+	channels    whatsapp.ChannelService
+	tenantID    uuid.UUID
+}
+
+func setup(t *testing.T) fixture {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&whatsapp.Message{}, &whatsapp.PendingEvent{}))
+
+	tenantID := uuid.New()
+	tenantRepo := &tenants.MockTenantRepository{
+		FindByWhatsAppPhoneNumberIDFunc: func(_ context.Context, phoneNumberID string) (*tenants.Tenant, error) {
+			if phoneNumberID != "PHONE_ID" {
+				return nil, nil
+			}
+			return &tenants.Tenant{ID: tenantID}, nil
+		},
+	}
+
+	telemetryService := telemetry.NewTelemetryService(false)
+	messageRepo := whatsapp.NewMessageRepository(db, telemetryService)
+	channels := whatsapp.NewChannelService(&whatsapp.MockChannelRepository{}, tenantRepo, nil, nil, nil, nil, telemetryService)
+	service := whatsapp.NewWebhookService(messageRepo, channels, telemetryService)
+	config := whatsapp.Config{VerifyToken: "verify-me", AppSecret: appSecret}
+
+	return fixture{
+		handler:     whatsapp.NewWebhookHandler(config, service),
+		service:     service,
+		messageRepo: messageRepo,
+		pendingRepo: whatsapp.NewPendingEventRepository(db, telemetryService),
+		channels:    channels,
+		tenantID:    tenantID,
+	}
+}
+
+func post(handler *whatsapp.WebhookHandler, body, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/webhooks/whatsapp", bytes.NewBufferString(body))
+	req.Header.Set(whatsapp.SignatureHeader, signature)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	handler.Receive(c)
+	return w
+}
+
+func TestWebhookHandler_Verify(t *testing.T) {
+	f := setup(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/webhooks/whatsapp?hub.mode=subscribe&hub.verify_token=verify-me&hub.challenge=12345", nil)
+	f.handler.Verify(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "12345", w.Body.String())
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/webhooks/whatsapp?hub.mode=subscribe&hub.verify_token=wrong&hub.challenge=12345", nil)
+	f.handler.Verify(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestWebhookHandler_RejectsInvalidSignature(t *testing.T) {
+	f := setup(t)
+
+	w := post(f.handler, inboundPayload, whatsapp.Sign("other-secret", []byte(inboundPayload)))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestWebhookHandler_RejectsOversizedBody(t *testing.T) {
+	f := setup(t)
+	body := strings.Repeat("x", whatsapp.MaxWebhookBodySize+1)
+
+	w := post(f.handler, body, whatsapp.Sign(appSecret, []byte(body)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestWebhookHandler_InboundIsIdempotent(t *testing.T) {
+	// Setup
+	f := setup(t)
+	var events []whatsapp.Event
+	f.service.Subscribe(func(_ context.Context, event whatsapp.Event) {
+		events = append(events, event)
+	})
+	signature := whatsapp.Sign(appSecret, []byte(inboundPayload))
+
+	// Execute - Meta may deliver the same webhook more than once
+	first := post(f.handler, inboundPayload, signature)
+	second := post(f.handler, inboundPayload, signature)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Len(t, events, 1)
+
+	inbound := events[0].(whatsapp.InboundMessageEvent)
+	assert.Equal(t, f.tenantID, inbound.TenantID)
+	assert.Equal(t, "Olá!", inbound.Message.Body)
+	assert.Equal(t, "Maria", inbound.Message.ContactName)
+
+	stored, err := f.messageRepo.FindByWAMessageID(context.Background(), "wamid.ABC")
+	assert.NoError(t, err)
+	assert.Equal(t, f.tenantID, stored.TenantID)
+	assert.Equal(t, whatsapp.DirectionInbound, stored.Direction)
+}
+
+func TestWebhookHandler_StatusUpdate(t *testing.T) {
+	f := setup(t)
+	post(f.handler, inboundPayload, whatsapp.Sign(appSecret, []byte(inboundPayload)))
+
+	w := post(f.handler, statusPayload, whatsapp.Sign(appSecret, []byte(statusPayload)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	stored, err := f.messageRepo.FindByWAMessageID(context.Background(), "wamid.ABC")
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.StatusRead, stored.Status)
+}
+
+func TestMessageStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, whatsapp.StatusSent.CanTransitionTo(whatsapp.StatusDelivered))
+	assert.True(t, whatsapp.StatusDelivered.CanTransitionTo(whatsapp.StatusRead))
+	assert.False(t, whatsapp.StatusRead.CanTransitionTo(whatsapp.StatusDelivered))
+	assert.True(t, whatsapp.StatusSent.CanTransitionTo(whatsapp.StatusFailed))
+	assert.False(t, whatsapp.StatusFailed.CanTransitionTo(whatsapp.StatusRead))
+}
+
+func TestEventQueue_ListenersRunAfterTheWebhookResponds(t *testing.T) {
+	// Setup
+	f := setup(t)
+	queue := newEventQueue(f)
+	f.service.Subscribe(queue.Enqueue)
+
+	release := make(chan struct{})
+	var delivered []string
+	queue.Subscribe(func(_ context.Context, event whatsapp.Event) {
+		<-release // um listener lento, como o download de uma mídia grande
+		delivered = append(delivered, event.EventName())
+	})
+	queue.Start(context.Background())
+
+	// Execute: mensagem e status do mesmo contato, com o listener ainda preso
+	inbound := post(f.handler, inboundPayload, whatsapp.Sign(appSecret, []byte(inboundPayload)))
+	status := post(f.handler, statusPayload, whatsapp.Sign(appSecret, []byte(statusPayload)))
+	close(release)
+	queue.Stop()
+
+	// Assertions: a Meta teve a resposta antes dos listeners, que rodaram na ordem
+	assert.Equal(t, http.StatusOK, inbound.Code)
+	assert.Equal(t, http.StatusOK, status.Code)
+	assert.Equal(t, []string{whatsapp.EventInboundMessage, whatsapp.EventStatusUpdate}, delivered)
+
+	pending, err := f.pendingRepo.List(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestEventQueue_ReplaysEventsLeftByACrashedProcess(t *testing.T) {
+	// Setup: a fila que recebe o webhook cai antes de os workers rodarem
+	f := setup(t)
+	crashed := newEventQueue(f)
+	f.service.Subscribe(crashed.Enqueue)
+
+	assert.Equal(t, http.StatusOK, post(f.handler, inboundPayload, whatsapp.Sign(appSecret, []byte(inboundPayload))).Code)
+	assert.Equal(t, http.StatusOK, post(f.handler, statusPayload, whatsapp.Sign(appSecret, []byte(statusPayload))).Code)
+
+	var delivered []whatsapp.Event
+	restarted := newEventQueue(f)
+	restarted.Subscribe(func(_ context.Context, event whatsapp.Event) {
+		delivered = append(delivered, event)
+	})
+
+	// Execute
+	restarted.Start(context.Background())
+	restarted.Stop()
+
+	// Assertions: os listeners recebem os eventos na ordem, com a mensagem do banco
+	if assert.Len(t, delivered, 2) {
+		inbound := delivered[0].(whatsapp.InboundMessageEvent)
+		assert.Equal(t, f.tenantID, inbound.TenantID)
+		assert.Equal(t, "Olá!", inbound.Message.Body)
+		assert.NotNil(t, inbound.Channel)
+
+		status := delivered[1].(whatsapp.StatusUpdateEvent)
+		assert.Equal(t, "wamid.ABC", status.WAMessageID)
+		assert.Equal(t, whatsapp.StatusRead, status.Status)
+	}
+
+	pending, err := f.pendingRepo.List(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func newEventQueue(f fixture) *whatsapp.EventQueue {
+	config := whatsapp.Config{EventWorkers: 2, EventBuffer: 8}
+	return whatsapp.NewEventQueue(config, f.pendingRepo, f.messageRepo, f.channels, telemetry.NewTelemetryService(false))
+}